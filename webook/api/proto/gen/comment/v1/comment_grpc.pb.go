@@ -23,6 +23,7 @@ const (
 	CommentService_DeleteComment_FullMethodName  = "/comment.v1.CommentService/DeleteComment"
 	CommentService_CreateComment_FullMethodName  = "/comment.v1.CommentService/CreateComment"
 	CommentService_GetMoreReplies_FullMethodName = "/comment.v1.CommentService/GetMoreReplies"
+	CommentService_CountByBizIds_FullMethodName  = "/comment.v1.CommentService/CountByBizIds"
 )
 
 // CommentServiceClient is the client API for CommentService service.
@@ -37,6 +38,8 @@ type CommentServiceClient interface {
 	CreateComment(ctx context.Context, in *CreateCommentRequest, opts ...grpc.CallOption) (*CreateCommentResponse, error)
 	// GetMoreReplies 获取更多回复
 	GetMoreReplies(ctx context.Context, in *GetMoreRepliesRequest, opts ...grpc.CallOption) (*GetMoreRepliesResponse, error)
+	// CountByBizIds 批量统计评论数，包含回复
+	CountByBizIds(ctx context.Context, in *CountByBizIdsRequest, opts ...grpc.CallOption) (*CountByBizIdsResponse, error)
 }
 
 type commentServiceClient struct {
@@ -87,6 +90,16 @@ func (c *commentServiceClient) GetMoreReplies(ctx context.Context, in *GetMoreRe
 	return out, nil
 }
 
+func (c *commentServiceClient) CountByBizIds(ctx context.Context, in *CountByBizIdsRequest, opts ...grpc.CallOption) (*CountByBizIdsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CountByBizIdsResponse)
+	err := c.cc.Invoke(ctx, CommentService_CountByBizIds_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CommentServiceServer is the server API for CommentService service.
 // All implementations must embed UnimplementedCommentServiceServer
 // for forward compatibility.
@@ -99,6 +112,8 @@ type CommentServiceServer interface {
 	CreateComment(context.Context, *CreateCommentRequest) (*CreateCommentResponse, error)
 	// GetMoreReplies 获取更多回复
 	GetMoreReplies(context.Context, *GetMoreRepliesRequest) (*GetMoreRepliesResponse, error)
+	// CountByBizIds 批量统计评论数，包含回复
+	CountByBizIds(context.Context, *CountByBizIdsRequest) (*CountByBizIdsResponse, error)
 	mustEmbedUnimplementedCommentServiceServer()
 }
 
@@ -121,6 +136,9 @@ func (UnimplementedCommentServiceServer) CreateComment(context.Context, *CreateC
 func (UnimplementedCommentServiceServer) GetMoreReplies(context.Context, *GetMoreRepliesRequest) (*GetMoreRepliesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetMoreReplies not implemented")
 }
+func (UnimplementedCommentServiceServer) CountByBizIds(context.Context, *CountByBizIdsRequest) (*CountByBizIdsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CountByBizIds not implemented")
+}
 func (UnimplementedCommentServiceServer) mustEmbedUnimplementedCommentServiceServer() {}
 func (UnimplementedCommentServiceServer) testEmbeddedByValue()                        {}
 
@@ -214,6 +232,24 @@ func _CommentService_GetMoreReplies_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CommentService_CountByBizIds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CountByBizIdsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentServiceServer).CountByBizIds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CommentService_CountByBizIds_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentServiceServer).CountByBizIds(ctx, req.(*CountByBizIdsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CommentService_ServiceDesc is the grpc.ServiceDesc for CommentService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -237,6 +273,10 @@ var CommentService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetMoreReplies",
 			Handler:    _CommentService_GetMoreReplies_Handler,
 		},
+		{
+			MethodName: "CountByBizIds",
+			Handler:    _CommentService_CountByBizIds_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "comment/v1/comment.proto",
@@ -463,6 +463,112 @@ func (x *GetFolloweeResponse) GetFollowRelation() []*FollowRelation {
 	return nil
 }
 
+type GetFollowerRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 被关注者，也就是查看某人的粉丝列表
+	Followee int64 `protobuf:"varint,1,opt,name=followee,proto3" json:"followee,omitempty"`
+	// 游标分页，返回 id 小于 min_id 的关注关系，为 0 的时候从最新的开始
+	MinId         int64 `protobuf:"varint,2,opt,name=min_id,json=minId,proto3" json:"min_id,omitempty"`
+	Limit         int64 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFollowerRequest) Reset() {
+	*x = GetFollowerRequest{}
+	mi := &file_follow_v1_follow_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFollowerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFollowerRequest) ProtoMessage() {}
+
+func (x *GetFollowerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_follow_v1_follow_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFollowerRequest.ProtoReflect.Descriptor instead.
+func (*GetFollowerRequest) Descriptor() ([]byte, []int) {
+	return file_follow_v1_follow_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetFollowerRequest) GetFollowee() int64 {
+	if x != nil {
+		return x.Followee
+	}
+	return 0
+}
+
+func (x *GetFollowerRequest) GetMinId() int64 {
+	if x != nil {
+		return x.MinId
+	}
+	return 0
+}
+
+func (x *GetFollowerRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetFollowerResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	FollowRelation []*FollowRelation      `protobuf:"bytes,1,rep,name=follow_relation,json=followRelation,proto3" json:"follow_relation,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetFollowerResponse) Reset() {
+	*x = GetFollowerResponse{}
+	mi := &file_follow_v1_follow_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFollowerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFollowerResponse) ProtoMessage() {}
+
+func (x *GetFollowerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_follow_v1_follow_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFollowerResponse.ProtoReflect.Descriptor instead.
+func (*GetFollowerResponse) Descriptor() ([]byte, []int) {
+	return file_follow_v1_follow_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetFollowerResponse) GetFollowRelation() []*FollowRelation {
+	if x != nil {
+		return x.FollowRelation
+	}
+	return nil
+}
+
 type FollowInfoRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Follower      int64                  `protobuf:"varint,1,opt,name=follower,proto3" json:"follower,omitempty"`
@@ -473,7 +579,7 @@ type FollowInfoRequest struct {
 
 func (x *FollowInfoRequest) Reset() {
 	*x = FollowInfoRequest{}
-	mi := &file_follow_v1_follow_proto_msgTypes[9]
+	mi := &file_follow_v1_follow_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -485,7 +591,7 @@ func (x *FollowInfoRequest) String() string {
 func (*FollowInfoRequest) ProtoMessage() {}
 
 func (x *FollowInfoRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_follow_v1_follow_proto_msgTypes[9]
+	mi := &file_follow_v1_follow_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -498,7 +604,7 @@ func (x *FollowInfoRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FollowInfoRequest.ProtoReflect.Descriptor instead.
 func (*FollowInfoRequest) Descriptor() ([]byte, []int) {
-	return file_follow_v1_follow_proto_rawDescGZIP(), []int{9}
+	return file_follow_v1_follow_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *FollowInfoRequest) GetFollower() int64 {
@@ -524,7 +630,7 @@ type FollowInfoResponse struct {
 
 func (x *FollowInfoResponse) Reset() {
 	*x = FollowInfoResponse{}
-	mi := &file_follow_v1_follow_proto_msgTypes[10]
+	mi := &file_follow_v1_follow_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -536,7 +642,7 @@ func (x *FollowInfoResponse) String() string {
 func (*FollowInfoResponse) ProtoMessage() {}
 
 func (x *FollowInfoResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_follow_v1_follow_proto_msgTypes[10]
+	mi := &file_follow_v1_follow_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -549,7 +655,7 @@ func (x *FollowInfoResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FollowInfoResponse.ProtoReflect.Descriptor instead.
 func (*FollowInfoResponse) Descriptor() ([]byte, []int) {
-	return file_follow_v1_follow_proto_rawDescGZIP(), []int{10}
+	return file_follow_v1_follow_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *FollowInfoResponse) GetFollowRelation() *FollowRelation {
@@ -559,6 +665,262 @@ func (x *FollowInfoResponse) GetFollowRelation() *FollowRelation {
 	return nil
 }
 
+type GetRelationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Viewer        int64                  `protobuf:"varint,1,opt,name=viewer,proto3" json:"viewer,omitempty"`
+	Uids          []int64                `protobuf:"varint,2,rep,packed,name=uids,proto3" json:"uids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRelationsRequest) Reset() {
+	*x = GetRelationsRequest{}
+	mi := &file_follow_v1_follow_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRelationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRelationsRequest) ProtoMessage() {}
+
+func (x *GetRelationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_follow_v1_follow_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRelationsRequest.ProtoReflect.Descriptor instead.
+func (*GetRelationsRequest) Descriptor() ([]byte, []int) {
+	return file_follow_v1_follow_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetRelationsRequest) GetViewer() int64 {
+	if x != nil {
+		return x.Viewer
+	}
+	return 0
+}
+
+func (x *GetRelationsRequest) GetUids() []int64 {
+	if x != nil {
+		return x.Uids
+	}
+	return nil
+}
+
+type Relation struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Uid   int64                  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	// viewer 关注了 uid
+	Following bool `protobuf:"varint,2,opt,name=following,proto3" json:"following,omitempty"`
+	// uid 关注了 viewer
+	Followed bool `protobuf:"varint,3,opt,name=followed,proto3" json:"followed,omitempty"`
+	// 互相关注
+	Mutual        bool `protobuf:"varint,4,opt,name=mutual,proto3" json:"mutual,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Relation) Reset() {
+	*x = Relation{}
+	mi := &file_follow_v1_follow_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Relation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Relation) ProtoMessage() {}
+
+func (x *Relation) ProtoReflect() protoreflect.Message {
+	mi := &file_follow_v1_follow_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Relation.ProtoReflect.Descriptor instead.
+func (*Relation) Descriptor() ([]byte, []int) {
+	return file_follow_v1_follow_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Relation) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *Relation) GetFollowing() bool {
+	if x != nil {
+		return x.Following
+	}
+	return false
+}
+
+func (x *Relation) GetFollowed() bool {
+	if x != nil {
+		return x.Followed
+	}
+	return false
+}
+
+func (x *Relation) GetMutual() bool {
+	if x != nil {
+		return x.Mutual
+	}
+	return false
+}
+
+type GetRelationsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// key 是 uid
+	Relations     map[int64]*Relation `protobuf:"bytes,1,rep,name=relations,proto3" json:"relations,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRelationsResponse) Reset() {
+	*x = GetRelationsResponse{}
+	mi := &file_follow_v1_follow_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRelationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRelationsResponse) ProtoMessage() {}
+
+func (x *GetRelationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_follow_v1_follow_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRelationsResponse.ProtoReflect.Descriptor instead.
+func (*GetRelationsResponse) Descriptor() ([]byte, []int) {
+	return file_follow_v1_follow_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetRelationsResponse) GetRelations() map[int64]*Relation {
+	if x != nil {
+		return x.Relations
+	}
+	return nil
+}
+
+type MergeUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SrcUid        int64                  `protobuf:"varint,1,opt,name=src_uid,json=srcUid,proto3" json:"src_uid,omitempty"`
+	DstUid        int64                  `protobuf:"varint,2,opt,name=dst_uid,json=dstUid,proto3" json:"dst_uid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergeUserRequest) Reset() {
+	*x = MergeUserRequest{}
+	mi := &file_follow_v1_follow_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeUserRequest) ProtoMessage() {}
+
+func (x *MergeUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_follow_v1_follow_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeUserRequest.ProtoReflect.Descriptor instead.
+func (*MergeUserRequest) Descriptor() ([]byte, []int) {
+	return file_follow_v1_follow_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *MergeUserRequest) GetSrcUid() int64 {
+	if x != nil {
+		return x.SrcUid
+	}
+	return 0
+}
+
+func (x *MergeUserRequest) GetDstUid() int64 {
+	if x != nil {
+		return x.DstUid
+	}
+	return 0
+}
+
+type MergeUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergeUserResponse) Reset() {
+	*x = MergeUserResponse{}
+	mi := &file_follow_v1_follow_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeUserResponse) ProtoMessage() {}
+
+func (x *MergeUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_follow_v1_follow_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeUserResponse.ProtoReflect.Descriptor instead.
+func (*MergeUserResponse) Descriptor() ([]byte, []int) {
+	return file_follow_v1_follow_proto_rawDescGZIP(), []int{17}
+}
+
 var File_follow_v1_follow_proto protoreflect.FileDescriptor
 
 var file_follow_v1_follow_proto_rawDesc = string([]byte{
@@ -602,32 +964,77 @@ var file_follow_v1_follow_proto_rawDesc = string([]byte{
 	0x77, 0x5f, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
 	0x32, 0x19, 0x2e, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x6f, 0x6c,
 	0x6c, 0x6f, 0x77, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0e, 0x66, 0x6f, 0x6c,
-	0x6c, 0x6f, 0x77, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x4b, 0x0a, 0x11, 0x46,
-	0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x1a, 0x0a, 0x08, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x03, 0x52, 0x08, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08,
-	0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08,
-	0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x65, 0x22, 0x58, 0x0a, 0x12, 0x46, 0x6f, 0x6c, 0x6c,
-	0x6f, 0x77, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x42,
-	0x0a, 0x0f, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77,
-	0x2e, 0x76, 0x31, 0x2e, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x52, 0x0e, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x32, 0x95, 0x03, 0x0a, 0x0d, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x53, 0x65, 0x72,
-	0x76, 0x69, 0x63, 0x65, 0x12, 0x3d, 0x0a, 0x06, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x12, 0x18,
-	0x2e, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x6f, 0x6c, 0x6c, 0x6f,
-	0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x66, 0x6f, 0x6c, 0x6c, 0x6f,
-	0x77, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x4f, 0x0a, 0x0c, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x46, 0x6f, 0x6c,
-	0x6c, 0x6f, 0x77, 0x12, 0x1e, 0x2e, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x2e, 0x76, 0x31, 0x2e,
-	0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x2e, 0x76, 0x31, 0x2e,
-	0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x52, 0x65, 0x73, 0x70,
+	0x6c, 0x6f, 0x77, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x5d, 0x0a, 0x12, 0x47,
+	0x65, 0x74, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x08, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x65, 0x12, 0x15, 0x0a,
+	0x06, 0x6d, 0x69, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6d,
+	0x69, 0x6e, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x59, 0x0a, 0x13, 0x47, 0x65,
+	0x74, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x42, 0x0a, 0x0f, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x72, 0x65, 0x6c, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x66, 0x6f, 0x6c,
+	0x6c, 0x6f, 0x77, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x52, 0x65, 0x6c,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0e, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x52, 0x65, 0x6c,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x4b, 0x0a, 0x11, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x49,
+	0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x6f,
+	0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x66, 0x6f,
+	0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77,
+	0x65, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77,
+	0x65, 0x65, 0x22, 0x58, 0x0a, 0x12, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x49, 0x6e, 0x66, 0x6f,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x42, 0x0a, 0x0f, 0x66, 0x6f, 0x6c, 0x6c,
+	0x6f, 0x77, 0x5f, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x19, 0x2e, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x6f,
+	0x6c, 0x6c, 0x6f, 0x77, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0e, 0x66, 0x6f,
+	0x6c, 0x6c, 0x6f, 0x77, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x41, 0x0a, 0x13,
+	0x47, 0x65, 0x74, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x69, 0x65, 0x77, 0x65, 0x72, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x06, 0x76, 0x69, 0x65, 0x77, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x75,
+	0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x03, 0x52, 0x04, 0x75, 0x69, 0x64, 0x73, 0x22,
+	0x6e, 0x0a, 0x08, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x75,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x1c, 0x0a,
+	0x09, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x09, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x69, 0x6e, 0x67, 0x12, 0x1a, 0x0a, 0x08, 0x66,
+	0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x66,
+	0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x75, 0x74, 0x75, 0x61,
+	0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x6d, 0x75, 0x74, 0x75, 0x61, 0x6c, 0x22,
+	0xb7, 0x01, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4c, 0x0a, 0x09, 0x72, 0x65, 0x6c, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x66, 0x6f,
+	0x6c, 0x6c, 0x6f, 0x77, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x6c, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x52, 0x65, 0x6c,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x09, 0x72, 0x65, 0x6c,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0x51, 0x0a, 0x0e, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x29, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x66, 0x6f, 0x6c, 0x6c,
+	0x6f, 0x77, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x44, 0x0a, 0x10, 0x4d, 0x65, 0x72,
+	0x67, 0x65, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a,
+	0x07, 0x73, 0x72, 0x63, 0x5f, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06,
+	0x73, 0x72, 0x63, 0x55, 0x69, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x64, 0x73, 0x74, 0x5f, 0x75, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x64, 0x73, 0x74, 0x55, 0x69, 0x64, 0x22,
+	0x13, 0x0a, 0x11, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x32, 0xfc, 0x04, 0x0a, 0x0d, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x3d, 0x0a, 0x06, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77,
+	0x12, 0x18, 0x2e, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x6f, 0x6c,
+	0x6c, 0x6f, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x66, 0x6f, 0x6c,
+	0x6c, 0x6f, 0x77, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4f, 0x0a, 0x0c, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x46,
+	0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x12, 0x1e, 0x2e, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x2e, 0x76,
+	0x31, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x2e, 0x76,
+	0x31, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4c, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x46, 0x6f, 0x6c,
+	0x6c, 0x6f, 0x77, 0x65, 0x65, 0x12, 0x1d, 0x2e, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x2e, 0x76,
+	0x31, 0x2e, 0x47, 0x65, 0x74, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x2e, 0x76, 0x31,
+	0x2e, 0x47, 0x65, 0x74, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x65, 0x52, 0x65, 0x73, 0x70,
 	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4c, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x46, 0x6f, 0x6c, 0x6c, 0x6f,
-	0x77, 0x65, 0x65, 0x12, 0x1d, 0x2e, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x2e, 0x76, 0x31, 0x2e,
-	0x47, 0x65, 0x74, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x77, 0x65, 0x72, 0x12, 0x1d, 0x2e, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x2e, 0x76, 0x31, 0x2e,
+	0x47, 0x65, 0x74, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
 	0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x2e, 0x76, 0x31, 0x2e, 0x47,
-	0x65, 0x74, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x65, 0x74, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
 	0x73, 0x65, 0x12, 0x49, 0x0a, 0x0a, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x49, 0x6e, 0x66, 0x6f,
 	0x12, 0x1c, 0x2e, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x6f, 0x6c,
 	0x6c, 0x6f, 0x77, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d,
@@ -638,19 +1045,28 @@ var file_follow_v1_follow_proto_rawDesc = string([]byte{
 	0x74, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x53, 0x74, 0x61, 0x74, 0x69, 0x63, 0x73, 0x52, 0x65,
 	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x2e, 0x76,
 	0x31, 0x2e, 0x47, 0x65, 0x74, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x53, 0x74, 0x61, 0x74, 0x69,
-	0x63, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0xad, 0x01, 0x0a, 0x0d, 0x63,
-	0x6f, 0x6d, 0x2e, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x2e, 0x76, 0x31, 0x42, 0x0b, 0x46, 0x6f,
-	0x6c, 0x6c, 0x6f, 0x77, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x4a, 0x67, 0x69, 0x74,
-	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x62, 0x61, 0x73, 0x69, 0x63, 0x2d, 0x67, 0x6f,
-	0x2d, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x2d, 0x77, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x2f,
-	0x77, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x2f, 0x76, 0x31, 0x3b, 0x66,
-	0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x76, 0x31, 0xa2, 0x02, 0x03, 0x46, 0x58, 0x58, 0xaa, 0x02, 0x09,
-	0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x2e, 0x56, 0x31, 0xca, 0x02, 0x09, 0x46, 0x6f, 0x6c, 0x6c,
-	0x6f, 0x77, 0x5c, 0x56, 0x31, 0xe2, 0x02, 0x15, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x5c, 0x56,
-	0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x0a,
-	0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x3a, 0x3a, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x33,
+	0x63, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4f, 0x0a, 0x0c, 0x47, 0x65,
+	0x74, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1e, 0x2e, 0x66, 0x6f, 0x6c,
+	0x6c, 0x6f, 0x77, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x66, 0x6f, 0x6c,
+	0x6c, 0x6f, 0x77, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x46, 0x0a, 0x09, 0x4d,
+	0x65, 0x72, 0x67, 0x65, 0x55, 0x73, 0x65, 0x72, 0x12, 0x1b, 0x2e, 0x66, 0x6f, 0x6c, 0x6c, 0x6f,
+	0x77, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x2e, 0x76,
+	0x31, 0x2e, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x42, 0xad, 0x01, 0x0a, 0x0d, 0x63, 0x6f, 0x6d, 0x2e, 0x66, 0x6f, 0x6c, 0x6c,
+	0x6f, 0x77, 0x2e, 0x76, 0x31, 0x42, 0x0b, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x50, 0x72, 0x6f,
+	0x74, 0x6f, 0x50, 0x01, 0x5a, 0x4a, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x62, 0x61, 0x73, 0x69, 0x63, 0x2d, 0x67, 0x6f, 0x2d, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63,
+	0x74, 0x2d, 0x77, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x2f, 0x77, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x2f,
+	0x61, 0x70, 0x69, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x66, 0x6f,
+	0x6c, 0x6c, 0x6f, 0x77, 0x2f, 0x76, 0x31, 0x3b, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x76, 0x31,
+	0xa2, 0x02, 0x03, 0x46, 0x58, 0x58, 0xaa, 0x02, 0x09, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x2e,
+	0x56, 0x31, 0xca, 0x02, 0x09, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x5c, 0x56, 0x31, 0xe2, 0x02,
+	0x15, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x5c, 0x56, 0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65,
+	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x0a, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x3a,
+	0x3a, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 })
 
 var (
@@ -665,7 +1081,7 @@ func file_follow_v1_follow_proto_rawDescGZIP() []byte {
 	return file_follow_v1_follow_proto_rawDescData
 }
 
-var file_follow_v1_follow_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_follow_v1_follow_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
 var file_follow_v1_follow_proto_goTypes = []any{
 	(*GetFollowStaticsRequest)(nil),  // 0: follow.v1.GetFollowStaticsRequest
 	(*GetFollowStaticsResponse)(nil), // 1: follow.v1.GetFollowStaticsResponse
@@ -676,27 +1092,44 @@ var file_follow_v1_follow_proto_goTypes = []any{
 	(*CancelFollowResponse)(nil),     // 6: follow.v1.CancelFollowResponse
 	(*GetFolloweeRequest)(nil),       // 7: follow.v1.GetFolloweeRequest
 	(*GetFolloweeResponse)(nil),      // 8: follow.v1.GetFolloweeResponse
-	(*FollowInfoRequest)(nil),        // 9: follow.v1.FollowInfoRequest
-	(*FollowInfoResponse)(nil),       // 10: follow.v1.FollowInfoResponse
+	(*GetFollowerRequest)(nil),       // 9: follow.v1.GetFollowerRequest
+	(*GetFollowerResponse)(nil),      // 10: follow.v1.GetFollowerResponse
+	(*FollowInfoRequest)(nil),        // 11: follow.v1.FollowInfoRequest
+	(*FollowInfoResponse)(nil),       // 12: follow.v1.FollowInfoResponse
+	(*GetRelationsRequest)(nil),      // 13: follow.v1.GetRelationsRequest
+	(*Relation)(nil),                 // 14: follow.v1.Relation
+	(*GetRelationsResponse)(nil),     // 15: follow.v1.GetRelationsResponse
+	(*MergeUserRequest)(nil),         // 16: follow.v1.MergeUserRequest
+	(*MergeUserResponse)(nil),        // 17: follow.v1.MergeUserResponse
+	nil,                              // 18: follow.v1.GetRelationsResponse.RelationsEntry
 }
 var file_follow_v1_follow_proto_depIdxs = []int32{
 	2,  // 0: follow.v1.GetFolloweeResponse.follow_relation:type_name -> follow.v1.FollowRelation
-	2,  // 1: follow.v1.FollowInfoResponse.follow_relation:type_name -> follow.v1.FollowRelation
-	3,  // 2: follow.v1.FollowService.Follow:input_type -> follow.v1.FollowRequest
-	5,  // 3: follow.v1.FollowService.CancelFollow:input_type -> follow.v1.CancelFollowRequest
-	7,  // 4: follow.v1.FollowService.GetFollowee:input_type -> follow.v1.GetFolloweeRequest
-	9,  // 5: follow.v1.FollowService.FollowInfo:input_type -> follow.v1.FollowInfoRequest
-	0,  // 6: follow.v1.FollowService.GetFollowStatics:input_type -> follow.v1.GetFollowStaticsRequest
-	4,  // 7: follow.v1.FollowService.Follow:output_type -> follow.v1.FollowResponse
-	6,  // 8: follow.v1.FollowService.CancelFollow:output_type -> follow.v1.CancelFollowResponse
-	8,  // 9: follow.v1.FollowService.GetFollowee:output_type -> follow.v1.GetFolloweeResponse
-	10, // 10: follow.v1.FollowService.FollowInfo:output_type -> follow.v1.FollowInfoResponse
-	1,  // 11: follow.v1.FollowService.GetFollowStatics:output_type -> follow.v1.GetFollowStaticsResponse
-	7,  // [7:12] is the sub-list for method output_type
-	2,  // [2:7] is the sub-list for method input_type
-	2,  // [2:2] is the sub-list for extension type_name
-	2,  // [2:2] is the sub-list for extension extendee
-	0,  // [0:2] is the sub-list for field type_name
+	2,  // 1: follow.v1.GetFollowerResponse.follow_relation:type_name -> follow.v1.FollowRelation
+	2,  // 2: follow.v1.FollowInfoResponse.follow_relation:type_name -> follow.v1.FollowRelation
+	18, // 3: follow.v1.GetRelationsResponse.relations:type_name -> follow.v1.GetRelationsResponse.RelationsEntry
+	14, // 4: follow.v1.GetRelationsResponse.RelationsEntry.value:type_name -> follow.v1.Relation
+	3,  // 5: follow.v1.FollowService.Follow:input_type -> follow.v1.FollowRequest
+	5,  // 6: follow.v1.FollowService.CancelFollow:input_type -> follow.v1.CancelFollowRequest
+	7,  // 7: follow.v1.FollowService.GetFollowee:input_type -> follow.v1.GetFolloweeRequest
+	9,  // 8: follow.v1.FollowService.GetFollower:input_type -> follow.v1.GetFollowerRequest
+	11, // 9: follow.v1.FollowService.FollowInfo:input_type -> follow.v1.FollowInfoRequest
+	0,  // 10: follow.v1.FollowService.GetFollowStatics:input_type -> follow.v1.GetFollowStaticsRequest
+	13, // 11: follow.v1.FollowService.GetRelations:input_type -> follow.v1.GetRelationsRequest
+	16, // 12: follow.v1.FollowService.MergeUser:input_type -> follow.v1.MergeUserRequest
+	4,  // 13: follow.v1.FollowService.Follow:output_type -> follow.v1.FollowResponse
+	6,  // 14: follow.v1.FollowService.CancelFollow:output_type -> follow.v1.CancelFollowResponse
+	8,  // 15: follow.v1.FollowService.GetFollowee:output_type -> follow.v1.GetFolloweeResponse
+	10, // 16: follow.v1.FollowService.GetFollower:output_type -> follow.v1.GetFollowerResponse
+	12, // 17: follow.v1.FollowService.FollowInfo:output_type -> follow.v1.FollowInfoResponse
+	1,  // 18: follow.v1.FollowService.GetFollowStatics:output_type -> follow.v1.GetFollowStaticsResponse
+	15, // 19: follow.v1.FollowService.GetRelations:output_type -> follow.v1.GetRelationsResponse
+	17, // 20: follow.v1.FollowService.MergeUser:output_type -> follow.v1.MergeUserResponse
+	13, // [13:21] is the sub-list for method output_type
+	5,  // [5:13] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_follow_v1_follow_proto_init() }
@@ -710,7 +1143,7 @@ func file_follow_v1_follow_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_follow_v1_follow_proto_rawDesc), len(file_follow_v1_follow_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   11,
+			NumMessages:   19,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
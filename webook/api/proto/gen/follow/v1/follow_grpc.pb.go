@@ -22,8 +22,11 @@ const (
 	FollowService_Follow_FullMethodName           = "/follow.v1.FollowService/Follow"
 	FollowService_CancelFollow_FullMethodName     = "/follow.v1.FollowService/CancelFollow"
 	FollowService_GetFollowee_FullMethodName      = "/follow.v1.FollowService/GetFollowee"
+	FollowService_GetFollower_FullMethodName      = "/follow.v1.FollowService/GetFollower"
 	FollowService_FollowInfo_FullMethodName       = "/follow.v1.FollowService/FollowInfo"
 	FollowService_GetFollowStatics_FullMethodName = "/follow.v1.FollowService/GetFollowStatics"
+	FollowService_GetRelations_FullMethodName     = "/follow.v1.FollowService/GetRelations"
+	FollowService_MergeUser_FullMethodName        = "/follow.v1.FollowService/MergeUser"
 )
 
 // FollowServiceClient is the client API for FollowService service.
@@ -34,9 +37,15 @@ type FollowServiceClient interface {
 	CancelFollow(ctx context.Context, in *CancelFollowRequest, opts ...grpc.CallOption) (*CancelFollowResponse, error)
 	// 获得某个人的关注列表
 	GetFollowee(ctx context.Context, in *GetFolloweeRequest, opts ...grpc.CallOption) (*GetFolloweeResponse, error)
+	// 获得某个人的粉丝列表
+	GetFollower(ctx context.Context, in *GetFollowerRequest, opts ...grpc.CallOption) (*GetFollowerResponse, error)
 	// 获得某个人关注另外一个人的详细信息
 	FollowInfo(ctx context.Context, in *FollowInfoRequest, opts ...grpc.CallOption) (*FollowInfoResponse, error)
 	GetFollowStatics(ctx context.Context, in *GetFollowStaticsRequest, opts ...grpc.CallOption) (*GetFollowStaticsResponse, error)
+	// 批量获得 viewer 和一批用户之间的关注关系，单次最多 100 个
+	GetRelations(ctx context.Context, in *GetRelationsRequest, opts ...grpc.CallOption) (*GetRelationsResponse, error)
+	// MergeUser 合并账号，把 src_uid 的关注和粉丝转给 dst_uid，重复调用没有副作用
+	MergeUser(ctx context.Context, in *MergeUserRequest, opts ...grpc.CallOption) (*MergeUserResponse, error)
 }
 
 type followServiceClient struct {
@@ -77,6 +86,16 @@ func (c *followServiceClient) GetFollowee(ctx context.Context, in *GetFolloweeRe
 	return out, nil
 }
 
+func (c *followServiceClient) GetFollower(ctx context.Context, in *GetFollowerRequest, opts ...grpc.CallOption) (*GetFollowerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetFollowerResponse)
+	err := c.cc.Invoke(ctx, FollowService_GetFollower_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *followServiceClient) FollowInfo(ctx context.Context, in *FollowInfoRequest, opts ...grpc.CallOption) (*FollowInfoResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(FollowInfoResponse)
@@ -97,6 +116,26 @@ func (c *followServiceClient) GetFollowStatics(ctx context.Context, in *GetFollo
 	return out, nil
 }
 
+func (c *followServiceClient) GetRelations(ctx context.Context, in *GetRelationsRequest, opts ...grpc.CallOption) (*GetRelationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRelationsResponse)
+	err := c.cc.Invoke(ctx, FollowService_GetRelations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *followServiceClient) MergeUser(ctx context.Context, in *MergeUserRequest, opts ...grpc.CallOption) (*MergeUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MergeUserResponse)
+	err := c.cc.Invoke(ctx, FollowService_MergeUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // FollowServiceServer is the server API for FollowService service.
 // All implementations must embed UnimplementedFollowServiceServer
 // for forward compatibility.
@@ -105,9 +144,15 @@ type FollowServiceServer interface {
 	CancelFollow(context.Context, *CancelFollowRequest) (*CancelFollowResponse, error)
 	// 获得某个人的关注列表
 	GetFollowee(context.Context, *GetFolloweeRequest) (*GetFolloweeResponse, error)
+	// 获得某个人的粉丝列表
+	GetFollower(context.Context, *GetFollowerRequest) (*GetFollowerResponse, error)
 	// 获得某个人关注另外一个人的详细信息
 	FollowInfo(context.Context, *FollowInfoRequest) (*FollowInfoResponse, error)
 	GetFollowStatics(context.Context, *GetFollowStaticsRequest) (*GetFollowStaticsResponse, error)
+	// 批量获得 viewer 和一批用户之间的关注关系，单次最多 100 个
+	GetRelations(context.Context, *GetRelationsRequest) (*GetRelationsResponse, error)
+	// MergeUser 合并账号，把 src_uid 的关注和粉丝转给 dst_uid，重复调用没有副作用
+	MergeUser(context.Context, *MergeUserRequest) (*MergeUserResponse, error)
 	mustEmbedUnimplementedFollowServiceServer()
 }
 
@@ -127,12 +172,21 @@ func (UnimplementedFollowServiceServer) CancelFollow(context.Context, *CancelFol
 func (UnimplementedFollowServiceServer) GetFollowee(context.Context, *GetFolloweeRequest) (*GetFolloweeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetFollowee not implemented")
 }
+func (UnimplementedFollowServiceServer) GetFollower(context.Context, *GetFollowerRequest) (*GetFollowerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFollower not implemented")
+}
 func (UnimplementedFollowServiceServer) FollowInfo(context.Context, *FollowInfoRequest) (*FollowInfoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method FollowInfo not implemented")
 }
 func (UnimplementedFollowServiceServer) GetFollowStatics(context.Context, *GetFollowStaticsRequest) (*GetFollowStaticsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetFollowStatics not implemented")
 }
+func (UnimplementedFollowServiceServer) GetRelations(context.Context, *GetRelationsRequest) (*GetRelationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRelations not implemented")
+}
+func (UnimplementedFollowServiceServer) MergeUser(context.Context, *MergeUserRequest) (*MergeUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MergeUser not implemented")
+}
 func (UnimplementedFollowServiceServer) mustEmbedUnimplementedFollowServiceServer() {}
 func (UnimplementedFollowServiceServer) testEmbeddedByValue()                       {}
 
@@ -208,6 +262,24 @@ func _FollowService_GetFollowee_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FollowService_GetFollower_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFollowerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FollowServiceServer).GetFollower(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FollowService_GetFollower_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FollowServiceServer).GetFollower(ctx, req.(*GetFollowerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _FollowService_FollowInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(FollowInfoRequest)
 	if err := dec(in); err != nil {
@@ -244,6 +316,42 @@ func _FollowService_GetFollowStatics_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FollowService_GetRelations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRelationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FollowServiceServer).GetRelations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FollowService_GetRelations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FollowServiceServer).GetRelations(ctx, req.(*GetRelationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FollowService_MergeUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FollowServiceServer).MergeUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FollowService_MergeUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FollowServiceServer).MergeUser(ctx, req.(*MergeUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // FollowService_ServiceDesc is the grpc.ServiceDesc for FollowService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -263,6 +371,10 @@ var FollowService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetFollowee",
 			Handler:    _FollowService_GetFollowee_Handler,
 		},
+		{
+			MethodName: "GetFollower",
+			Handler:    _FollowService_GetFollower_Handler,
+		},
 		{
 			MethodName: "FollowInfo",
 			Handler:    _FollowService_FollowInfo_Handler,
@@ -271,6 +383,14 @@ var FollowService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetFollowStatics",
 			Handler:    _FollowService_GetFollowStatics_Handler,
 		},
+		{
+			MethodName: "GetRelations",
+			Handler:    _FollowService_GetRelations_Handler,
+		},
+		{
+			MethodName: "MergeUser",
+			Handler:    _FollowService_MergeUser_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "follow/v1/follow.proto",
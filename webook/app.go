@@ -2,6 +2,7 @@ package main
 
 import (
 	"github.com/basic-go-project-webook/webook/internal/events"
+	"github.com/basic-go-project-webook/webook/internal/job"
 	"github.com/gin-gonic/gin"
 	"github.com/robfig/cron/v3"
 )
@@ -10,4 +11,5 @@ type App struct {
 	web       *gin.Engine
 	consumers []events.Consumer
 	cron      *cron.Cron
+	scheduler *job.Scheduler
 }
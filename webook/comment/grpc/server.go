@@ -2,10 +2,14 @@ package grpc
 
 import (
 	"context"
+	"errors"
 	"github.com/basic-go-project-webook/webook/api/proto/gen/comment/v1"
 	"github.com/basic-go-project-webook/webook/comment/domain"
 	"github.com/basic-go-project-webook/webook/comment/service"
+	"github.com/basic-go-project-webook/webook/pkg/grpcx/interceptors/auth"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"math"
 )
@@ -40,12 +44,23 @@ func (c *CommentServiceServer) GetCommentList(ctx context.Context, request *comm
 }
 
 func (c *CommentServiceServer) DeleteComment(ctx context.Context, request *commentv1.DeleteCommentRequest) (*commentv1.DeleteCommentResponse, error) {
-	err := c.svc.DeleteComment(ctx, request.GetId())
-	return &commentv1.DeleteCommentResponse{}, err
+	if err := auth.CheckUid(ctx, request.GetUid()); err != nil {
+		return nil, err
+	}
+	err := c.svc.DeleteComment(ctx, request.GetId(), request.GetUid())
+	if errors.Is(err, service.ErrCommentNotFound) {
+		return nil, status.Error(codes.NotFound, "评论不存在")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &commentv1.DeleteCommentResponse{}, nil
 }
 
 func (c *CommentServiceServer) CreateComment(ctx context.Context, request *commentv1.CreateCommentRequest) (*commentv1.CreateCommentResponse, error) {
-	request.GetComment()
+	if err := auth.CheckUid(ctx, request.GetComment().GetUid()); err != nil {
+		return nil, err
+	}
 	err := c.svc.CreateComment(ctx, c.toDomain(request.GetComment()))
 	return &commentv1.CreateCommentResponse{}, err
 }
@@ -60,6 +75,16 @@ func (c *CommentServiceServer) GetMoreReplies(ctx context.Context, request *comm
 	}, nil
 }
 
+func (c *CommentServiceServer) CountByBizIds(ctx context.Context, request *commentv1.CountByBizIdsRequest) (*commentv1.CountByBizIdsResponse, error) {
+	counts, err := c.svc.CountByBizIds(ctx, request.GetBiz(), request.GetBizIds())
+	if err != nil {
+		return nil, err
+	}
+	return &commentv1.CountByBizIdsResponse{
+		Counts: counts,
+	}, nil
+}
+
 func (c *CommentServiceServer) toDomain(comment *commentv1.Comment) domain.Comment {
 	domainComment := domain.Comment{
 		Id:      comment.GetId(),
@@ -0,0 +1,20 @@
+package ioc
+
+import (
+	commentv1 "github.com/basic-go-project-webook/webook/api/proto/gen/comment/v1"
+	"github.com/basic-go-project-webook/webook/pkg/grpcx/interceptors/auth"
+	"google.golang.org/grpc"
+)
+
+// InitAuthInterceptor 验证 webook 转发过来的短 token 和 webook 自己的服务凭证，
+// 代表用户操作的方法必须带短 token
+func InitAuthInterceptor() grpc.UnaryServerInterceptor {
+	builder, err := auth.NewBuilderFromConfig("auth")
+	if err != nil {
+		panic(err)
+	}
+	return builder.Require(
+		commentv1.CommentService_CreateComment_FullMethodName,
+		commentv1.CommentService_DeleteComment_FullMethodName,
+	).Build()
+}
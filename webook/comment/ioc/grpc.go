@@ -19,7 +19,7 @@ func InitGRPCXServer(commentSvc *grpc2.CommentServiceServer) *grpcx.Server {
 		panic(err)
 	}
 
-	server := grpc.NewServer()
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(InitAuthInterceptor()))
 	commentSvc.Register(server)
 
 	return &grpcx.Server{
@@ -9,18 +9,25 @@ import (
 	"time"
 )
 
+var ErrCommentNotFound = dao.ErrRecordNotFound
+
 type CommentRepository interface {
 	CreateComment(ctx context.Context, comment domain.Comment) error
 	DeleteComment(ctx context.Context, comment domain.Comment) error
 	FindByBiz(ctx context.Context, biz string, bizId int64, limit int64, minId int64) ([]domain.Comment, error)
 	GetMoreReplies(ctx context.Context, rid int64, limit int64, maxId int64) ([]domain.Comment, error)
 	GetCommentByIds(ctx context.Context, ids []int64) ([]domain.Comment, error)
+	CountByBizIds(ctx context.Context, biz string, bizIds []int64) (map[int64]int64, error)
 }
 
 type CachedCommentRepository struct {
 	dao dao.CommentDAO
 }
 
+func (c *CachedCommentRepository) CountByBizIds(ctx context.Context, biz string, bizIds []int64) (map[int64]int64, error) {
+	return c.dao.CountByBizIds(ctx, biz, bizIds)
+}
+
 func (c *CachedCommentRepository) GetCommentByIds(ctx context.Context, ids []int64) ([]domain.Comment, error) {
 	comments, err := c.dao.GetCommentByIds(ctx, ids)
 	if err != nil {
@@ -81,7 +88,8 @@ func (c *CachedCommentRepository) FindByBiz(ctx context.Context, biz string, biz
 
 func (c *CachedCommentRepository) DeleteComment(ctx context.Context, comment domain.Comment) error {
 	return c.dao.Delete(ctx, dao.Comment{
-		Id: comment.Id,
+		Id:  comment.Id,
+		Uid: comment.Commentator.Id,
 	})
 }
 
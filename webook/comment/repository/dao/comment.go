@@ -6,6 +6,8 @@ import (
 	"gorm.io/gorm"
 )
 
+var ErrRecordNotFound = gorm.ErrRecordNotFound
+
 type CommentDAO interface {
 	Insert(ctx context.Context, comment Comment) error
 	Delete(ctx context.Context, comment Comment) error
@@ -13,12 +15,34 @@ type CommentDAO interface {
 	FindRepliesByPid(ctx context.Context, pid int64, offset, limit int) ([]Comment, error)
 	FindRepliesByRid(ctx context.Context, rid int64, limit int64, maxId int64) ([]Comment, error)
 	GetCommentByIds(ctx context.Context, ids []int64) ([]Comment, error)
+	CountByBizIds(ctx context.Context, biz string, bizIds []int64) (map[int64]int64, error)
 }
 
 type GORMCommentDao struct {
 	db *gorm.DB
 }
 
+func (dao *GORMCommentDao) CountByBizIds(ctx context.Context, biz string, bizIds []int64) (map[int64]int64, error) {
+	type bizCnt struct {
+		BizId int64
+		Cnt   int64
+	}
+	var rows []bizCnt
+	err := dao.db.WithContext(ctx).Model(&Comment{}).
+		Select("biz_id, COUNT(*) AS cnt").
+		Where("biz = ? AND biz_id IN ?", biz, bizIds).
+		Group("biz_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[int64]int64, len(rows))
+	for _, row := range rows {
+		res[row.BizId] = row.Cnt
+	}
+	return res, nil
+}
+
 func (dao *GORMCommentDao) GetCommentByIds(ctx context.Context, ids []int64) ([]Comment, error) {
 	var res []Comment
 	err := dao.db.WithContext(ctx).Where("id IN ?", ids).Find(&res).Error
@@ -51,10 +75,18 @@ func (dao *GORMCommentDao) FindByBiz(ctx context.Context, biz string, bizId int6
 	return res, err
 }
 
+// Delete 评论不存在或者不是 comment.Uid 发表的，都返回 ErrRecordNotFound
 func (dao *GORMCommentDao) Delete(ctx context.Context, comment Comment) error {
-	return dao.db.WithContext(ctx).Delete(&Comment{
-		Id: comment.Id,
-	}).Error
+	res := dao.db.WithContext(ctx).
+		Where("id = ? AND uid = ?", comment.Id, comment.Uid).
+		Delete(&Comment{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrRecordNotFound
+	}
+	return nil
 }
 
 func (dao *GORMCommentDao) Insert(ctx context.Context, comment Comment) error {
@@ -6,11 +6,16 @@ import (
 	"github.com/basic-go-project-webook/webook/comment/repository"
 )
 
+var ErrCommentNotFound = repository.ErrCommentNotFound
+
 type CommentService interface {
 	GetCommentList(ctx context.Context, biz string, bizId int64, limit int64, minId int64) ([]domain.Comment, error)
-	DeleteComment(ctx context.Context, id int64) error
+	// DeleteComment 只能删除 uid 自己的评论，别人的评论返回 ErrCommentNotFound
+	DeleteComment(ctx context.Context, id int64, uid int64) error
 	GetMoreReplies(ctx context.Context, rid int64, limit int64, maxId int64) ([]domain.Comment, error)
 	CreateComment(ctx context.Context, comment domain.Comment) error
+	// CountByBizIds 批量统计评论数，没有评论的 bizId 不在结果里
+	CountByBizIds(ctx context.Context, biz string, bizIds []int64) (map[int64]int64, error)
 }
 
 type commentService struct {
@@ -31,9 +36,10 @@ func (c *commentService) GetCommentList(ctx context.Context, biz string, bizId i
 	return list, nil
 }
 
-func (c *commentService) DeleteComment(ctx context.Context, id int64) error {
+func (c *commentService) DeleteComment(ctx context.Context, id int64, uid int64) error {
 	return c.repo.DeleteComment(ctx, domain.Comment{
-		Id: id,
+		Id:          id,
+		Commentator: domain.User{Id: uid},
 	})
 }
 
@@ -44,3 +50,10 @@ func (c *commentService) GetMoreReplies(ctx context.Context, rid int64, limit in
 func (c *commentService) CreateComment(ctx context.Context, comment domain.Comment) error {
 	return c.repo.CreateComment(ctx, comment)
 }
+
+func (c *commentService) CountByBizIds(ctx context.Context, biz string, bizIds []int64) (map[int64]int64, error) {
+	if len(bizIds) == 0 {
+		return map[int64]int64{}, nil
+	}
+	return c.repo.CountByBizIds(ctx, biz, bizIds)
+}
@@ -1,7 +1,11 @@
 package main
 
-import "github.com/basic-go-project-webook/webook/pkg/grpcx"
+import (
+	"github.com/basic-go-project-webook/webook/pkg/grpcx"
+	"github.com/robfig/cron/v3"
+)
 
 type App struct {
 	server *grpcx.Server
+	cron   *cron.Cron
 }
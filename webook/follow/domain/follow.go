@@ -1,6 +1,7 @@
 package domain
 
 type FollowRelation struct {
+	Id int64
 	// 被关注的人
 	Followee int64
 	// 关注者
@@ -13,3 +14,17 @@ type FollowStatics struct {
 	// 自己关注了多少人
 	Followees int64
 }
+
+// Relation viewer 和 Uid 之间的关注关系
+type Relation struct {
+	Uid int64
+	// viewer 关注了 Uid
+	Following bool
+	// Uid 关注了 viewer
+	Followed bool
+}
+
+// Mutual 互相关注
+func (r Relation) Mutual() bool {
+	return r.Following && r.Followed
+}
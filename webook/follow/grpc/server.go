@@ -2,10 +2,14 @@ package grpc
 
 import (
 	"context"
+	"errors"
 	"github.com/basic-go-project-webook/webook/api/proto/gen/follow/v1"
 	"github.com/basic-go-project-webook/webook/follow/domain"
 	"github.com/basic-go-project-webook/webook/follow/service"
+	"github.com/basic-go-project-webook/webook/pkg/grpcx/interceptors/auth"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type FollowServiceServer struct {
@@ -20,17 +24,26 @@ func NewFollowServiceServer(svc service.FollowService) *FollowServiceServer {
 }
 
 func (f *FollowServiceServer) Follow(ctx context.Context, request *followv1.FollowRequest) (*followv1.FollowResponse, error) {
+	if err := auth.CheckUid(ctx, request.GetFollower()); err != nil {
+		return nil, err
+	}
 	err := f.svc.Follow(ctx, request.GetFollowee(), request.GetFollower())
 	return &followv1.FollowResponse{}, err
 }
 
 func (f *FollowServiceServer) CancelFollow(ctx context.Context, request *followv1.CancelFollowRequest) (*followv1.CancelFollowResponse, error) {
+	if err := auth.CheckUid(ctx, request.GetFollower()); err != nil {
+		return nil, err
+	}
 	err := f.svc.CancelFollow(ctx, request.GetFollowee(), request.GetFollower())
 	return &followv1.CancelFollowResponse{}, err
 }
 
 func (f *FollowServiceServer) GetFollowee(ctx context.Context, request *followv1.GetFolloweeRequest) (*followv1.GetFolloweeResponse, error) {
 	relationList, err := f.svc.GetFollowee(ctx, request.GetFollower(), request.GetOffset(), request.GetLimit())
+	if errors.Is(err, service.ErrInvalidOffset) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -43,6 +56,20 @@ func (f *FollowServiceServer) GetFollowee(ctx context.Context, request *followv1
 	}, nil
 }
 
+func (f *FollowServiceServer) GetFollower(ctx context.Context, request *followv1.GetFollowerRequest) (*followv1.GetFollowerResponse, error) {
+	relationList, err := f.svc.GetFollower(ctx, request.GetFollowee(), request.GetMinId(), request.GetLimit())
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*followv1.FollowRelation, 0, len(relationList))
+	for _, relation := range relationList {
+		res = append(res, f.toDTO(relation))
+	}
+	return &followv1.GetFollowerResponse{
+		FollowRelation: res,
+	}, nil
+}
+
 func (f *FollowServiceServer) FollowInfo(ctx context.Context, request *followv1.FollowInfoRequest) (*followv1.FollowInfoResponse, error) {
 	relation, err := f.svc.FollowInfo(ctx, request.GetFollower(), request.GetFollowee())
 	if err != nil {
@@ -53,19 +80,60 @@ func (f *FollowServiceServer) FollowInfo(ctx context.Context, request *followv1.
 	}, nil
 }
 
+func (f *FollowServiceServer) GetRelations(ctx context.Context, request *followv1.GetRelationsRequest) (*followv1.GetRelationsResponse, error) {
+	if err := auth.CheckUid(ctx, request.GetViewer()); err != nil {
+		return nil, err
+	}
+	relations, err := f.svc.GetRelations(ctx, request.GetViewer(), request.GetUids())
+	if errors.Is(err, service.ErrTooManyUids) {
+		return nil, status.Errorf(codes.InvalidArgument, "uids 最多 %d 个", service.MaxRelationBatchSize)
+	}
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[int64]*followv1.Relation, len(relations))
+	for _, relation := range relations {
+		res[relation.Uid] = &followv1.Relation{
+			Uid:       relation.Uid,
+			Following: relation.Following,
+			Followed:  relation.Followed,
+			Mutual:    relation.Mutual(),
+		}
+	}
+	return &followv1.GetRelationsResponse{
+		Relations: res,
+	}, nil
+}
+
+func (f *FollowServiceServer) MergeUser(ctx context.Context, request *followv1.MergeUserRequest) (*followv1.MergeUserResponse, error) {
+	if request.GetSrcUid() <= 0 || request.GetDstUid() <= 0 || request.GetSrcUid() == request.GetDstUid() {
+		return nil, status.Error(codes.InvalidArgument, "uid 错误")
+	}
+	// srcUid 是不是这个用户的，只有 webook 验证合并凭证的时候知道，所以只接受服务调用
+	if _, ok := auth.Service(ctx); !ok {
+		return nil, status.Error(codes.PermissionDenied, "只接受内部服务调用")
+	}
+	err := f.svc.MergeUser(ctx, request.GetSrcUid(), request.GetDstUid())
+	if err != nil {
+		return nil, err
+	}
+	return &followv1.MergeUserResponse{}, nil
+}
+
 func (f *FollowServiceServer) GetFollowStatics(ctx context.Context, request *followv1.GetFollowStaticsRequest) (*followv1.GetFollowStaticsResponse, error) {
 	statics, err := f.svc.GetFollowStatics(ctx, request.GetUid())
 	if err != nil {
 		return nil, err
 	}
 	return &followv1.GetFollowStaticsResponse{
-		FollowerCnt:  statics.Followees,
-		FollowingCnt: statics.Followers,
+		FollowerCnt:  statics.Followers,
+		FollowingCnt: statics.Followees,
 	}, nil
 }
 
 func (f *FollowServiceServer) toDTO(domainRelation domain.FollowRelation) *followv1.FollowRelation {
 	return &followv1.FollowRelation{
+		Id:       domainRelation.Id,
 		Followee: domainRelation.Followee,
 		Follower: domainRelation.Follower,
 	}
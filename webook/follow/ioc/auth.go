@@ -0,0 +1,24 @@
+package ioc
+
+import (
+	followv1 "github.com/basic-go-project-webook/webook/api/proto/gen/follow/v1"
+	"github.com/basic-go-project-webook/webook/pkg/grpcx/interceptors/auth"
+	"google.golang.org/grpc"
+)
+
+// InitAuthInterceptor 验证 webook 转发过来的短 token 和 webook 自己的服务凭证，
+// 代表用户操作的方法必须带短 token
+func InitAuthInterceptor() grpc.UnaryServerInterceptor {
+	builder, err := auth.NewBuilderFromConfig("auth")
+	if err != nil {
+		panic(err)
+	}
+	return builder.Require(
+		followv1.FollowService_Follow_FullMethodName,
+		followv1.FollowService_CancelFollow_FullMethodName,
+		followv1.FollowService_GetRelations_FullMethodName,
+	).RequireService(
+		// 合并账号的凭证在 webook 里面验证过，用户自己的 token 不能调用
+		followv1.FollowService_MergeUser_FullMethodName,
+	).Build()
+}
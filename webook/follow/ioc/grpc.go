@@ -18,7 +18,7 @@ func InitGRPCXServer(followServer *grpc2.FollowServiceServer) *grpcx.Server {
 	if err != nil {
 		panic(err)
 	}
-	server := grpc.NewServer()
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(InitAuthInterceptor()))
 	followServer.Register(server)
 	return &grpcx.Server{
 		Server:   server,
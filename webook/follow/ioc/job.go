@@ -0,0 +1,41 @@
+package ioc
+
+import (
+	"github.com/basic-go-project-webook/webook/follow/job"
+	"github.com/basic-go-project-webook/webook/follow/service"
+	rlock "github.com/gotomicro/redis-lock"
+	"github.com/robfig/cron/v3"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+	"time"
+)
+
+func InitStaticsReconcileJob(svc service.FollowService, client *rlock.Client) *job.StaticsReconcileJob {
+	return job.NewStaticsReconcileJob(svc, client, time.Minute*10)
+}
+
+func InitJobs(reconcileJob *job.StaticsReconcileJob) *cron.Cron {
+	type Config struct {
+		Spec string `yaml:"spec"`
+	}
+	var cfg Config
+	err := viper.UnmarshalKey("job.staticsReconcile", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	expr := cron.New(cron.WithSeconds())
+	_, err = expr.AddFunc(cfg.Spec, func() {
+		start := time.Now()
+		er := reconcileJob.Run()
+		if er != nil {
+			zap.L().Error("执行 cron job 失败", zap.String("job", reconcileJob.Name()), zap.Error(er))
+			return
+		}
+		zap.L().Info("执行 cron job 完成", zap.String("job", reconcileJob.Name()),
+			zap.Duration("duration", time.Since(start)))
+	})
+	if err != nil {
+		panic(err)
+	}
+	return expr
+}
@@ -1,6 +1,7 @@
 package ioc
 
 import (
+	rlock "github.com/gotomicro/redis-lock"
 	"github.com/redis/go-redis/v9"
 	"github.com/spf13/viper"
 )
@@ -18,3 +19,7 @@ func InitRedis() redis.Cmdable {
 		Addr: cfg.Addr,
 	})
 }
+
+func InitRlockClient(client redis.Cmdable) *rlock.Client {
+	return rlock.NewClient(client)
+}
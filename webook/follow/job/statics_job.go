@@ -0,0 +1,51 @@
+package job
+
+import (
+	"context"
+	"github.com/basic-go-project-webook/webook/follow/service"
+	rlock "github.com/gotomicro/redis-lock"
+	"go.uber.org/zap"
+	"time"
+)
+
+// StaticsReconcileJob 定时根据关注关系修复关注统计数据，
+// 多个实例之间用分布式锁保证同一时刻只有一个实例在修复
+type StaticsReconcileJob struct {
+	svc     service.FollowService
+	client  *rlock.Client
+	key     string
+	timeout time.Duration
+}
+
+func NewStaticsReconcileJob(svc service.FollowService, client *rlock.Client, timeout time.Duration) *StaticsReconcileJob {
+	return &StaticsReconcileJob{
+		svc:     svc,
+		client:  client,
+		key:     "job:follow_statics_reconcile",
+		timeout: timeout,
+	}
+}
+
+func (j *StaticsReconcileJob) Name() string {
+	return "follow_statics_reconcile"
+}
+
+func (j *StaticsReconcileJob) Run() error {
+	ctx, cancel := context.WithTimeout(context.Background(), j.timeout)
+	defer cancel()
+	lock, err := j.client.TryLock(ctx, j.key, j.timeout)
+	if err != nil {
+		// 别的实例正在修复
+		zap.L().Warn("获取分布式锁失败", zap.String("job", j.Name()), zap.Error(err))
+		return nil
+	}
+	defer func() {
+		unlockCtx, unlockCancel := context.WithTimeout(context.Background(), time.Second)
+		defer unlockCancel()
+		er := lock.Unlock(unlockCtx)
+		if er != nil {
+			zap.L().Error("释放分布式锁失败", zap.String("job", j.Name()), zap.Error(er))
+		}
+	}()
+	return j.svc.ReconcileStatics(ctx)
+}
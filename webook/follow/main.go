@@ -13,6 +13,10 @@ func main() {
 	initPrometheus()
 	initZap()
 	app := InitApp()
+	app.cron.Start()
+	defer func() {
+		<-app.cron.Stop().Done()
+	}()
 	err := app.server.Serve()
 	if err != nil {
 		panic(err)
@@ -4,12 +4,14 @@ local key2 = KEYS[2]
 local field1 = ARGV[1]
 local field2 = ARGV[2]
 local delta = tonumber(ARGV[3])
-local exist1=redis.call("EXISTS", key1)
-local exist2=redis.call("EXISTS", key2)
-if exist1 == 1 and exist2 == 1 then
+-- 两个 key 分别判断, 不存在的 key 等下次查询的时候再回写
+local res = 0
+if redis.call("EXISTS", key1) == 1 then
     redis.call("HINCRBY", key1, field1, delta)
+    res = res + 1
+end
+if redis.call("EXISTS", key2) == 1 then
     redis.call("HINCRBY", key2, field2, delta)
-    return 1
-else
-    return 0
-end
\ No newline at end of file
+    res = res + 1
+end
+return res
\ No newline at end of file
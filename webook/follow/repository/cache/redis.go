@@ -7,6 +7,7 @@ import (
 	"github.com/basic-go-project-webook/webook/follow/domain"
 	"github.com/redis/go-redis/v9"
 	"strconv"
+	"time"
 )
 
 var (
@@ -21,11 +22,25 @@ const (
 	fieldFolloweeCnt = "followee_cnt"
 )
 
+// 关系缓存里面每个 uid 对应的值是一个位图
+const (
+	relationFollowing = 1 << iota
+	relationFollowed
+)
+
+const relationExpiration = time.Minute * 15
+
 type FollowCache interface {
 	StaticsInfo(ctx context.Context, uid int64) (domain.FollowStatics, error)
 	SetStaticsInfo(ctx context.Context, uid int64, statics domain.FollowStatics) error
 	Follow(ctx context.Context, follower, followee int64) error
 	CancelFollow(ctx context.Context, follower, followee int64) error
+	DelStaticsInfo(ctx context.Context, uids ...int64) error
+	// GetRelations 只返回命中缓存的部分
+	GetRelations(ctx context.Context, viewer int64, uids []int64) (map[int64]domain.Relation, error)
+	SetRelations(ctx context.Context, viewer int64, relations []domain.Relation) error
+	// DelRelation 关注关系变化的时候，删除双方视角下的缓存
+	DelRelation(ctx context.Context, follower, followee int64) error
 }
 
 type RedisFollowCache struct {
@@ -59,6 +74,79 @@ func (r *RedisFollowCache) CancelFollow(ctx context.Context, follower, followee
 	return r.updateStaticsInfo(ctx, follower, followee, -1)
 }
 
+func (r *RedisFollowCache) DelStaticsInfo(ctx context.Context, uids ...int64) error {
+	if len(uids) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(uids))
+	for _, uid := range uids {
+		keys = append(keys, r.staticsKey(uid))
+	}
+	return r.client.Del(ctx, keys...).Err()
+}
+
+func (r *RedisFollowCache) GetRelations(ctx context.Context, viewer int64, uids []int64) (map[int64]domain.Relation, error) {
+	res := make(map[int64]domain.Relation, len(uids))
+	if len(uids) == 0 {
+		return res, nil
+	}
+	fields := make([]string, 0, len(uids))
+	for _, uid := range uids {
+		fields = append(fields, strconv.FormatInt(uid, 10))
+	}
+	vals, err := r.client.HMGet(ctx, r.relationKey(viewer), fields...).Result()
+	if err != nil {
+		return nil, err
+	}
+	for i, val := range vals {
+		str, ok := val.(string)
+		if !ok {
+			continue
+		}
+		flag, er := strconv.Atoi(str)
+		if er != nil {
+			continue
+		}
+		res[uids[i]] = domain.Relation{
+			Uid:       uids[i],
+			Following: flag&relationFollowing != 0,
+			Followed:  flag&relationFollowed != 0,
+		}
+	}
+	return res, nil
+}
+
+func (r *RedisFollowCache) SetRelations(ctx context.Context, viewer int64, relations []domain.Relation) error {
+	if len(relations) == 0 {
+		return nil
+	}
+	values := make([]any, 0, len(relations)*2)
+	for _, relation := range relations {
+		var flag int
+		if relation.Following {
+			flag |= relationFollowing
+		}
+		if relation.Followed {
+			flag |= relationFollowed
+		}
+		values = append(values, strconv.FormatInt(relation.Uid, 10), flag)
+	}
+	key := r.relationKey(viewer)
+	pipe := r.client.Pipeline()
+	pipe.HSet(ctx, key, values...)
+	pipe.Expire(ctx, key, relationExpiration)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (r *RedisFollowCache) DelRelation(ctx context.Context, follower, followee int64) error {
+	pipe := r.client.Pipeline()
+	pipe.HDel(ctx, r.relationKey(follower), strconv.FormatInt(followee, 10))
+	pipe.HDel(ctx, r.relationKey(followee), strconv.FormatInt(follower, 10))
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
 func (r *RedisFollowCache) updateStaticsInfo(ctx context.Context, follower int64, followee int64, delta int) error {
 	return r.client.Eval(ctx, updateScript,
 		[]string{r.staticsKey(follower), r.staticsKey(followee)}, fieldFolloweeCnt, fieldFollowerCnt, delta).Err()
@@ -68,6 +156,10 @@ func (r *RedisFollowCache) staticsKey(uid int64) string {
 	return fmt.Sprintf("follow:statics:%d", uid)
 }
 
+func (r *RedisFollowCache) relationKey(viewer int64) string {
+	return fmt.Sprintf("follow:relations:%d", viewer)
+}
+
 func NewRedisFollowCache(client redis.Cmdable) FollowCache {
 	return &RedisFollowCache{
 		client: client,
@@ -2,6 +2,7 @@ package dao
 
 import (
 	"context"
+	"errors"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 	"time"
@@ -11,20 +12,20 @@ type GORMFollowDAO struct {
 	db *gorm.DB
 }
 
+// CntFollower 统计 uid 的粉丝数
 func (dao *GORMFollowDAO) CntFollower(ctx context.Context, uid int64) (int64, error) {
 	var res int64
-	err := dao.db.WithContext(ctx).
-		Select("count(follower)").
-		Where("follower = ? AND status = ?", uid, FollowRelationStatusActive).
+	err := dao.db.WithContext(ctx).Model(&FollowRelation{}).
+		Where("followee = ? AND status = ?", uid, FollowRelationStatusActive).
 		Count(&res).Error
 	return res, err
 }
 
+// CntFollowee 统计 uid 关注了多少人
 func (dao *GORMFollowDAO) CntFollowee(ctx context.Context, uid int64) (int64, error) {
 	var res int64
-	err := dao.db.WithContext(ctx).
-		Select("count(followee)").
-		Where("followee = ? AND status = ?", uid, FollowRelationStatusActive).
+	err := dao.db.WithContext(ctx).Model(&FollowRelation{}).
+		Where("follower = ? AND status = ?", uid, FollowRelationStatusActive).
 		Count(&res).Error
 	return res, err
 }
@@ -37,6 +38,19 @@ func (dao *GORMFollowDAO) FollowRelationDetail(ctx context.Context, follower int
 	return res, err
 }
 
+func (dao *GORMFollowDAO) FindRelations(ctx context.Context, viewer int64, uids []int64) ([]FollowRelation, error) {
+	var res []FollowRelation
+	if len(uids) == 0 {
+		return res, nil
+	}
+	// 两个分支分别命中 follower_followee 和 followee_status 索引
+	err := dao.db.WithContext(ctx).
+		Where("status = ? AND ((follower = ? AND followee IN ?) OR (followee = ? AND follower IN ?))",
+			FollowRelationStatusActive, viewer, uids, viewer, uids).
+		Find(&res).Error
+	return res, err
+}
+
 func (dao *GORMFollowDAO) GetFollowee(ctx context.Context, follower int64, offset int64, limit int64) ([]FollowRelation, error) {
 	var res []FollowRelation
 	err := dao.db.WithContext(ctx).Where("follower = ? AND status = ?", follower, FollowRelationStatusActive).
@@ -44,30 +58,234 @@ func (dao *GORMFollowDAO) GetFollowee(ctx context.Context, follower int64, offse
 	return res, err
 }
 
-func (dao *GORMFollowDAO) UpdateStatus(ctx context.Context, followee int64, follower int64, status uint8) error {
-	now := time.Now().UnixMilli()
-	return dao.db.WithContext(ctx).
-		Where("followee = ? AND follower = ?", followee, follower).
-		Updates(map[string]interface{}{
-			"status": status,
-			"utime":  now,
+func (dao *GORMFollowDAO) GetFollower(ctx context.Context, followee int64, minId int64, limit int64) ([]FollowRelation, error) {
+	var res []FollowRelation
+	err := dao.db.WithContext(ctx).
+		Where("followee = ? AND status = ? AND id < ?", followee, FollowRelationStatusActive, minId).
+		Order("id DESC").
+		Limit(int(limit)).
+		Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMFollowDAO) UpdateStatus(ctx context.Context, followee int64, follower int64, status uint8) (bool, error) {
+	var changed bool
+	err := dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var relation FollowRelation
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("followee = ? AND follower = ?", followee, follower).
+			First(&relation).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// 没有关注过，也就不需要更新
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if relation.Status == status {
+			return nil
+		}
+		now := time.Now().UnixMilli()
+		err = tx.Model(&FollowRelation{}).
+			Where("id = ?", relation.Id).
+			Updates(map[string]interface{}{
+				"status": status,
+				"utime":  now,
+			}).Error
+		if err != nil {
+			return err
+		}
+		changed = true
+		var delta int64
+		switch {
+		case status == FollowRelationStatusActive:
+			delta = 1
+		case relation.Status == FollowRelationStatusActive:
+			delta = -1
+		default:
+			return nil
+		}
+		return dao.updateStatics(tx, followee, follower, delta, now)
+	})
+	return changed, err
+}
+
+func (dao *GORMFollowDAO) CreateFollowRelation(ctx context.Context, followee int64, follower int64) (bool, error) {
+	var changed bool
+	err := dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var relation FollowRelation
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("followee = ? AND follower = ?", followee, follower).
+			First(&relation).Error
+		switch {
+		case err == nil && relation.Status == FollowRelationStatusActive:
+			// 已经关注了
+			return nil
+		case err == nil, errors.Is(err, gorm.ErrRecordNotFound):
+		default:
+			return err
+		}
+		now := time.Now().UnixMilli()
+		err = tx.Clauses(clause.OnConflict{
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"status": FollowRelationStatusActive,
+				"utime":  now,
+			}),
+		}).Create(&FollowRelation{
+			Followee: followee,
+			Follower: follower,
+			Status:   FollowRelationStatusActive,
+			Ctime:    now,
+			Utime:    now,
 		}).Error
+		if err != nil {
+			return err
+		}
+		changed = true
+		return dao.updateStatics(tx, followee, follower, 1, now)
+	})
+	return changed, err
 }
 
-func (dao *GORMFollowDAO) CreateFollowRelation(ctx context.Context, followee int64, follower int64) error {
-	now := time.Now().UnixMilli()
-	f := FollowRelation{
-		Followee: followee,
-		Follower: follower,
-		Ctime:    now,
-		Utime:    now,
+// updateStatics 在关注关系变更的事务里面同步更新双方的统计数据
+// follower 的关注数和 followee 的粉丝数一起变化
+func (dao *GORMFollowDAO) updateStatics(tx *gorm.DB, followee int64, follower int64, delta int64, now int64) error {
+	err := dao.incrStatics(tx, follower, "followees", delta, now)
+	if err != nil {
+		return err
 	}
-	return dao.db.WithContext(ctx).Clauses(clause.OnConflict{
+	return dao.incrStatics(tx, followee, "followers", delta, now)
+}
+
+func (dao *GORMFollowDAO) incrStatics(tx *gorm.DB, uid int64, field string, delta int64, now int64) error {
+	res := tx.Model(&FollowStatics{}).
+		Where("uid = ?", uid).
+		Updates(map[string]interface{}{
+			field:   gorm.Expr(field+" + ?", delta),
+			"utime": now,
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected > 0 {
+		return nil
+	}
+	// 还没有统计数据，比如说历史数据，直接从关注关系里面算一遍。
+	// 当前事务内的关注关系变更已经可见了，所以不需要再加上 delta
+	return dao.resetStatics(tx, uid, now)
+}
+
+// resetStatics 用关注关系重新计算 uid 的统计数据
+func (dao *GORMFollowDAO) resetStatics(tx *gorm.DB, uid int64, now int64) error {
+	statics, err := dao.countStatics(tx, uid)
+	if err != nil {
+		return err
+	}
+	statics.Status = FollowStaticsStatusActive
+	statics.Ctime = now
+	statics.Utime = now
+	return tx.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "uid"}},
 		DoUpdates: clause.Assignments(map[string]interface{}{
-			"status": FollowRelationStatusActive,
-			"utime":  now,
+			"followers": statics.Followers,
+			"followees": statics.Followees,
+			"utime":     now,
 		}),
-	}).Create(&f).Error
+	}).Create(&statics).Error
+}
+
+func (dao *GORMFollowDAO) countStatics(tx *gorm.DB, uid int64) (FollowStatics, error) {
+	res := FollowStatics{Uid: uid}
+	err := tx.Model(&FollowRelation{}).
+		Where("followee = ? AND status = ?", uid, FollowRelationStatusActive).
+		Count(&res.Followers).Error
+	if err != nil {
+		return FollowStatics{}, err
+	}
+	err = tx.Model(&FollowRelation{}).
+		Where("follower = ? AND status = ?", uid, FollowRelationStatusActive).
+		Count(&res.Followees).Error
+	return res, err
+}
+
+func (dao *GORMFollowDAO) GetStatics(ctx context.Context, uid int64) (FollowStatics, error) {
+	var res FollowStatics
+	err := dao.db.WithContext(ctx).Where("uid = ?", uid).First(&res).Error
+	return res, err
+}
+
+func (dao *GORMFollowDAO) ListStatics(ctx context.Context, startId int64, limit int) ([]FollowStatics, error) {
+	var res []FollowStatics
+	err := dao.db.WithContext(ctx).
+		Where("id > ?", startId).
+		Order("id ASC").
+		Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMFollowDAO) ReconcileStatics(ctx context.Context, statics []FollowStatics) ([]int64, error) {
+	if len(statics) == 0 {
+		return nil, nil
+	}
+	uids := make([]int64, 0, len(statics))
+	for _, s := range statics {
+		uids = append(uids, s.Uid)
+	}
+	type cnt struct {
+		Uid int64
+		Cnt int64
+	}
+	db := dao.db.WithContext(ctx)
+	var followers, followees []cnt
+	err := db.Model(&FollowRelation{}).
+		Select("followee AS uid, COUNT(*) AS cnt").
+		Where("followee IN ? AND status = ?", uids, FollowRelationStatusActive).
+		Group("followee").
+		Scan(&followers).Error
+	if err != nil {
+		return nil, err
+	}
+	err = db.Model(&FollowRelation{}).
+		Select("follower AS uid, COUNT(*) AS cnt").
+		Where("follower IN ? AND status = ?", uids, FollowRelationStatusActive).
+		Group("follower").
+		Scan(&followees).Error
+	if err != nil {
+		return nil, err
+	}
+	followerMap := make(map[int64]int64, len(followers))
+	for _, c := range followers {
+		followerMap[c.Uid] = c.Cnt
+	}
+	followeeMap := make(map[int64]int64, len(followees))
+	for _, c := range followees {
+		followeeMap[c.Uid] = c.Cnt
+	}
+
+	var fixed []int64
+	now := time.Now().UnixMilli()
+	for _, s := range statics {
+		followerCnt, followeeCnt := followerMap[s.Uid], followeeMap[s.Uid]
+		if s.Followers == followerCnt && s.Followees == followeeCnt {
+			continue
+		}
+		// 这里用 utime 做乐观锁，如果期间有新的关注，那么就留到下一轮修复
+		res := db.Model(&FollowStatics{}).
+			Where("id = ? AND utime = ?", s.Id, s.Utime).
+			Updates(map[string]interface{}{
+				"followers": followerCnt,
+				"followees": followeeCnt,
+				"utime":     now,
+			})
+		if res.Error != nil {
+			return fixed, res.Error
+		}
+		if res.RowsAffected > 0 {
+			fixed = append(fixed, s.Uid)
+		}
+	}
+	return fixed, nil
 }
 
 func NewGORMFollowDAO(db *gorm.DB) FollowDAO {
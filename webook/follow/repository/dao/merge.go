@@ -0,0 +1,83 @@
+package dao
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+func (dao *GORMFollowDAO) MergeUser(ctx context.Context, srcUid int64, dstUid int64) ([]int64, error) {
+	var uids []int64
+	err := dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var relations []FollowRelation
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("follower = ? OR followee = ?", srcUid, srcUid).
+			Find(&relations).Error
+		if err != nil {
+			return err
+		}
+		now := time.Now().UnixMilli()
+		seen := make(map[int64]struct{}, len(relations))
+		for _, r := range relations {
+			follower, followee, other := dstUid, r.Followee, r.Followee
+			if r.Followee == srcUid {
+				follower, followee, other = r.Follower, dstUid, r.Follower
+			}
+			if _, ok := seen[other]; !ok && other != dstUid {
+				seen[other] = struct{}{}
+				uids = append(uids, other)
+			}
+			if follower == followee {
+				// src 和 dst 之间的关注，合并之后就是自己关注自己
+				err = tx.Delete(&FollowRelation{}, r.Id).Error
+				if err != nil {
+					return err
+				}
+				continue
+			}
+			var existing FollowRelation
+			err = tx.Where("follower = ? AND followee = ?", follower, followee).
+				Limit(1).Find(&existing).Error
+			if err != nil {
+				return err
+			}
+			if existing.Id == 0 {
+				err = tx.Model(&FollowRelation{}).Where("id = ?", r.Id).
+					Updates(map[string]any{
+						"follower": follower,
+						"followee": followee,
+						"utime":    now,
+					}).Error
+				if err != nil {
+					return err
+				}
+				continue
+			}
+			// dst 也有这条关注关系，只要有一边是有效的就保留有效
+			if r.Status == FollowRelationStatusActive && existing.Status != FollowRelationStatusActive {
+				err = tx.Model(&FollowRelation{}).Where("id = ?", existing.Id).
+					Updates(map[string]any{
+						"status": FollowRelationStatusActive,
+						"utime":  now,
+					}).Error
+				if err != nil {
+					return err
+				}
+			}
+			err = tx.Delete(&FollowRelation{}, r.Id).Error
+			if err != nil {
+				return err
+			}
+		}
+		// 重复的关注会让对方的计数变化，所以涉及到的人都重新算一遍
+		for _, uid := range append([]int64{srcUid, dstUid}, uids...) {
+			err = dao.resetStatics(tx, uid, now)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return uids, err
+}
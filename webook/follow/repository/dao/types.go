@@ -2,15 +2,31 @@ package dao
 
 import (
 	"context"
+	"gorm.io/gorm"
 )
 
+var ErrRecordNotFound = gorm.ErrRecordNotFound
+
 type FollowDAO interface {
-	CreateFollowRelation(ctx context.Context, followee int64, follower int64) error
-	UpdateStatus(ctx context.Context, followee int64, follower int64, status uint8) error
+	// CreateFollowRelation 和 UpdateStatus 会在同一个事务里面维护 FollowStatics，
+	// 返回的 bool 表示关注关系是否真的发生了变化
+	CreateFollowRelation(ctx context.Context, followee int64, follower int64) (bool, error)
+	UpdateStatus(ctx context.Context, followee int64, follower int64, status uint8) (bool, error)
 	GetFollowee(ctx context.Context, follower int64, offset int64, limit int64) ([]FollowRelation, error)
+	// GetFollower 按照 id 倒序查询粉丝列表，只返回 id 小于 minId 的数据
+	GetFollower(ctx context.Context, followee int64, minId int64, limit int64) ([]FollowRelation, error)
 	FollowRelationDetail(ctx context.Context, follower int64, followee int64) (FollowRelation, error)
+	// FindRelations 一次查询出 viewer 和 uids 之间双向的有效关注关系
+	FindRelations(ctx context.Context, viewer int64, uids []int64) ([]FollowRelation, error)
 	CntFollower(ctx context.Context, uid int64) (int64, error)
 	CntFollowee(ctx context.Context, uid int64) (int64, error)
+	GetStatics(ctx context.Context, uid int64) (FollowStatics, error)
+	// ListStatics 按照 id 升序遍历统计数据
+	ListStatics(ctx context.Context, startId int64, limit int) ([]FollowStatics, error)
+	// ReconcileStatics 用关注关系重新计算统计数据，返回被修正了的 uid
+	ReconcileStatics(ctx context.Context, statics []FollowStatics) ([]int64, error)
+	// MergeUser 把 srcUid 的关注和粉丝转给 dstUid，并且重新计算统计数据，返回关注关系变化了的另外一方
+	MergeUser(ctx context.Context, srcUid int64, dstUid int64) ([]int64, error)
 }
 
 const (
@@ -19,11 +35,17 @@ const (
 	FollowRelationStatusInactive
 )
 
+const (
+	FollowStaticsStatusUnknown uint8 = iota
+	FollowStaticsStatusActive
+)
+
 type FollowRelation struct {
 	Id       int64 `gorm:"column:id;autoIncrement;primaryKey;"`
 	Follower int64 `gorm:"uniqueIndex:follower_followee"`
-	Followee int64 `gorm:"uniqueIndex:follower_followee"`
-	Status   uint8
+	// followee_status 用于查询粉丝列表，InnoDB 二级索引自带主键，可以直接按照 id 排序
+	Followee int64 `gorm:"uniqueIndex:follower_followee;index:followee_status"`
+	Status   uint8 `gorm:"index:followee_status"`
 	Ctime    int64
 	Utime    int64
 }
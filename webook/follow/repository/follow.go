@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"github.com/basic-go-project-webook/webook/follow/domain"
 	"github.com/basic-go-project-webook/webook/follow/repository/cache"
 	"github.com/basic-go-project-webook/webook/follow/repository/dao"
@@ -13,8 +14,14 @@ type FollowRepository interface {
 	AddFollowRelation(ctx context.Context, followee int64, follower int64) error
 	InactiveFollowRelation(ctx context.Context, followee int64, follower int64) error
 	GetFollowee(ctx context.Context, follower int64, offset int64, limit int64) ([]domain.FollowRelation, error)
+	GetFollower(ctx context.Context, followee int64, minId int64, limit int64) ([]domain.FollowRelation, error)
 	FollowInfo(ctx context.Context, follower int64, followee int64) (domain.FollowRelation, error)
+	// GetRelations 返回的结果和 uids 一一对应
+	GetRelations(ctx context.Context, viewer int64, uids []int64) ([]domain.Relation, error)
 	GetFollowStatics(ctx context.Context, uid int64) (domain.FollowStatics, error)
+	// ReconcileStatics 修复 id 大于 startId 的一批统计数据，返回这一批最大的 id，没有数据的时候返回 startId
+	ReconcileStatics(ctx context.Context, startId int64, limit int) (int64, error)
+	MergeUser(ctx context.Context, srcUid int64, dstUid int64) error
 }
 
 type CachedFollowRepository struct {
@@ -28,6 +35,31 @@ func (c *CachedFollowRepository) GetFollowStatics(ctx context.Context, uid int64
 	if err == nil {
 		return res, nil
 	}
+	statics, err := c.dao.GetStatics(ctx, uid)
+	switch {
+	case err == nil:
+		res = domain.FollowStatics{
+			Followers: statics.Followers,
+			Followees: statics.Followees,
+		}
+	case errors.Is(err, dao.ErrRecordNotFound):
+		// 历史数据还没有统计记录，直接从关注关系里面统计
+		res, err = c.countStatics(ctx, uid)
+		if err != nil {
+			return domain.FollowStatics{}, err
+		}
+	default:
+		return domain.FollowStatics{}, err
+	}
+	err = c.cache.SetStaticsInfo(ctx, uid, res)
+	if err != nil {
+		zap.L().Error("redis 写入失败", zap.Error(err))
+	}
+	return res, nil
+}
+
+func (c *CachedFollowRepository) countStatics(ctx context.Context, uid int64) (domain.FollowStatics, error) {
+	var res domain.FollowStatics
 	var eg errgroup.Group
 	eg.Go(func() error {
 		followees, er := c.dao.CntFollowee(ctx, uid)
@@ -45,15 +77,48 @@ func (c *CachedFollowRepository) GetFollowStatics(ctx context.Context, uid int64
 		res.Followers = followers
 		return nil
 	})
-	err = eg.Wait()
+	err := eg.Wait()
+	return res, err
+}
+
+func (c *CachedFollowRepository) ReconcileStatics(ctx context.Context, startId int64, limit int) (int64, error) {
+	statics, err := c.dao.ListStatics(ctx, startId, limit)
+	if err != nil || len(statics) == 0 {
+		return startId, err
+	}
+	fixed, err := c.dao.ReconcileStatics(ctx, statics)
+	if len(fixed) > 0 {
+		zap.L().Warn("关注统计数据不一致，已修复", zap.Int64s("uids", fixed))
+		er := c.cache.DelStaticsInfo(ctx, fixed...)
+		if er != nil {
+			zap.L().Error("删除关注统计缓存失败", zap.Error(er), zap.Int64s("uids", fixed))
+		}
+	}
 	if err != nil {
-		return domain.FollowStatics{}, err
+		return startId, err
 	}
-	err = c.cache.SetStaticsInfo(ctx, uid, res)
+	return statics[len(statics)-1].Id, nil
+}
+
+func (c *CachedFollowRepository) MergeUser(ctx context.Context, srcUid int64, dstUid int64) error {
+	uids, err := c.dao.MergeUser(ctx, srcUid, dstUid)
 	if err != nil {
-		zap.L().Error("redis 写入失败", zap.Error(err))
+		return err
 	}
-	return res, nil
+	er := c.cache.DelStaticsInfo(ctx, append([]int64{srcUid, dstUid}, uids...)...)
+	if er != nil {
+		zap.L().Error("删除关注统计缓存失败", zap.Error(er), zap.Int64("srcUid", srcUid), zap.Int64("dstUid", dstUid))
+	}
+	for _, uid := range append([]int64{dstUid}, uids...) {
+		er = c.cache.DelRelation(ctx, srcUid, uid)
+		if er == nil && uid != dstUid {
+			er = c.cache.DelRelation(ctx, dstUid, uid)
+		}
+		if er != nil {
+			zap.L().Error("删除关注关系缓存失败", zap.Error(er), zap.Int64("uid", uid))
+		}
+	}
+	return nil
 }
 
 func (c *CachedFollowRepository) FollowInfo(ctx context.Context, follower int64, followee int64) (domain.FollowRelation, error) {
@@ -64,6 +129,57 @@ func (c *CachedFollowRepository) FollowInfo(ctx context.Context, follower int64,
 	return c.toDomain(val), nil
 }
 
+func (c *CachedFollowRepository) GetRelations(ctx context.Context, viewer int64, uids []int64) ([]domain.Relation, error) {
+	cached, err := c.cache.GetRelations(ctx, viewer, uids)
+	if err != nil {
+		// 缓存出问题了就全部查数据库
+		zap.L().Error("读取关注关系缓存失败", zap.Error(err), zap.Int64("viewer", viewer))
+		cached = map[int64]domain.Relation{}
+	}
+	missed := make([]int64, 0, len(uids))
+	for _, uid := range uids {
+		if _, ok := cached[uid]; !ok {
+			missed = append(missed, uid)
+		}
+	}
+	if len(missed) > 0 {
+		relations, err := c.dao.FindRelations(ctx, viewer, missed)
+		if err != nil {
+			return nil, err
+		}
+		loaded := make(map[int64]domain.Relation, len(missed))
+		for _, uid := range missed {
+			loaded[uid] = domain.Relation{Uid: uid}
+		}
+		for _, relation := range relations {
+			if relation.Follower == viewer {
+				r := loaded[relation.Followee]
+				r.Following = true
+				loaded[relation.Followee] = r
+			}
+			if relation.Followee == viewer {
+				r := loaded[relation.Follower]
+				r.Followed = true
+				loaded[relation.Follower] = r
+			}
+		}
+		toCache := make([]domain.Relation, 0, len(loaded))
+		for uid, relation := range loaded {
+			cached[uid] = relation
+			toCache = append(toCache, relation)
+		}
+		err = c.cache.SetRelations(ctx, viewer, toCache)
+		if err != nil {
+			zap.L().Error("写入关注关系缓存失败", zap.Error(err), zap.Int64("viewer", viewer))
+		}
+	}
+	res := make([]domain.Relation, 0, len(uids))
+	for _, uid := range uids {
+		res = append(res, cached[uid])
+	}
+	return res, nil
+}
+
 func (c *CachedFollowRepository) GetFollowee(ctx context.Context, follower int64, offset int64, limit int64) ([]domain.FollowRelation, error) {
 	followRelations, err := c.dao.GetFollowee(ctx, follower, offset, limit)
 	if err != nil {
@@ -76,12 +192,61 @@ func (c *CachedFollowRepository) GetFollowee(ctx context.Context, follower int64
 	return res, nil
 }
 
+func (c *CachedFollowRepository) GetFollower(ctx context.Context, followee int64, minId int64, limit int64) ([]domain.FollowRelation, error) {
+	followRelations, err := c.dao.GetFollower(ctx, followee, minId, limit)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.FollowRelation, 0, len(followRelations))
+	for _, followRelation := range followRelations {
+		res = append(res, c.toDomain(followRelation))
+	}
+	return res, nil
+}
+
 func (c *CachedFollowRepository) InactiveFollowRelation(ctx context.Context, followee int64, follower int64) error {
-	return c.dao.UpdateStatus(ctx, followee, follower, dao.FollowRelationStatusInactive)
+	changed, err := c.dao.UpdateStatus(ctx, followee, follower, dao.FollowRelationStatusInactive)
+	if err != nil || !changed {
+		return err
+	}
+	c.invalidRelation(ctx, followee, follower)
+	err = c.cache.CancelFollow(ctx, follower, followee)
+	if err != nil {
+		c.invalidStatics(ctx, err, followee, follower)
+	}
+	return nil
 }
 
 func (c *CachedFollowRepository) AddFollowRelation(ctx context.Context, followee int64, follower int64) error {
-	return c.dao.CreateFollowRelation(ctx, followee, follower)
+	changed, err := c.dao.CreateFollowRelation(ctx, followee, follower)
+	if err != nil || !changed {
+		return err
+	}
+	c.invalidRelation(ctx, followee, follower)
+	err = c.cache.Follow(ctx, follower, followee)
+	if err != nil {
+		c.invalidStatics(ctx, err, followee, follower)
+	}
+	return nil
+}
+
+// invalidStatics 数据库已经更新成功，缓存增量更新失败的时候直接删除缓存
+func (c *CachedFollowRepository) invalidStatics(ctx context.Context, err error, followee int64, follower int64) {
+	zap.L().Error("更新关注统计缓存失败", zap.Error(err),
+		zap.Int64("followee", followee), zap.Int64("follower", follower))
+	er := c.cache.DelStaticsInfo(ctx, followee, follower)
+	if er != nil {
+		zap.L().Error("删除关注统计缓存失败", zap.Error(er),
+			zap.Int64("followee", followee), zap.Int64("follower", follower))
+	}
+}
+
+func (c *CachedFollowRepository) invalidRelation(ctx context.Context, followee int64, follower int64) {
+	err := c.cache.DelRelation(ctx, follower, followee)
+	if err != nil {
+		zap.L().Error("删除关注关系缓存失败", zap.Error(err),
+			zap.Int64("followee", followee), zap.Int64("follower", follower))
+	}
 }
 
 func NewFollowRepository(dao dao.FollowDAO, cache cache.FollowCache) FollowRepository {
@@ -93,6 +258,7 @@ func NewFollowRepository(dao dao.FollowDAO, cache cache.FollowCache) FollowRepos
 
 func (c *CachedFollowRepository) toDomain(followRelation dao.FollowRelation) domain.FollowRelation {
 	return domain.FollowRelation{
+		Id:       followRelation.Id,
 		Followee: followRelation.Followee,
 		Follower: followRelation.Follower,
 	}
@@ -2,21 +2,68 @@ package service
 
 import (
 	"context"
+	"errors"
 	"github.com/basic-go-project-webook/webook/follow/domain"
 	"github.com/basic-go-project-webook/webook/follow/repository"
+	"math"
+)
+
+// MaxRelationBatchSize GetRelations 单次最多查询的 uid 数量
+const MaxRelationBatchSize = 100
+
+const (
+	// DefaultPageSize 关注列表和粉丝列表没有传 limit 的时候每页的数量
+	DefaultPageSize = 20
+	// MaxPageSize 关注列表和粉丝列表每页最多返回的数量
+	MaxPageSize = 100
+)
+
+var (
+	ErrTooManyUids   = errors.New("uid 数量超过上限")
+	ErrInvalidOffset = errors.New("offset 不能是负数")
 )
 
 type FollowService interface {
 	Follow(ctx context.Context, followee, follower int64) error
 	CancelFollow(ctx context.Context, followee, follower int64) error
 	GetFollowee(ctx context.Context, follower int64, offset int64, limit int64) ([]domain.FollowRelation, error)
+	// GetFollower 粉丝列表，minId 为 0 的时候从最新关注的开始
+	GetFollower(ctx context.Context, followee int64, minId int64, limit int64) ([]domain.FollowRelation, error)
 	FollowInfo(ctx context.Context, follower int64, followee int64) (domain.FollowRelation, error)
+	// GetRelations 批量查询 viewer 和 uids 之间的关注关系，重复的 uid 只会返回一次
+	GetRelations(ctx context.Context, viewer int64, uids []int64) ([]domain.Relation, error)
 	GetFollowStatics(ctx context.Context, uid int64) (domain.FollowStatics, error)
+	// ReconcileStatics 根据关注关系修复全部的统计数据
+	ReconcileStatics(ctx context.Context) error
+	// MergeUser 合并账号，把 srcUid 的关注和粉丝转给 dstUid
+	MergeUser(ctx context.Context, srcUid int64, dstUid int64) error
 }
 type followService struct {
 	repo repository.FollowRepository
 }
 
+func (f *followService) MergeUser(ctx context.Context, srcUid int64, dstUid int64) error {
+	return f.repo.MergeUser(ctx, srcUid, dstUid)
+}
+
+func (f *followService) ReconcileStatics(ctx context.Context) error {
+	const batchSize = 100
+	var startId int64
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		lastId, err := f.repo.ReconcileStatics(ctx, startId, batchSize)
+		if err != nil {
+			return err
+		}
+		if lastId == startId {
+			return nil
+		}
+		startId = lastId
+	}
+}
+
 func (f *followService) GetFollowStatics(ctx context.Context, uid int64) (domain.FollowStatics, error) {
 	return f.repo.GetFollowStatics(ctx, uid)
 }
@@ -26,8 +73,49 @@ func (f *followService) FollowInfo(ctx context.Context, follower int64, followee
 	return res, err
 }
 
+func (f *followService) GetRelations(ctx context.Context, viewer int64, uids []int64) ([]domain.Relation, error) {
+	if len(uids) > MaxRelationBatchSize {
+		return nil, ErrTooManyUids
+	}
+	seen := make(map[int64]struct{}, len(uids))
+	targets := make([]int64, 0, len(uids))
+	for _, uid := range uids {
+		// 自己和自己没有关注关系
+		if uid <= 0 || uid == viewer {
+			continue
+		}
+		if _, ok := seen[uid]; ok {
+			continue
+		}
+		seen[uid] = struct{}{}
+		targets = append(targets, uid)
+	}
+	if len(targets) == 0 {
+		return []domain.Relation{}, nil
+	}
+	return f.repo.GetRelations(ctx, viewer, targets)
+}
+
 func (f *followService) GetFollowee(ctx context.Context, follower int64, offset int64, limit int64) ([]domain.FollowRelation, error) {
-	return f.repo.GetFollowee(ctx, follower, offset, limit)
+	if offset < 0 {
+		return nil, ErrInvalidOffset
+	}
+	return f.repo.GetFollowee(ctx, follower, offset, pageSize(limit))
+}
+
+func (f *followService) GetFollower(ctx context.Context, followee int64, minId int64, limit int64) ([]domain.FollowRelation, error) {
+	if minId <= 0 {
+		minId = math.MaxInt64
+	}
+	return f.repo.GetFollower(ctx, followee, minId, pageSize(limit))
+}
+
+// pageSize 没有传 limit 的时候用默认值，超过上限的按照上限来
+func pageSize(limit int64) int64 {
+	if limit <= 0 {
+		return DefaultPageSize
+	}
+	return min(limit, MaxPageSize)
 }
 
 func (f *followService) Follow(ctx context.Context, followee, follower int64) error {
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"github.com/basic-go-project-webook/webook/follow/domain"
+	"github.com/basic-go-project-webook/webook/follow/repository"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// limitRepo 只记录传给 repository 的 limit
+type limitRepo struct {
+	repository.FollowRepository
+	limit int64
+}
+
+func (r *limitRepo) GetFollowee(ctx context.Context, follower int64, offset int64, limit int64) ([]domain.FollowRelation, error) {
+	r.limit = limit
+	return nil, nil
+}
+
+func (r *limitRepo) GetFollower(ctx context.Context, followee int64, minId int64, limit int64) ([]domain.FollowRelation, error) {
+	r.limit = limit
+	return nil, nil
+}
+
+func TestFollowService_PageSize(t *testing.T) {
+	testCases := []struct {
+		name      string
+		offset    int64
+		limit     int64
+		wantLimit int64
+		wantErr   error
+	}{
+		{name: "没有传 limit", limit: 0, wantLimit: DefaultPageSize},
+		{name: "负数 limit", limit: -1, wantLimit: DefaultPageSize},
+		{name: "正常 limit", limit: 10, wantLimit: 10},
+		{name: "超过上限", limit: 1 << 40, wantLimit: MaxPageSize},
+		{name: "负数 offset", offset: -1, limit: 10, wantErr: ErrInvalidOffset},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &limitRepo{}
+			svc := NewFollowService(repo)
+			_, err := svc.GetFollowee(context.Background(), 1, tc.offset, tc.limit)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantLimit, repo.limit)
+			if tc.wantErr != nil {
+				return
+			}
+
+			repo.limit = 0
+			_, err = svc.GetFollower(context.Background(), 1, 0, tc.limit)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantLimit, repo.limit)
+		})
+	}
+}
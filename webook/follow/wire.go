@@ -15,6 +15,7 @@ import (
 var thirdProvider = wire.NewSet(
 	ioc.InitDB,
 	ioc.InitRedis,
+	ioc.InitRlockClient,
 )
 
 var serviceProvider = wire.NewSet(
@@ -30,6 +31,8 @@ func InitApp() *App {
 		thirdProvider,
 		serviceProvider,
 		ioc.InitGRPCXServer,
+		ioc.InitStaticsReconcileJob,
+		ioc.InitJobs,
 		wire.Struct(new(App), "*"),
 	)
 	return new(App)
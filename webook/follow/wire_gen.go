@@ -27,14 +27,18 @@ func InitApp() *App {
 	followService := service.NewFollowService(followRepository)
 	followServiceServer := grpc.NewFollowServiceServer(followService)
 	server := ioc.InitGRPCXServer(followServiceServer)
+	client := ioc.InitRlockClient(cmdable)
+	staticsReconcileJob := ioc.InitStaticsReconcileJob(followService, client)
+	cron := ioc.InitJobs(staticsReconcileJob)
 	app := &App{
 		server: server,
+		cron:   cron,
 	}
 	return app
 }
 
 // wire.go:
 
-var thirdProvider = wire.NewSet(ioc.InitDB, ioc.InitRedis)
+var thirdProvider = wire.NewSet(ioc.InitDB, ioc.InitRedis, ioc.InitRlockClient)
 
 var serviceProvider = wire.NewSet(dao.NewGORMFollowDAO, cache.NewRedisFollowCache, repository.NewFollowRepository, service.NewFollowService, grpc.NewFollowServiceServer)
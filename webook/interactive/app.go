@@ -1,6 +1,7 @@
 package main
 
 import (
+	"github.com/basic-go-project-webook/webook/interactive/events"
 	"github.com/basic-go-project-webook/webook/pkg/ginx"
 	"github.com/basic-go-project-webook/webook/pkg/grpcx"
 	"github.com/basic-go-project-webook/webook/pkg/kafkax"
@@ -11,4 +12,5 @@ type App struct {
 	server      *grpcx.Server
 	consumers   []kafkax.Consumer
 	adminServer *ginx.Server
+	producer    events.Producer
 }
@@ -1,5 +1,7 @@
 package domain
 
+import "time"
+
 type Interactive struct {
 	Biz        string
 	BizId      int64
@@ -9,3 +11,22 @@ type Interactive struct {
 	Liked      bool
 	Collected  bool
 }
+
+// Collection 收藏夹，Id 为 0 的是每个用户都有的默认收藏夹，不会落库
+type Collection struct {
+	Id      int64
+	Uid     int64
+	Name    string
+	Private bool
+	Ctime   time.Time
+	Utime   time.Time
+}
+
+// CollectionItem 收藏夹里面收藏的资源
+type CollectionItem struct {
+	Id    int64
+	Cid   int64
+	Biz   string
+	BizId int64
+	Ctime time.Time
+}
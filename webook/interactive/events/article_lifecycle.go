@@ -0,0 +1,102 @@
+package events
+
+import (
+	"context"
+	"github.com/basic-go-project-webook/webook/interactive/repository"
+	"github.com/basic-go-project-webook/webook/pkg/kafkax"
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+	"time"
+)
+
+const (
+	topicPublishedArticle = "published-article"
+	topicWithdrawnArticle = "withdrawn-article"
+	// articleEventVersion 能够处理的最高的文章事件版本
+	articleEventVersion = 1
+)
+
+// ArticleWithdrawnEvent 和 webook 的 article.ArticleWithdrawn 保持一致
+type ArticleWithdrawnEvent struct {
+	Version int
+	Aid     int64
+	Uid     int64
+	Utime   int64
+}
+
+// ArticleWithdrawnEventConsumer 文章撤回之后隐藏计数。
+// 点赞和收藏不能删，重新发表之后还要能看到
+type ArticleWithdrawnEventConsumer struct {
+	*kafkax.HandlerConsumer[ArticleWithdrawnEvent]
+	repo repository.InteractiveRepository
+}
+
+func NewArticleWithdrawnEventConsumer(addrs []string, repo repository.InteractiveRepository,
+	dlq *kafka.Writer) *ArticleWithdrawnEventConsumer {
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:  addrs,
+		GroupID:  "interactive",
+		Topic:    topicWithdrawnArticle,
+		MinBytes: 10e3,
+		MaxBytes: 10e6,
+	})
+	c := &ArticleWithdrawnEventConsumer{
+		repo: repo,
+	}
+	c.HandlerConsumer = kafkax.NewHandlerConsumer[ArticleWithdrawnEvent](reader,
+		func(ctx context.Context, msg kafka.Message, evt ArticleWithdrawnEvent) error {
+			return c.Consume(ctx, evt)
+		}).DLQ(dlq)
+	return c
+}
+
+func (c *ArticleWithdrawnEventConsumer) Consume(ctx context.Context, evt ArticleWithdrawnEvent) error {
+	if evt.Version > articleEventVersion {
+		zap.L().Warn("不认识的文章事件版本，跳过", zap.Int("version", evt.Version), zap.Int64("aid", evt.Aid))
+		return nil
+	}
+	// 重复消费也没有关系，比已经处理过的旧的事件会被忽略
+	return c.repo.SetHidden(ctx, "article", evt.Aid, true, time.UnixMilli(evt.Utime))
+}
+
+// ArticlePublishedEvent 和 webook 的 article.ArticlePublished 保持一致，只用到了下面这几个字段
+type ArticlePublishedEvent struct {
+	Version int
+	Aid     int64
+	Uid     int64
+	Utime   int64
+}
+
+// ArticlePublishedEventConsumer 撤回之后重新发表的文章恢复计数。
+// 第一次发表的时候也会写入一条可见的记录，没有副作用
+type ArticlePublishedEventConsumer struct {
+	*kafkax.HandlerConsumer[ArticlePublishedEvent]
+	repo repository.InteractiveRepository
+}
+
+func NewArticlePublishedEventConsumer(addrs []string, repo repository.InteractiveRepository,
+	dlq *kafka.Writer) *ArticlePublishedEventConsumer {
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:  addrs,
+		GroupID:  "interactive",
+		Topic:    topicPublishedArticle,
+		MinBytes: 10e3,
+		MaxBytes: 10e6,
+	})
+	c := &ArticlePublishedEventConsumer{
+		repo: repo,
+	}
+	c.HandlerConsumer = kafkax.NewHandlerConsumer[ArticlePublishedEvent](reader,
+		func(ctx context.Context, msg kafka.Message, evt ArticlePublishedEvent) error {
+			return c.Consume(ctx, evt)
+		}).DLQ(dlq)
+	return c
+}
+
+func (c *ArticlePublishedEventConsumer) Consume(ctx context.Context, evt ArticlePublishedEvent) error {
+	if evt.Version > articleEventVersion {
+		zap.L().Warn("不认识的文章事件版本，跳过", zap.Int("version", evt.Version), zap.Int64("aid", evt.Aid))
+		return nil
+	}
+	return c.repo.SetHidden(ctx, "article", evt.Aid, false, time.UnixMilli(evt.Utime))
+}
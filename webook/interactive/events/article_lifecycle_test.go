@@ -0,0 +1,114 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"github.com/basic-go-project-webook/webook/interactive/domain"
+	"github.com/basic-go-project-webook/webook/interactive/repository"
+	"github.com/basic-go-project-webook/webook/interactive/repository/cache"
+	"github.com/basic-go-project-webook/webook/interactive/repository/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// memDAO 只实现了测试用到的方法，调用别的方法会直接 panic，
+// 所以撤回的时候动了计数、点赞或者收藏，测试就会失败
+type memDAO struct {
+	dao.InteractiveDAO
+	intrs map[int64]dao.Interactive
+}
+
+func (d *memDAO) GetByIds(ctx context.Context, biz string, ids []int64) ([]dao.Interactive, error) {
+	res := make([]dao.Interactive, 0, len(ids))
+	for _, id := range ids {
+		if intr, ok := d.intrs[id]; ok {
+			res = append(res, intr)
+		}
+	}
+	return res, nil
+}
+
+func (d *memDAO) Get(ctx context.Context, biz string, bizId int64) (dao.Interactive, error) {
+	intr, ok := d.intrs[bizId]
+	if !ok {
+		return dao.Interactive{}, dao.ErrRecordNotFount
+	}
+	return intr, nil
+}
+
+func (d *memDAO) SetStatus(ctx context.Context, biz string, bizId int64, status uint8, statusUtime int64) error {
+	intr := d.intrs[bizId]
+	if intr.StatusUtime < statusUtime {
+		intr.Status = status
+		intr.StatusUtime = statusUtime
+	}
+	d.intrs[bizId] = intr
+	return nil
+}
+
+type memCache struct {
+	cache.InteractiveCache
+	intrs map[int64]domain.Interactive
+}
+
+func (c *memCache) Get(ctx context.Context, biz string, bizId int64) (domain.Interactive, error) {
+	intr, ok := c.intrs[bizId]
+	if !ok {
+		return domain.Interactive{}, errors.New("缓存不存在")
+	}
+	return intr, nil
+}
+
+func (c *memCache) Set(ctx context.Context, biz string, bizId int64, intr domain.Interactive) error {
+	c.intrs[bizId] = intr
+	return nil
+}
+
+func (c *memCache) Del(ctx context.Context, biz string, bizId int64) error {
+	delete(c.intrs, bizId)
+	return nil
+}
+
+func TestArticleWithdrawnEventConsumer_Republish(t *testing.T) {
+	d := &memDAO{intrs: map[int64]dao.Interactive{
+		1: {BizId: 1, Biz: "article", ReadCnt: 10, LikeCnt: 3, CollectCnt: 2},
+	}}
+	ca := &memCache{intrs: map[int64]domain.Interactive{}}
+	repo := repository.NewCachedInteractiveRepository(d, ca)
+	ctx := context.Background()
+
+	before, err := repo.Get(ctx, "article", 1)
+	require.NoError(t, err)
+	assert.Contains(t, ca.intrs, int64(1))
+
+	// 撤回之后计数隐藏，并且不会回写缓存
+	withdrawn := &ArticleWithdrawnEventConsumer{repo: repo}
+	err = withdrawn.Consume(ctx, ArticleWithdrawnEvent{Version: articleEventVersion, Aid: 1, Uid: 2, Utime: 200})
+	require.NoError(t, err)
+	assert.NotContains(t, ca.intrs, int64(1))
+	hidden, err := repo.Get(ctx, "article", 1)
+	require.NoError(t, err)
+	assert.Equal(t, domain.Interactive{Biz: "article", BizId: 1}, hidden)
+	assert.NotContains(t, ca.intrs, int64(1))
+	intrs, err := repo.GetByIds(ctx, "article", []int64{1})
+	require.NoError(t, err)
+	assert.Equal(t, []domain.Interactive{hidden}, intrs)
+
+	// 比撤回早的发表事件晚到了，不能恢复
+	published := &ArticlePublishedEventConsumer{repo: repo}
+	err = published.Consume(ctx, ArticlePublishedEvent{Version: articleEventVersion, Aid: 1, Uid: 2, Utime: 100})
+	require.NoError(t, err)
+	stale, err := repo.Get(ctx, "article", 1)
+	require.NoError(t, err)
+	assert.Equal(t, hidden, stale)
+
+	// 重新发表之后计数还在
+	err = published.Consume(ctx, ArticlePublishedEvent{Version: articleEventVersion, Aid: 1, Uid: 2, Utime: 300})
+	require.NoError(t, err)
+	after, err := repo.Get(ctx, "article", 1)
+	require.NoError(t, err)
+	assert.Equal(t, before, after)
+	assert.Equal(t, int64(3), after.LikeCnt)
+	assert.Equal(t, int64(2), after.CollectCnt)
+}
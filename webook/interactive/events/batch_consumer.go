@@ -0,0 +1,62 @@
+package events
+
+import (
+	"context"
+	"github.com/basic-go-project-webook/webook/interactive/repository"
+	"github.com/basic-go-project-webook/webook/pkg/kafkax"
+	"github.com/segmentio/kafka-go"
+	"time"
+)
+
+// InteractiveReadEventBatchConsumer 批量消费阅读事件。
+// 攒够 batchSize 条消息或者等满 window 之后，按照 (biz, bizId) 聚合成一次批量 upsert，
+// 写入成功之后才提交偏移量，所以最坏情况是重复计数，而不会丢失计数。
+type InteractiveReadEventBatchConsumer struct {
+	*kafkax.BatchHandlerConsumer[ReadEvent]
+	repo repository.InteractiveRepository
+}
+
+func NewInteractiveReadEventBatchConsumer(addrs []string, repo repository.InteractiveRepository,
+	dlq *kafka.Writer, batchSize int, window time.Duration) *InteractiveReadEventBatchConsumer {
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:  addrs,
+		GroupID:  "interactive",
+		Topic:    "read-article",
+		MinBytes: 10e3,
+		MaxBytes: 10e6,
+	})
+	c := &InteractiveReadEventBatchConsumer{
+		repo: repo,
+	}
+	// 阅读数不能丢，一直重试到成功为止
+	c.BatchHandlerConsumer = kafkax.NewBatchHandlerConsumer[ReadEvent](reader,
+		func(ctx context.Context, msgs []kafka.Message, evts []ReadEvent) error {
+			return c.Consume(ctx, evts)
+		}, batchSize, window).
+		Retry(-1, time.Millisecond*100, time.Second*10).
+		DLQ(dlq)
+	return c
+}
+
+// Consume 按照 (biz, bizId) 聚合之后一次写入
+func (i *InteractiveReadEventBatchConsumer) Consume(ctx context.Context, evts []ReadEvent) error {
+	if len(evts) == 0 {
+		return nil
+	}
+	const biz = "article"
+	cnts := make(map[int64]int64, len(evts))
+	bizIds := make([]int64, 0, len(evts))
+	for _, evt := range evts {
+		if _, ok := cnts[evt.Aid]; !ok {
+			bizIds = append(bizIds, evt.Aid)
+		}
+		cnts[evt.Aid]++
+	}
+	bizs := make([]string, 0, len(bizIds))
+	incrs := make([]int64, 0, len(bizIds))
+	for _, bizId := range bizIds {
+		bizs = append(bizs, biz)
+		incrs = append(incrs, cnts[bizId])
+	}
+	return i.repo.BatchIncrReadCnt(ctx, bizs, bizIds, incrs)
+}
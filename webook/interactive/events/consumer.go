@@ -2,48 +2,24 @@ package events
 
 import (
 	"context"
-	"encoding/json"
 	"github.com/basic-go-project-webook/webook/interactive/repository"
+	"github.com/basic-go-project-webook/webook/pkg/kafkax"
 	"github.com/segmentio/kafka-go"
-	"go.uber.org/zap"
 )
 
+// InteractiveReadEventConsumer 逐条消费阅读事件，
+// 流量大的时候用 InteractiveReadEventBatchConsumer
 type InteractiveReadEventConsumer struct {
-	reader *kafka.Reader
-	repo   repository.InteractiveRepository
-}
-
-func (i *InteractiveReadEventConsumer) Start() {
-	go func() {
-		for {
-			msg, err := i.reader.ReadMessage(context.Background())
-			if err != nil {
-				zap.L().Error("kafka 读取消息失败", zap.Error(err))
-				continue
-			}
-			var evt ReadEvent
-			err = json.Unmarshal(msg.Value, &evt)
-			if err != nil {
-				zap.L().Error("kafka 反序列化消息失败", zap.Error(err))
-				continue
-			}
-			err = i.Consume(context.Background(), evt)
-			if err != nil {
-				zap.L().Error("kafka 消费消息失败", zap.Error(err))
-				continue
-			}
-		}
-	}()
-}
-
-func (i *InteractiveReadEventConsumer) Close() error {
-	return i.reader.Close()
+	*kafkax.HandlerConsumer[ReadEvent]
+	repo repository.InteractiveRepository
 }
 
 func (i *InteractiveReadEventConsumer) Consume(ctx context.Context, evt ReadEvent) error {
 	return i.repo.IncrReadCnt(ctx, "article", evt.Aid)
 }
-func NewInteractiveReadEventConsumer(addrs []string, repo repository.InteractiveRepository) *InteractiveReadEventConsumer {
+
+func NewInteractiveReadEventConsumer(addrs []string, repo repository.InteractiveRepository,
+	dlq *kafka.Writer) *InteractiveReadEventConsumer {
 	reader := kafka.NewReader(kafka.ReaderConfig{
 		Brokers:  addrs,
 		GroupID:  "interactive",
@@ -51,8 +27,12 @@ func NewInteractiveReadEventConsumer(addrs []string, repo repository.Interactive
 		MinBytes: 10e3,
 		MaxBytes: 10e6,
 	})
-	return &InteractiveReadEventConsumer{
-		reader: reader,
-		repo:   repo,
+	c := &InteractiveReadEventConsumer{
+		repo: repo,
 	}
+	c.HandlerConsumer = kafkax.NewHandlerConsumer[ReadEvent](reader,
+		func(ctx context.Context, msg kafka.Message, evt ReadEvent) error {
+			return c.Consume(ctx, evt)
+		}).DLQ(dlq)
+	return c
 }
@@ -0,0 +1,155 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	TopicInteractive = "interactive"
+	// InteractiveEventVersion 事件结构有不兼容的变化的时候加一
+	InteractiveEventVersion = 1
+
+	ActionLike          = "like"
+	ActionCancelLike    = "cancel_like"
+	ActionCollect       = "collect"
+	ActionCancelCollect = "cancel_collect"
+)
+
+// InteractiveEvent 点赞、收藏之类的互动，给热榜之类的下游用。
+// 阅读事件还是 webook 发的 read-article
+type InteractiveEvent struct {
+	Version int
+	Biz     string
+	BizId   int64
+	Uid     int64
+	Action  string
+	Ctime   int64
+}
+
+// ErrQueueFull 发送跟不上的时候直接丢弃新的事件，不阻塞点赞、收藏
+var ErrQueueFull = errors.New("互动事件队列已满")
+
+var ErrProducerClosed = errors.New("互动事件 producer 已经关闭")
+
+type Producer interface {
+	// ProduceInteractiveEvent 只是把事件放进队列，不会阻塞，
+	// 队列满了返回 ErrQueueFull，关闭之后返回 ErrProducerClosed
+	ProduceInteractiveEvent(ctx context.Context, evt InteractiveEvent) error
+	// Close 不再接受新的事件，等队列里面的事件发送完
+	Close() error
+}
+
+type messageWriter interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+}
+
+// KafkaProducer 用一个有界的队列和一个后台 goroutine 批量发送，
+// 点赞、收藏再多也只有一个 goroutine 在发
+type KafkaProducer struct {
+	writer messageWriter
+	queue  chan kafka.Message
+	// 一次最多发送多少条
+	batchSize int
+	// 发送一批的超时时间
+	timeout time.Duration
+
+	mu     sync.RWMutex
+	closed bool
+	done   chan struct{}
+}
+
+func NewKafkaProducer(addrs []string) Producer {
+	return newKafkaProducer(&kafka.Writer{
+		Addr:     kafka.TCP(addrs...),
+		Balancer: &kafka.Hash{},
+		// 默认要攒 1s 才发送一批，队列里面已经是攒好的一批了
+		BatchTimeout: time.Millisecond * 10,
+		RequiredAcks: kafka.RequireAll,
+	}, 10000, 100, time.Second*10)
+}
+
+func newKafkaProducer(writer messageWriter, queueSize int, batchSize int, timeout time.Duration) *KafkaProducer {
+	k := &KafkaProducer{
+		writer:    writer,
+		queue:     make(chan kafka.Message, queueSize),
+		batchSize: batchSize,
+		timeout:   timeout,
+		done:      make(chan struct{}),
+	}
+	go k.loop()
+	return k
+}
+
+func (k *KafkaProducer) ProduceInteractiveEvent(ctx context.Context, evt InteractiveEvent) error {
+	data, err := json.Marshal(evt)
+	if err != nil {
+		return err
+	}
+	msg := kafka.Message{
+		Topic: TopicInteractive,
+		// 同一个资源的事件进同一个分区
+		Key:   []byte(evt.Biz + ":" + strconv.FormatInt(evt.BizId, 10)),
+		Value: data,
+	}
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	if k.closed {
+		return ErrProducerClosed
+	}
+	select {
+	case k.queue <- msg:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+func (k *KafkaProducer) Close() error {
+	k.mu.Lock()
+	if !k.closed {
+		k.closed = true
+		close(k.queue)
+	}
+	k.mu.Unlock()
+	<-k.done
+	return nil
+}
+
+func (k *KafkaProducer) loop() {
+	defer close(k.done)
+	batch := make([]kafka.Message, 0, k.batchSize)
+	for msg := range k.queue {
+		batch = append(batch[:0], msg)
+		// 队列里面已经有的一起发，没有就不等了
+	fill:
+		for len(batch) < k.batchSize {
+			select {
+			case msg, ok := <-k.queue:
+				if !ok {
+					break fill
+				}
+				batch = append(batch, msg)
+			default:
+				break fill
+			}
+		}
+		k.send(batch)
+	}
+}
+
+func (k *KafkaProducer) send(batch []kafka.Message) {
+	ctx, cancel := context.WithTimeout(context.Background(), k.timeout)
+	defer cancel()
+	// kafka.Writer 自己会重试，到这里还失败的只能丢掉
+	err := k.writer.WriteMessages(ctx, batch...)
+	if err != nil {
+		zap.L().Error("发送互动事件失败", zap.Error(err), zap.Int("size", len(batch)))
+	}
+}
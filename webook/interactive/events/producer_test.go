@@ -0,0 +1,55 @@
+package events
+
+import (
+	"context"
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sync"
+	"testing"
+	"time"
+)
+
+// blockingWriter 在 release 关闭之前不返回，用来模拟 kafka 发送得比较慢
+type blockingWriter struct {
+	release chan struct{}
+	mu      sync.Mutex
+	batches [][]kafka.Message
+}
+
+func (w *blockingWriter) WriteMessages(ctx context.Context, msgs ...kafka.Message) error {
+	<-w.release
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.batches = append(w.batches, append([]kafka.Message(nil), msgs...))
+	return nil
+}
+
+func TestKafkaProducer_ProduceInteractiveEvent(t *testing.T) {
+	w := &blockingWriter{release: make(chan struct{})}
+	p := newKafkaProducer(w, 3, 2, time.Second)
+	ctx := context.Background()
+	evt := InteractiveEvent{Version: InteractiveEventVersion, Biz: "article", BizId: 1, Uid: 2, Action: ActionLike}
+
+	// 第一条被后台 goroutine 取走，卡在发送上
+	require.NoError(t, p.ProduceInteractiveEvent(ctx, evt))
+	require.Eventually(t, func() bool {
+		return len(p.queue) == 0
+	}, time.Second, time.Millisecond)
+	// 之后的三条把队列塞满，第五条不阻塞，直接返回错误
+	for i := 0; i < 3; i++ {
+		require.NoError(t, p.ProduceInteractiveEvent(ctx, evt))
+	}
+	assert.Equal(t, ErrQueueFull, p.ProduceInteractiveEvent(ctx, evt))
+
+	// 关闭的时候把队列里面剩下的发完，每一批最多两条
+	close(w.release)
+	require.NoError(t, p.Close())
+	assert.Equal(t, ErrProducerClosed, p.ProduceInteractiveEvent(ctx, evt))
+	sizes := make([]int, 0, len(w.batches))
+	for _, batch := range w.batches {
+		sizes = append(sizes, len(batch))
+	}
+	assert.Equal(t, []int{1, 2, 1}, sizes)
+	assert.Equal(t, []byte("article:1"), w.batches[0][0].Key)
+}
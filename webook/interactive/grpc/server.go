@@ -2,9 +2,11 @@ package grpc
 
 import (
 	"context"
+	"errors"
 	"github.com/basic-go-project-webook/webook/api/proto/gen/intr/v1"
 	"github.com/basic-go-project-webook/webook/interactive/domain"
 	"github.com/basic-go-project-webook/webook/interactive/service"
+	"github.com/basic-go-project-webook/webook/pkg/grpcx/interceptors/auth"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -27,6 +29,9 @@ func (i *InteractiveServiceServer) Like(ctx context.Context, request *intrv1.Lik
 	if request.GetUid() <= 0 {
 		return nil, status.Error(codes.InvalidArgument, "uid 错误")
 	}
+	if err := auth.CheckUid(ctx, request.GetUid()); err != nil {
+		return nil, err
+	}
 	err := i.svc.Like(ctx, request.GetBiz(), request.GetBizId(), request.GetUid())
 	return &intrv1.LikeResponse{}, err
 }
@@ -35,6 +40,9 @@ func (i *InteractiveServiceServer) CancelLike(ctx context.Context, request *intr
 	if request.GetUid() <= 0 {
 		return nil, status.Error(codes.InvalidArgument, "uid 错误")
 	}
+	if err := auth.CheckUid(ctx, request.GetUid()); err != nil {
+		return nil, err
+	}
 	err := i.svc.CancelLike(ctx, request.GetBiz(), request.GetBizId(), request.GetUid())
 	return &intrv1.CancelLikeResponse{}, err
 }
@@ -51,11 +59,137 @@ func (i *InteractiveServiceServer) Collect(ctx context.Context, request *intrv1.
 	if request.GetUid() <= 0 {
 		return nil, status.Error(codes.InvalidArgument, "uid 错误")
 	}
+	if err := auth.CheckUid(ctx, request.GetUid()); err != nil {
+		return nil, err
+	}
 	err := i.svc.Collect(ctx, request.GetBiz(), request.GetBizId(), request.GetCid(), request.GetUid())
-	return &intrv1.CollectResponse{}, err
+	if err != nil {
+		return nil, i.toStatusErr(err)
+	}
+	return &intrv1.CollectResponse{}, nil
+}
+
+func (i *InteractiveServiceServer) CancelCollect(ctx context.Context, request *intrv1.CancelCollectRequest) (*intrv1.CancelCollectResponse, error) {
+	if request.GetUid() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "uid 错误")
+	}
+	if err := auth.CheckUid(ctx, request.GetUid()); err != nil {
+		return nil, err
+	}
+	err := i.svc.CancelCollect(ctx, request.GetBiz(), request.GetBizId(), request.GetUid())
+	return &intrv1.CancelCollectResponse{}, err
+}
+
+func (i *InteractiveServiceServer) MoveCollectionItem(ctx context.Context, request *intrv1.MoveCollectionItemRequest) (*intrv1.MoveCollectionItemResponse, error) {
+	if request.GetUid() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "uid 错误")
+	}
+	if err := auth.CheckUid(ctx, request.GetUid()); err != nil {
+		return nil, err
+	}
+	err := i.svc.MoveCollectionItem(ctx, request.GetBiz(), request.GetBizId(), request.GetUid(), request.GetCid())
+	if err != nil {
+		return nil, i.toStatusErr(err)
+	}
+	return &intrv1.MoveCollectionItemResponse{}, nil
+}
+
+func (i *InteractiveServiceServer) CreateCollection(ctx context.Context, request *intrv1.CreateCollectionRequest) (*intrv1.CreateCollectionResponse, error) {
+	c := request.GetCollection()
+	if c.GetUid() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "uid 错误")
+	}
+	if err := auth.CheckUid(ctx, c.GetUid()); err != nil {
+		return nil, err
+	}
+	if c.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "收藏夹名字不能为空")
+	}
+	id, err := i.svc.CreateCollection(ctx, i.toCollectionDomain(c))
+	if err != nil {
+		return nil, err
+	}
+	return &intrv1.CreateCollectionResponse{Id: id}, nil
+}
+
+func (i *InteractiveServiceServer) UpdateCollection(ctx context.Context, request *intrv1.UpdateCollectionRequest) (*intrv1.UpdateCollectionResponse, error) {
+	c := request.GetCollection()
+	if c.GetUid() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "uid 错误")
+	}
+	if err := auth.CheckUid(ctx, c.GetUid()); err != nil {
+		return nil, err
+	}
+	if c.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "收藏夹名字不能为空")
+	}
+	err := i.svc.UpdateCollection(ctx, i.toCollectionDomain(c))
+	if err != nil {
+		return nil, i.toStatusErr(err)
+	}
+	return &intrv1.UpdateCollectionResponse{}, nil
+}
+
+func (i *InteractiveServiceServer) DeleteCollection(ctx context.Context, request *intrv1.DeleteCollectionRequest) (*intrv1.DeleteCollectionResponse, error) {
+	if request.GetUid() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "uid 错误")
+	}
+	if err := auth.CheckUid(ctx, request.GetUid()); err != nil {
+		return nil, err
+	}
+	err := i.svc.DeleteCollection(ctx, request.GetId(), request.GetUid())
+	if err != nil {
+		return nil, i.toStatusErr(err)
+	}
+	return &intrv1.DeleteCollectionResponse{}, nil
+}
+
+func (i *InteractiveServiceServer) ListCollections(ctx context.Context, request *intrv1.ListCollectionsRequest) (*intrv1.ListCollectionsResponse, error) {
+	if err := auth.CheckUid(ctx, request.GetViewer()); err != nil {
+		return nil, err
+	}
+	collections, err := i.svc.ListCollections(ctx, request.GetUid(), request.GetViewer(),
+		int(request.GetOffset()), int(request.GetLimit()))
+	if err != nil {
+		return nil, i.toStatusErr(err)
+	}
+	res := make([]*intrv1.Collection, 0, len(collections))
+	for _, c := range collections {
+		res = append(res, i.toCollectionDTO(c))
+	}
+	return &intrv1.ListCollectionsResponse{
+		Collections: res,
+	}, nil
+}
+
+func (i *InteractiveServiceServer) ListCollectionItems(ctx context.Context, request *intrv1.ListCollectionItemsRequest) (*intrv1.ListCollectionItemsResponse, error) {
+	if err := auth.CheckUid(ctx, request.GetViewer()); err != nil {
+		return nil, err
+	}
+	items, err := i.svc.ListCollectionItems(ctx, request.GetUid(), request.GetCid(), request.GetViewer(),
+		int(request.GetOffset()), int(request.GetLimit()))
+	if err != nil {
+		return nil, i.toStatusErr(err)
+	}
+	res := make([]*intrv1.CollectionItem, 0, len(items))
+	for _, item := range items {
+		res = append(res, &intrv1.CollectionItem{
+			Id:    item.Id,
+			Cid:   item.Cid,
+			Biz:   item.Biz,
+			BizId: item.BizId,
+			Ctime: item.Ctime.UnixMilli(),
+		})
+	}
+	return &intrv1.ListCollectionItemsResponse{
+		Items: res,
+	}, nil
 }
 
 func (i *InteractiveServiceServer) Get(ctx context.Context, request *intrv1.GetRequest) (*intrv1.GetResponse, error) {
+	if err := auth.CheckUid(ctx, request.GetUid()); err != nil {
+		return nil, err
+	}
 	intr, err := i.svc.Get(ctx, request.GetBiz(), request.GetBizId(), request.GetUid())
 	if err != nil {
 		return nil, err
@@ -79,6 +213,73 @@ func (i *InteractiveServiceServer) GetByIds(ctx context.Context, request *intrv1
 	}, nil
 }
 
+func (i *InteractiveServiceServer) GetByIdsWithUser(ctx context.Context, request *intrv1.GetByIdsWithUserRequest) (*intrv1.GetByIdsWithUserResponse, error) {
+	if request.GetUid() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "uid 错误")
+	}
+	if err := auth.CheckUid(ctx, request.GetUid()); err != nil {
+		return nil, err
+	}
+	res, err := i.svc.GetByIdsWithUser(ctx, request.GetBiz(), request.GetIds(), request.GetUid())
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[int64]*intrv1.Interactive, len(res))
+	for bizId, intr := range res {
+		m[bizId] = i.toDTO(intr)
+	}
+	return &intrv1.GetByIdsWithUserResponse{
+		Intrs: m,
+	}, nil
+}
+
+func (i *InteractiveServiceServer) MergeUser(ctx context.Context, request *intrv1.MergeUserRequest) (*intrv1.MergeUserResponse, error) {
+	if request.GetSrcUid() <= 0 || request.GetDstUid() <= 0 || request.GetSrcUid() == request.GetDstUid() {
+		return nil, status.Error(codes.InvalidArgument, "uid 错误")
+	}
+	// srcUid 是不是这个用户的，只有 webook 验证合并凭证的时候知道，所以只接受服务调用
+	if _, ok := auth.Service(ctx); !ok {
+		return nil, status.Error(codes.PermissionDenied, "只接受内部服务调用")
+	}
+	err := i.svc.MergeUser(ctx, request.GetSrcUid(), request.GetDstUid())
+	if err != nil {
+		return nil, err
+	}
+	return &intrv1.MergeUserResponse{}, nil
+}
+
+// toStatusErr 把业务错误转换成 grpc 的错误码
+func (i *InteractiveServiceServer) toStatusErr(err error) error {
+	switch {
+	case errors.Is(err, service.ErrCollectionNotFound), errors.Is(err, service.ErrNotCollected):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrInvalidOffset):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return err
+	}
+}
+
+func (i *InteractiveServiceServer) toCollectionDomain(c *intrv1.Collection) domain.Collection {
+	return domain.Collection{
+		Id:      c.GetId(),
+		Uid:     c.GetUid(),
+		Name:    c.GetName(),
+		Private: c.GetPrivate(),
+	}
+}
+
+func (i *InteractiveServiceServer) toCollectionDTO(c domain.Collection) *intrv1.Collection {
+	return &intrv1.Collection{
+		Id:      c.Id,
+		Uid:     c.Uid,
+		Name:    c.Name,
+		Private: c.Private,
+		Ctime:   c.Ctime.UnixMilli(),
+		Utime:   c.Utime.UnixMilli(),
+	}
+}
+
 // toDTO data transfer object
 func (i *InteractiveServiceServer) toDTO(intr domain.Interactive) *intrv1.Interactive {
 	return &intrv1.Interactive{
@@ -0,0 +1,33 @@
+package ioc
+
+import (
+	intrv1 "github.com/basic-go-project-webook/webook/api/proto/gen/intr/v1"
+	"github.com/basic-go-project-webook/webook/pkg/grpcx/interceptors/auth"
+	"google.golang.org/grpc"
+)
+
+// InitAuthInterceptor 验证 webook 转发过来的短 token 和 webook 自己的服务凭证，
+// 代表用户操作或者查看用户私有数据的方法必须带短 token
+func InitAuthInterceptor() grpc.UnaryServerInterceptor {
+	builder, err := auth.NewBuilderFromConfig("auth")
+	if err != nil {
+		panic(err)
+	}
+	return builder.Require(
+		intrv1.InteractiveService_Like_FullMethodName,
+		intrv1.InteractiveService_CancelLike_FullMethodName,
+		intrv1.InteractiveService_Collect_FullMethodName,
+		intrv1.InteractiveService_CancelCollect_FullMethodName,
+		intrv1.InteractiveService_MoveCollectionItem_FullMethodName,
+		intrv1.InteractiveService_CreateCollection_FullMethodName,
+		intrv1.InteractiveService_UpdateCollection_FullMethodName,
+		intrv1.InteractiveService_DeleteCollection_FullMethodName,
+		intrv1.InteractiveService_ListCollections_FullMethodName,
+		intrv1.InteractiveService_ListCollectionItems_FullMethodName,
+		intrv1.InteractiveService_Get_FullMethodName,
+		intrv1.InteractiveService_GetByIdsWithUser_FullMethodName,
+	).RequireService(
+		// 合并账号的凭证在 webook 里面验证过，用户自己的 token 不能调用
+		intrv1.InteractiveService_MergeUser_FullMethodName,
+	).Build()
+}
@@ -18,7 +18,7 @@ func InitGRPCXServer(intrSvc *grpc2.InteractiveServiceServer) *grpcx.Server {
 	if err != nil {
 		panic(err)
 	}
-	server := grpc.NewServer()
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(InitAuthInterceptor()))
 	intrSvc.Register(server)
 	return &grpcx.Server{
 		Server:   server,
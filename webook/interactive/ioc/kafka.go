@@ -7,10 +7,42 @@ import (
 	"github.com/basic-go-project-webook/webook/pkg/kafkax"
 	events2 "github.com/basic-go-project-webook/webook/pkg/migrator/events"
 	"github.com/basic-go-project-webook/webook/pkg/migrator/events/fixer"
+	"github.com/segmentio/kafka-go"
 	"github.com/spf13/viper"
+	"time"
 )
 
-func InitInteractiveReadEventConsumer(repo repository.InteractiveRepository) *events.InteractiveReadEventConsumer {
+func InitInteractiveReadEventConsumer(repo repository.InteractiveRepository, dlq *kafka.Writer) *events.InteractiveReadEventBatchConsumer {
+	type Config struct {
+		Addr []string `yaml:"addr"`
+		// 每一批最多的消息数和最长的等待时间
+		ReadBatchSize   int           `yaml:"readBatchSize"`
+		ReadBatchWindow time.Duration `yaml:"readBatchWindow"`
+	}
+	cfg := Config{
+		ReadBatchSize:   100,
+		ReadBatchWindow: time.Second,
+	}
+	err := viper.UnmarshalKey("kafka", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	return events.NewInteractiveReadEventBatchConsumer(cfg.Addr, repo, dlq, cfg.ReadBatchSize, cfg.ReadBatchWindow)
+}
+
+func InitArticleWithdrawnEventConsumer(repo repository.InteractiveRepository, dlq *kafka.Writer) *events.ArticleWithdrawnEventConsumer {
+	type Config struct {
+		Addr []string `yaml:"addr"`
+	}
+	var cfg Config
+	err := viper.UnmarshalKey("kafka", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	return events.NewArticleWithdrawnEventConsumer(cfg.Addr, repo, dlq)
+}
+
+func InitArticlePublishedEventConsumer(repo repository.InteractiveRepository, dlq *kafka.Writer) *events.ArticlePublishedEventConsumer {
 	type Config struct {
 		Addr []string `yaml:"addr"`
 	}
@@ -19,10 +51,10 @@ func InitInteractiveReadEventConsumer(repo repository.InteractiveRepository) *ev
 	if err != nil {
 		panic(err)
 	}
-	return events.NewInteractiveReadEventConsumer(cfg.Addr, repo)
+	return events.NewArticlePublishedEventConsumer(cfg.Addr, repo, dlq)
 }
 
-func InitFixerConsumer(src SrcDB, dst DstDB) *fixer.Consumer[dao.Interactive] {
+func InitFixerConsumer(src SrcDB, dst DstDB, dlq *kafka.Writer) *fixer.Consumer[dao.Interactive] {
 	type Config struct {
 		Addr []string `yaml:"addr"`
 	}
@@ -31,13 +63,26 @@ func InitFixerConsumer(src SrcDB, dst DstDB) *fixer.Consumer[dao.Interactive] {
 	if err != nil {
 		panic(err)
 	}
-	c, err := fixer.NewConsumer[dao.Interactive](cfg.Addr, "inconsistent_interactive", src, dst)
+	c, err := fixer.NewConsumer[dao.Interactive](cfg.Addr, "inconsistent_interactive", src, dst, dlq)
 	if err != nil {
 		panic(err)
 	}
 	return c
 }
 
+// InitDLQWriter 所有消费者共用的死信队列 writer
+func InitDLQWriter() *kafka.Writer {
+	type Config struct {
+		Addr []string `yaml:"addr"`
+	}
+	var cfg Config
+	err := viper.UnmarshalKey("kafka", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	return kafkax.NewDLQWriter(cfg.Addr)
+}
+
 func InitInconsistentProducer() events2.Producer {
 	type Config struct {
 		Addr []string `yaml:"addr"`
@@ -50,6 +95,20 @@ func InitInconsistentProducer() events2.Producer {
 	return events2.NewKafkaProducer(cfg.Addr, "inconsistent_interactive")
 }
 
-func InitConsumers(c1 *events.InteractiveReadEventConsumer, fixConsumer *fixer.Consumer[dao.Interactive]) []kafkax.Consumer {
-	return []kafkax.Consumer{c1, fixConsumer}
+// InitInteractiveProducer 点赞、收藏事件
+func InitInteractiveProducer() events.Producer {
+	type Config struct {
+		Addr []string `yaml:"addr"`
+	}
+	var cfg Config
+	err := viper.UnmarshalKey("kafka", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	return events.NewKafkaProducer(cfg.Addr)
+}
+
+func InitConsumers(c1 *events.InteractiveReadEventBatchConsumer, c2 *events.ArticleWithdrawnEventConsumer,
+	c3 *events.ArticlePublishedEventConsumer, fixConsumer *fixer.Consumer[dao.Interactive]) []kafkax.Consumer {
+	return []kafkax.Consumer{c1, c2, c3, fixConsumer}
 }
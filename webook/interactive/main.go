@@ -1,11 +1,14 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/spf13/viper"
 	"go.uber.org/zap"
 	"net/http"
+	"os/signal"
+	"syscall"
 )
 
 func main() {
@@ -17,6 +20,21 @@ func main() {
 	for _, c := range app.consumers {
 		c.Start()
 	}
+	go func() {
+		ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+		defer stop()
+		<-ctx.Done()
+		// 先停掉消费者，等正在处理的消息处理完
+		for _, c := range app.consumers {
+			err := c.Close()
+			if err != nil {
+				zap.L().Error("关闭消费者失败", zap.Error(err))
+			}
+		}
+		_ = app.server.Close()
+		// 服务停了之后不会再有新的互动事件，发送完队列里面剩下的
+		_ = app.producer.Close()
+	}()
 
 	go func() {
 		err1 := app.adminServer.Start()
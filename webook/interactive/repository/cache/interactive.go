@@ -23,11 +23,23 @@ const (
 
 type InteractiveCache interface {
 	IncrReadCntIfPresent(ctx context.Context, biz string, bizId int64) error
+	BatchIncrReadCntIfPresent(ctx context.Context, bizs []string, bizIds []int64, cnts []int64) error
 	DecrLikeCntIfPresent(ctx context.Context, biz string, bizId int64) error
 	IncrLikeCntIfPresent(ctx context.Context, biz string, bizId int64) error
 	IncrCollectionCntIfPresent(ctx context.Context, biz string, bizId int64) error
+	DecrCollectionCntIfPresent(ctx context.Context, biz string, bizId int64) error
 	Get(ctx context.Context, biz string, bizId int64) (domain.Interactive, error)
 	Set(ctx context.Context, biz string, bizId int64, inter domain.Interactive) error
+	Del(ctx context.Context, biz string, bizId int64) error
+
+	// LikedStatus 只返回缓存里面有的部分
+	LikedStatus(ctx context.Context, biz string, uid int64, bizIds []int64) (map[int64]bool, error)
+	SetLikedStatus(ctx context.Context, biz string, uid int64, status map[int64]bool) error
+	DelLikedStatus(ctx context.Context, biz string, uid int64, bizIds ...int64) error
+	// CollectedStatus 只返回缓存里面有的部分
+	CollectedStatus(ctx context.Context, biz string, uid int64, bizIds []int64) (map[int64]bool, error)
+	SetCollectedStatus(ctx context.Context, biz string, uid int64, status map[int64]bool) error
+	DelCollectedStatus(ctx context.Context, biz string, uid int64, bizIds ...int64) error
 }
 
 type InteractiveRedisCache struct {
@@ -45,6 +57,10 @@ func (c *InteractiveRedisCache) Set(ctx context.Context, biz string, bizId int64
 	return c.client.Expire(ctx, key, time.Minute*15).Err()
 }
 
+func (c *InteractiveRedisCache) Del(ctx context.Context, biz string, bizId int64) error {
+	return c.client.Del(ctx, c.key(biz, bizId)).Err()
+}
+
 func (c *InteractiveRedisCache) Get(ctx context.Context, biz string, bizId int64) (domain.Interactive, error) {
 	key := c.key(biz, bizId)
 	res, err := c.client.HGetAll(ctx, key).Result()
@@ -66,6 +82,11 @@ func (c *InteractiveRedisCache) IncrCollectionCntIfPresent(ctx context.Context,
 	return c.client.Eval(ctx, luaIncrCnt, []string{key}, fieldCollectCnt, 1).Err()
 }
 
+func (c *InteractiveRedisCache) DecrCollectionCntIfPresent(ctx context.Context, biz string, bizId int64) error {
+	key := c.key(biz, bizId)
+	return c.client.Eval(ctx, luaIncrCnt, []string{key}, fieldCollectCnt, -1).Err()
+}
+
 func (c *InteractiveRedisCache) DecrLikeCntIfPresent(ctx context.Context, biz string, bizId int64) error {
 	key := c.key(biz, bizId)
 	return c.client.Eval(ctx, luaIncrCnt, []string{key}, fieldLikeCnt, -1).Err()
@@ -73,7 +94,7 @@ func (c *InteractiveRedisCache) DecrLikeCntIfPresent(ctx context.Context, biz st
 
 func (c *InteractiveRedisCache) IncrLikeCntIfPresent(ctx context.Context, biz string, bizId int64) error {
 	key := c.key(biz, bizId)
-	return c.client.Eval(ctx, luaIncrCnt, []string{key}, fieldReadCnt, 1).Err()
+	return c.client.Eval(ctx, luaIncrCnt, []string{key}, fieldLikeCnt, 1).Err()
 }
 
 func (c *InteractiveRedisCache) IncrReadCntIfPresent(ctx context.Context, biz string, bizId int64) error {
@@ -81,6 +102,18 @@ func (c *InteractiveRedisCache) IncrReadCntIfPresent(ctx context.Context, biz st
 	return c.client.Eval(ctx, luaIncrCnt, []string{key}, fieldReadCnt, 1).Err()
 }
 
+func (c *InteractiveRedisCache) BatchIncrReadCntIfPresent(ctx context.Context, bizs []string, bizIds []int64, cnts []int64) error {
+	if len(bizs) == 0 {
+		return nil
+	}
+	pipe := c.client.Pipeline()
+	for i := range bizs {
+		pipe.Eval(ctx, luaIncrCnt, []string{c.key(bizs[i], bizIds[i])}, fieldReadCnt, cnts[i])
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
 func NewInteractiveRedisCache(client redis.Cmdable) InteractiveCache {
 	return &InteractiveRedisCache{
 		client: client,
@@ -0,0 +1,25 @@
+package cache
+
+import (
+	"context"
+	"github.com/basic-go-project-webook/webook/internal/repository/cache/redismocks"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+func TestInteractiveRedisCache_IncrLikeCntIfPresent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	cmd := redismocks.NewMockCmdable(ctrl)
+	res := redis.NewCmd(context.Background())
+	res.SetVal(int64(1))
+	// 点赞加的是点赞数，不是阅读数
+	cmd.EXPECT().Eval(gomock.Any(), luaIncrCnt, []string{"interactive:article:1"}, fieldLikeCnt, 1).
+		Return(res)
+
+	c := NewInteractiveRedisCache(cmd)
+	err := c.IncrLikeCntIfPresent(context.Background(), "article", 1)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,103 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// 用户对资源的点赞、收藏状态，每个用户每种业务一个 set。
+// 点赞了存 bizId，确认没有点赞存 -bizId，两个都不在说明没有缓存
+const (
+	statusLiked     = "liked"
+	statusCollected = "collected"
+)
+
+const userStatusExpiration = time.Minute * 15
+
+func (c *InteractiveRedisCache) LikedStatus(ctx context.Context, biz string, uid int64, bizIds []int64) (map[int64]bool, error) {
+	return c.userStatus(ctx, c.userStatusKey(statusLiked, biz, uid), bizIds)
+}
+
+func (c *InteractiveRedisCache) SetLikedStatus(ctx context.Context, biz string, uid int64, status map[int64]bool) error {
+	return c.setUserStatus(ctx, c.userStatusKey(statusLiked, biz, uid), status)
+}
+
+func (c *InteractiveRedisCache) DelLikedStatus(ctx context.Context, biz string, uid int64, bizIds ...int64) error {
+	return c.delUserStatus(ctx, c.userStatusKey(statusLiked, biz, uid), bizIds)
+}
+
+func (c *InteractiveRedisCache) CollectedStatus(ctx context.Context, biz string, uid int64, bizIds []int64) (map[int64]bool, error) {
+	return c.userStatus(ctx, c.userStatusKey(statusCollected, biz, uid), bizIds)
+}
+
+func (c *InteractiveRedisCache) SetCollectedStatus(ctx context.Context, biz string, uid int64, status map[int64]bool) error {
+	return c.setUserStatus(ctx, c.userStatusKey(statusCollected, biz, uid), status)
+}
+
+func (c *InteractiveRedisCache) DelCollectedStatus(ctx context.Context, biz string, uid int64, bizIds ...int64) error {
+	return c.delUserStatus(ctx, c.userStatusKey(statusCollected, biz, uid), bizIds)
+}
+
+func (c *InteractiveRedisCache) userStatus(ctx context.Context, key string, bizIds []int64) (map[int64]bool, error) {
+	res := make(map[int64]bool, len(bizIds))
+	if len(bizIds) == 0 {
+		return res, nil
+	}
+	members := make([]any, 0, len(bizIds)*2)
+	for _, id := range bizIds {
+		members = append(members, strconv.FormatInt(id, 10))
+	}
+	for _, id := range bizIds {
+		members = append(members, strconv.FormatInt(-id, 10))
+	}
+	flags, err := c.client.SMIsMember(ctx, key, members...).Result()
+	if err != nil {
+		return nil, err
+	}
+	n := len(bizIds)
+	for i, id := range bizIds {
+		switch {
+		case flags[i]:
+			res[id] = true
+		case flags[n+i]:
+			res[id] = false
+		}
+	}
+	return res, nil
+}
+
+func (c *InteractiveRedisCache) setUserStatus(ctx context.Context, key string, status map[int64]bool) error {
+	if len(status) == 0 {
+		return nil
+	}
+	members := make([]any, 0, len(status))
+	for id, ok := range status {
+		if ok {
+			members = append(members, strconv.FormatInt(id, 10))
+		} else {
+			members = append(members, strconv.FormatInt(-id, 10))
+		}
+	}
+	pipe := c.client.Pipeline()
+	pipe.SAdd(ctx, key, members...)
+	pipe.Expire(ctx, key, userStatusExpiration)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (c *InteractiveRedisCache) delUserStatus(ctx context.Context, key string, bizIds []int64) error {
+	if len(bizIds) == 0 {
+		return nil
+	}
+	members := make([]any, 0, len(bizIds)*2)
+	for _, id := range bizIds {
+		members = append(members, strconv.FormatInt(id, 10), strconv.FormatInt(-id, 10))
+	}
+	return c.client.SRem(ctx, key, members...).Err()
+}
+
+func (c *InteractiveRedisCache) userStatusKey(status string, biz string, uid int64) string {
+	return fmt.Sprintf("interactive:%s:%s:%d", status, biz, uid)
+}
@@ -0,0 +1,147 @@
+package dao
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"time"
+)
+
+func (dao *GORMInteractiveDAO) DeleteCollectionBiz(ctx context.Context, biz string, bizId int64, uid int64) error {
+	now := time.Now().UnixMilli()
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("uid = ? AND biz_id = ? AND biz = ?", uid, bizId, biz).
+			Delete(&UserCollectionBiz{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrRecordNotFount
+		}
+		return tx.Model(&Interactive{}).
+			Where("biz_id = ? AND biz = ?", bizId, biz).
+			Updates(map[string]interface{}{
+				"collect_cnt": gorm.Expr("collect_cnt - ?", 1),
+				"utime":       now,
+			}).Error
+	})
+}
+
+func (dao *GORMInteractiveDAO) UpdateCollectionBizCid(ctx context.Context, biz string, bizId int64, uid int64, cid int64) error {
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var item UserCollectionBiz
+		err := tx.Where("uid = ? AND biz_id = ? AND biz = ?", uid, bizId, biz).
+			First(&item).Error
+		if err != nil {
+			return err
+		}
+		if item.Cid == cid {
+			return nil
+		}
+		return tx.Model(&UserCollectionBiz{}).
+			Where("id = ?", item.Id).
+			Updates(map[string]interface{}{
+				"cid":   cid,
+				"utime": time.Now().UnixMilli(),
+			}).Error
+	})
+}
+
+func (dao *GORMInteractiveDAO) ListCollectionBiz(ctx context.Context, uid int64, cid int64, offset int, limit int) ([]UserCollectionBiz, error) {
+	var res []UserCollectionBiz
+	err := dao.db.WithContext(ctx).
+		Where("uid = ? AND cid = ?", uid, cid).
+		Order("id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMInteractiveDAO) InsertCollection(ctx context.Context, c Collection) (int64, error) {
+	now := time.Now().UnixMilli()
+	c.Ctime = now
+	c.Utime = now
+	err := dao.db.WithContext(ctx).Create(&c).Error
+	return c.Id, err
+}
+
+func (dao *GORMInteractiveDAO) UpdateCollection(ctx context.Context, c Collection) error {
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 不能依赖 RowsAffected 判断，内容没有变化的时候 MySQL 也会返回 0
+		err := tx.Where("id = ? AND uid = ?", c.Id, c.Uid).First(&Collection{}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Model(&Collection{}).
+			Where("id = ?", c.Id).
+			Updates(map[string]interface{}{
+				"name":    c.Name,
+				"private": c.Private,
+				"utime":   time.Now().UnixMilli(),
+			}).Error
+	})
+}
+
+func (dao *GORMInteractiveDAO) DeleteCollection(ctx context.Context, id int64, uid int64) ([]UserCollectionBiz, error) {
+	var items []UserCollectionBiz
+	err := dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("id = ? AND uid = ?", id, uid).Delete(&Collection{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrRecordNotFount
+		}
+		err := tx.Where("uid = ? AND cid = ?", uid, id).Find(&items).Error
+		if err != nil || len(items) == 0 {
+			return err
+		}
+		err = tx.Where("uid = ? AND cid = ?", uid, id).Delete(&UserCollectionBiz{}).Error
+		if err != nil {
+			return err
+		}
+		now := time.Now().UnixMilli()
+		for _, item := range items {
+			err = tx.Model(&Interactive{}).
+				Where("biz_id = ? AND biz = ?", item.BizId, item.Biz).
+				Updates(map[string]interface{}{
+					"collect_cnt": gorm.Expr("collect_cnt - ?", 1),
+					"utime":       now,
+				}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return items, err
+}
+
+func (dao *GORMInteractiveDAO) GetCollection(ctx context.Context, id int64) (Collection, error) {
+	var res Collection
+	err := dao.db.WithContext(ctx).Where("id = ?", id).First(&res).Error
+	return res, err
+}
+
+func (dao *GORMInteractiveDAO) ListCollections(ctx context.Context, uid int64, onlyPublic bool, offset int, limit int) ([]Collection, error) {
+	var res []Collection
+	query := dao.db.WithContext(ctx).Where("uid = ?", uid)
+	if onlyPublic {
+		query = query.Where("private = ?", false)
+	}
+	err := query.Order("id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+// Collection 收藏夹
+type Collection struct {
+	Id      int64  `gorm:"primaryKey,autoIncrement"`
+	Uid     int64  `gorm:"index"`
+	Name    string `gorm:"type:varchar(256)"`
+	Private bool
+	Ctime   int64
+	Utime   int64
+}
@@ -53,6 +53,37 @@ func (dao *DoubleWriteDao) IncrReadCnt(ctx context.Context, biz string, bizId in
 	}
 }
 
+func (dao *DoubleWriteDao) BatchIncrReadCnt(ctx context.Context, bizs []string, bizIds []int64, cnts []int64) error {
+	pattern := dao.pattern.Load()
+	switch pattern {
+	case PatternSrcOnly:
+		return dao.src.BatchIncrReadCnt(ctx, bizs, bizIds, cnts)
+	case PatternDstOnly:
+		return dao.dst.BatchIncrReadCnt(ctx, bizs, bizIds, cnts)
+	case PatternSrcFirst:
+		err := dao.src.BatchIncrReadCnt(ctx, bizs, bizIds, cnts)
+		if err != nil {
+			return err
+		}
+		err = dao.dst.BatchIncrReadCnt(ctx, bizs, bizIds, cnts)
+		if err != nil {
+			zap.L().Error("双写批量 read_cnt 写入dst失败", zap.Error(err), zap.Int("size", len(bizs)))
+		}
+		return nil
+	case PatternDstFirst:
+		err := dao.dst.BatchIncrReadCnt(ctx, bizs, bizIds, cnts)
+		if err == nil {
+			err1 := dao.src.BatchIncrReadCnt(ctx, bizs, bizIds, cnts)
+			if err1 != nil {
+				zap.L().Error("双写批量 read_cnt 写入src失败", zap.Error(err1), zap.Int("size", len(bizs)))
+			}
+		}
+		return err
+	default:
+		return errUnknownPattern
+	}
+}
+
 func (dao *DoubleWriteDao) InsertLikeInfo(ctx context.Context, biz string, id int64, uid int64) error {
 	pattern := dao.pattern.Load()
 	switch pattern {
@@ -146,6 +177,200 @@ func (dao *DoubleWriteDao) InsertCollectionBiz(ctx context.Context, biz string,
 	}
 }
 
+func (dao *DoubleWriteDao) DeleteCollectionBiz(ctx context.Context, biz string, bizId int64, uid int64) error {
+	pattern := dao.pattern.Load()
+	switch pattern {
+	case PatternSrcOnly:
+		return dao.src.DeleteCollectionBiz(ctx, biz, bizId, uid)
+	case PatternDstOnly:
+		return dao.dst.DeleteCollectionBiz(ctx, biz, bizId, uid)
+	case PatternSrcFirst:
+		err := dao.src.DeleteCollectionBiz(ctx, biz, bizId, uid)
+		if err != nil {
+			return err
+		}
+		err = dao.dst.DeleteCollectionBiz(ctx, biz, bizId, uid)
+		if err != nil {
+			zap.L().Error("双写 collection_biz 删除dst失败", zap.Error(err), zap.String("biz", biz), zap.Int64("bizId", bizId), zap.Int64("uid", uid))
+		}
+		return nil
+	case PatternDstFirst:
+		err := dao.dst.DeleteCollectionBiz(ctx, biz, bizId, uid)
+		if err == nil {
+			err1 := dao.src.DeleteCollectionBiz(ctx, biz, bizId, uid)
+			if err1 != nil {
+				zap.L().Error("双写 collection_biz 删除src失败", zap.Error(err1), zap.String("biz", biz), zap.Int64("bizId", bizId), zap.Int64("uid", uid))
+			}
+		}
+		return err
+	default:
+		return errUnknownPattern
+	}
+}
+
+func (dao *DoubleWriteDao) UpdateCollectionBizCid(ctx context.Context, biz string, bizId int64, uid int64, cid int64) error {
+	pattern := dao.pattern.Load()
+	switch pattern {
+	case PatternSrcOnly:
+		return dao.src.UpdateCollectionBizCid(ctx, biz, bizId, uid, cid)
+	case PatternDstOnly:
+		return dao.dst.UpdateCollectionBizCid(ctx, biz, bizId, uid, cid)
+	case PatternSrcFirst:
+		err := dao.src.UpdateCollectionBizCid(ctx, biz, bizId, uid, cid)
+		if err != nil {
+			return err
+		}
+		err = dao.dst.UpdateCollectionBizCid(ctx, biz, bizId, uid, cid)
+		if err != nil {
+			zap.L().Error("双写 collection_biz 更新dst失败", zap.Error(err), zap.String("biz", biz), zap.Int64("bizId", bizId), zap.Int64("cid", cid), zap.Int64("uid", uid))
+		}
+		return nil
+	case PatternDstFirst:
+		err := dao.dst.UpdateCollectionBizCid(ctx, biz, bizId, uid, cid)
+		if err == nil {
+			err1 := dao.src.UpdateCollectionBizCid(ctx, biz, bizId, uid, cid)
+			if err1 != nil {
+				zap.L().Error("双写 collection_biz 更新src失败", zap.Error(err1), zap.String("biz", biz), zap.Int64("bizId", bizId), zap.Int64("cid", cid), zap.Int64("uid", uid))
+			}
+		}
+		return err
+	default:
+		return errUnknownPattern
+	}
+}
+
+func (dao *DoubleWriteDao) InsertCollection(ctx context.Context, c Collection) (int64, error) {
+	pattern := dao.pattern.Load()
+	switch pattern {
+	case PatternSrcOnly:
+		return dao.src.InsertCollection(ctx, c)
+	case PatternDstOnly:
+		return dao.dst.InsertCollection(ctx, c)
+	case PatternSrcFirst:
+		id, err := dao.src.InsertCollection(ctx, c)
+		if err != nil {
+			return 0, err
+		}
+		// 两边使用同一个 id
+		c.Id = id
+		_, err = dao.dst.InsertCollection(ctx, c)
+		if err != nil {
+			zap.L().Error("双写 collection 写入dst失败", zap.Error(err), zap.Int64("id", id), zap.Int64("uid", c.Uid))
+		}
+		return id, nil
+	case PatternDstFirst:
+		id, err := dao.dst.InsertCollection(ctx, c)
+		if err == nil {
+			c.Id = id
+			_, err1 := dao.src.InsertCollection(ctx, c)
+			if err1 != nil {
+				zap.L().Error("双写 collection 写入src失败", zap.Error(err1), zap.Int64("id", id), zap.Int64("uid", c.Uid))
+			}
+		}
+		return id, err
+	default:
+		return 0, errUnknownPattern
+	}
+}
+
+func (dao *DoubleWriteDao) UpdateCollection(ctx context.Context, c Collection) error {
+	pattern := dao.pattern.Load()
+	switch pattern {
+	case PatternSrcOnly:
+		return dao.src.UpdateCollection(ctx, c)
+	case PatternDstOnly:
+		return dao.dst.UpdateCollection(ctx, c)
+	case PatternSrcFirst:
+		err := dao.src.UpdateCollection(ctx, c)
+		if err != nil {
+			return err
+		}
+		err = dao.dst.UpdateCollection(ctx, c)
+		if err != nil {
+			zap.L().Error("双写 collection 更新dst失败", zap.Error(err), zap.Int64("id", c.Id), zap.Int64("uid", c.Uid))
+		}
+		return nil
+	case PatternDstFirst:
+		err := dao.dst.UpdateCollection(ctx, c)
+		if err == nil {
+			err1 := dao.src.UpdateCollection(ctx, c)
+			if err1 != nil {
+				zap.L().Error("双写 collection 更新src失败", zap.Error(err1), zap.Int64("id", c.Id), zap.Int64("uid", c.Uid))
+			}
+		}
+		return err
+	default:
+		return errUnknownPattern
+	}
+}
+
+func (dao *DoubleWriteDao) DeleteCollection(ctx context.Context, id int64, uid int64) ([]UserCollectionBiz, error) {
+	pattern := dao.pattern.Load()
+	switch pattern {
+	case PatternSrcOnly:
+		return dao.src.DeleteCollection(ctx, id, uid)
+	case PatternDstOnly:
+		return dao.dst.DeleteCollection(ctx, id, uid)
+	case PatternSrcFirst:
+		items, err := dao.src.DeleteCollection(ctx, id, uid)
+		if err != nil {
+			return nil, err
+		}
+		_, err = dao.dst.DeleteCollection(ctx, id, uid)
+		if err != nil {
+			zap.L().Error("双写 collection 删除dst失败", zap.Error(err), zap.Int64("id", id), zap.Int64("uid", uid))
+		}
+		return items, nil
+	case PatternDstFirst:
+		items, err := dao.dst.DeleteCollection(ctx, id, uid)
+		if err == nil {
+			_, err1 := dao.src.DeleteCollection(ctx, id, uid)
+			if err1 != nil {
+				zap.L().Error("双写 collection 删除src失败", zap.Error(err1), zap.Int64("id", id), zap.Int64("uid", uid))
+			}
+		}
+		return items, err
+	default:
+		return nil, errUnknownPattern
+	}
+}
+
+func (dao *DoubleWriteDao) ListCollectionBiz(ctx context.Context, uid int64, cid int64, offset int, limit int) ([]UserCollectionBiz, error) {
+	pattern := dao.pattern.Load()
+	switch pattern {
+	case PatternSrcOnly, PatternSrcFirst:
+		return dao.src.ListCollectionBiz(ctx, uid, cid, offset, limit)
+	case PatternDstOnly, PatternDstFirst:
+		return dao.dst.ListCollectionBiz(ctx, uid, cid, offset, limit)
+	default:
+		return nil, errUnknownPattern
+	}
+}
+
+func (dao *DoubleWriteDao) GetCollection(ctx context.Context, id int64) (Collection, error) {
+	pattern := dao.pattern.Load()
+	switch pattern {
+	case PatternSrcOnly, PatternSrcFirst:
+		return dao.src.GetCollection(ctx, id)
+	case PatternDstOnly, PatternDstFirst:
+		return dao.dst.GetCollection(ctx, id)
+	default:
+		return Collection{}, errUnknownPattern
+	}
+}
+
+func (dao *DoubleWriteDao) ListCollections(ctx context.Context, uid int64, onlyPublic bool, offset int, limit int) ([]Collection, error) {
+	pattern := dao.pattern.Load()
+	switch pattern {
+	case PatternSrcOnly, PatternSrcFirst:
+		return dao.src.ListCollections(ctx, uid, onlyPublic, offset, limit)
+	case PatternDstOnly, PatternDstFirst:
+		return dao.dst.ListCollections(ctx, uid, onlyPublic, offset, limit)
+	default:
+		return nil, errUnknownPattern
+	}
+}
+
 func (dao *DoubleWriteDao) Get(ctx context.Context, biz string, bizId int64) (Interactive, error) {
 	pattern := dao.pattern.Load()
 	switch pattern {
@@ -194,6 +419,92 @@ func (dao *DoubleWriteDao) GetByIds(ctx context.Context, biz string, ids []int64
 	}
 }
 
+func (dao *DoubleWriteDao) GetLikeInfos(ctx context.Context, biz string, ids []int64, uid int64) ([]UserLikeBiz, error) {
+	pattern := dao.pattern.Load()
+	switch pattern {
+	case PatternSrcOnly, PatternSrcFirst:
+		return dao.src.GetLikeInfos(ctx, biz, ids, uid)
+	case PatternDstOnly, PatternDstFirst:
+		return dao.dst.GetLikeInfos(ctx, biz, ids, uid)
+	default:
+		return nil, errUnknownPattern
+	}
+}
+
+func (dao *DoubleWriteDao) GetCollectInfos(ctx context.Context, biz string, ids []int64, uid int64) ([]UserCollectionBiz, error) {
+	pattern := dao.pattern.Load()
+	switch pattern {
+	case PatternSrcOnly, PatternSrcFirst:
+		return dao.src.GetCollectInfos(ctx, biz, ids, uid)
+	case PatternDstOnly, PatternDstFirst:
+		return dao.dst.GetCollectInfos(ctx, biz, ids, uid)
+	default:
+		return nil, errUnknownPattern
+	}
+}
+
+func (dao *DoubleWriteDao) MergeUser(ctx context.Context, srcUid int64, dstUid int64) (MergeUserResult, error) {
+	pattern := dao.pattern.Load()
+	switch pattern {
+	case PatternSrcOnly:
+		return dao.src.MergeUser(ctx, srcUid, dstUid)
+	case PatternDstOnly:
+		return dao.dst.MergeUser(ctx, srcUid, dstUid)
+	case PatternSrcFirst:
+		res, err := dao.src.MergeUser(ctx, srcUid, dstUid)
+		if err != nil {
+			return res, err
+		}
+		_, err = dao.dst.MergeUser(ctx, srcUid, dstUid)
+		if err != nil {
+			zap.L().Error("双写合并账号dst失败", zap.Error(err), zap.Int64("srcUid", srcUid), zap.Int64("dstUid", dstUid))
+		}
+		return res, nil
+	case PatternDstFirst:
+		res, err := dao.dst.MergeUser(ctx, srcUid, dstUid)
+		if err == nil {
+			_, err1 := dao.src.MergeUser(ctx, srcUid, dstUid)
+			if err1 != nil {
+				zap.L().Error("双写合并账号src失败", zap.Error(err1), zap.Int64("srcUid", srcUid), zap.Int64("dstUid", dstUid))
+			}
+		}
+		return res, err
+	default:
+		return MergeUserResult{}, errUnknownPattern
+	}
+}
+
+func (dao *DoubleWriteDao) SetStatus(ctx context.Context, biz string, bizId int64, status uint8, statusUtime int64) error {
+	pattern := dao.pattern.Load()
+	switch pattern {
+	case PatternSrcOnly:
+		return dao.src.SetStatus(ctx, biz, bizId, status, statusUtime)
+	case PatternDstOnly:
+		return dao.dst.SetStatus(ctx, biz, bizId, status, statusUtime)
+	case PatternSrcFirst:
+		err := dao.src.SetStatus(ctx, biz, bizId, status, statusUtime)
+		if err != nil {
+			return err
+		}
+		err = dao.dst.SetStatus(ctx, biz, bizId, status, statusUtime)
+		if err != nil {
+			zap.L().Error("双写 status 写入dst失败", zap.Error(err), zap.String("biz", biz), zap.Int64("bizId", bizId))
+		}
+		return nil
+	case PatternDstFirst:
+		err := dao.dst.SetStatus(ctx, biz, bizId, status, statusUtime)
+		if err == nil {
+			err1 := dao.src.SetStatus(ctx, biz, bizId, status, statusUtime)
+			if err1 != nil {
+				zap.L().Error("双写 status 写入src失败", zap.Error(err1), zap.String("biz", biz), zap.Int64("bizId", bizId))
+			}
+		}
+		return err
+	default:
+		return errUnknownPattern
+	}
+}
+
 func NewDoubleWriteDao(src InteractiveDAO, dst InteractiveDAO) *DoubleWriteDao {
 	return &DoubleWriteDao{
 		src:     src,
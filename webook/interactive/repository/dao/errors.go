@@ -1,7 +1,11 @@
 package dao
 
-import "gorm.io/gorm"
+import (
+	"errors"
+	"gorm.io/gorm"
+)
 
 var (
-	ErrRecordNotFount = gorm.ErrRecordNotFound
+	ErrRecordNotFount      = gorm.ErrRecordNotFound
+	ErrDuplicateCollection = errors.New("重复收藏")
 )
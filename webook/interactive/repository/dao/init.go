@@ -3,5 +3,5 @@ package dao
 import "gorm.io/gorm"
 
 func InitTable(db *gorm.DB) error {
-	return db.AutoMigrate(&Interactive{}, &UserLikeBiz{}, &UserCollectionBiz{})
+	return db.AutoMigrate(&Interactive{}, &UserLikeBiz{}, &UserCollectionBiz{}, &Collection{})
 }
@@ -10,15 +10,45 @@ import (
 
 type InteractiveDAO interface {
 	IncrReadCnt(ctx context.Context, biz string, bizId int64) error
+	// BatchIncrReadCnt 一次 upsert 给 bizs[i], bizIds[i] 的阅读数加上 cnts[i]
+	BatchIncrReadCnt(ctx context.Context, bizs []string, bizIds []int64, cnts []int64) error
 	InsertLikeInfo(ctx context.Context, biz string, id int64, uid int64) error
 	DeleteLikeInfo(ctx context.Context, biz string, id int64, uid int64) error
+	// InsertCollectionBiz 已经收藏过的时候返回 ErrDuplicateCollection
 	InsertCollectionBiz(ctx context.Context, biz string, bizId int64, cid int64, uid int64) error
+	// DeleteCollectionBiz 没有收藏过的时候返回 ErrRecordNotFount
+	DeleteCollectionBiz(ctx context.Context, biz string, bizId int64, uid int64) error
+	// UpdateCollectionBizCid 没有收藏过的时候返回 ErrRecordNotFount
+	UpdateCollectionBizCid(ctx context.Context, biz string, bizId int64, uid int64, cid int64) error
+	ListCollectionBiz(ctx context.Context, uid int64, cid int64, offset int, limit int) ([]UserCollectionBiz, error)
+	InsertCollection(ctx context.Context, c Collection) (int64, error)
+	// UpdateCollection 只会更新 uid 自己的收藏夹，找不到的时候返回 ErrRecordNotFount
+	UpdateCollection(ctx context.Context, c Collection) error
+	// DeleteCollection 删除收藏夹以及里面的收藏，并且同步减少收藏数，返回被删除的收藏
+	DeleteCollection(ctx context.Context, id int64, uid int64) ([]UserCollectionBiz, error)
+	GetCollection(ctx context.Context, id int64) (Collection, error)
+	ListCollections(ctx context.Context, uid int64, onlyPublic bool, offset int, limit int) ([]Collection, error)
 	Get(ctx context.Context, biz string, bizId int64) (Interactive, error)
 	GetLikeInfo(ctx context.Context, biz string, bizId int64, uid int64) (UserLikeBiz, error)
 	GetCollectInfo(ctx context.Context, biz string, bizId int64, uid int64) (UserCollectionBiz, error)
 	GetByIds(ctx context.Context, biz string, ids []int64) ([]Interactive, error)
+	// GetLikeInfos 返回 uid 在 ids 里面点赞了的部分
+	GetLikeInfos(ctx context.Context, biz string, ids []int64, uid int64) ([]UserLikeBiz, error)
+	// GetCollectInfos 返回 uid 在 ids 里面收藏了的部分
+	GetCollectInfos(ctx context.Context, biz string, ids []int64, uid int64) ([]UserCollectionBiz, error)
+	// MergeUser 把 srcUid 的点赞、收藏和收藏夹转给 dstUid，重复的点赞和收藏会被删掉
+	MergeUser(ctx context.Context, srcUid int64, dstUid int64) (MergeUserResult, error)
+	// SetStatus 修改计数的可见状态，statusUtime 比已经记录的旧的时候什么也不做
+	SetStatus(ctx context.Context, biz string, bizId int64, status uint8, statusUtime int64) error
 }
 
+const (
+	// InteractiveStatusVisible 默认值，加上 status 这一列之前的数据都是可见的
+	InteractiveStatusVisible uint8 = iota
+	// InteractiveStatusHidden 文章撤回之后隐藏计数，点赞、收藏都保留，重新发表之后恢复
+	InteractiveStatusHidden
+)
+
 type GORMInteractiveDAO struct {
 	db *gorm.DB
 }
@@ -29,6 +59,22 @@ func (dao *GORMInteractiveDAO) GetByIds(ctx context.Context, biz string, ids []i
 	return intrs, err
 }
 
+func (dao *GORMInteractiveDAO) GetLikeInfos(ctx context.Context, biz string, ids []int64, uid int64) ([]UserLikeBiz, error) {
+	var res []UserLikeBiz
+	err := dao.db.WithContext(ctx).
+		Where("uid = ? AND biz = ? AND biz_id IN ? AND status = ?", uid, biz, ids, 1).
+		Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMInteractiveDAO) GetCollectInfos(ctx context.Context, biz string, ids []int64, uid int64) ([]UserCollectionBiz, error) {
+	var res []UserCollectionBiz
+	err := dao.db.WithContext(ctx).
+		Where("uid = ? AND biz = ? AND biz_id IN ?", uid, biz, ids).
+		Find(&res).Error
+	return res, err
+}
+
 func (dao *GORMInteractiveDAO) GetCollectInfo(ctx context.Context, biz string, bizId int64, uid int64) (UserCollectionBiz, error) {
 	var res UserCollectionBiz
 	err := dao.db.WithContext(ctx).
@@ -39,7 +85,7 @@ func (dao *GORMInteractiveDAO) GetCollectInfo(ctx context.Context, biz string, b
 func (dao *GORMInteractiveDAO) GetLikeInfo(ctx context.Context, biz string, bizId int64, uid int64) (UserLikeBiz, error) {
 	var res UserLikeBiz
 	err := dao.db.WithContext(ctx).
-		Where("biz = ? AND biz_id = ? AND uid = ?", biz, bizId, uid).
+		Where("biz = ? AND biz_id = ? AND uid = ? AND status = ?", biz, bizId, uid, 1).
 		First(&res).Error
 	return res, err
 }
@@ -53,16 +99,20 @@ func (dao *GORMInteractiveDAO) Get(ctx context.Context, biz string, bizId int64)
 func (dao *GORMInteractiveDAO) InsertCollectionBiz(ctx context.Context, biz string, bizId int64, cid int64, uid int64) error {
 	now := time.Now().UnixMilli()
 	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		err := tx.Create(&UserCollectionBiz{
+		res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&UserCollectionBiz{
 			Biz:   biz,
 			BizId: bizId,
 			Cid:   cid,
 			Uid:   uid,
 			Utime: now,
 			Ctime: now,
-		}).Error
-		if err != nil {
-			return err
+		})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			// 已经收藏过了，收藏数不能再加
+			return ErrDuplicateCollection
 		}
 		return tx.WithContext(ctx).Clauses(clause.OnConflict{
 			DoUpdates: clause.Assignments(map[string]interface{}{
@@ -150,6 +200,49 @@ func (dao *GORMInteractiveDAO) IncrReadCnt(ctx context.Context, biz string, bizI
 	}).Error
 }
 
+func (dao *GORMInteractiveDAO) BatchIncrReadCnt(ctx context.Context, bizs []string, bizIds []int64, cnts []int64) error {
+	if len(bizs) == 0 {
+		return nil
+	}
+	now := time.Now().UnixMilli()
+	intrs := make([]Interactive, 0, len(bizs))
+	for i := range bizs {
+		intrs = append(intrs, Interactive{
+			Biz:     bizs[i],
+			BizId:   bizIds[i],
+			ReadCnt: cnts[i],
+			Utime:   now,
+			Ctime:   now,
+		})
+	}
+	return dao.db.WithContext(ctx).Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"read_cnt": gorm.Expr("read_cnt + VALUES(read_cnt)"),
+			"utime":    now,
+		}),
+	}).Create(&intrs).Error
+}
+
+func (dao *GORMInteractiveDAO) SetStatus(ctx context.Context, biz string, bizId int64, status uint8, statusUtime int64) error {
+	now := time.Now().UnixMilli()
+	// 发表和撤回的事件在不同的 topic 上，没有顺序保证，按照文章的 utime 后写的赢。
+	// MySQL 按照顺序执行赋值，status 要在 status_utime 之前
+	return dao.db.WithContext(ctx).Clauses(clause.OnConflict{
+		DoUpdates: clause.Set{
+			{Column: clause.Column{Name: "status"}, Value: gorm.Expr("IF(status_utime < ?, ?, status)", statusUtime, status)},
+			{Column: clause.Column{Name: "status_utime"}, Value: gorm.Expr("GREATEST(status_utime, ?)", statusUtime)},
+			{Column: clause.Column{Name: "utime"}, Value: now},
+		},
+	}).Create(&Interactive{
+		Biz:         biz,
+		BizId:       bizId,
+		Status:      status,
+		StatusUtime: statusUtime,
+		Utime:       now,
+		Ctime:       now,
+	}).Error
+}
+
 func NewGORMInteractiveDAO(db *gorm.DB) InteractiveDAO {
 	return &GORMInteractiveDAO{
 		db: db,
@@ -163,8 +256,12 @@ type Interactive struct {
 	ReadCnt    int64
 	LikeCnt    int64
 	CollectCnt int64
-	Ctime      int64
-	Utime      int64
+	// Status 计数是否可见，见 InteractiveStatusVisible 和 InteractiveStatusHidden
+	Status uint8
+	// StatusUtime 最后一次修改 Status 的文章事件的 utime
+	StatusUtime int64
+	Ctime       int64
+	Utime       int64
 }
 
 func (i Interactive) ID() int64 {
@@ -191,10 +288,11 @@ type UserLikeBiz struct {
 
 type UserCollectionBiz struct {
 	Id    int64  `gorm:"primaryKey,autoIncrement"`
-	Uid   int64  `gorm:"uniqueIndex:uid_biz_type_id"`
+	Uid   int64  `gorm:"uniqueIndex:uid_biz_type_id;index:uid_cid"`
 	BizId int64  `gorm:"uniqueIndex:uid_biz_type_id"`
 	Biz   string `gorm:"uniqueIndex:uid_biz_type_id;type:varchar(128)"`
-	Cid   int64  `gorm:"index"`
+	// 收藏夹，0 是默认收藏夹
+	Cid   int64 `gorm:"index;index:uid_cid"`
 	Ctime int64
 	Utime int64
 }
@@ -0,0 +1,63 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"testing"
+)
+
+func TestGORMInteractiveDAO_GetLikeInfo(t *testing.T) {
+	query := "SELECT \\* FROM `user_like_bizs` WHERE biz = \\? AND biz_id = \\? AND uid = \\? AND status = \\?"
+	testCases := []struct {
+		name    string
+		mock    func(t *testing.T) *sql.DB
+		wantRes UserLikeBiz
+		wantErr error
+	}{
+		{
+			name: "点赞过",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				rows := sqlmock.NewRows([]string{"id", "uid", "biz_id", "biz", "status"}).
+					AddRow(1, 123, 2, "article", 1)
+				mock.ExpectQuery(query).WithArgs("article", 2, 123, 1, 1).WillReturnRows(rows)
+				return db
+			},
+			wantRes: UserLikeBiz{Id: 1, Uid: 123, BizId: 2, Biz: "article", Status: 1},
+		},
+		{
+			name: "取消过点赞",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				// 取消点赞只是把 status 改成 0，这条记录不能算点赞过
+				mock.ExpectQuery(query).WithArgs("article", 2, 123, 1, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "uid", "biz_id", "biz", "status"}))
+				return db
+			},
+			wantErr: gorm.ErrRecordNotFound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, err := gorm.Open(mysql.New(mysql.Config{
+				Conn:                      tc.mock(t),
+				SkipInitializeWithVersion: true,
+			}), &gorm.Config{
+				DisableAutomaticPing:   true,
+				SkipDefaultTransaction: true,
+			})
+			require.NoError(t, err)
+			dao := NewGORMInteractiveDAO(db)
+			res, err := dao.GetLikeInfo(context.Background(), "article", 2, 123)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantRes, res)
+		})
+	}
+}
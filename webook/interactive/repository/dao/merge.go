@@ -0,0 +1,106 @@
+package dao
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"time"
+)
+
+// MergeUserResult 合并账号影响到的点赞和收藏。
+// 两个账号都点赞或者收藏过的资源，计数会减掉一个，放在 DupLikes 和 DupCollects 里面
+type MergeUserResult struct {
+	Likes       []UserLikeBiz
+	DupLikes    []UserLikeBiz
+	Collects    []UserCollectionBiz
+	DupCollects []UserCollectionBiz
+}
+
+func (dao *GORMInteractiveDAO) MergeUser(ctx context.Context, srcUid int64, dstUid int64) (MergeUserResult, error) {
+	var res MergeUserResult
+	now := time.Now().UnixMilli()
+	err := dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var likes []UserLikeBiz
+		err := tx.Where("uid = ? AND status = ?", srcUid, 1).Find(&likes).Error
+		if err != nil {
+			return err
+		}
+		for _, like := range likes {
+			var dst UserLikeBiz
+			err = tx.Where("uid = ? AND biz_id = ? AND biz = ?", dstUid, like.BizId, like.Biz).
+				Limit(1).Find(&dst).Error
+			if err != nil {
+				return err
+			}
+			switch {
+			case dst.Id == 0:
+				err = tx.Model(&UserLikeBiz{}).Where("id = ?", like.Id).
+					Updates(map[string]any{"uid": dstUid, "utime": now}).Error
+				res.Likes = append(res.Likes, like)
+			case dst.Status == 1:
+				err = tx.Model(&Interactive{}).
+					Where("biz_id = ? AND biz = ?", like.BizId, like.Biz).
+					Updates(map[string]any{
+						"like_cnt": gorm.Expr("like_cnt - ?", 1),
+						"utime":    now,
+					}).Error
+				res.DupLikes = append(res.DupLikes, like)
+			default:
+				// dst 以前点赞过又取消了，复用 dst 的记录
+				err = tx.Model(&UserLikeBiz{}).Where("id = ?", dst.Id).
+					Updates(map[string]any{"status": 1, "utime": now}).Error
+				res.Likes = append(res.Likes, like)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		// 剩下的都是取消了的点赞和已经转过去的
+		err = tx.Where("uid = ?", srcUid).Delete(&UserLikeBiz{}).Error
+		if err != nil {
+			return err
+		}
+
+		// 收藏夹直接转过去，里面的收藏 cid 不用变
+		err = tx.Model(&Collection{}).Where("uid = ?", srcUid).
+			Updates(map[string]any{"uid": dstUid, "utime": now}).Error
+		if err != nil {
+			return err
+		}
+		var collects []UserCollectionBiz
+		err = tx.Where("uid = ?", srcUid).Find(&collects).Error
+		if err != nil {
+			return err
+		}
+		for _, item := range collects {
+			var cnt int64
+			err = tx.Model(&UserCollectionBiz{}).
+				Where("uid = ? AND biz_id = ? AND biz = ?", dstUid, item.BizId, item.Biz).
+				Count(&cnt).Error
+			if err != nil {
+				return err
+			}
+			if cnt == 0 {
+				err = tx.Model(&UserCollectionBiz{}).Where("id = ?", item.Id).
+					Updates(map[string]any{"uid": dstUid, "utime": now}).Error
+				res.Collects = append(res.Collects, item)
+			} else {
+				err = tx.Where("id = ?", item.Id).Delete(&UserCollectionBiz{}).Error
+				if err != nil {
+					return err
+				}
+				err = tx.Model(&Interactive{}).
+					Where("biz_id = ? AND biz = ?", item.BizId, item.Biz).
+					Updates(map[string]any{
+						"collect_cnt": gorm.Expr("collect_cnt - ?", 1),
+						"utime":       now,
+					}).Error
+				res.DupCollects = append(res.DupCollects, item)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return res, err
+}
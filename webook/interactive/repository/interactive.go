@@ -7,19 +7,44 @@ import (
 	"github.com/basic-go-project-webook/webook/interactive/repository/cache"
 	"github.com/basic-go-project-webook/webook/interactive/repository/dao"
 	"go.uber.org/zap"
+	"time"
 )
 
 type InteractiveRepository interface {
 	IncrReadCnt(ctx context.Context, biz string, bizId int64) error
+	// BatchIncrReadCnt 数据库更新成功之后就返回成功，缓存更新失败只记录日志，避免调用方重试导致重复计数
+	BatchIncrReadCnt(ctx context.Context, bizs []string, bizIds []int64, cnts []int64) error
 	IncrLike(ctx context.Context, biz string, id int64, uid int64) error
+	// SetHidden 文章撤回之后隐藏计数，重新发表之后恢复，点赞和收藏都保留。
+	// utime 是文章事件的 utime，比已经处理过的旧的事件会被忽略
+	SetHidden(ctx context.Context, biz string, bizId int64, hidden bool, utime time.Time) error
 	DecrLike(ctx context.Context, biz string, id int64, uid int64) error
+	// AddCollectionItem 重复收藏的时候什么也不做
 	AddCollectionItem(ctx context.Context, biz string, bizId int64, cid int64, uid int64) error
+	// RemoveCollectionItem 没有收藏的时候什么也不做
+	RemoveCollectionItem(ctx context.Context, biz string, bizId int64, uid int64) error
+	// MoveCollectionItem 没有收藏的时候返回 ErrRecordNotFound
+	MoveCollectionItem(ctx context.Context, biz string, bizId int64, uid int64, cid int64) error
+	ListCollectionItems(ctx context.Context, uid int64, cid int64, offset int, limit int) ([]domain.CollectionItem, error)
+	CreateCollection(ctx context.Context, c domain.Collection) (int64, error)
+	UpdateCollection(ctx context.Context, c domain.Collection) error
+	DeleteCollection(ctx context.Context, id int64, uid int64) error
+	GetCollection(ctx context.Context, id int64) (domain.Collection, error)
+	ListCollections(ctx context.Context, uid int64, onlyPublic bool, offset int, limit int) ([]domain.Collection, error)
 	Get(ctx context.Context, biz string, bizId int64) (domain.Interactive, error)
 	Liked(ctx context.Context, biz string, bizId int64, uid int64) (bool, error)
 	Collected(ctx context.Context, biz string, bizId int64, uid int64) (bool, error)
 	GetByIds(ctx context.Context, biz string, ids []int64) ([]domain.Interactive, error)
+	// BatchLiked 返回 ids 里面每一个是否被 uid 点赞
+	BatchLiked(ctx context.Context, biz string, ids []int64, uid int64) (map[int64]bool, error)
+	// BatchCollected 返回 ids 里面每一个是否被 uid 收藏
+	BatchCollected(ctx context.Context, biz string, ids []int64, uid int64) (map[int64]bool, error)
+	// MergeUser 合并账号，重复的点赞和收藏会减掉计数
+	MergeUser(ctx context.Context, srcUid int64, dstUid int64) error
 }
 
+var ErrRecordNotFound = dao.ErrRecordNotFount
+
 type CachedInteractiveRepository struct {
 	dao   dao.InteractiveDAO
 	cache cache.InteractiveCache
@@ -38,6 +63,82 @@ func (c *CachedInteractiveRepository) GetByIds(ctx context.Context, biz string,
 	return res, nil
 }
 
+func (c *CachedInteractiveRepository) BatchLiked(ctx context.Context, biz string, ids []int64, uid int64) (map[int64]bool, error) {
+	return c.batchStatus(ctx, ids,
+		func(ids []int64) (map[int64]bool, error) {
+			return c.cache.LikedStatus(ctx, biz, uid, ids)
+		},
+		func(ids []int64) ([]int64, error) {
+			likes, err := c.dao.GetLikeInfos(ctx, biz, ids, uid)
+			res := make([]int64, 0, len(likes))
+			for _, like := range likes {
+				res = append(res, like.BizId)
+			}
+			return res, err
+		},
+		func(status map[int64]bool) error {
+			return c.cache.SetLikedStatus(ctx, biz, uid, status)
+		})
+}
+
+func (c *CachedInteractiveRepository) BatchCollected(ctx context.Context, biz string, ids []int64, uid int64) (map[int64]bool, error) {
+	return c.batchStatus(ctx, ids,
+		func(ids []int64) (map[int64]bool, error) {
+			return c.cache.CollectedStatus(ctx, biz, uid, ids)
+		},
+		func(ids []int64) ([]int64, error) {
+			items, err := c.dao.GetCollectInfos(ctx, biz, ids, uid)
+			res := make([]int64, 0, len(items))
+			for _, item := range items {
+				res = append(res, item.BizId)
+			}
+			return res, err
+		},
+		func(status map[int64]bool) error {
+			return c.cache.SetCollectedStatus(ctx, biz, uid, status)
+		})
+}
+
+// batchStatus 先查缓存，没有命中的部分用一次 IN 查询补上，再回写缓存
+func (c *CachedInteractiveRepository) batchStatus(ctx context.Context, ids []int64,
+	getCache func(ids []int64) (map[int64]bool, error),
+	load func(ids []int64) ([]int64, error),
+	setCache func(status map[int64]bool) error) (map[int64]bool, error) {
+	res, err := getCache(ids)
+	if err != nil {
+		zap.L().Error("读取用户互动状态缓存失败", zap.Error(err))
+		res = make(map[int64]bool, len(ids))
+	}
+	missed := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := res[id]; !ok {
+			missed = append(missed, id)
+		}
+	}
+	if len(missed) == 0 {
+		return res, nil
+	}
+	hit, err := load(missed)
+	if err != nil {
+		return nil, err
+	}
+	loaded := make(map[int64]bool, len(missed))
+	for _, id := range missed {
+		loaded[id] = false
+	}
+	for _, id := range hit {
+		loaded[id] = true
+	}
+	for id, ok := range loaded {
+		res[id] = ok
+	}
+	err = setCache(loaded)
+	if err != nil {
+		zap.L().Error("回写用户互动状态缓存失败", zap.Error(err))
+	}
+	return res, nil
+}
+
 func (c *CachedInteractiveRepository) Liked(ctx context.Context, biz string, bizId int64, uid int64) (bool, error) {
 	_, err := c.dao.GetLikeInfo(ctx, biz, bizId, uid)
 	switch {
@@ -73,6 +174,10 @@ func (c *CachedInteractiveRepository) Get(ctx context.Context, biz string, bizId
 	}
 
 	res := c.toDomain(ie)
+	if ie.Status == dao.InteractiveStatusHidden {
+		// 不回写缓存，不然隐藏期间的点赞、收藏会加到缓存里面的 0 上
+		return res, nil
+	}
 	err = c.cache.Set(ctx, biz, bizId, res)
 	if err != nil {
 		zap.L().Error("回写缓存失败", zap.Error(err), zap.Int64("bizId", bizId),
@@ -83,17 +188,100 @@ func (c *CachedInteractiveRepository) Get(ctx context.Context, biz string, bizId
 
 func (c *CachedInteractiveRepository) AddCollectionItem(ctx context.Context, biz string, bizId int64, cid int64, uid int64) error {
 	err := c.dao.InsertCollectionBiz(ctx, biz, bizId, cid, uid)
+	if errors.Is(err, dao.ErrDuplicateCollection) {
+		return nil
+	}
 	if err != nil {
 		return err
 	}
+	c.delCollectedStatus(ctx, biz, uid, bizId)
 	return c.cache.IncrCollectionCntIfPresent(ctx, biz, bizId)
 }
 
+func (c *CachedInteractiveRepository) RemoveCollectionItem(ctx context.Context, biz string, bizId int64, uid int64) error {
+	err := c.dao.DeleteCollectionBiz(ctx, biz, bizId, uid)
+	if errors.Is(err, dao.ErrRecordNotFount) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	c.delCollectedStatus(ctx, biz, uid, bizId)
+	return c.cache.DecrCollectionCntIfPresent(ctx, biz, bizId)
+}
+
+func (c *CachedInteractiveRepository) MoveCollectionItem(ctx context.Context, biz string, bizId int64, uid int64, cid int64) error {
+	return c.dao.UpdateCollectionBizCid(ctx, biz, bizId, uid, cid)
+}
+
+func (c *CachedInteractiveRepository) ListCollectionItems(ctx context.Context, uid int64, cid int64, offset int, limit int) ([]domain.CollectionItem, error) {
+	items, err := c.dao.ListCollectionBiz(ctx, uid, cid, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.CollectionItem, 0, len(items))
+	for _, item := range items {
+		res = append(res, domain.CollectionItem{
+			Id:    item.Id,
+			Cid:   item.Cid,
+			Biz:   item.Biz,
+			BizId: item.BizId,
+			Ctime: time.UnixMilli(item.Ctime),
+		})
+	}
+	return res, nil
+}
+
+func (c *CachedInteractiveRepository) CreateCollection(ctx context.Context, collection domain.Collection) (int64, error) {
+	return c.dao.InsertCollection(ctx, c.toCollectionEntity(collection))
+}
+
+func (c *CachedInteractiveRepository) UpdateCollection(ctx context.Context, collection domain.Collection) error {
+	return c.dao.UpdateCollection(ctx, c.toCollectionEntity(collection))
+}
+
+func (c *CachedInteractiveRepository) DeleteCollection(ctx context.Context, id int64, uid int64) error {
+	items, err := c.dao.DeleteCollection(ctx, id, uid)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		c.delCollectedStatus(ctx, item.Biz, uid, item.BizId)
+		er := c.cache.DecrCollectionCntIfPresent(ctx, item.Biz, item.BizId)
+		if er != nil {
+			zap.L().Error("删除收藏夹，更新收藏数缓存失败", zap.Error(er),
+				zap.String("biz", item.Biz), zap.Int64("bizId", item.BizId))
+		}
+	}
+	return nil
+}
+
+func (c *CachedInteractiveRepository) GetCollection(ctx context.Context, id int64) (domain.Collection, error) {
+	collection, err := c.dao.GetCollection(ctx, id)
+	if err != nil {
+		return domain.Collection{}, err
+	}
+	return c.toCollectionDomain(collection), nil
+}
+
+func (c *CachedInteractiveRepository) ListCollections(ctx context.Context, uid int64, onlyPublic bool, offset int, limit int) ([]domain.Collection, error) {
+	collections, err := c.dao.ListCollections(ctx, uid, onlyPublic, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.Collection, 0, len(collections))
+	for _, collection := range collections {
+		res = append(res, c.toCollectionDomain(collection))
+	}
+	return res, nil
+}
+
 func (c *CachedInteractiveRepository) DecrLike(ctx context.Context, biz string, id int64, uid int64) error {
 	err := c.dao.DeleteLikeInfo(ctx, biz, id, uid)
 	if err != nil {
 		return err
 	}
+	c.delLikedStatus(ctx, biz, uid, id)
 	return c.cache.DecrLikeCntIfPresent(ctx, biz, id)
 }
 
@@ -102,6 +290,7 @@ func (c *CachedInteractiveRepository) IncrLike(ctx context.Context, biz string,
 	if err != nil {
 		return err
 	}
+	c.delLikedStatus(ctx, biz, uid, id)
 	return c.cache.IncrLikeCntIfPresent(ctx, biz, id)
 }
 
@@ -114,7 +303,84 @@ func (c *CachedInteractiveRepository) IncrReadCnt(ctx context.Context, biz strin
 	return err
 }
 
+func (c *CachedInteractiveRepository) SetHidden(ctx context.Context, biz string, bizId int64, hidden bool, utime time.Time) error {
+	status := dao.InteractiveStatusVisible
+	if hidden {
+		status = dao.InteractiveStatusHidden
+	}
+	err := c.dao.SetStatus(ctx, biz, bizId, status, utime.UnixMilli())
+	if err != nil {
+		return err
+	}
+	return c.cache.Del(ctx, biz, bizId)
+}
+
+func (c *CachedInteractiveRepository) MergeUser(ctx context.Context, srcUid int64, dstUid int64) error {
+	res, err := c.dao.MergeUser(ctx, srcUid, dstUid)
+	if err != nil {
+		return err
+	}
+	for _, like := range append(res.Likes, res.DupLikes...) {
+		c.delLikedStatus(ctx, like.Biz, srcUid, like.BizId)
+		c.delLikedStatus(ctx, like.Biz, dstUid, like.BizId)
+	}
+	for _, like := range res.DupLikes {
+		er := c.cache.DecrLikeCntIfPresent(ctx, like.Biz, like.BizId)
+		if er != nil {
+			zap.L().Error("合并账号，更新点赞数缓存失败", zap.Error(er),
+				zap.String("biz", like.Biz), zap.Int64("bizId", like.BizId))
+		}
+	}
+	for _, item := range append(res.Collects, res.DupCollects...) {
+		c.delCollectedStatus(ctx, item.Biz, srcUid, item.BizId)
+		c.delCollectedStatus(ctx, item.Biz, dstUid, item.BizId)
+	}
+	for _, item := range res.DupCollects {
+		er := c.cache.DecrCollectionCntIfPresent(ctx, item.Biz, item.BizId)
+		if er != nil {
+			zap.L().Error("合并账号，更新收藏数缓存失败", zap.Error(er),
+				zap.String("biz", item.Biz), zap.Int64("bizId", item.BizId))
+		}
+	}
+	return nil
+}
+
+func (c *CachedInteractiveRepository) delLikedStatus(ctx context.Context, biz string, uid int64, bizId int64) {
+	err := c.cache.DelLikedStatus(ctx, biz, uid, bizId)
+	if err != nil {
+		zap.L().Error("删除点赞状态缓存失败", zap.Error(err),
+			zap.String("biz", biz), zap.Int64("bizId", bizId), zap.Int64("uid", uid))
+	}
+}
+
+func (c *CachedInteractiveRepository) delCollectedStatus(ctx context.Context, biz string, uid int64, bizId int64) {
+	err := c.cache.DelCollectedStatus(ctx, biz, uid, bizId)
+	if err != nil {
+		zap.L().Error("删除收藏状态缓存失败", zap.Error(err),
+			zap.String("biz", biz), zap.Int64("bizId", bizId), zap.Int64("uid", uid))
+	}
+}
+
+func (c *CachedInteractiveRepository) BatchIncrReadCnt(ctx context.Context, bizs []string, bizIds []int64, cnts []int64) error {
+	err := c.dao.BatchIncrReadCnt(ctx, bizs, bizIds, cnts)
+	if err != nil {
+		return err
+	}
+	err = c.cache.BatchIncrReadCntIfPresent(ctx, bizs, bizIds, cnts)
+	if err != nil {
+		zap.L().Error("批量更新阅读数缓存失败", zap.Error(err), zap.Int("size", len(bizs)))
+	}
+	return nil
+}
+
+// toDomain 隐藏的计数都返回 0
 func (c *CachedInteractiveRepository) toDomain(interactive dao.Interactive) domain.Interactive {
+	if interactive.Status == dao.InteractiveStatusHidden {
+		return domain.Interactive{
+			Biz:   interactive.Biz,
+			BizId: interactive.BizId,
+		}
+	}
 	return domain.Interactive{
 		Biz:        interactive.Biz,
 		BizId:      interactive.BizId,
@@ -124,6 +390,26 @@ func (c *CachedInteractiveRepository) toDomain(interactive dao.Interactive) doma
 	}
 }
 
+func (c *CachedInteractiveRepository) toCollectionDomain(collection dao.Collection) domain.Collection {
+	return domain.Collection{
+		Id:      collection.Id,
+		Uid:     collection.Uid,
+		Name:    collection.Name,
+		Private: collection.Private,
+		Ctime:   time.UnixMilli(collection.Ctime),
+		Utime:   time.UnixMilli(collection.Utime),
+	}
+}
+
+func (c *CachedInteractiveRepository) toCollectionEntity(collection domain.Collection) dao.Collection {
+	return dao.Collection{
+		Id:      collection.Id,
+		Uid:     collection.Uid,
+		Name:    collection.Name,
+		Private: collection.Private,
+	}
+}
+
 func NewCachedInteractiveRepository(dao dao.InteractiveDAO, cache cache.InteractiveCache) InteractiveRepository {
 	return &CachedInteractiveRepository{
 		dao:   dao,
@@ -2,22 +2,54 @@ package service
 
 import (
 	"context"
+	"errors"
 	"github.com/basic-go-project-webook/webook/interactive/domain"
+	"github.com/basic-go-project-webook/webook/interactive/events"
 	"github.com/basic-go-project-webook/webook/interactive/repository"
+	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
+	"time"
 )
 
 type InteractiveService interface {
 	Like(ctx context.Context, biz string, id int64, uid int64) error
 	CancelLike(ctx context.Context, biz string, id int64, uid int64) error
 	IncrReadCnt(ctx context.Context, biz string, bizId int64) error
+	// Collect 收藏到 cid 收藏夹，cid 为 0 的时候收藏到默认收藏夹
 	Collect(ctx context.Context, biz string, bizId int64, cid int64, uid int64) error
+	CancelCollect(ctx context.Context, biz string, bizId int64, uid int64) error
+	MoveCollectionItem(ctx context.Context, biz string, bizId int64, uid int64, cid int64) error
+	CreateCollection(ctx context.Context, c domain.Collection) (int64, error)
+	UpdateCollection(ctx context.Context, c domain.Collection) error
+	DeleteCollection(ctx context.Context, id int64, uid int64) error
+	// ListCollections viewer 不是 uid 的时候只返回公开的收藏夹
+	ListCollections(ctx context.Context, uid int64, viewer int64, offset int, limit int) ([]domain.Collection, error)
+	// ListCollectionItems 私密收藏夹和默认收藏夹只有自己能看
+	ListCollectionItems(ctx context.Context, uid int64, cid int64, viewer int64, offset int, limit int) ([]domain.CollectionItem, error)
 	Get(ctx context.Context, biz string, bizId int64, uid int64) (domain.Interactive, error)
 	GetByIds(ctx context.Context, biz string, bizIds []int64) (map[int64]domain.Interactive, error)
+	// GetByIdsWithUser 每一个 bizId 都会返回，并且带上 uid 是否点赞、收藏
+	GetByIdsWithUser(ctx context.Context, biz string, bizIds []int64, uid int64) (map[int64]domain.Interactive, error)
+	// MergeUser 合并账号，把 srcUid 的点赞、收藏和收藏夹转给 dstUid
+	MergeUser(ctx context.Context, srcUid int64, dstUid int64) error
 }
 
+const (
+	// DefaultPageSize 收藏夹列表和收藏列表没有传 limit 的时候每页的数量
+	DefaultPageSize = 20
+	// MaxPageSize 收藏夹列表和收藏列表每页最多返回的数量
+	MaxPageSize = 100
+)
+
+var (
+	ErrCollectionNotFound = errors.New("收藏夹不存在")
+	ErrNotCollected       = errors.New("没有收藏")
+	ErrInvalidOffset      = errors.New("offset 不能是负数")
+)
+
 type interactiveService struct {
-	repo repository.InteractiveRepository
+	repo     repository.InteractiveRepository
+	producer events.Producer
 }
 
 func (i *interactiveService) GetByIds(ctx context.Context, biz string, bizIds []int64) (map[int64]domain.Interactive, error) {
@@ -32,6 +64,55 @@ func (i *interactiveService) GetByIds(ctx context.Context, biz string, bizIds []
 	return res, nil
 }
 
+func (i *interactiveService) MergeUser(ctx context.Context, srcUid int64, dstUid int64) error {
+	return i.repo.MergeUser(ctx, srcUid, dstUid)
+}
+
+func (i *interactiveService) GetByIdsWithUser(ctx context.Context, biz string, bizIds []int64, uid int64) (map[int64]domain.Interactive, error) {
+	res := make(map[int64]domain.Interactive, len(bizIds))
+	if len(bizIds) == 0 {
+		return res, nil
+	}
+	var (
+		eg        errgroup.Group
+		intrs     []domain.Interactive
+		liked     map[int64]bool
+		collected map[int64]bool
+	)
+	eg.Go(func() error {
+		var er error
+		intrs, er = i.repo.GetByIds(ctx, biz, bizIds)
+		return er
+	})
+	eg.Go(func() error {
+		var er error
+		liked, er = i.repo.BatchLiked(ctx, biz, bizIds, uid)
+		return er
+	})
+	eg.Go(func() error {
+		var er error
+		collected, er = i.repo.BatchCollected(ctx, biz, bizIds, uid)
+		return er
+	})
+	err := eg.Wait()
+	if err != nil {
+		return nil, err
+	}
+	for _, intr := range intrs {
+		res[intr.BizId] = intr
+	}
+	for _, bizId := range bizIds {
+		intr, ok := res[bizId]
+		if !ok {
+			intr = domain.Interactive{Biz: biz, BizId: bizId}
+		}
+		intr.Liked = liked[bizId]
+		intr.Collected = collected[bizId]
+		res[bizId] = intr
+	}
+	return res, nil
+}
+
 func (i *interactiveService) Get(ctx context.Context, biz string, bizId int64, uid int64) (domain.Interactive, error) {
 	intr, err := i.repo.Get(ctx, biz, bizId)
 	if err != nil {
@@ -54,7 +135,113 @@ func (i *interactiveService) Get(ctx context.Context, biz string, bizId int64, u
 }
 
 func (i *interactiveService) Collect(ctx context.Context, biz string, bizId int64, cid int64, uid int64) error {
-	return i.repo.AddCollectionItem(ctx, biz, bizId, cid, uid)
+	err := i.checkCollection(ctx, cid, uid)
+	if err != nil {
+		return err
+	}
+	err = i.repo.AddCollectionItem(ctx, biz, bizId, cid, uid)
+	if err == nil {
+		i.produce(ctx, biz, bizId, uid, events.ActionCollect)
+	}
+	return err
+}
+
+func (i *interactiveService) CancelCollect(ctx context.Context, biz string, bizId int64, uid int64) error {
+	err := i.repo.RemoveCollectionItem(ctx, biz, bizId, uid)
+	if err == nil {
+		i.produce(ctx, biz, bizId, uid, events.ActionCancelCollect)
+	}
+	return err
+}
+
+func (i *interactiveService) MoveCollectionItem(ctx context.Context, biz string, bizId int64, uid int64, cid int64) error {
+	err := i.checkCollection(ctx, cid, uid)
+	if err != nil {
+		return err
+	}
+	err = i.repo.MoveCollectionItem(ctx, biz, bizId, uid, cid)
+	if errors.Is(err, repository.ErrRecordNotFound) {
+		return ErrNotCollected
+	}
+	return err
+}
+
+func (i *interactiveService) CreateCollection(ctx context.Context, c domain.Collection) (int64, error) {
+	return i.repo.CreateCollection(ctx, c)
+}
+
+func (i *interactiveService) UpdateCollection(ctx context.Context, c domain.Collection) error {
+	err := i.repo.UpdateCollection(ctx, c)
+	if errors.Is(err, repository.ErrRecordNotFound) {
+		return ErrCollectionNotFound
+	}
+	return err
+}
+
+func (i *interactiveService) DeleteCollection(ctx context.Context, id int64, uid int64) error {
+	err := i.repo.DeleteCollection(ctx, id, uid)
+	if errors.Is(err, repository.ErrRecordNotFound) {
+		return ErrCollectionNotFound
+	}
+	return err
+}
+
+func (i *interactiveService) ListCollections(ctx context.Context, uid int64, viewer int64, offset int, limit int) ([]domain.Collection, error) {
+	if offset < 0 {
+		return nil, ErrInvalidOffset
+	}
+	return i.repo.ListCollections(ctx, uid, uid != viewer, offset, pageSize(limit))
+}
+
+func (i *interactiveService) ListCollectionItems(ctx context.Context, uid int64, cid int64, viewer int64, offset int, limit int) ([]domain.CollectionItem, error) {
+	if offset < 0 {
+		return nil, ErrInvalidOffset
+	}
+	limit = pageSize(limit)
+	if cid == 0 {
+		if uid != viewer {
+			return nil, ErrCollectionNotFound
+		}
+		return i.repo.ListCollectionItems(ctx, uid, cid, offset, limit)
+	}
+	c, err := i.repo.GetCollection(ctx, cid)
+	if errors.Is(err, repository.ErrRecordNotFound) {
+		return nil, ErrCollectionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	// 别人的私密收藏夹当作不存在
+	if c.Uid != uid || (c.Private && c.Uid != viewer) {
+		return nil, ErrCollectionNotFound
+	}
+	return i.repo.ListCollectionItems(ctx, uid, cid, offset, limit)
+}
+
+// pageSize 没有传 limit 的时候用默认值，超过上限的按照上限来
+func pageSize(limit int) int {
+	if limit <= 0 {
+		return DefaultPageSize
+	}
+	return min(limit, MaxPageSize)
+}
+
+// checkCollection 确认 cid 是 uid 自己的收藏夹
+func (i *interactiveService) checkCollection(ctx context.Context, cid int64, uid int64) error {
+	if cid == 0 {
+		return nil
+	}
+	c, err := i.repo.GetCollection(ctx, cid)
+	if errors.Is(err, repository.ErrRecordNotFound) {
+		return ErrCollectionNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if c.Uid != uid {
+		return ErrCollectionNotFound
+	}
+	return nil
 }
 
 func (i *interactiveService) IncrReadCnt(ctx context.Context, biz string, bizId int64) error {
@@ -62,15 +249,41 @@ func (i *interactiveService) IncrReadCnt(ctx context.Context, biz string, bizId
 }
 
 func (i *interactiveService) CancelLike(ctx context.Context, biz string, id int64, uid int64) error {
-	return i.repo.DecrLike(ctx, biz, id, uid)
+	err := i.repo.DecrLike(ctx, biz, id, uid)
+	if err == nil {
+		i.produce(ctx, biz, id, uid, events.ActionCancelLike)
+	}
+	return err
 }
 
 func (i *interactiveService) Like(ctx context.Context, biz string, id int64, uid int64) error {
-	return i.repo.IncrLike(ctx, biz, id, uid)
+	err := i.repo.IncrLike(ctx, biz, id, uid)
+	if err == nil {
+		i.produce(ctx, biz, id, uid, events.ActionLike)
+	}
+	return err
+}
+
+// produce 互动事件只是给下游用的，发送失败不影响互动本身。
+// producer 只是放进队列，不会阻塞请求
+func (i *interactiveService) produce(ctx context.Context, biz string, bizId int64, uid int64, action string) {
+	err := i.producer.ProduceInteractiveEvent(ctx, events.InteractiveEvent{
+		Version: events.InteractiveEventVersion,
+		Biz:     biz,
+		BizId:   bizId,
+		Uid:     uid,
+		Action:  action,
+		Ctime:   time.Now().UnixMilli(),
+	})
+	if err != nil {
+		zap.L().Error("发送互动事件失败", zap.Error(err),
+			zap.String("biz", biz), zap.Int64("bizId", bizId), zap.String("action", action))
+	}
 }
 
-func NewInteractiveService(repo repository.InteractiveRepository) InteractiveService {
+func NewInteractiveService(repo repository.InteractiveRepository, producer events.Producer) InteractiveService {
 	return &interactiveService{
-		repo: repo,
+		repo:     repo,
+		producer: producer,
 	}
 }
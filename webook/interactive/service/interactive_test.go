@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"github.com/basic-go-project-webook/webook/interactive/domain"
+	"github.com/basic-go-project-webook/webook/interactive/repository"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// limitRepo 只记录传给 repository 的 limit
+type limitRepo struct {
+	repository.InteractiveRepository
+	limit int
+}
+
+func (r *limitRepo) ListCollections(ctx context.Context, uid int64, onlyPublic bool, offset int, limit int) ([]domain.Collection, error) {
+	r.limit = limit
+	return nil, nil
+}
+
+func (r *limitRepo) ListCollectionItems(ctx context.Context, uid int64, cid int64, offset int, limit int) ([]domain.CollectionItem, error) {
+	r.limit = limit
+	return nil, nil
+}
+
+func TestInteractiveService_PageSize(t *testing.T) {
+	testCases := []struct {
+		name      string
+		offset    int
+		limit     int
+		wantLimit int
+		wantErr   error
+	}{
+		{name: "没有传 limit", limit: 0, wantLimit: DefaultPageSize},
+		{name: "负数 limit", limit: -1, wantLimit: DefaultPageSize},
+		{name: "正常 limit", limit: 10, wantLimit: 10},
+		{name: "超过上限", limit: 1 << 40, wantLimit: MaxPageSize},
+		{name: "负数 offset", offset: -1, limit: 10, wantErr: ErrInvalidOffset},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &limitRepo{}
+			svc := NewInteractiveService(repo, nil)
+			_, err := svc.ListCollections(context.Background(), 1, 1, tc.offset, tc.limit)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantLimit, repo.limit)
+
+			repo.limit = 0
+			// 默认收藏夹只有自己能看，不需要查收藏夹
+			_, err = svc.ListCollectionItems(context.Background(), 1, 0, 1, tc.offset, tc.limit)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantLimit, repo.limit)
+		})
+	}
+}
@@ -33,7 +33,11 @@ func InitAPP() *App {
 		thirdPartySet,
 		grpc.NewInteractiveServiceServer,
 		ioc.InitInconsistentProducer,
+		ioc.InitInteractiveProducer,
+		ioc.InitDLQWriter,
 		ioc.InitInteractiveReadEventConsumer,
+		ioc.InitArticleWithdrawnEventConsumer,
+		ioc.InitArticlePublishedEventConsumer,
 		ioc.InitFixerConsumer,
 		ioc.InitConsumers,
 		ioc.InitGRPCXServer,
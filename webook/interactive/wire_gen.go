@@ -27,18 +27,23 @@ func InitAPP() *App {
 	cmdable := ioc.InitRedis()
 	interactiveCache := cache.NewInteractiveRedisCache(cmdable)
 	interactiveRepository := repository.NewCachedInteractiveRepository(interactiveDAO, interactiveCache)
-	interactiveService := service.NewInteractiveService(interactiveRepository)
+	producer := ioc.InitInteractiveProducer()
+	interactiveService := service.NewInteractiveService(interactiveRepository, producer)
 	interactiveServiceServer := grpc.NewInteractiveServiceServer(interactiveService)
 	server := ioc.InitGRPCXServer(interactiveServiceServer)
-	interactiveReadEventConsumer := ioc.InitInteractiveReadEventConsumer(interactiveRepository)
-	consumer := ioc.InitFixerConsumer(srcDB, dstDB)
-	v := ioc.InitConsumers(interactiveReadEventConsumer, consumer)
-	producer := ioc.InitInconsistentProducer()
-	ginxServer := ioc.InitGinxServer(srcDB, dstDB, doubleWritePool, producer)
+	writer := ioc.InitDLQWriter()
+	interactiveReadEventBatchConsumer := ioc.InitInteractiveReadEventConsumer(interactiveRepository, writer)
+	articleWithdrawnEventConsumer := ioc.InitArticleWithdrawnEventConsumer(interactiveRepository, writer)
+	articlePublishedEventConsumer := ioc.InitArticlePublishedEventConsumer(interactiveRepository, writer)
+	consumer := ioc.InitFixerConsumer(srcDB, dstDB, writer)
+	v := ioc.InitConsumers(interactiveReadEventBatchConsumer, articleWithdrawnEventConsumer, articlePublishedEventConsumer, consumer)
+	eventsProducer := ioc.InitInconsistentProducer()
+	ginxServer := ioc.InitGinxServer(srcDB, dstDB, doubleWritePool, eventsProducer)
 	app := &App{
 		server:      server,
 		consumers:   v,
 		adminServer: ginxServer,
+		producer:    producer,
 	}
 	return app
 }
@@ -7,9 +7,12 @@ type Article struct {
 	Title   string
 	Content string
 	Author  Author
-	Ctime   time.Time
-	Utime   time.Time
-	Status  ArticleStatus
+	// Category 每篇文章最多一个分类
+	Category string
+	Tags     []string
+	Ctime    time.Time
+	Utime    time.Time
+	Status   ArticleStatus
 }
 
 func (a Article) Abstract() string {
@@ -27,6 +30,8 @@ const (
 	ArticleStatusUnpublished
 	ArticleStatusPublished
 	ArticleStatusPrivate
+	// ArticleStatusScheduled 定时发表，到时间之前只在制作库里面
+	ArticleStatusScheduled
 )
 
 func (s ArticleStatus) ToUint8() uint8 {
@@ -49,6 +54,8 @@ func (s ArticleStatus) String() string {
 		return "Published"
 	case ArticleStatusPrivate:
 		return "Private"
+	case ArticleStatusScheduled:
+		return "Scheduled"
 	default:
 		return "Unknown"
 	}
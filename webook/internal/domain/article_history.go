@@ -0,0 +1,75 @@
+package domain
+
+import "time"
+
+// ArticleHistory 制作库的一个历史版本
+type ArticleHistory struct {
+	ArticleId int64
+	// Version 同一篇文章从 1 开始递增
+	Version int64
+	Title   string
+	Content string
+	Author  Author
+	Action  ArticleHistoryAction
+	Ctime   time.Time
+}
+
+// ArticleHistoryAction 产生这个版本的操作
+type ArticleHistoryAction uint8
+
+const (
+	ArticleHistoryActionUnknown ArticleHistoryAction = iota
+	ArticleHistoryActionSave
+	ArticleHistoryActionPublish
+	ArticleHistoryActionRestore
+)
+
+func (a ArticleHistoryAction) ToUint8() uint8 {
+	return uint8(a)
+}
+
+func (a ArticleHistoryAction) String() string {
+	switch a {
+	case ArticleHistoryActionSave:
+		return "Save"
+	case ArticleHistoryActionPublish:
+		return "Publish"
+	case ArticleHistoryActionRestore:
+		return "Restore"
+	default:
+		return "Unknown"
+	}
+}
+
+// ArticleDiff 两个版本之间按行比较的结果
+type ArticleDiff struct {
+	ArticleId int64
+	From      int64
+	To        int64
+	Title     []DiffLine
+	Content   []DiffLine
+}
+
+type DiffOp uint8
+
+const (
+	DiffOpEqual DiffOp = iota
+	DiffOpInsert
+	DiffOpDelete
+)
+
+func (o DiffOp) String() string {
+	switch o {
+	case DiffOpInsert:
+		return "+"
+	case DiffOpDelete:
+		return "-"
+	default:
+		return "="
+	}
+}
+
+type DiffLine struct {
+	Op   DiffOp
+	Text string
+}
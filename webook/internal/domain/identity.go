@@ -0,0 +1,17 @@
+package domain
+
+import "time"
+
+// Identity 第三方登录的账号，一个用户可以有多个
+type Identity struct {
+	Uid int64
+	// Provider 第三方的标识，例如 wechat、github
+	Provider string
+	// ExternalId 用户在第三方的唯一 id，微信是 openid，OIDC 是 sub
+	ExternalId string
+	// UnionId 微信同一个开放平台下面的应用共用的 id，别的第三方没有
+	UnionId  string
+	Nickname string
+	Email    string
+	Ctime    time.Time
+}
@@ -6,8 +6,9 @@ import (
 )
 
 type Job struct {
-	Id         int64
-	Name       string
+	Id   int64
+	Name string
+	// Expression 为空的是一次性任务，执行成功之后就结束了
 	Expression string
 	Executor   string
 	Cfg        string
@@ -19,3 +20,7 @@ func (j Job) NextTime() time.Time {
 	s, _ := parser.Parse(j.Expression)
 	return s.Next(time.Now())
 }
+
+func (j Job) OneShot() bool {
+	return j.Expression == ""
+}
@@ -0,0 +1,18 @@
+package domain
+
+// OutboxMessage 和业务数据在同一个事务里面写入，再由 relay 任务投递到 kafka
+type OutboxMessage struct {
+	Id      int64
+	Topic   string
+	Key     string
+	Payload []byte
+	// 已经投递失败的次数
+	Retries int
+}
+
+// OutboxMessageFunc 根据文章 id 生成需要写入的消息。
+// 新建的文章要在事务里面插入之后才知道 id，所以这里用函数
+type OutboxMessageFunc func(aid int64) ([]OutboxMessage, error)
+
+// ArticleOutboxFunc 批量修改文章的时候，根据每一篇文章生成需要写入的消息
+type ArticleOutboxFunc func(art Article) ([]OutboxMessage, error)
@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+// RankingBoard 一个榜单，比如小时榜、日榜、周榜或者某个分类的榜
+type RankingBoard struct {
+	Name string
+	// Window 只统计这段时间内更新过的文章
+	Window time.Duration
+	// N 榜单最多多少篇
+	N int
+	// Category 为空表示不限分类
+	Category string
+	// Tag 为空表示不限标签，不为空的时候只统计带有这个标签的文章
+	Tag string
+	// Interval 多久重新计算一次
+	Interval time.Duration
+}
+
+// RankedArticle 榜单上的文章，Rank 从 1 开始
+type RankedArticle struct {
+	Article Article
+	Rank    int64
+	Score   float64
+}
@@ -0,0 +1,44 @@
+package article
+
+// 文章生命周期事件，都以文章 id 作为 key。
+// 三种事件在不同的 topic 上，同一篇文章的事件之间没有顺序保证，
+// 消费者需要按照 Utime 处理乱序，例如后写的赢
+const (
+	TopicPublishedArticle = "published-article"
+	TopicUpdatedArticle   = "updated-article"
+	TopicWithdrawnArticle = "withdrawn-article"
+)
+
+// ArticleEventVersion 生命周期事件的 schema 版本。
+// 只能增加字段，删除或者修改字段含义的时候要升级版本，消费者遇到不认识的版本要跳过
+const ArticleEventVersion = 1
+
+// ArticlePublished 文章第一次发表，或者撤回之后重新发表
+type ArticlePublished struct {
+	Version int
+	Aid     int64
+	// 作者
+	Uid     int64
+	Title   string
+	Content string
+	// 毫秒数
+	Utime int64
+}
+
+// ArticleUpdated 已经发表的文章修改之后重新发表
+type ArticleUpdated struct {
+	Version int
+	Aid     int64
+	Uid     int64
+	Title   string
+	Content string
+	Utime   int64
+}
+
+// ArticleWithdrawn 文章撤回，线上不再可见
+type ArticleWithdrawn struct {
+	Version int
+	Aid     int64
+	Uid     int64
+	Utime   int64
+}
@@ -3,11 +3,32 @@ package article
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/basic-go-project-webook/webook/internal/domain"
 	"github.com/segmentio/kafka-go"
+	"strconv"
+	"time"
 )
 
 type Producer interface {
 	ProduceReadEvent(ctx context.Context, evt ReadEvent) error
+	// ProduceOutboxMessages 一次投递一批 outbox 里面已经序列化好的消息。
+	// 只有部分消息失败的时候返回 WriteErrors，和 msgs 一一对应
+	ProduceOutboxMessages(ctx context.Context, msgs []domain.OutboxMessage) error
+}
+
+// WriteErrors 一批消息里面每一条的投递结果，nil 表示这一条投递成功
+type WriteErrors []error
+
+func (w WriteErrors) Error() string {
+	cnt := 0
+	for _, err := range w {
+		if err != nil {
+			cnt++
+		}
+	}
+	return fmt.Sprintf("%d 条消息里面有 %d 条投递失败", len(w), cnt)
 }
 
 type KafkaProducer struct {
@@ -25,11 +46,46 @@ func (k *KafkaProducer) ProduceReadEvent(ctx context.Context, evt ReadEvent) err
 	})
 }
 
+func (k *KafkaProducer) ProduceOutboxMessages(ctx context.Context, msgs []domain.OutboxMessage) error {
+	kmsgs := make([]kafka.Message, 0, len(msgs))
+	for _, msg := range msgs {
+		kmsgs = append(kmsgs, kafka.Message{
+			Topic: msg.Topic,
+			Key:   []byte(msg.Key),
+			Value: msg.Payload,
+		})
+	}
+	err := k.producer.WriteMessages(ctx, kmsgs...)
+	var we kafka.WriteErrors
+	if errors.As(err, &we) {
+		return WriteErrors(we)
+	}
+	return err
+}
+
+// NewOutboxMessage 把事件序列化成 outbox 消息，用文章 id 作为 key 保证同一篇文章的消息有序
+func NewOutboxMessage(topic string, aid int64, evt any) (domain.OutboxMessage, error) {
+	data, err := json.Marshal(evt)
+	if err != nil {
+		return domain.OutboxMessage{}, err
+	}
+	return domain.OutboxMessage{
+		Topic:   topic,
+		Key:     strconv.FormatInt(aid, 10),
+		Payload: data,
+	}, nil
+}
+
 func NewKafkaProducer(addrs []string) Producer {
 	return &KafkaProducer{
 		producer: &kafka.Writer{
-			Addr:     kafka.TCP(addrs...),
-			Balancer: &kafka.LeastBytes{},
+			Addr: kafka.TCP(addrs...),
+			// outbox 消息用文章 id、用户 id 作为 key，同一个 key 要落到同一个分区才能保证有序，
+			// 阅读事件没有 key，Hash 会退化成轮询
+			Balancer: &kafka.Hash{},
+			// 默认要攒 1s 才发送一批，同步写入的时候每次调用都要等满 1s
+			BatchTimeout: time.Millisecond * 10,
+			RequiredAcks: kafka.RequireAll,
 		},
 	}
 }
@@ -0,0 +1,44 @@
+package feed
+
+import (
+	"context"
+	"github.com/basic-go-project-webook/webook/internal/events/article"
+	"github.com/basic-go-project-webook/webook/internal/service"
+	"github.com/basic-go-project-webook/webook/pkg/kafkax"
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+	"time"
+)
+
+// PublishedEventConsumer 文章发表之后推送到粉丝的收件箱
+type PublishedEventConsumer struct {
+	*kafkax.HandlerConsumer[article.ArticlePublished]
+	svc service.FeedService
+}
+
+func NewPublishedEventConsumer(addrs []string, svc service.FeedService, dlq *kafka.Writer) *PublishedEventConsumer {
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:  addrs,
+		GroupID:  "feed",
+		Topic:    article.TopicPublishedArticle,
+		MinBytes: 10e3,
+		MaxBytes: 10e6,
+	})
+	c := &PublishedEventConsumer{
+		svc: svc,
+	}
+	// 粉丝多的时候推送要分很多批，所以超时时间设置得长一点
+	c.HandlerConsumer = kafkax.NewHandlerConsumer[article.ArticlePublished](reader,
+		func(ctx context.Context, msg kafka.Message, evt article.ArticlePublished) error {
+			return c.Consume(ctx, evt)
+		}).Timeout(time.Minute).DLQ(dlq)
+	return c
+}
+
+func (c *PublishedEventConsumer) Consume(ctx context.Context, evt article.ArticlePublished) error {
+	if evt.Version > article.ArticleEventVersion {
+		zap.L().Warn("不认识的文章事件版本，跳过", zap.Int("version", evt.Version), zap.Int64("aid", evt.Aid))
+		return nil
+	}
+	return c.svc.Push(ctx, evt.Aid)
+}
@@ -0,0 +1,97 @@
+package ranking
+
+import (
+	"context"
+	"github.com/basic-go-project-webook/webook/internal/events/article"
+	"github.com/basic-go-project-webook/webook/internal/service"
+	"github.com/basic-go-project-webook/webook/pkg/kafkax"
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+)
+
+const (
+	topicInteractive = "interactive"
+	// interactiveEventVersion 能够处理的最高的互动事件版本
+	interactiveEventVersion = 1
+)
+
+// InteractiveEvent 和 interactive 的 events.InteractiveEvent 保持一致
+type InteractiveEvent struct {
+	Version int
+	Biz     string
+	BizId   int64
+	Uid     int64
+	Action  string
+	Ctime   int64
+}
+
+var interactiveActions = map[string]service.RankingAction{
+	"like":           service.RankingActionLike,
+	"cancel_like":    service.RankingActionCancelLike,
+	"collect":        service.RankingActionCollect,
+	"cancel_collect": service.RankingActionCancelCollect,
+}
+
+// InteractiveEventConsumer 点赞、收藏计入实时热榜
+type InteractiveEventConsumer struct {
+	*kafkax.HandlerConsumer[InteractiveEvent]
+	svc service.RealtimeRankingService
+}
+
+func NewInteractiveEventConsumer(addrs []string, svc service.RealtimeRankingService, dlq *kafka.Writer) *InteractiveEventConsumer {
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:  addrs,
+		GroupID:  "ranking",
+		Topic:    topicInteractive,
+		MinBytes: 10e3,
+		MaxBytes: 10e6,
+	})
+	c := &InteractiveEventConsumer{
+		svc: svc,
+	}
+	c.HandlerConsumer = kafkax.NewHandlerConsumer[InteractiveEvent](reader,
+		func(ctx context.Context, msg kafka.Message, evt InteractiveEvent) error {
+			return c.Consume(ctx, evt)
+		}).DLQ(dlq)
+	return c
+}
+
+func (c *InteractiveEventConsumer) Consume(ctx context.Context, evt InteractiveEvent) error {
+	if evt.Version > interactiveEventVersion {
+		zap.L().Warn("不认识的互动事件版本，跳过", zap.Int("version", evt.Version), zap.Int64("bizId", evt.BizId))
+		return nil
+	}
+	action, ok := interactiveActions[evt.Action]
+	if evt.Biz != "article" || !ok {
+		return nil
+	}
+	return c.svc.Record(ctx, evt.BizId, evt.Uid, action)
+}
+
+// ReadEventConsumer 阅读计入实时热榜
+type ReadEventConsumer struct {
+	*kafkax.HandlerConsumer[article.ReadEvent]
+	svc service.RealtimeRankingService
+}
+
+func NewReadEventConsumer(addrs []string, svc service.RealtimeRankingService, dlq *kafka.Writer) *ReadEventConsumer {
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:  addrs,
+		GroupID:  "ranking",
+		Topic:    "read-article",
+		MinBytes: 10e3,
+		MaxBytes: 10e6,
+	})
+	c := &ReadEventConsumer{
+		svc: svc,
+	}
+	c.HandlerConsumer = kafkax.NewHandlerConsumer[article.ReadEvent](reader,
+		func(ctx context.Context, msg kafka.Message, evt article.ReadEvent) error {
+			return c.Consume(ctx, evt)
+		}).DLQ(dlq)
+	return c
+}
+
+func (c *ReadEventConsumer) Consume(ctx context.Context, evt article.ReadEvent) error {
+	return c.svc.Record(ctx, evt.Aid, evt.Uid, service.RankingActionRead)
+}
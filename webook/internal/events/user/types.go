@@ -0,0 +1,32 @@
+package user
+
+import (
+	"encoding/json"
+	"github.com/basic-go-project-webook/webook/internal/domain"
+	"strconv"
+)
+
+const TopicUpdatedUser = "updated-user"
+
+// UserEventVersion 用户事件的 schema 版本，规则和文章事件一样
+const UserEventVersion = 1
+
+// UserUpdated 用户修改了资料，目前用于搜索索引用户昵称
+type UserUpdated struct {
+	Version  int
+	Uid      int64
+	Nickname string
+}
+
+// NewOutboxMessage 用 uid 作为 key 保证同一个用户的消息有序
+func NewOutboxMessage(evt UserUpdated) (domain.OutboxMessage, error) {
+	data, err := json.Marshal(evt)
+	if err != nil {
+		return domain.OutboxMessage{}, err
+	}
+	return domain.OutboxMessage{
+		Topic:   TopicUpdatedUser,
+		Key:     strconv.FormatInt(evt.Uid, 10),
+		Payload: data,
+	}, nil
+}
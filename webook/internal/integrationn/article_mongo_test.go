@@ -0,0 +1,229 @@
+package integrationn
+
+import (
+	"context"
+	"github.com/basic-go-project-webook/webook/internal/repository/dao/article"
+	"github.com/basic-go-project-webook/webook/internal/repository/dao/outbox"
+	"github.com/bwmarrin/snowflake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+	"gorm.io/gorm"
+	"math"
+	"testing"
+	"time"
+)
+
+// ArticleMongoDBTestSuite 需要 docker-compose 里面的单节点副本集
+type ArticleMongoDBTestSuite struct {
+	suite.Suite
+	mdb       *mongo.Database
+	dao       article.ArticleDAO
+	readerDAO article.ArticleReaderDAO
+	outboxDAO outbox.OutboxDAO
+}
+
+func (s *ArticleMongoDBTestSuite) SetupSuite() {
+	client, err := mongo.Connect(options.Client().
+		ApplyURI("mongodb://localhost:27017/?directConnection=true"))
+	require.NoError(s.T(), err)
+	s.mdb = client.Database("webook_test")
+	node, err := snowflake.NewNode(1)
+	require.NoError(s.T(), err)
+	s.dao = article.NewMongoDBArticleDAO(s.mdb, node)
+	s.readerDAO = article.NewMongoDBArticleReaderDAO(s.mdb)
+	s.outboxDAO = outbox.NewMongoDBOutboxDAO(s.mdb)
+}
+
+func (s *ArticleMongoDBTestSuite) SetupTest() {
+	require.NoError(s.T(), article.InitCollections(s.mdb))
+}
+
+func (s *ArticleMongoDBTestSuite) TearDownTest() {
+	require.NoError(s.T(), s.mdb.Drop(context.Background()))
+}
+
+func (s *ArticleMongoDBTestSuite) TestInsertAndUpdate() {
+	t := s.T()
+	ctx := context.Background()
+	id, err := s.dao.Insert(ctx, article.Article{
+		Title:    "我的标题",
+		Content:  "我的内容",
+		AuthorId: 123,
+		Status:   1,
+	})
+	require.NoError(t, err)
+	assert.True(t, id > 0)
+
+	err = s.dao.UpdateById(ctx, article.Article{
+		Id:       id,
+		Title:    "新的标题",
+		Content:  "新的内容",
+		AuthorId: 123,
+		Status:   1,
+	})
+	require.NoError(t, err)
+	// 别人的文章不能改
+	err = s.dao.UpdateById(ctx, article.Article{Id: id, Title: "非法", AuthorId: 234})
+	assert.Error(t, err)
+
+	art, err := s.dao.GetById(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, "新的标题", art.Title)
+	assert.Equal(t, "新的内容", art.Content)
+
+	_, err = s.dao.GetById(ctx, id+1)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+	his, err := s.dao.ListHistory(ctx, id, 123, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, his, 2)
+	assert.Equal(t, int64(2), his[0].Version)
+	assert.Equal(t, "新的标题", his[0].Title)
+	// 列表不带内容
+	assert.Equal(t, "", his[0].Content)
+}
+
+func (s *ArticleMongoDBTestSuite) TestSync() {
+	t := s.T()
+	ctx := context.Background()
+	outboxFn := func(id int64) ([]outbox.OutboxMessage, error) {
+		return []outbox.OutboxMessage{{Topic: "article_published", Payload: []byte("{}")}}, nil
+	}
+	id, err := s.dao.Sync(ctx, article.Article{
+		Title:    "我的标题",
+		Content:  "我的内容",
+		AuthorId: 123,
+		Tags:     []string{"Go"},
+		Status:   2,
+	}, outboxFn)
+	require.NoError(t, err)
+
+	pub, err := s.dao.GetPubById(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, "我的标题", pub.Title)
+	assert.Equal(t, []string{"Go"}, pub.Tags)
+	assert.True(t, pub.Ctime > 0)
+
+	// 再发表一次，清空标签也要同步到线上库
+	_, err = s.dao.Sync(ctx, article.Article{
+		Id:       id,
+		Title:    "新的标题",
+		Content:  "新的内容",
+		AuthorId: 123,
+		Status:   2,
+	}, outboxFn)
+	require.NoError(t, err)
+	pub, err = s.dao.GetPubById(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, "新的标题", pub.Title)
+	assert.Empty(t, pub.Tags)
+
+	// 撤回
+	err = s.dao.SyncStatus(ctx, id, 123, 3, outboxFn)
+	require.NoError(t, err)
+	pubs, err := s.readerDAO.GetPubByIds(ctx, []int64{id})
+	require.NoError(t, err)
+	assert.Len(t, pubs, 0)
+	// 别人的文章，也不会插入到线上库
+	err = s.dao.SyncStatus(ctx, id+1, 123, 3, outboxFn)
+	assert.Error(t, err)
+	cnt, err := s.mdb.Collection("published_articles").CountDocuments(ctx, bson.D{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), cnt)
+
+	msgs, err := s.outboxDAO.Claim(ctx, time.Now().UnixMilli(), 1000, 10)
+	require.NoError(t, err)
+	assert.Len(t, msgs, 3)
+	// lease 内不会被重复取到
+	again, err := s.outboxDAO.Claim(ctx, time.Now().UnixMilli(), 1000, 10)
+	require.NoError(t, err)
+	assert.Len(t, again, 0)
+	for _, msg := range msgs {
+		require.NoError(t, s.outboxDAO.MarkSent(ctx, msg.Id))
+	}
+	msgs, err = s.outboxDAO.Claim(ctx, time.Now().Add(time.Minute).UnixMilli(), 1000, 10)
+	require.NoError(t, err)
+	assert.Len(t, msgs, 0)
+}
+
+func (s *ArticleMongoDBTestSuite) TestListPub() {
+	t := s.T()
+	ctx := context.Background()
+	var ids []int64
+	for i := 0; i < 3; i++ {
+		id, err := s.dao.Sync(ctx, article.Article{
+			Title:    "我的标题",
+			AuthorId: 123,
+			Status:   2,
+		}, nil)
+		require.NoError(t, err)
+		ids = append(ids, id)
+		time.Sleep(time.Millisecond * 2)
+	}
+	arts, err := s.dao.ListPub(ctx, time.Now().Add(time.Second), 0, 10)
+	require.NoError(t, err)
+	require.Len(t, arts, 3)
+	assert.Equal(t, ids[2], arts[0].Id)
+	assert.Equal(t, ids[0], arts[2].Id)
+
+	arts, err = s.dao.ListPub(ctx, time.Now().Add(time.Second), 1, 1)
+	require.NoError(t, err)
+	require.Len(t, arts, 1)
+	assert.Equal(t, ids[1], arts[0].Id)
+
+	drafts, err := s.dao.GetByAuthor(ctx, 123, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, drafts, 3)
+	assert.Equal(t, ids[2], drafts[0].Id)
+
+	pubs, err := s.readerDAO.ListPubByAuthors(ctx, []int64{123}, time.Now().Add(time.Second).UnixMilli(), math.MaxInt64, 2)
+	require.NoError(t, err)
+	require.Len(t, pubs, 2)
+	assert.Equal(t, ids[2], pubs[0].Id)
+}
+
+func (s *ArticleMongoDBTestSuite) TestRestore() {
+	t := s.T()
+	ctx := context.Background()
+	id, err := s.dao.Insert(ctx, article.Article{
+		Title:    "第一版",
+		Content:  "第一版内容",
+		AuthorId: 123,
+		Category: "Go",
+		Status:   1,
+	})
+	require.NoError(t, err)
+	_, err = s.dao.Sync(ctx, article.Article{
+		Id:       id,
+		Title:    "第二版",
+		Content:  "第二版内容",
+		AuthorId: 123,
+		Category: "Go",
+		Status:   2,
+	}, nil)
+	require.NoError(t, err)
+
+	version, err := s.dao.Restore(ctx, id, 123, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), version)
+	art, err := s.dao.GetById(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, "第一版", art.Title)
+	assert.Equal(t, "Go", art.Category)
+	assert.Equal(t, uint8(1), art.Status)
+	// 线上库不受影响
+	pub, err := s.dao.GetPubById(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, "第二版", pub.Title)
+
+	_, err = s.dao.GetHistory(ctx, id, 123, 10)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestArticleMongoDB(t *testing.T) {
+	suite.Run(t, new(ArticleMongoDBTestSuite))
+}
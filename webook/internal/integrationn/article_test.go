@@ -9,7 +9,6 @@ import (
 	ijwt "github.com/basic-go-project-webook/webook/internal/web/jwt"
 	"github.com/basic-go-project-webook/webook/ioc"
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
@@ -227,7 +226,6 @@ func generateToken(uid int64) string {
 	claims := ijwt.UserClaims{
 		Uid: uid,
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenStr, _ := token.SignedString(ijwt.AtKey)
+	tokenStr, _ := startup.AtKeyring.Sign(claims)
 	return tokenStr
 }
@@ -0,0 +1,26 @@
+package startup
+
+import (
+	ijwt "github.com/basic-go-project-webook/webook/internal/web/jwt"
+	"github.com/basic-go-project-webook/webook/pkg/jwtx"
+	"github.com/redis/go-redis/v9"
+	"time"
+)
+
+// AtKeyring 测试里面自己签发 token 的时候也要用它
+var (
+	AtKeyring = mustKeyring("test-at", "BTv_D7]5q+f)9MTLwAA'5N!PJ6d6PNQQ", ijwt.AccessTokenExpiration)
+	RtKeyring = mustKeyring("test-rt", "BTv_D7]5q+f)9MTLwAA'5N!PJ6d6xyad", ijwt.RefreshTokenExpiration)
+)
+
+func InitJwtHandler(cmd redis.Cmdable) ijwt.Handler {
+	return ijwt.NewRedisJwtHandler(cmd, 5, AtKeyring, RtKeyring)
+}
+
+func mustKeyring(kid string, secret string, ttl time.Duration) *jwtx.Keyring {
+	keyring, err := jwtx.NewKeyringFromConfig([]jwtx.KeyConfig{{Kid: kid, Secret: secret}}, ttl)
+	if err != nil {
+		panic(err)
+	}
+	return keyring
+}
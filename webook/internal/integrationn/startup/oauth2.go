@@ -0,0 +1,17 @@
+package startup
+
+import (
+	"github.com/basic-go-project-webook/webook/internal/service"
+	"github.com/basic-go-project-webook/webook/internal/service/oauth2"
+	"github.com/basic-go-project-webook/webook/internal/web"
+	ijwt "github.com/basic-go-project-webook/webook/internal/web/jwt"
+)
+
+func InitOAuth2Handler(providers []oauth2.Provider, userSvc service.UserService,
+	accountSvc service.AccountService, jwtHdl ijwt.Handler, mergeKey web.MergeKey) *web.OAuth2Handler {
+	return web.NewOAuth2Handler(providers, userSvc, accountSvc, jwtHdl, []byte("test-oauth2-state-key-0123456789abcdef"), mergeKey)
+}
+
+func InitMergeKey() web.MergeKey {
+	return web.MergeKey("test-account-merge-key-0123456789abcdef")
+}
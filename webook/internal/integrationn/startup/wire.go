@@ -3,6 +3,8 @@
 package startup
 
 import (
+	intrv1 "github.com/basic-go-project-webook/webook/api/proto/gen/intr/v1"
+	ioc2 "github.com/basic-go-project-webook/webook/interactive/ioc"
 	repository2 "github.com/basic-go-project-webook/webook/interactive/repository"
 	cache2 "github.com/basic-go-project-webook/webook/interactive/repository/cache"
 	dao2 "github.com/basic-go-project-webook/webook/interactive/repository/dao"
@@ -14,7 +16,7 @@ import (
 	article2 "github.com/basic-go-project-webook/webook/internal/repository/dao/article"
 	"github.com/basic-go-project-webook/webook/internal/service"
 	"github.com/basic-go-project-webook/webook/internal/web"
-	ijwt "github.com/basic-go-project-webook/webook/internal/web/jwt"
+	"github.com/basic-go-project-webook/webook/internal/web/client"
 	"github.com/basic-go-project-webook/webook/ioc"
 	"github.com/gin-gonic/gin"
 	"github.com/google/wire"
@@ -26,32 +28,65 @@ func InitWebServer() *gin.Engine {
 		ioc.InitDBDefault, ioc.InitRedis,
 		// dao 部分
 		dao.NewUserDAO,
+		dao.NewGORMJobDAO,
 		article2.NewArticleDAO,
+		article2.NewGORMArticleReaderDAO,
 		dao2.NewGORMInteractiveDAO,
 		// cache 部分
 		cache.NewUserCache, cache.NewCodeCache,
 		cache.NewRedisArticleCache,
 		cache2.NewInteractiveRedisCache,
+		cache.NewRedisFeedCache,
+		cache.NewRankingRedisCache,
+		cache.NewRankingLocalCache,
+		cache.NewRankingBoardRedisCache,
 		// repository
 		repository.NewUserRepository, repository.NewCodeRepository,
 		article.NewArticleRepository,
 		repository2.NewCachedInteractiveRepository,
+		repository.NewCachedFeedRepository,
+		repository.NewPreemptJobRepository,
+		repository.NewOnlyCachedRankingRepository,
 
 		// producer 部分
 		ioc.InitProducer,
+		ioc2.InitInteractiveProducer,
 
 		// service 部分
 		ioc.InitSMSService,
 		service.NewUserService,
 		service.NewCodeService,
-		ioc.InitOAuth2WechatService,
+		ioc.InitEmailService,
+		service.NewEmailCodeService,
+		service.NewAccountService,
+		ioc.InitOAuth2Providers,
 		service.NewArticleService,
+		service.NewCronJobService,
 		service2.NewInteractiveService,
+		ioc.InitFeedService,
+		service.NewBatchRankingService,
+		service.NewLikeRankingScorer,
+		ioc.InitRankingBoards,
+
+		// grpc client 部分
+		client.NewInteractiveServiceAdapter,
+		wire.Bind(new(intrv1.InteractiveServiceClient), new(*client.InteractiveServiceAdapter)),
+		ioc.InitETCD,
+		ioc.InitCommentGRPCClientEtcd,
+		ioc.InitFollowGRPCClientEtcd,
 		// handler 部分
-		ijwt.NewRedisJwtHandler,
+		InitJwtHandler,
 		web.NewUserHandle,
+		InitMergeKey,
+		web.NewAccountHandler,
 		web.NewArticleHandle,
-		web.NewOAuth2WechatHandler,
+		InitOAuth2Handler,
+		web.NewCommentHandler,
+		web.NewFollowHandler,
+		web.NewFeedHandler,
+		web.NewCollectionHandler,
+		web.NewAuthorHandler,
+		web.NewRankingHandler,
 		ioc.InitGinMiddlewares,
 		ioc.InitWebserver,
 	)
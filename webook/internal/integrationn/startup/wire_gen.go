@@ -7,6 +7,7 @@
 package startup
 
 import (
+	ioc2 "github.com/basic-go-project-webook/webook/interactive/ioc"
 	repository2 "github.com/basic-go-project-webook/webook/interactive/repository"
 	cache2 "github.com/basic-go-project-webook/webook/interactive/repository/cache"
 	dao2 "github.com/basic-go-project-webook/webook/interactive/repository/dao"
@@ -18,7 +19,7 @@ import (
 	"github.com/basic-go-project-webook/webook/internal/repository/dao/article"
 	"github.com/basic-go-project-webook/webook/internal/service"
 	"github.com/basic-go-project-webook/webook/internal/web"
-	"github.com/basic-go-project-webook/webook/internal/web/jwt"
+	"github.com/basic-go-project-webook/webook/internal/web/client"
 	"github.com/basic-go-project-webook/webook/ioc"
 	"github.com/gin-gonic/gin"
 )
@@ -27,7 +28,7 @@ import (
 
 func InitWebServer() *gin.Engine {
 	cmdable := ioc.InitRedis()
-	handler := jwt.NewRedisJwtHandler(cmdable)
+	handler := InitJwtHandler(cmdable)
 	v := ioc.InitGinMiddlewares(cmdable, handler)
 	db := ioc.InitDBDefault()
 	userDAO := dao.NewUserDAO(db)
@@ -39,18 +40,48 @@ func InitWebServer() *gin.Engine {
 	smsService := ioc.InitSMSService()
 	codeService := service.NewCodeService(codeRepository, smsService)
 	userHandle := web.NewUserHandle(userService, codeService, cmdable, handler)
-	wechatService := ioc.InitOAuth2WechatService()
-	oAuth2WechatHandler := web.NewOAuth2WechatHandler(wechatService, userService, handler)
 	articleDAO := article.NewArticleDAO(db)
 	articleCache := cache.NewRedisArticleCache(cmdable)
 	articleRepository := article2.NewArticleRepository(articleDAO, articleCache, userRepository)
 	producer := ioc.InitProducer()
-	articleService := service.NewArticleService(articleRepository, producer)
+	jobDAO := dao.NewGORMJobDAO(db)
+	cronJobRepository := repository.NewPreemptJobRepository(jobDAO)
+	cronJobService := service.NewCronJobService(cronJobRepository)
+	articleService := service.NewArticleService(articleRepository, producer, cronJobService)
 	interactiveDAO := dao2.NewGORMInteractiveDAO(db)
 	interactiveCache := cache2.NewInteractiveRedisCache(cmdable)
 	interactiveRepository := repository2.NewCachedInteractiveRepository(interactiveDAO, interactiveCache)
-	interactiveService := service2.NewInteractiveService(interactiveRepository)
-	articleHandle := web.NewArticleHandle(articleService, handler, interactiveService)
-	engine := ioc.InitWebserver(v, userHandle, oAuth2WechatHandler, articleHandle)
+	eventsProducer := ioc2.InitInteractiveProducer()
+	interactiveService := service2.NewInteractiveService(interactiveRepository, eventsProducer)
+	interactiveServiceAdapter := client.NewInteractiveServiceAdapter(interactiveService)
+	clientv3Client := ioc.InitETCD()
+	followServiceClient := ioc.InitFollowGRPCClientEtcd(clientv3Client)
+	accountService := service.NewAccountService(userRepository, articleService, interactiveServiceAdapter, followServiceClient)
+	emailService := ioc.InitEmailService()
+	emailCodeService := service.NewEmailCodeService(codeRepository, emailService)
+	mergeKey := InitMergeKey()
+	accountHandler := web.NewAccountHandler(accountService, codeService, emailCodeService, handler, mergeKey)
+	v2 := ioc.InitOAuth2Providers()
+	oAuth2Handler := InitOAuth2Handler(v2, userService, accountService, handler, mergeKey)
+	articleHandle := web.NewArticleHandle(articleService, handler, interactiveServiceAdapter)
+	commentServiceClient := ioc.InitCommentGRPCClientEtcd(clientv3Client)
+	commentHandler := web.NewCommentHandler(commentServiceClient, handler)
+	followHandler := web.NewFollowHandler(followServiceClient, handler)
+	articleReaderDAO := article.NewGORMArticleReaderDAO(db)
+	feedCache := cache.NewRedisFeedCache(cmdable)
+	feedRepository := repository.NewCachedFeedRepository(articleReaderDAO, feedCache)
+	feedService := ioc.InitFeedService(feedRepository, followServiceClient)
+	feedHandler := web.NewFeedHandler(feedService, interactiveServiceAdapter, handler)
+	collectionHandler := web.NewCollectionHandler(interactiveServiceAdapter, handler)
+	authorHandler := web.NewAuthorHandler(articleService, userService, followServiceClient, interactiveServiceAdapter)
+	rankingRedisCache := cache.NewRankingRedisCache(cmdable)
+	rankingLocalCache := cache.NewRankingLocalCache()
+	rankingBoardCache := cache.NewRankingBoardRedisCache(cmdable)
+	rankingRepository := repository.NewOnlyCachedRankingRepository(rankingRedisCache, rankingLocalCache, rankingBoardCache)
+	rankingScorer := service.NewLikeRankingScorer()
+	v3 := ioc.InitRankingBoards()
+	rankingService := service.NewBatchRankingService(articleService, interactiveServiceAdapter, commentServiceClient, rankingRepository, rankingScorer, v3)
+	rankingHandler := web.NewRankingHandler(rankingService, interactiveServiceAdapter)
+	engine := ioc.InitWebserver(v, userHandle, accountHandler, oAuth2Handler, articleHandle, commentHandler, followHandler, feedHandler, collectionHandler, authorHandler, rankingHandler)
 	return engine
 }
@@ -0,0 +1,32 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/basic-go-project-webook/webook/internal/domain"
+	"github.com/basic-go-project-webook/webook/internal/service"
+)
+
+// ArticlePublishExecutor 执行定时发表任务
+type ArticlePublishExecutor struct {
+	svc service.ArticleService
+}
+
+func NewArticlePublishExecutor(svc service.ArticleService) *ArticlePublishExecutor {
+	return &ArticlePublishExecutor{
+		svc: svc,
+	}
+}
+
+func (a *ArticlePublishExecutor) Name() string {
+	return service.ArticlePublishExecutor
+}
+
+func (a *ArticlePublishExecutor) Execute(ctx context.Context, job domain.Job) error {
+	var cfg service.ArticlePublishJobCfg
+	err := json.Unmarshal([]byte(job.Cfg), &cfg)
+	if err != nil {
+		return err
+	}
+	return a.svc.PublishScheduled(ctx, cfg.Aid, cfg.Uid)
+}
@@ -46,14 +46,25 @@ type Scheduler struct {
 	// 限制并发量
 	limiter   *semaphore.Weighted
 	dbTimeout time.Duration
-	executors map[string]Executor
+	// 没有可以执行的任务的时候，等待多久再抢占
+	interval time.Duration
+	// 执行失败之后多久再重试
+	retryInterval time.Duration
+	// 这个实例没有对应的执行器的时候，多久之后再让别的实例抢占，
+	// 一般是滚动发布的时候新的执行器只在部分实例上
+	missingExecutorDelay time.Duration
+	executors            map[string]Executor
 }
 
 func NewScheduler(svc service.CronJobService) *Scheduler {
 	return &Scheduler{
-		svc:       svc,
-		limiter:   semaphore.NewWeighted(100),
-		executors: make(map[string]Executor),
+		svc:                  svc,
+		limiter:              semaphore.NewWeighted(100),
+		dbTimeout:            time.Second,
+		interval:             time.Second,
+		retryInterval:        time.Minute,
+		missingExecutorDelay: time.Minute * 10,
+		executors:            make(map[string]Executor),
 	}
 }
 
@@ -77,12 +88,19 @@ func (s *Scheduler) Schedule(ctx context.Context) error {
 		job, err := s.svc.Preempt(dbCtx)
 		cancel()
 		if err != nil {
-			// 有错误，直接进入下一轮抢占
+			// 没有任务或者有错误，等一会再进入下一轮抢占
+			s.limiter.Release(1)
+			s.sleep(ctx)
 			continue
 		}
 		executor, ok := s.executors[job.Executor]
 		if !ok {
-			zap.L().Error("未注册的执行器", zap.String("executor", job.Executor))
+			zap.L().Error("未注册的执行器", zap.String("executor", job.Executor), zap.Int64("jid", job.Id))
+			// 不推迟的话释放之后马上又会被抢到
+			s.delay(ctx, job, s.missingExecutorDelay)
+			s.limiter.Release(1)
+			job.CancelFunc()
+			s.sleep(ctx)
 			continue
 		}
 		go func() {
@@ -93,6 +111,7 @@ func (s *Scheduler) Schedule(ctx context.Context) error {
 			err1 := executor.Execute(ctx, job)
 			if err1 != nil {
 				zap.L().Error("执行任务执行失败", zap.Int64("jid", job.Id), zap.Error(err1))
+				s.delay(ctx, job, s.retryInterval)
 				return
 			}
 			err1 = s.svc.ResetNextTime(ctx, job)
@@ -102,3 +121,23 @@ func (s *Scheduler) Schedule(ctx context.Context) error {
 		}()
 	}
 }
+
+// delay 推迟任务的下一次执行时间，失败了也只能等下一次抢占
+func (s *Scheduler) delay(ctx context.Context, job domain.Job, d time.Duration) {
+	// 放弃调度的时候 ctx 已经取消了，但还是要推迟
+	dbCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), s.dbTimeout)
+	defer cancel()
+	err := s.svc.Delay(dbCtx, job, d)
+	if err != nil {
+		zap.L().Error("推迟任务失败", zap.Int64("jid", job.Id), zap.Error(err))
+	}
+}
+
+func (s *Scheduler) sleep(ctx context.Context) {
+	timer := time.NewTimer(s.interval)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+}
@@ -0,0 +1,69 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"github.com/basic-go-project-webook/webook/internal/domain"
+	svcmocks "github.com/basic-go-project-webook/webook/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+type failedExecutor struct{}
+
+func (f failedExecutor) Name() string {
+	return "failed"
+}
+
+func (f failedExecutor) Execute(ctx context.Context, job domain.Job) error {
+	return errors.New("执行失败")
+}
+
+func TestScheduler_Schedule(t *testing.T) {
+	testCases := []struct {
+		name      string
+		executor  string
+		wantDelay time.Duration
+	}{
+		{
+			name:      "没有执行器，推迟到别的实例抢占",
+			executor:  "unknown",
+			wantDelay: time.Minute * 10,
+		},
+		{
+			name:      "执行失败，推迟之后重试",
+			executor:  "failed",
+			wantDelay: time.Minute,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+			defer cancel()
+
+			released := make(chan struct{})
+			job := domain.Job{Id: 1, Executor: tc.executor, CancelFunc: func() {
+				close(released)
+			}}
+			svc := svcmocks.NewMockCronJobService(ctrl)
+			// 只抢到一次，之后没有任务
+			svc.EXPECT().Preempt(gomock.Any()).Return(job, nil)
+			svc.EXPECT().Preempt(gomock.Any()).Return(domain.Job{}, errors.New("没有任务")).AnyTimes()
+			svc.EXPECT().Delay(gomock.Any(), gomock.Any(), tc.wantDelay).Return(nil)
+
+			s := NewScheduler(svc)
+			s.interval = time.Millisecond * 10
+			s.RegisterExecutor(failedExecutor{})
+			go func() {
+				<-released
+				cancel()
+			}()
+			err := s.Schedule(ctx)
+			assert.Equal(t, context.Canceled, err)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package job
+
+import (
+	"context"
+	"github.com/basic-go-project-webook/webook/internal/service"
+	"time"
+)
+
+// OutboxRelayJob 定时把 outbox 里面的消息投递到 kafka，
+// 每次运行都会一直投递到没有到期的消息或者超时为止。
+// outbox 可能不止一个，例如文章存 MongoDB 的时候用户的 outbox 还在 MySQL 里面
+type OutboxRelayJob struct {
+	svcs    []service.OutboxRelayService
+	timeout time.Duration
+}
+
+func NewOutboxRelayJob(timeout time.Duration, svcs ...service.OutboxRelayService) *OutboxRelayJob {
+	return &OutboxRelayJob{
+		svcs:    svcs,
+		timeout: timeout,
+	}
+}
+
+func (o *OutboxRelayJob) Name() string {
+	return "outbox_relay"
+}
+
+func (o *OutboxRelayJob) Run() error {
+	ctx, cancel := context.WithTimeout(context.Background(), o.timeout)
+	defer cancel()
+	for _, svc := range o.svcs {
+		err := o.relay(ctx, svc)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o *OutboxRelayJob) relay(ctx context.Context, svc service.OutboxRelayService) error {
+	for ctx.Err() == nil {
+		n, err := svc.Relay(ctx)
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return nil
+		}
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package job
 
 import (
 	"context"
+	"github.com/basic-go-project-webook/webook/internal/domain"
 	"github.com/basic-go-project-webook/webook/internal/service"
 	rlock "github.com/gotomicro/redis-lock"
 	"go.uber.org/zap"
@@ -10,7 +11,8 @@ import (
 )
 
 type RankingJob struct {
-	svc       service.RankingService
+	name      string
+	run       func(ctx context.Context) error
 	timeout   time.Duration
 	client    *rlock.Client
 	key       string
@@ -19,7 +21,7 @@ type RankingJob struct {
 }
 
 func (r *RankingJob) Name() string {
-	return "ranking"
+	return r.name
 }
 
 func (r *RankingJob) Run() error {
@@ -59,13 +61,16 @@ func (r *RankingJob) Run() error {
 	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
 	defer cancel()
 
-	return r.svc.TopN(ctx)
+	return r.run(ctx)
 }
 
 func (r *RankingJob) Close() error {
 	r.localLock.Lock()
 	lock := r.lock
 	r.localLock.Unlock()
+	if lock == nil {
+		return nil
+	}
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
 	return lock.Unlock(ctx)
@@ -73,10 +78,27 @@ func (r *RankingJob) Close() error {
 
 func NewRankingJob(svc service.RankingService, client *rlock.Client, timeout time.Duration) *RankingJob {
 	return &RankingJob{
-		svc:       svc,
+		name:      "ranking",
+		run:       svc.TopN,
 		key:       "job:ranking",
 		client:    client,
 		localLock: &sync.Mutex{},
 		timeout:   timeout,
 	}
 }
+
+// NewRankingBoardJob 计算一个榜单，每个榜单各自抢各自的锁
+func NewRankingBoardJob(svc service.RankingService, board domain.RankingBoard, client *rlock.Client) *RankingJob {
+	name := "ranking:" + board.Name
+	return &RankingJob{
+		name: name,
+		run: func(ctx context.Context) error {
+			return svc.ComputeBoard(ctx, board.Name)
+		},
+		key:       "job:" + name,
+		client:    client,
+		localLock: &sync.Mutex{},
+		// 一个周期内算不完的话，下一次调度也拿不到锁
+		timeout: board.Interval,
+	}
+}
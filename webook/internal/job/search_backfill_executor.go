@@ -0,0 +1,157 @@
+package job
+
+import (
+	"context"
+	"github.com/basic-go-project-webook/webook/internal/domain"
+	events "github.com/basic-go-project-webook/webook/internal/events/article"
+	userevt "github.com/basic-go-project-webook/webook/internal/events/user"
+	"github.com/basic-go-project-webook/webook/internal/service"
+	"go.uber.org/zap"
+	"time"
+)
+
+// SearchBackfillExecutorName 全量导入搜索索引任务的执行器名字
+const SearchBackfillExecutorName = "search-backfill"
+
+// SearchBackfillExecutor 把已有的用户和已发表的文章全量导入搜索索引。
+// 搜索服务平时只靠事件同步，第一次上线或者索引丢了的时候，在 cron_jobs 里面插入一条
+// executor 为 search-backfill、没有 expression 的任务，调度器抢到之后执行一次。
+// 导入也是发 updated-user、updated-article 事件，每个搜索实例都有自己的索引和消费者组，
+// 这样每个实例都能收到全部的数据，直接调用搜索服务只会写到其中一个实例上。
+// 和事件同步同时进行也没关系，文章索引按照 utime 后写的赢，用户资料重复写入没有副作用
+type SearchBackfillExecutor struct {
+	userSvc   service.UserService
+	artSvc    service.ArticleService
+	producer  events.Producer
+	batchSize int
+	// 每一批消息的发送超时时间
+	timeout time.Duration
+}
+
+func NewSearchBackfillExecutor(userSvc service.UserService, artSvc service.ArticleService,
+	producer events.Producer) *SearchBackfillExecutor {
+	return &SearchBackfillExecutor{
+		userSvc:   userSvc,
+		artSvc:    artSvc,
+		producer:  producer,
+		batchSize: 100,
+		timeout:   time.Second * 10,
+	}
+}
+
+func (s *SearchBackfillExecutor) Name() string {
+	return SearchBackfillExecutorName
+}
+
+func (s *SearchBackfillExecutor) Execute(ctx context.Context, job domain.Job) error {
+	err := s.backfillUsers(ctx)
+	if err != nil {
+		return err
+	}
+	return s.backfillArticles(ctx)
+}
+
+func (s *SearchBackfillExecutor) backfillUsers(ctx context.Context) error {
+	var minId int64
+	cnt := 0
+	for {
+		users, err := s.userSvc.ListAfter(ctx, minId, s.batchSize)
+		if err != nil {
+			return err
+		}
+		err = s.produceUsers(ctx, users)
+		if err != nil {
+			return err
+		}
+		cnt += len(users)
+		if len(users) < s.batchSize {
+			zap.L().Info("用户全量导入搜索索引完成", zap.Int("cnt", cnt))
+			return nil
+		}
+		minId = users[len(users)-1].Id
+	}
+}
+
+func (s *SearchBackfillExecutor) backfillArticles(ctx context.Context) error {
+	// ListPub 按照 utime 倒序返回 utime < start 的文章。
+	// 每一页之后 start 挪到最后一篇文章的 utime + 1，offset 跳过已经导入的、utime 相同的文章，
+	// 这样导入期间有文章被修改，utime 变大移出这个范围，也不会让后面的文章错位漏掉
+	start := time.Now()
+	offset := 0
+	cnt := 0
+	for {
+		arts, err := s.artSvc.ListPub(ctx, start, offset, s.batchSize)
+		if err != nil {
+			return err
+		}
+		err = s.produceArticles(ctx, arts)
+		if err != nil {
+			return err
+		}
+		cnt += len(arts)
+		if len(arts) < s.batchSize {
+			zap.L().Info("文章全量导入搜索索引完成", zap.Int("cnt", cnt))
+			return nil
+		}
+		last := arts[len(arts)-1].Utime.UnixMilli()
+		same := 0
+		for _, art := range arts {
+			if art.Utime.UnixMilli() == last {
+				same++
+			}
+		}
+		next := time.UnixMilli(last + 1)
+		if next.Equal(start) {
+			// 一整页的 utime 都一样，start 没有变，接着往后跳
+			offset += same
+		} else {
+			offset = same
+		}
+		start = next
+	}
+}
+
+func (s *SearchBackfillExecutor) produceUsers(ctx context.Context, users []domain.User) error {
+	msgs := make([]domain.OutboxMessage, 0, len(users))
+	for _, user := range users {
+		msg, err := userevt.NewOutboxMessage(userevt.UserUpdated{
+			Version:  userevt.UserEventVersion,
+			Uid:      user.Id,
+			Nickname: user.Nickname,
+		})
+		if err != nil {
+			return err
+		}
+		msgs = append(msgs, msg)
+	}
+	return s.produce(ctx, msgs)
+}
+
+func (s *SearchBackfillExecutor) produceArticles(ctx context.Context, arts []domain.Article) error {
+	msgs := make([]domain.OutboxMessage, 0, len(arts))
+	for _, art := range arts {
+		msg, err := events.NewOutboxMessage(events.TopicUpdatedArticle, art.Id, events.ArticleUpdated{
+			Version: events.ArticleEventVersion,
+			Aid:     art.Id,
+			Uid:     art.Author.Id,
+			Title:   art.Title,
+			Content: art.Content,
+			Utime:   art.Utime.UnixMilli(),
+		})
+		if err != nil {
+			return err
+		}
+		msgs = append(msgs, msg)
+	}
+	return s.produce(ctx, msgs)
+}
+
+func (s *SearchBackfillExecutor) produce(ctx context.Context, msgs []domain.OutboxMessage) error {
+	if len(msgs) == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	// 部分失败也整个任务失败，重新执行一次没有副作用
+	return s.producer.ProduceOutboxMessages(ctx, msgs)
+}
@@ -0,0 +1,99 @@
+package job
+
+import (
+	"context"
+	"github.com/basic-go-project-webook/webook/internal/domain"
+	events "github.com/basic-go-project-webook/webook/internal/events/article"
+	userevt "github.com/basic-go-project-webook/webook/internal/events/user"
+	"github.com/basic-go-project-webook/webook/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sort"
+	"testing"
+	"time"
+)
+
+// memUserService 和 memArticleService 只实现了全量导入用到的方法
+type memUserService struct {
+	service.UserService
+	users []domain.User
+}
+
+func (m *memUserService) ListAfter(ctx context.Context, minId int64, limit int) ([]domain.User, error) {
+	var res []domain.User
+	for _, user := range m.users {
+		if user.Id > minId && len(res) < limit {
+			res = append(res, user)
+		}
+	}
+	return res, nil
+}
+
+type memArticleService struct {
+	service.ArticleService
+	arts []domain.Article
+}
+
+func (m *memArticleService) ListPub(ctx context.Context, start time.Time, offset int, limit int) ([]domain.Article, error) {
+	var matched []domain.Article
+	for _, art := range m.arts {
+		if art.Utime.Before(start) {
+			matched = append(matched, art)
+		}
+	}
+	sort.SliceStable(matched, func(i, j int) bool {
+		return matched[i].Utime.After(matched[j].Utime)
+	})
+	if offset >= len(matched) {
+		return nil, nil
+	}
+	matched = matched[offset:]
+	if len(matched) > limit {
+		matched = matched[:limit]
+	}
+	return matched, nil
+}
+
+// memProducer 按照 topic 和 key 记录投递的次数
+type memProducer struct {
+	events.Producer
+	msgs map[string]map[string]int
+}
+
+func (m *memProducer) ProduceOutboxMessages(ctx context.Context, msgs []domain.OutboxMessage) error {
+	for _, msg := range msgs {
+		if m.msgs[msg.Topic] == nil {
+			m.msgs[msg.Topic] = map[string]int{}
+		}
+		m.msgs[msg.Topic][msg.Key]++
+	}
+	return nil
+}
+
+func TestSearchBackfillExecutor_Execute(t *testing.T) {
+	now := time.Now().Add(-time.Minute)
+	users := make([]domain.User, 0, 5)
+	for i := int64(1); i <= 5; i++ {
+		users = append(users, domain.User{Id: i, Nickname: "user"})
+	}
+	// 7 篇文章，其中 4 篇 utime 一样，跨越了分页的边界
+	utimes := []time.Time{
+		now, now.Add(-time.Second), now.Add(-time.Second), now.Add(-time.Second),
+		now.Add(-time.Second), now.Add(-time.Second * 2), now.Add(-time.Second * 3),
+	}
+	arts := make([]domain.Article, 0, len(utimes))
+	for i, utime := range utimes {
+		arts = append(arts, domain.Article{Id: int64(i + 1), Title: "title", Utime: utime})
+	}
+	producer := &memProducer{msgs: map[string]map[string]int{}}
+	e := NewSearchBackfillExecutor(&memUserService{users: users}, &memArticleService{arts: arts}, producer)
+	e.batchSize = 2
+
+	err := e.Execute(context.Background(), domain.Job{})
+	require.NoError(t, err)
+	assert.Len(t, producer.msgs[userevt.TopicUpdatedUser], len(users))
+	assert.Len(t, producer.msgs[events.TopicUpdatedArticle], len(arts))
+	for id, cnt := range producer.msgs[events.TopicUpdatedArticle] {
+		assert.Equal(t, 1, cnt, "文章 %s 重复导入", id)
+	}
+}
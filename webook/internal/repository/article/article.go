@@ -6,6 +6,7 @@ import (
 	"github.com/basic-go-project-webook/webook/internal/repository"
 	"github.com/basic-go-project-webook/webook/internal/repository/cache"
 	"github.com/basic-go-project-webook/webook/internal/repository/dao/article"
+	"github.com/basic-go-project-webook/webook/internal/repository/dao/outbox"
 	"go.uber.org/zap"
 	"time"
 )
@@ -13,14 +14,31 @@ import (
 type ArticleRepository interface {
 	Create(ctx context.Context, art domain.Article) (int64, error)
 	Update(ctx context.Context, art domain.Article) error
-	Sync(ctx context.Context, art domain.Article) (int64, error)
-	SyncStatus(ctx context.Context, id int64, authorId int64, status domain.ArticleStatus) error
+	// Sync 和 SyncStatus 会把 outbox 生成的消息和文章写在同一个事务里面，outbox 可以为 nil
+	Sync(ctx context.Context, art domain.Article, outbox domain.OutboxMessageFunc) (int64, error)
+	SyncStatus(ctx context.Context, id int64, authorId int64, status domain.ArticleStatus, outbox domain.OutboxMessageFunc) error
+	// CompareAndSetStatus 只修改制作库的状态，当前状态不是 old 的时候返回 false
+	CompareAndSetStatus(ctx context.Context, id int64, authorId int64, old domain.ArticleStatus, status domain.ArticleStatus) (bool, error)
 	List(ctx context.Context, uid int64, limit int, offset int) ([]domain.Article, error)
 	GetById(ctx context.Context, id int64) (domain.Article, error)
 	GetPubById(ctx context.Context, id int64) (domain.Article, error)
+	// GetPubByIds 不查作者信息，也不保证顺序
+	GetPubByIds(ctx context.Context, ids []int64) ([]domain.Article, error)
 	ListPub(ctx context.Context, start time.Time, offset int, limit int) ([]domain.Article, error)
+	ListHistory(ctx context.Context, id int64, authorId int64, offset int, limit int) ([]domain.ArticleHistory, error)
+	GetHistory(ctx context.Context, id int64, authorId int64, version int64) (domain.ArticleHistory, error)
+	// Restore 把历史版本恢复到制作库，返回新的版本号
+	Restore(ctx context.Context, id int64, authorId int64, version int64) (int64, error)
+	ListPubByTag(ctx context.Context, tag string, cursor int64, limit int) ([]domain.Article, error)
+	ListPubByAuthor(ctx context.Context, uid int64, maxUtime time.Time, maxId int64, limit int) ([]domain.Article, error)
+	SearchTags(ctx context.Context, prefix string, limit int) ([]string, error)
+	// TransferAuthor 把 srcUid 的文章全部转给 dstUid，已发表的文章每一篇调用一次 outbox
+	TransferAuthor(ctx context.Context, srcUid int64, dstUid int64, outbox domain.ArticleOutboxFunc) error
 }
 
+// ErrHistoryNotFound 版本不存在或者不属于这个作者
+var ErrHistoryNotFound = article.ErrHistoryNotFound
+
 type CachedArticleRepository struct {
 	dao      article.ArticleDAO
 	userRepo repository.UserRepository
@@ -133,7 +151,7 @@ func (c *CachedArticleRepository) List(ctx context.Context, uid int64, limit int
 	return res, nil
 }
 
-func (c *CachedArticleRepository) SyncStatus(ctx context.Context, id int64, authorId int64, status domain.ArticleStatus) error {
+func (c *CachedArticleRepository) SyncStatus(ctx context.Context, id int64, authorId int64, status domain.ArticleStatus, outbox domain.OutboxMessageFunc) error {
 	defer func() {
 		err := c.cache.DeleteFirstPage(ctx, authorId)
 		if err != nil {
@@ -144,7 +162,23 @@ func (c *CachedArticleRepository) SyncStatus(ctx context.Context, id int64, auth
 			zap.L().Warn("删除缓存文章失败", zap.Int64("art.id", authorId), zap.Error(err))
 		}
 	}()
-	return c.dao.SyncStatus(ctx, id, authorId, status.ToUint8())
+	return c.dao.SyncStatus(ctx, id, authorId, status.ToUint8(), toOutboxEntityFunc(outbox))
+}
+
+func (c *CachedArticleRepository) CompareAndSetStatus(ctx context.Context, id int64, authorId int64, old domain.ArticleStatus, status domain.ArticleStatus) (bool, error) {
+	ok, err := c.dao.CompareAndSetStatus(ctx, id, authorId, old.ToUint8(), status.ToUint8())
+	if err != nil || !ok {
+		return ok, err
+	}
+	err = c.cache.DeleteFirstPage(ctx, authorId)
+	if err != nil {
+		zap.L().Warn("删除文章list缓存失败", zap.Int64("art.author_id", authorId), zap.Error(err))
+	}
+	err = c.cache.Del(ctx, id)
+	if err != nil {
+		zap.L().Warn("删除文章缓存失败", zap.Int64("art.id", id), zap.Error(err))
+	}
+	return true, nil
 }
 
 func (c *CachedArticleRepository) Create(ctx context.Context, art domain.Article) (int64, error) {
@@ -157,7 +191,7 @@ func (c *CachedArticleRepository) Create(ctx context.Context, art domain.Article
 	return c.dao.Insert(ctx, toArticleEntity(art))
 }
 
-func (c *CachedArticleRepository) Sync(ctx context.Context, art domain.Article) (int64, error) {
+func (c *CachedArticleRepository) Sync(ctx context.Context, art domain.Article, outbox domain.OutboxMessageFunc) (int64, error) {
 	defer func() {
 		err := c.cache.DeleteFirstPage(ctx, art.Author.Id)
 		if err != nil {
@@ -168,7 +202,7 @@ func (c *CachedArticleRepository) Sync(ctx context.Context, art domain.Article)
 			zap.L().Warn("删除文章缓存失败", zap.Int64("art.id", art.Id), zap.Error(err))
 		}
 	}()
-	return c.dao.Sync(ctx, toArticleEntity(art))
+	return c.dao.Sync(ctx, toArticleEntity(art), toOutboxEntityFunc(outbox))
 }
 
 func (c *CachedArticleRepository) Update(ctx context.Context, art domain.Article) error {
@@ -185,6 +219,115 @@ func (c *CachedArticleRepository) Update(ctx context.Context, art domain.Article
 	return c.dao.UpdateById(ctx, toArticleEntity(art))
 }
 
+func (c *CachedArticleRepository) ListHistory(ctx context.Context, id int64, authorId int64, offset int, limit int) ([]domain.ArticleHistory, error) {
+	his, err := c.dao.ListHistory(ctx, id, authorId, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.ArticleHistory, 0, len(his))
+	for _, h := range his {
+		res = append(res, historyToDomain(h))
+	}
+	return res, nil
+}
+
+func (c *CachedArticleRepository) GetHistory(ctx context.Context, id int64, authorId int64, version int64) (domain.ArticleHistory, error) {
+	his, err := c.dao.GetHistory(ctx, id, authorId, version)
+	if err != nil {
+		return domain.ArticleHistory{}, err
+	}
+	return historyToDomain(his), nil
+}
+
+func (c *CachedArticleRepository) Restore(ctx context.Context, id int64, authorId int64, version int64) (int64, error) {
+	defer func() {
+		err := c.cache.DeleteFirstPage(ctx, authorId)
+		if err != nil {
+			zap.L().Error("删除文章list缓存失败", zap.Int64("art.author_id", authorId), zap.Error(err))
+		}
+		err = c.cache.Del(ctx, id)
+		if err != nil {
+			zap.L().Warn("删除文章缓存失败", zap.Int64("art.id", id), zap.Error(err))
+		}
+	}()
+	return c.dao.Restore(ctx, id, authorId, version)
+}
+
+func (c *CachedArticleRepository) GetPubByIds(ctx context.Context, ids []int64) ([]domain.Article, error) {
+	arts, err := c.dao.GetPubByIds(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.Article, 0, len(arts))
+	for _, art := range arts {
+		res = append(res, pubToDomain(art))
+	}
+	return res, nil
+}
+
+func (c *CachedArticleRepository) ListPubByTag(ctx context.Context, tag string, cursor int64, limit int) ([]domain.Article, error) {
+	arts, err := c.dao.ListPubByTag(ctx, tag, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.Article, 0, len(arts))
+	for _, art := range arts {
+		res = append(res, pubToDomain(art))
+	}
+	return res, nil
+}
+
+func (c *CachedArticleRepository) ListPubByAuthor(ctx context.Context, uid int64, maxUtime time.Time, maxId int64, limit int) ([]domain.Article, error) {
+	arts, err := c.dao.ListPubByAuthor(ctx, uid, maxUtime.UnixMilli(), maxId, limit)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.Article, 0, len(arts))
+	for _, art := range arts {
+		res = append(res, pubToDomain(art))
+	}
+	return res, nil
+}
+
+func (c *CachedArticleRepository) SearchTags(ctx context.Context, prefix string, limit int) ([]string, error) {
+	tags, err := c.dao.SearchTags(ctx, prefix, limit)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]string, 0, len(tags))
+	for _, t := range tags {
+		res = append(res, t.Name)
+	}
+	return res, nil
+}
+
+func (c *CachedArticleRepository) TransferAuthor(ctx context.Context, srcUid int64, dstUid int64, fn domain.ArticleOutboxFunc) error {
+	var ids []int64
+	err := c.dao.TransferAuthor(ctx, srcUid, dstUid, func(art article.Article) ([]outbox.OutboxMessage, error) {
+		ids = append(ids, art.Id)
+		if fn == nil {
+			return nil, nil
+		}
+		return toOutboxEntityFunc(func(aid int64) ([]domain.OutboxMessage, error) {
+			return fn(toDomain(art))
+		})(art.Id)
+	})
+	for _, uid := range []int64{srcUid, dstUid} {
+		er := c.cache.DeleteFirstPage(ctx, uid)
+		if er != nil {
+			zap.L().Warn("删除文章list缓存失败", zap.Int64("art.author_id", uid), zap.Error(er))
+		}
+	}
+	// 草稿的缓存很快会过期，这里只删除已发表的
+	for _, id := range ids {
+		er := c.cache.Del(ctx, id)
+		if er != nil {
+			zap.L().Warn("删除文章缓存失败", zap.Int64("art.id", id), zap.Error(er))
+		}
+	}
+	return err
+}
+
 func (c *CachedArticleRepository) preCache(ctx context.Context, arts []domain.Article) {
 	const size = 1024 * 1024
 	if len(arts) > 0 && len(arts[0].Content) < size {
@@ -195,12 +338,35 @@ func (c *CachedArticleRepository) preCache(ctx context.Context, arts []domain.Ar
 	}
 }
 
+func toOutboxEntityFunc(fn domain.OutboxMessageFunc) article.OutboxMessageFunc {
+	if fn == nil {
+		return nil
+	}
+	return func(id int64) ([]outbox.OutboxMessage, error) {
+		msgs, err := fn(id)
+		if err != nil {
+			return nil, err
+		}
+		res := make([]outbox.OutboxMessage, 0, len(msgs))
+		for _, msg := range msgs {
+			res = append(res, outbox.OutboxMessage{
+				Topic:   msg.Topic,
+				MsgKey:  msg.Key,
+				Payload: msg.Payload,
+			})
+		}
+		return res, nil
+	}
+}
+
 func toArticleEntity(art domain.Article) article.Article {
 	return article.Article{
 		Id:       art.Id,
 		Title:    art.Title,
 		Content:  art.Content,
 		AuthorId: art.Author.Id,
+		Category: art.Category,
+		Tags:     art.Tags,
 		Status:   art.Status.ToUint8(),
 	}
 }
@@ -213,9 +379,25 @@ func toDomain(art article.Article) domain.Article {
 		Author: domain.Author{
 			Id: art.AuthorId,
 		},
-		Ctime:  time.UnixMilli(art.Ctime),
-		Utime:  time.UnixMilli(art.Utime),
-		Status: domain.ArticleStatus(art.Status),
+		Category: art.Category,
+		Tags:     art.Tags,
+		Ctime:    time.UnixMilli(art.Ctime),
+		Utime:    time.UnixMilli(art.Utime),
+		Status:   domain.ArticleStatus(art.Status),
+	}
+}
+
+func historyToDomain(his article.ArticleHistory) domain.ArticleHistory {
+	return domain.ArticleHistory{
+		ArticleId: his.ArticleId,
+		Version:   his.Version,
+		Title:     his.Title,
+		Content:   his.Content,
+		Author: domain.Author{
+			Id: his.AuthorId,
+		},
+		Action: domain.ArticleHistoryAction(his.Action),
+		Ctime:  time.UnixMilli(his.Ctime),
 	}
 }
 
@@ -227,9 +409,11 @@ func pubToDomain(art article.PublishedArticle) domain.Article {
 		Author: domain.Author{
 			Id: art.AuthorId,
 		},
-		Ctime:  time.UnixMilli(art.Ctime),
-		Utime:  time.UnixMilli(art.Utime),
-		Status: domain.ArticleStatus(art.Status),
+		Category: art.Category,
+		Tags:     art.Tags,
+		Ctime:    time.UnixMilli(art.Ctime),
+		Utime:    time.UnixMilli(art.Utime),
+		Status:   domain.ArticleStatus(art.Status),
 	}
 }
 
@@ -0,0 +1,165 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"sort"
+	"strconv"
+	"time"
+)
+
+type FeedCache interface {
+	// AddToInbox 把文章推到粉丝的收件箱里面，score 是文章的发表时间
+	AddToInbox(ctx context.Context, uids []int64, aid int64, ctime time.Time) error
+	// Inbox 按照 (发表时间, id) 倒序返回收件箱里面排在 (maxCtime, maxId) 之后的文章
+	Inbox(ctx context.Context, uid int64, maxCtime time.Time, maxId int64, limit int) ([]InboxItem, error)
+	// MarkPullAuthors 标记作者走拉模式，一旦标记就不会再推送
+	MarkPullAuthors(ctx context.Context, uids ...int64) error
+	// PullAuthors 返回 uids 里面走拉模式的作者
+	PullAuthors(ctx context.Context, uids []int64) ([]int64, error)
+}
+
+// InboxItem 收件箱里面的一篇文章，Ctime 是发表时间的毫秒数
+type InboxItem struct {
+	Aid   int64
+	Ctime int64
+}
+
+type RedisFeedCache struct {
+	client redis.Cmdable
+	// 每个收件箱最多保留的文章数
+	inboxSize  int64
+	expiration time.Duration
+}
+
+func NewRedisFeedCache(client redis.Cmdable) FeedCache {
+	return &RedisFeedCache{
+		client:     client,
+		inboxSize:  1000,
+		expiration: time.Hour * 24 * 30,
+	}
+}
+
+func (r *RedisFeedCache) AddToInbox(ctx context.Context, uids []int64, aid int64, ctime time.Time) error {
+	if len(uids) == 0 {
+		return nil
+	}
+	member := redis.Z{
+		Score:  float64(ctime.UnixMilli()),
+		Member: strconv.FormatInt(aid, 10),
+	}
+	pipe := r.client.Pipeline()
+	for _, uid := range uids {
+		key := r.inboxKey(uid)
+		// NX 保证重复发表的时候不会改变文章的位置
+		pipe.ZAddNX(ctx, key, member)
+		pipe.ZRemRangeByRank(ctx, key, 0, -r.inboxSize-1)
+		pipe.Expire(ctx, key, r.expiration)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (r *RedisFeedCache) Inbox(ctx context.Context, uid int64, maxCtime time.Time, maxId int64, limit int) ([]InboxItem, error) {
+	key := r.inboxKey(uid)
+	maxScore := strconv.FormatInt(maxCtime.UnixMilli(), 10)
+	// 分数相同的成员 redis 按照字典序排，和 id 的顺序不一样，
+	// 所以 maxCtime 上面的要全部取出来，自己按照 id 过滤
+	ties, err := r.client.ZCount(ctx, key, maxScore, maxScore).Result()
+	if err != nil {
+		return nil, err
+	}
+	count := int64(limit) + ties
+	zs, err := r.client.ZRevRangeByScoreWithScores(ctx, key, &redis.ZRangeBy{
+		Max:   maxScore,
+		Min:   "-inf",
+		Count: count,
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(zs)) == count && len(zs) > 0 {
+		// 同理，最后一个分数上面可能有没有取到的、id 更大的文章
+		last := strconv.FormatFloat(zs[len(zs)-1].Score, 'f', -1, 64)
+		if last != maxScore {
+			rest, er := r.client.ZRangeByScoreWithScores(ctx, key, &redis.ZRangeBy{
+				Min: last,
+				Max: last,
+			}).Result()
+			if er != nil {
+				return nil, er
+			}
+			zs = append(zs, rest...)
+		}
+	}
+
+	seen := make(map[int64]struct{}, len(zs))
+	res := make([]InboxItem, 0, len(zs))
+	for _, z := range zs {
+		member, _ := z.Member.(string)
+		aid, er := strconv.ParseInt(member, 10, 64)
+		if er != nil {
+			continue
+		}
+		item := InboxItem{Aid: aid, Ctime: int64(z.Score)}
+		if item.Ctime == maxCtime.UnixMilli() && item.Aid >= maxId {
+			continue
+		}
+		if _, ok := seen[aid]; ok {
+			continue
+		}
+		seen[aid] = struct{}{}
+		res = append(res, item)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Ctime == res[j].Ctime {
+			return res[i].Aid > res[j].Aid
+		}
+		return res[i].Ctime > res[j].Ctime
+	})
+	if len(res) > limit {
+		res = res[:limit]
+	}
+	return res, nil
+}
+
+func (r *RedisFeedCache) MarkPullAuthors(ctx context.Context, uids ...int64) error {
+	if len(uids) == 0 {
+		return nil
+	}
+	members := make([]any, 0, len(uids))
+	for _, uid := range uids {
+		members = append(members, strconv.FormatInt(uid, 10))
+	}
+	return r.client.SAdd(ctx, r.pullAuthorsKey(), members...).Err()
+}
+
+func (r *RedisFeedCache) PullAuthors(ctx context.Context, uids []int64) ([]int64, error) {
+	if len(uids) == 0 {
+		return nil, nil
+	}
+	members := make([]any, 0, len(uids))
+	for _, uid := range uids {
+		members = append(members, strconv.FormatInt(uid, 10))
+	}
+	flags, err := r.client.SMIsMember(ctx, r.pullAuthorsKey(), members...).Result()
+	if err != nil {
+		return nil, err
+	}
+	var res []int64
+	for i, ok := range flags {
+		if ok {
+			res = append(res, uids[i])
+		}
+	}
+	return res, nil
+}
+
+func (r *RedisFeedCache) inboxKey(uid int64) string {
+	return fmt.Sprintf("feed:inbox:%d", uid)
+}
+
+func (r *RedisFeedCache) pullAuthorsKey() string {
+	return "feed:pull_authors"
+}
@@ -0,0 +1,53 @@
+package cache
+
+import (
+	"context"
+	"github.com/basic-go-project-webook/webook/internal/repository/cache/redismocks"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+func TestRedisFeedCache_Inbox(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	cmd := redismocks.NewMockCmdable(ctrl)
+	ctx := context.Background()
+	key := "feed:inbox:123"
+
+	ties := redis.NewIntCmd(ctx)
+	ties.SetVal(2)
+	cmd.EXPECT().ZCount(gomock.Any(), key, "1000", "1000").Return(ties)
+	// 分数相同的时候 redis 按照字典序返回
+	page := redis.NewZSliceCmd(ctx)
+	page.SetVal([]redis.Z{
+		{Score: 1000, Member: "7"},
+		{Score: 1000, Member: "3"},
+		{Score: 900, Member: "9"},
+		{Score: 900, Member: "2"},
+	})
+	cmd.EXPECT().ZRevRangeByScoreWithScores(gomock.Any(), key, &redis.ZRangeBy{
+		Max:   "1000",
+		Min:   "-inf",
+		Count: 4,
+	}).Return(page)
+	// 900 上面还有没取到的 11
+	rest := redis.NewZSliceCmd(ctx)
+	rest.SetVal([]redis.Z{
+		{Score: 900, Member: "11"},
+		{Score: 900, Member: "2"},
+		{Score: 900, Member: "9"},
+	})
+	cmd.EXPECT().ZRangeByScoreWithScores(gomock.Any(), key, &redis.ZRangeBy{
+		Min: "900",
+		Max: "900",
+	}).Return(rest)
+
+	c := NewRedisFeedCache(cmd)
+	items, err := c.Inbox(ctx, 123, time.UnixMilli(1000), 5, 2)
+	assert.NoError(t, err)
+	// 7 排在 cursor 前面，已经返回过了
+	assert.Equal(t, []InboxItem{{Aid: 3, Ctime: 1000}, {Aid: 11, Ctime: 900}}, items)
+}
@@ -0,0 +1,24 @@
+-- 实时热榜压缩：去掉分数不是正数的，只保留前 keep 名，epoch 太旧的话整体缩小分数
+local key = KEYS[1]
+local epochKey = KEYS[2]
+local now = tonumber(ARGV[1])
+local halfLife = tonumber(ARGV[2])
+local keep = tonumber(ARGV[3])
+local rebaseAfter = tonumber(ARGV[4])
+
+-- 分数减到 0 的文章已经没有热度了
+redis.call("zremrangebyscore", key, "-inf", 0)
+redis.call("zremrangebyrank", key, 0, -keep - 1)
+
+local epoch = tonumber(redis.call("get", epochKey))
+if epoch == nil or now - epoch < rebaseAfter then
+    return 0
+end
+-- 以现在作为新的基准，所有分数乘上这段时间的衰减
+local factor = math.pow(2, -(now - epoch) / halfLife)
+local members = redis.call("zrange", key, 0, -1, "WITHSCORES")
+for i = 1, #members, 2 do
+    redis.call("zadd", key, tonumber(members[i + 1]) * factor, members[i])
+end
+redis.call("set", epochKey, ARGV[1])
+return 1
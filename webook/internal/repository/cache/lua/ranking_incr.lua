@@ -0,0 +1,25 @@
+-- 实时热榜加分
+local key = KEYS[1]
+local epochKey = KEYS[2]
+-- 点赞、收藏的时候记下加分的时间，取消的时候才能减掉当时加的分。阅读不需要
+local actionKey = KEYS[3]
+local member = ARGV[1]
+local weight = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local halfLife = tonumber(ARGV[4])
+local actionTTL = tonumber(ARGV[5])
+
+if actionKey ~= nil and not redis.call("set", actionKey, ARGV[3], "NX", "PX", actionTTL) then
+    -- 同一个用户的同一种互动已经加过分了，例如消息重复消费
+    return 0
+end
+local epoch = tonumber(redis.call("get", epochKey))
+if epoch == nil then
+    -- 第一次加分，就以现在作为基准
+    epoch = now
+    redis.call("set", epochKey, ARGV[3])
+end
+-- 越晚的互动加的分越多，等价于之前的分数按照半衰期衰减
+local delta = weight * math.pow(2, (now - epoch) / halfLife)
+redis.call("zincrby", key, delta, member)
+return 0
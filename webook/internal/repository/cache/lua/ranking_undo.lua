@@ -0,0 +1,28 @@
+-- 实时热榜取消点赞、收藏：减掉当时加的分，而不是按照现在的时间算
+local key = KEYS[1]
+local epochKey = KEYS[2]
+local actionKey = KEYS[3]
+local member = ARGV[1]
+local weight = tonumber(ARGV[2])
+local halfLife = tonumber(ARGV[3])
+
+local actedAt = tonumber(redis.call("get", actionKey))
+if actedAt == nil then
+    -- 没有加过分，或者记录已经过期，当时加的分也已经衰减得可以忽略了
+    return 0
+end
+redis.call("del", actionKey)
+local epoch = tonumber(redis.call("get", epochKey))
+local score = tonumber(redis.call("zscore", key, member))
+if epoch == nil or score == nil then
+    -- 文章已经被 Compact 裁掉了
+    return 0
+end
+-- epoch 重新选过也没关系，分数是一起缩小的，用新的 epoch 算出来的就是缩小之后的值
+local delta = weight * math.pow(2, (actedAt - epoch) / halfLife)
+if delta > score then
+    -- 浮点数误差，不能减成负数
+    delta = score
+end
+redis.call("zincrby", key, -delta, member)
+return 0
@@ -0,0 +1,128 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/basic-go-project-webook/webook/internal/domain"
+	"github.com/redis/go-redis/v9"
+	"strconv"
+	"time"
+)
+
+// ErrArticleNotRanked 文章不在榜单上，或者榜单还没有算出来
+var ErrArticleNotRanked = errors.New("文章不在榜单上")
+
+// RankingBoardCache 每个榜单一个 ZSET，member 是文章 id，score 是热度。
+// 文章的摘要信息另外放在一个 hash 里面，翻页的时候一起取出来
+type RankingBoardCache interface {
+	Replace(ctx context.Context, board string, arts []domain.RankedArticle, expiration time.Duration) error
+	// Range 按照名次返回 [offset, offset+limit) 的文章
+	Range(ctx context.Context, board string, offset, limit int) ([]domain.RankedArticle, error)
+	Rank(ctx context.Context, board string, aid int64) (domain.RankedArticle, error)
+}
+
+type RankingBoardRedisCache struct {
+	client redis.Cmdable
+}
+
+func NewRankingBoardRedisCache(client redis.Cmdable) RankingBoardCache {
+	return &RankingBoardRedisCache{
+		client: client,
+	}
+}
+
+func (r *RankingBoardRedisCache) Replace(ctx context.Context, board string, arts []domain.RankedArticle,
+	expiration time.Duration) error {
+	key, artKey := r.key(board), r.artKey(board)
+	if len(arts) == 0 {
+		return r.client.Del(ctx, key, artKey).Err()
+	}
+	members := make([]redis.Z, 0, len(arts))
+	vals := make([]any, 0, len(arts)*2)
+	for _, art := range arts {
+		art.Article.Content = ""
+		data, err := json.Marshal(art.Article)
+		if err != nil {
+			return err
+		}
+		members = append(members, redis.Z{Score: art.Score, Member: art.Article.Id})
+		vals = append(vals, art.Article.Id, data)
+	}
+	// 先写临时 key 再 RENAME，读的人不会看到算了一半的榜单
+	tmpKey, tmpArtKey := key+":tmp", artKey+":tmp"
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, tmpKey, tmpArtKey)
+		pipe.ZAdd(ctx, tmpKey, members...)
+		pipe.HSet(ctx, tmpArtKey, vals...)
+		pipe.Rename(ctx, tmpKey, key)
+		pipe.Rename(ctx, tmpArtKey, artKey)
+		pipe.Expire(ctx, key, expiration)
+		pipe.Expire(ctx, artKey, expiration)
+		return nil
+	})
+	return err
+}
+
+func (r *RankingBoardRedisCache) Range(ctx context.Context, board string, offset, limit int) ([]domain.RankedArticle, error) {
+	zs, err := r.client.ZRevRangeWithScores(ctx, r.key(board), int64(offset), int64(offset+limit-1)).Result()
+	if err != nil || len(zs) == 0 {
+		return []domain.RankedArticle{}, err
+	}
+	fields := make([]string, 0, len(zs))
+	for _, z := range zs {
+		fields = append(fields, z.Member.(string))
+	}
+	vals, err := r.client.HMGet(ctx, r.artKey(board), fields...).Result()
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.RankedArticle, 0, len(zs))
+	for i, z := range zs {
+		val, ok := vals[i].(string)
+		if !ok {
+			// 两个 key 过期的时间差里面可能取不到，跳过就可以
+			continue
+		}
+		var art domain.Article
+		err = json.Unmarshal([]byte(val), &art)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, domain.RankedArticle{
+			Article: art,
+			Rank:    int64(offset + i + 1),
+			Score:   z.Score,
+		})
+	}
+	return res, nil
+}
+
+func (r *RankingBoardRedisCache) Rank(ctx context.Context, board string, aid int64) (domain.RankedArticle, error) {
+	key, member := r.key(board), strconv.FormatInt(aid, 10)
+	pipe := r.client.Pipeline()
+	rankCmd := pipe.ZRevRank(ctx, key, member)
+	scoreCmd := pipe.ZScore(ctx, key, member)
+	_, err := pipe.Exec(ctx)
+	if errors.Is(err, redis.Nil) {
+		return domain.RankedArticle{}, ErrArticleNotRanked
+	}
+	if err != nil {
+		return domain.RankedArticle{}, err
+	}
+	return domain.RankedArticle{
+		Article: domain.Article{Id: aid},
+		Rank:    rankCmd.Val() + 1,
+		Score:   scoreCmd.Val(),
+	}, nil
+}
+
+// key 用 hash tag 把同一个榜单的 key 放到一个 slot 里，集群模式下才能 RENAME
+func (r *RankingBoardRedisCache) key(board string) string {
+	return fmt.Sprintf("ranking:board:{%s}", board)
+}
+
+func (r *RankingBoardRedisCache) artKey(board string) string {
+	return fmt.Sprintf("ranking:board:{%s}:arts", board)
+}
@@ -0,0 +1,132 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/basic-go-project-webook/webook/internal/domain"
+	"github.com/basic-go-project-webook/webook/internal/repository/cache/redismocks"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+func TestRankingBoardRedisCache_Replace(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	cmd := redismocks.NewMockCmdable(ctrl)
+	pipe := redismocks.NewMockPipeliner(ctrl)
+	key, artKey := "ranking:board:{daily}", "ranking:board:{daily}:arts"
+	tmpKey, tmpArtKey := key+":tmp", artKey+":tmp"
+
+	arts := []domain.RankedArticle{
+		{Article: domain.Article{Id: 2, Title: "第一", Content: "很长的内容"}, Rank: 1, Score: 9},
+		{Article: domain.Article{Id: 1, Title: "第二"}, Rank: 2, Score: 5},
+	}
+	first, err := json.Marshal(domain.Article{Id: 2, Title: "第一"})
+	require.NoError(t, err)
+	second, err := json.Marshal(domain.Article{Id: 1, Title: "第二"})
+	require.NoError(t, err)
+
+	// 新的榜单完整地写进临时 key 之后才替换掉旧的
+	gomock.InOrder(
+		pipe.EXPECT().Del(gomock.Any(), tmpKey, tmpArtKey),
+		pipe.EXPECT().ZAdd(gomock.Any(), tmpKey,
+			redis.Z{Score: 9, Member: int64(2)}, redis.Z{Score: 5, Member: int64(1)}),
+		pipe.EXPECT().HSet(gomock.Any(), tmpArtKey, int64(2), first, int64(1), second),
+		pipe.EXPECT().Rename(gomock.Any(), tmpKey, key),
+		pipe.EXPECT().Rename(gomock.Any(), tmpArtKey, artKey),
+		pipe.EXPECT().Expire(gomock.Any(), key, time.Hour),
+		pipe.EXPECT().Expire(gomock.Any(), artKey, time.Hour),
+	)
+	cmd.EXPECT().TxPipelined(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(redis.Pipeliner) error) ([]redis.Cmder, error) {
+			return nil, fn(pipe)
+		})
+
+	c := NewRankingBoardRedisCache(cmd)
+	err = c.Replace(context.Background(), "daily", arts, time.Hour)
+	assert.NoError(t, err)
+}
+
+func TestRankingBoardRedisCache_Range(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	cmd := redismocks.NewMockCmdable(ctrl)
+	ctx := context.Background()
+	data, err := json.Marshal(domain.Article{Id: 5, Title: "第十一"})
+	require.NoError(t, err)
+
+	zs := redis.NewZSliceCmd(ctx)
+	zs.SetVal([]redis.Z{{Score: 8, Member: "5"}, {Score: 7, Member: "3"}})
+	cmd.EXPECT().ZRevRangeWithScores(gomock.Any(), "ranking:board:{daily}", int64(10), int64(11)).Return(zs)
+	vals := redis.NewSliceCmd(ctx)
+	// 3 的摘要已经过期了
+	vals.SetVal([]any{string(data), nil})
+	cmd.EXPECT().HMGet(gomock.Any(), "ranking:board:{daily}:arts", "5", "3").Return(vals)
+
+	c := NewRankingBoardRedisCache(cmd)
+	res, err := c.Range(ctx, "daily", 10, 2)
+	assert.NoError(t, err)
+	// offset 从 0 开始，名次从 1 开始
+	assert.Equal(t, []domain.RankedArticle{
+		{Article: domain.Article{Id: 5, Title: "第十一"}, Rank: 11, Score: 8},
+	}, res)
+}
+
+func TestRankingBoardRedisCache_Rank(t *testing.T) {
+	ctx := context.Background()
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) redis.Cmdable
+		wantRes domain.RankedArticle
+		wantErr error
+	}{
+		{
+			name: "在榜单上",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				pipe := redismocks.NewMockPipeliner(ctrl)
+				rank := redis.NewIntCmd(ctx)
+				rank.SetVal(2)
+				score := redis.NewFloatCmd(ctx)
+				score.SetVal(6.5)
+				cmd.EXPECT().Pipeline().Return(pipe)
+				pipe.EXPECT().ZRevRank(gomock.Any(), "ranking:board:{daily}", "7").Return(rank)
+				pipe.EXPECT().ZScore(gomock.Any(), "ranking:board:{daily}", "7").Return(score)
+				pipe.EXPECT().Exec(gomock.Any()).Return([]redis.Cmder{rank, score}, nil)
+				return cmd
+			},
+			wantRes: domain.RankedArticle{Article: domain.Article{Id: 7}, Rank: 3, Score: 6.5},
+		},
+		{
+			name: "不在榜单上",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				pipe := redismocks.NewMockPipeliner(ctrl)
+				rank := redis.NewIntCmd(ctx)
+				rank.SetErr(redis.Nil)
+				score := redis.NewFloatCmd(ctx)
+				score.SetErr(redis.Nil)
+				cmd.EXPECT().Pipeline().Return(pipe)
+				pipe.EXPECT().ZRevRank(gomock.Any(), "ranking:board:{daily}", "7").Return(rank)
+				pipe.EXPECT().ZScore(gomock.Any(), "ranking:board:{daily}", "7").Return(score)
+				pipe.EXPECT().Exec(gomock.Any()).Return([]redis.Cmder{rank, score}, redis.Nil)
+				return cmd
+			},
+			wantErr: ErrArticleNotRanked,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			c := NewRankingBoardRedisCache(tc.mock(ctrl))
+			res, err := c.Rank(ctx, "daily", 7)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantRes, res)
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package cache
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"github.com/basic-go-project-webook/webook/internal/domain"
+	"github.com/redis/go-redis/v9"
+	"math"
+	"strconv"
+	"time"
+)
+
+//go:embed lua/ranking_incr.lua
+var luaRankingIncr string
+
+//go:embed lua/ranking_undo.lua
+var luaRankingUndo string
+
+//go:embed lua/ranking_compact.lua
+var luaRankingCompact string
+
+// RankingRealtimeCache 实时热榜。
+// 衰减不是把所有分数定时乘一个系数，而是反过来，越晚的互动加的分越多：
+// 加的分是 weight * 2^((now-epoch)/halfLife)，这样排序就等价于按照半衰期衰减之后的分数排序。
+// 分数会越来越大，所以 Compact 的时候会重新选一个 epoch，把所有分数一起缩小
+type RankingRealtimeCache interface {
+	Incr(ctx context.Context, aid int64, weight float64) error
+	// IncrAction 同一个用户对同一篇文章的同一种互动只加一次分，并且记下加分的时间
+	IncrAction(ctx context.Context, aid int64, uid int64, action string, weight float64) error
+	// UndoAction 减掉 IncrAction 当时加的分，不会减成负数
+	UndoAction(ctx context.Context, aid int64, uid int64, action string, weight float64) error
+	// Top 返回 [offset, offset+limit) 名的文章 id 和衰减到现在的分数
+	Top(ctx context.Context, offset, limit int) ([]domain.RankedArticle, error)
+	// Compact 只保留前 keep 名，并且在需要的时候重新选 epoch
+	Compact(ctx context.Context) error
+}
+
+type RankingRealtimeRedisCache struct {
+	client   redis.Cmdable
+	key      string
+	epochKey string
+	halfLife time.Duration
+	keep     int
+	// rebaseAfter epoch 离现在超过这么久就重新选，避免分数溢出
+	rebaseAfter time.Duration
+}
+
+func NewRankingRealtimeRedisCache(client redis.Cmdable, halfLife time.Duration, keep int) RankingRealtimeCache {
+	return &RankingRealtimeRedisCache{
+		client: client,
+		// 用 hash tag 保证两个 key 在同一个 slot，lua 脚本才能在集群上跑
+		key:         "ranking:{realtime}",
+		epochKey:    "ranking:{realtime}:epoch",
+		halfLife:    halfLife,
+		keep:        keep,
+		rebaseAfter: halfLife * 16,
+	}
+}
+
+func (r *RankingRealtimeRedisCache) Incr(ctx context.Context, aid int64, weight float64) error {
+	return r.client.Eval(ctx, luaRankingIncr, []string{r.key, r.epochKey},
+		aid, weight, time.Now().UnixMilli(), r.halfLife.Milliseconds()).Err()
+}
+
+func (r *RankingRealtimeRedisCache) IncrAction(ctx context.Context, aid int64, uid int64, action string, weight float64) error {
+	// 超过 rebaseAfter 之后当时加的分已经衰减得可以忽略了，记录也就不用留着
+	return r.client.Eval(ctx, luaRankingIncr, []string{r.key, r.epochKey, r.actionKey(aid, uid, action)},
+		aid, weight, time.Now().UnixMilli(), r.halfLife.Milliseconds(), r.rebaseAfter.Milliseconds()).Err()
+}
+
+func (r *RankingRealtimeRedisCache) UndoAction(ctx context.Context, aid int64, uid int64, action string, weight float64) error {
+	return r.client.Eval(ctx, luaRankingUndo, []string{r.key, r.epochKey, r.actionKey(aid, uid, action)},
+		aid, weight, r.halfLife.Milliseconds()).Err()
+}
+
+func (r *RankingRealtimeRedisCache) actionKey(aid int64, uid int64, action string) string {
+	return fmt.Sprintf("ranking:{realtime}:%s:%d:%d", action, aid, uid)
+}
+
+func (r *RankingRealtimeRedisCache) Top(ctx context.Context, offset, limit int) ([]domain.RankedArticle, error) {
+	pipe := r.client.Pipeline()
+	zCmd := pipe.ZRevRangeWithScores(ctx, r.key, int64(offset), int64(offset+limit-1))
+	epochCmd := pipe.Get(ctx, r.epochKey)
+	_, err := pipe.Exec(ctx)
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, err
+	}
+	zs := zCmd.Val()
+	res := make([]domain.RankedArticle, 0, len(zs))
+	if len(zs) == 0 {
+		return res, nil
+	}
+	epoch, err := epochCmd.Int64()
+	if err != nil {
+		return nil, err
+	}
+	decay := math.Pow(2, -float64(time.Now().UnixMilli()-epoch)/float64(r.halfLife.Milliseconds()))
+	for i, z := range zs {
+		aid, err := strconv.ParseInt(z.Member.(string), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, domain.RankedArticle{
+			Article: domain.Article{Id: aid},
+			Rank:    int64(offset + i + 1),
+			Score:   z.Score * decay,
+		})
+	}
+	return res, nil
+}
+
+func (r *RankingRealtimeRedisCache) Compact(ctx context.Context) error {
+	return r.client.Eval(ctx, luaRankingCompact, []string{r.key, r.epochKey},
+		time.Now().UnixMilli(), r.halfLife.Milliseconds(), r.keep, r.rebaseAfter.Milliseconds()).Err()
+}
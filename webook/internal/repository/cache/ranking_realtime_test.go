@@ -0,0 +1,37 @@
+package cache
+
+import (
+	"context"
+	"github.com/basic-go-project-webook/webook/internal/repository/cache/redismocks"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+func TestRankingRealtimeRedisCache_Action(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	cmd := redismocks.NewMockCmdable(ctrl)
+	keys := []string{"ranking:{realtime}", "ranking:{realtime}:epoch", "ranking:{realtime}:like:1:2"}
+	res := redis.NewCmd(context.Background())
+	res.SetVal(int64(0))
+	// 点赞记下加分的时间，取消的时候用同一个 key 找到当时加的分
+	cmd.EXPECT().Eval(gomock.Any(), luaRankingIncr, keys, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, script string, keys []string, args ...any) *redis.Cmd {
+			assert.Equal(t, int64(1), args[0])
+			assert.Equal(t, float64(3), args[1])
+			assert.Equal(t, time.Hour.Milliseconds(), args[3])
+			assert.Equal(t, (time.Hour * 16).Milliseconds(), args[4])
+			return res
+		})
+	cmd.EXPECT().Eval(gomock.Any(), luaRankingUndo, keys, int64(1), float64(3), time.Hour.Milliseconds()).
+		Return(res)
+
+	c := NewRankingRealtimeRedisCache(cmd, time.Hour, 100)
+	err := c.IncrAction(context.Background(), 1, 2, "like", 3)
+	assert.NoError(t, err)
+	err = c.UndoAction(context.Background(), 1, 2, "like", 3)
+	assert.NoError(t, err)
+}
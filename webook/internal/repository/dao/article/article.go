@@ -12,12 +12,31 @@ import (
 type ArticleDAO interface {
 	Insert(ctx context.Context, art Article) (int64, error)
 	UpdateById(ctx context.Context, art Article) error
-	Sync(ctx context.Context, art Article) (int64, error)
-	SyncStatus(ctx context.Context, id int64, authorId int64, status uint8) error
+	// Sync 同步到线上库，outbox 生成的消息会在同一个事务里面写入，可以为 nil
+	Sync(ctx context.Context, art Article, outbox OutboxMessageFunc) (int64, error)
+	SyncStatus(ctx context.Context, id int64, authorId int64, status uint8, outbox OutboxMessageFunc) error
+	// CompareAndSetStatus 只修改制作库，文章当前的状态是 old 的时候才改成 status，返回是否修改成功
+	CompareAndSetStatus(ctx context.Context, id int64, authorId int64, old uint8, status uint8) (bool, error)
 	GetByAuthor(ctx context.Context, uid int64, limit int, offset int) ([]Article, error)
 	GetById(ctx context.Context, id int64) (Article, error)
 	GetPubById(ctx context.Context, id int64) (PublishedArticle, error)
+	// GetPubByIds 批量查询已发表的文章，不保证顺序，撤回了的不返回
+	GetPubByIds(ctx context.Context, ids []int64) ([]PublishedArticle, error)
 	ListPub(ctx context.Context, start time.Time, offset int, limit int) ([]Article, error)
+	// ListPubByAuthor 按照 (utime, id) 倒序返回作者已发表的文章，只返回排在 (maxUtime, maxId) 之后的
+	ListPubByAuthor(ctx context.Context, uid int64, maxUtime int64, maxId int64, limit int) ([]PublishedArticle, error)
+	// ListHistory 按照版本号倒序返回历史版本，不包含内容
+	ListHistory(ctx context.Context, id int64, authorId int64, offset int, limit int) ([]ArticleHistory, error)
+	GetHistory(ctx context.Context, id int64, authorId int64, version int64) (ArticleHistory, error)
+	// Restore 把历史版本恢复到制作库，不影响线上库，返回恢复之后的新版本号
+	Restore(ctx context.Context, id int64, authorId int64, version int64) (int64, error)
+	// ListPubByTag 按照文章 id 倒序返回带有这个标签的已发表文章，只返回 id 小于 cursor 的
+	ListPubByTag(ctx context.Context, tag string, cursor int64, limit int) ([]PublishedArticle, error)
+	// SearchTags 标签补全，返回以 prefix 开头的标签
+	SearchTags(ctx context.Context, prefix string, limit int) ([]Tag, error)
+	// TransferAuthor 合并账号的时候把 srcUid 的文章，包括线上库和历史版本，全部转给 dstUid。
+	// 每一篇已发表的文章都会调用 outbox 生成消息，和修改在同一个事务里面写入，outbox 可以为 nil
+	TransferAuthor(ctx context.Context, srcUid int64, dstUid int64, outbox ArticleOutboxFunc) error
 }
 
 type GORMArticleDAO struct {
@@ -32,45 +51,125 @@ func NewArticleDAO(db *gorm.DB) ArticleDAO {
 
 func (dao *GORMArticleDAO) ListPub(ctx context.Context, start time.Time, offset int, limit int) ([]Article, error) {
 	var res []Article
-	err := dao.db.WithContext(ctx).
-		Where("utime < ?", start.UnixMilli()).
+	db := dao.db.WithContext(ctx)
+	// 热榜只看线上库里已发表的文章
+	err := db.Model(&PublishedArticle{}).
+		Where("utime < ? AND status = ?", start.UnixMilli(), articleStatusPublished).
 		Order("utime DESC").
 		Offset(offset).
 		Limit(limit).
 		Find(&res).Error
-	return res, err
+	if err != nil || len(res) == 0 {
+		return res, err
+	}
+	// 按标签的榜单要用到
+	ids := make([]int64, 0, len(res))
+	for _, art := range res {
+		ids = append(ids, art.Id)
+	}
+	tags, err := loadTags(db, &PublishedArticleTag{}, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range res {
+		res[i].Tags = tags[res[i].Id]
+	}
+	return res, nil
+}
+
+func (dao *GORMArticleDAO) ListPubByAuthor(ctx context.Context, uid int64, maxUtime int64, maxId int64, limit int) ([]PublishedArticle, error) {
+	var res []PublishedArticle
+	db := dao.db.WithContext(ctx)
+	err := db.Where("author_id = ? AND status = ? AND (utime < ? OR (utime = ? AND id < ?))",
+		uid, articleStatusPublished, maxUtime, maxUtime, maxId).
+		Order("utime DESC, id DESC").
+		Limit(limit).
+		Find(&res).Error
+	if err != nil || len(res) == 0 {
+		return res, err
+	}
+	ids := make([]int64, 0, len(res))
+	for _, art := range res {
+		ids = append(ids, art.Id)
+	}
+	tags, err := loadTags(db, &PublishedArticleTag{}, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range res {
+		res[i].Tags = tags[res[i].Id]
+	}
+	return res, nil
 }
 
 func (dao *GORMArticleDAO) GetPubById(ctx context.Context, id int64) (PublishedArticle, error) {
 	var art PublishedArticle
-	err := dao.db.WithContext(ctx).Where("id = ?", id).First(&art).Error
+	db := dao.db.WithContext(ctx)
+	err := db.Where("id = ?", id).First(&art).Error
 	if err != nil {
 		return PublishedArticle{}, err
 	}
-	return art, nil
+	tags, err := loadTags(db, &PublishedArticleTag{}, []int64{id})
+	art.Tags = tags[id]
+	return art, err
+}
+
+func (dao *GORMArticleDAO) GetPubByIds(ctx context.Context, ids []int64) ([]PublishedArticle, error) {
+	var res []PublishedArticle
+	db := dao.db.WithContext(ctx)
+	err := db.Where("id IN ? AND status = ?", ids, articleStatusPublished).Find(&res).Error
+	if err != nil || len(res) == 0 {
+		return res, err
+	}
+	tags, err := loadTags(db, &PublishedArticleTag{}, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range res {
+		res[i].Tags = tags[res[i].Id]
+	}
+	return res, nil
 }
 
 func (dao *GORMArticleDAO) GetById(ctx context.Context, id int64) (Article, error) {
 	var art Article
-	err := dao.db.WithContext(ctx).Where("id = ?", id).First(&art).Error
+	db := dao.db.WithContext(ctx)
+	err := db.Where("id = ?", id).First(&art).Error
 	if err != nil {
 		return Article{}, err
 	}
-	return art, nil
+	tags, err := loadTags(db, &ArticleTag{}, []int64{id})
+	art.Tags = tags[id]
+	return art, err
 }
 
 func (dao *GORMArticleDAO) GetByAuthor(ctx context.Context, uid int64, limit int, offset int) ([]Article, error) {
 	var arts []Article
-	err := dao.db.WithContext(ctx).Model(&Article{}).
+	db := dao.db.WithContext(ctx)
+	err := db.Model(&Article{}).
 		Where("author_id = ?", uid).
 		Offset(offset).
 		Limit(limit).
 		Order("utime DESC").
 		Find(&arts).Error
-	return arts, err
+	if err != nil || len(arts) == 0 {
+		return arts, err
+	}
+	ids := make([]int64, 0, len(arts))
+	for _, art := range arts {
+		ids = append(ids, art.Id)
+	}
+	tags, err := loadTags(db, &ArticleTag{}, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range arts {
+		arts[i].Tags = tags[arts[i].Id]
+	}
+	return arts, nil
 }
 
-func (dao *GORMArticleDAO) SyncStatus(ctx context.Context, id int64, authorId int64, status uint8) error {
+func (dao *GORMArticleDAO) SyncStatus(ctx context.Context, id int64, authorId int64, status uint8, outbox OutboxMessageFunc) error {
 	now := time.Now().UnixMilli()
 	err := dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		res := tx.Model(&Article{}).Where("id = ? AND author_id = ?", id, authorId).
@@ -87,31 +186,56 @@ func (dao *GORMArticleDAO) SyncStatus(ctx context.Context, id int64, authorId in
 			return fmt.Errorf("id 或者 authorId 错误, uid: %d, authorId: %d", id, authorId)
 		}
 
-		return tx.Model(&PublishedArticle{}).Where("id = ?", id).
+		err := tx.Model(&PublishedArticle{}).Where("id = ?", id).
 			Updates(map[string]interface{}{
 				"status": status,
 				"utime":  now,
 			}).Error
+		if err != nil {
+			return err
+		}
+		return insertOutbox(tx, id, outbox, now)
 	})
 
 	return err
 }
 
-func (dao *GORMArticleDAO) Sync(ctx context.Context, art Article) (int64, error) {
+func (dao *GORMArticleDAO) CompareAndSetStatus(ctx context.Context, id int64, authorId int64, old uint8, status uint8) (bool, error) {
+	res := dao.db.WithContext(ctx).Model(&Article{}).
+		Where("id = ? AND author_id = ? AND status = ?", id, authorId, old).
+		Updates(map[string]interface{}{
+			"status": status,
+			"utime":  time.Now().UnixMilli(),
+		})
+	return res.RowsAffected > 0, res.Error
+}
+
+func (dao *GORMArticleDAO) Sync(ctx context.Context, art Article, outbox OutboxMessageFunc) (int64, error) {
 	var id = art.Id
 	err := dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		var err error
-		daoTX := NewArticleDAO(tx)
+		now := time.Now().UnixMilli()
 		if id > 0 {
-			err = daoTX.UpdateById(ctx, art)
+			err = updateArticle(tx, art, now)
 		} else {
-			id, err = dao.Insert(ctx, art)
+			id, err = insertArticle(tx, art, now)
 		}
 		if err != nil {
 			return err
 		}
 		art.Id = id
-		now := time.Now().UnixMilli()
+		_, err = insertHistory(tx, art, historyActionPublish, now)
+		if err != nil {
+			return err
+		}
+		err = replaceTags(tx, &ArticleTag{}, id, art.Tags, now)
+		if err != nil {
+			return err
+		}
+		err = replaceTags(tx, &PublishedArticleTag{}, id, art.Tags, now)
+		if err != nil {
+			return err
+		}
 		pubArt := PublishedArticle{art}
 		pubArt.Utime = now
 		pubArt.Ctime = now
@@ -119,39 +243,79 @@ func (dao *GORMArticleDAO) Sync(ctx context.Context, art Article) (int64, error)
 		err = tx.Clauses(clause.OnConflict{
 			Columns: []clause.Column{{Name: "id"}},
 			DoUpdates: clause.Assignments(map[string]interface{}{
-				"title":   pubArt.Title,
-				"content": pubArt.Content,
-				"utime":   pubArt.Utime,
-				"status":  pubArt.Status,
+				"title":    pubArt.Title,
+				"content":  pubArt.Content,
+				"category": pubArt.Category,
+				"utime":    pubArt.Utime,
+				"status":   pubArt.Status,
 			}),
 		}).Create(&pubArt).Error
-		return err
+		if err != nil {
+			return err
+		}
+		return insertOutbox(tx, id, outbox, now)
 	})
 	return id, err
 }
 
 func (dao *GORMArticleDAO) Insert(ctx context.Context, art Article) (int64, error) {
-	now := time.Now().UnixMilli()
+	var id int64
+	err := dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		now := time.Now().UnixMilli()
+		id, err = insertArticle(tx, art, now)
+		if err != nil {
+			return err
+		}
+		art.Id = id
+		_, err = insertHistory(tx, art, historyActionSave, now)
+		if err != nil {
+			return err
+		}
+		return replaceTags(tx, &ArticleTag{}, id, art.Tags, now)
+	})
+	return id, err
+}
+
+func (dao *GORMArticleDAO) UpdateById(ctx context.Context, art Article) error {
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now().UnixMilli()
+		err := updateArticle(tx, art, now)
+		if err != nil {
+			return err
+		}
+		_, err = insertHistory(tx, art, historyActionSave, now)
+		if err != nil {
+			return err
+		}
+		return replaceTags(tx, &ArticleTag{}, art.Id, art.Tags, now)
+	})
+}
+
+func insertArticle(tx *gorm.DB, art Article, now int64) (int64, error) {
 	art.Ctime = now
 	art.Utime = now
-	err := dao.db.WithContext(ctx).Create(&art).Error
+	err := tx.Create(&art).Error
 	return art.Id, err
 }
 
-func (dao *GORMArticleDAO) UpdateById(ctx context.Context, art Article) error {
-	now := time.Now().UnixMilli()
+func updateArticle(tx *gorm.DB, art Article, now int64) error {
 	art.Utime = now
-	res := dao.db.WithContext(ctx).Model(&art).
+	res := tx.Model(&art).
 		Where("id = ? AND author_id = ?", art.Id, art.AuthorId).Updates(map[string]any{
-		"title":   art.Title,
-		"content": art.Content,
-		"utime":   art.Utime,
-		"status":  art.Status,
+		"title":    art.Title,
+		"content":  art.Content,
+		"category": art.Category,
+		"utime":    art.Utime,
+		"status":   art.Status,
 	})
+	if res.Error != nil {
+		return res.Error
+	}
 	if res.RowsAffected == 0 {
 		return fmt.Errorf("更新失败, 可能创作者非法, id: %d, author_id: %d", art.Id, art.AuthorId)
 	}
-	return res.Error
+	return nil
 }
 
 // Article 制作库
@@ -160,6 +324,9 @@ type Article struct {
 	Title    string `gorm:"type:varchar(1024)" bson:"title,omitempty"`
 	Content  string `gorm:"type:BLOB" bson:"content,omitempty"`
 	AuthorId int64  `gorm:"index" bson:"author_id,omitempty"`
+	Category string `gorm:"type:varchar(64);index" bson:"category,omitempty"`
+	// Tags 存放在关系表里面，MongoDB 直接存放在文档里面
+	Tags []string `gorm:"-" bson:"tags,omitempty"`
 	//AuthorId int64  `gorm:"index:aid_ctime"`
 	//Ctime    int64  `gorm:"index:aid_ctime"`
 	Ctime  int64 `bson:"ctime,omitempty"`
@@ -171,3 +338,12 @@ type Article struct {
 type PublishedArticle struct {
 	Article
 }
+
+// 和 domain.ArticleStatus 保持一致
+const (
+	articleStatusUnknown uint8 = iota
+	articleStatusUnpublished
+	articleStatusPublished
+	articleStatusPrivate
+	articleStatusScheduled
+)
@@ -0,0 +1,108 @@
+package article
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"time"
+)
+
+// ErrHistoryNotFound 版本不存在或者不属于这个作者
+var ErrHistoryNotFound = gorm.ErrRecordNotFound
+
+// 和 domain.ArticleHistoryAction 保持一致
+const (
+	historyActionUnknown uint8 = iota
+	historyActionSave
+	historyActionPublish
+	historyActionRestore
+)
+
+func (dao *GORMArticleDAO) ListHistory(ctx context.Context, id int64, authorId int64, offset int, limit int) ([]ArticleHistory, error) {
+	var res []ArticleHistory
+	// 列表不需要内容，内容在 diff 的时候再查
+	err := dao.db.WithContext(ctx).
+		Select("id", "article_id", "version", "title", "author_id", "action", "ctime").
+		Where("article_id = ? AND author_id = ?", id, authorId).
+		Order("version DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMArticleDAO) GetHistory(ctx context.Context, id int64, authorId int64, version int64) (ArticleHistory, error) {
+	var res ArticleHistory
+	err := dao.db.WithContext(ctx).
+		Where("article_id = ? AND author_id = ? AND version = ?", id, authorId, version).
+		First(&res).Error
+	return res, err
+}
+
+func (dao *GORMArticleDAO) Restore(ctx context.Context, id int64, authorId int64, version int64) (int64, error) {
+	var newVersion int64
+	err := dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var his ArticleHistory
+		err := tx.Where("article_id = ? AND author_id = ? AND version = ?", id, authorId, version).
+			First(&his).Error
+		if err != nil {
+			return err
+		}
+		var cur Article
+		err = tx.Where("id = ? AND author_id = ?", id, authorId).First(&cur).Error
+		if err != nil {
+			return err
+		}
+		// 只恢复标题和内容到制作库，分类和标签保持不变，线上库要等作者重新发表
+		art := Article{
+			Id:       id,
+			Title:    his.Title,
+			Content:  his.Content,
+			AuthorId: authorId,
+			Category: cur.Category,
+			Status:   articleStatusUnpublished,
+		}
+		now := time.Now().UnixMilli()
+		err = updateArticle(tx, art, now)
+		if err != nil {
+			return err
+		}
+		newVersion, err = insertHistory(tx, art, historyActionRestore, now)
+		return err
+	})
+	return newVersion, err
+}
+
+// insertHistory 在修改制作库的事务里面调用，返回新的版本号。
+// 调用之前文章的那一行已经被 UPDATE 锁住了，所以同一篇文章的版本号不会冲突
+func insertHistory(tx *gorm.DB, art Article, action uint8, now int64) (int64, error) {
+	var version int64
+	err := tx.Model(&ArticleHistory{}).
+		Select("COALESCE(MAX(version), 0)").
+		Where("article_id = ?", art.Id).
+		Scan(&version).Error
+	if err != nil {
+		return 0, err
+	}
+	his := ArticleHistory{
+		ArticleId: art.Id,
+		Version:   version + 1,
+		Title:     art.Title,
+		Content:   art.Content,
+		AuthorId:  art.AuthorId,
+		Action:    action,
+		Ctime:     now,
+	}
+	return his.Version, tx.Create(&his).Error
+}
+
+// ArticleHistory 制作库每一次保存、发表和恢复都会留下一个版本
+type ArticleHistory struct {
+	Id        int64  `gorm:"primaryKey;autoIncrement" bson:"id"`
+	ArticleId int64  `gorm:"uniqueIndex:aid_version" bson:"article_id"`
+	Version   int64  `gorm:"uniqueIndex:aid_version" bson:"version"`
+	Title     string `gorm:"type:varchar(1024)" bson:"title"`
+	Content   string `gorm:"type:BLOB" bson:"content,omitempty"`
+	AuthorId  int64  `bson:"author_id"`
+	Action    uint8  `bson:"action"`
+	Ctime     int64  `bson:"ctime"`
+}
@@ -3,144 +3,436 @@ package article
 import (
 	"context"
 	"errors"
+	"fmt"
+	"github.com/basic-go-project-webook/webook/internal/repository/dao/outbox"
 	"github.com/bwmarrin/snowflake"
 	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
 	"go.mongodb.org/mongo-driver/v2/mongo/options"
-	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"regexp"
+	"sort"
+	"strings"
 	"time"
 )
 
+// MongoDBArticleDAO 制作库、线上库、历史版本和 outbox 各一个集合。
+// 要一起修改的地方都放在事务里面，所以 MongoDB 必须是副本集
 type MongoDBArticleDAO struct {
-	node    *snowflake.Node
-	col     *mongo.Collection
-	liveCol *mongo.Collection
+	node      *snowflake.Node
+	client    *mongo.Client
+	col       *mongo.Collection
+	liveCol   *mongo.Collection
+	hisCol    *mongo.Collection
+	outboxCol *mongo.Collection
+}
+
+func NewMongoDBArticleDAO(mdb *mongo.Database, node *snowflake.Node) ArticleDAO {
+	return &MongoDBArticleDAO{
+		node:      node,
+		client:    mdb.Client(),
+		col:       mdb.Collection(mongoArticleCollection),
+		liveCol:   mdb.Collection(mongoPublishedArticleCollection),
+		hisCol:    mdb.Collection(mongoArticleHistoryCollection),
+		outboxCol: mdb.Collection(outbox.MongoCollection),
+	}
 }
 
 func (m *MongoDBArticleDAO) ListPub(ctx context.Context, start time.Time, offset int, limit int) ([]Article, error) {
-	//TODO implement me
-	panic("implement me")
+	filter := bson.D{
+		{Key: "status", Value: articleStatusPublished},
+		{Key: "utime", Value: bson.D{{Key: "$lt", Value: start.UnixMilli()}}},
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "utime", Value: -1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+	return findArticles(ctx, m.liveCol, filter, opts)
 }
 
 func (m *MongoDBArticleDAO) GetPubById(ctx context.Context, id int64) (PublishedArticle, error) {
-	filter := bson.M{"id": id}
 	var art Article
-	err := m.liveCol.FindOne(ctx, filter).Decode(&art)
-	return PublishedArticle{
-		art,
-	}, err
+	err := m.liveCol.FindOne(ctx, bson.D{{Key: "id", Value: id}}).Decode(&art)
+	if err != nil {
+		return PublishedArticle{}, mongoErr(err)
+	}
+	return PublishedArticle{art}, nil
+}
+
+func (m *MongoDBArticleDAO) GetPubByIds(ctx context.Context, ids []int64) ([]PublishedArticle, error) {
+	filter := bson.D{
+		{Key: "id", Value: bson.D{{Key: "$in", Value: ids}}},
+		{Key: "status", Value: articleStatusPublished},
+	}
+	arts, err := findArticles(ctx, m.liveCol, filter)
+	return toPublished(arts), err
 }
 
 func (m *MongoDBArticleDAO) GetById(ctx context.Context, id int64) (Article, error) {
-	filter := bson.M{"id": id}
 	var art Article
-	err := m.col.FindOne(ctx, filter).Decode(&art)
-	if errors.Is(err, mongo.ErrNoDocuments) {
-		return Article{}, errors.New("article not found")
+	err := m.col.FindOne(ctx, bson.D{{Key: "id", Value: id}}).Decode(&art)
+	if err != nil {
+		return Article{}, mongoErr(err)
 	}
-	return art, err
+	return art, nil
 }
 
 func (m *MongoDBArticleDAO) GetByAuthor(ctx context.Context, uid int64, limit int, offset int) ([]Article, error) {
-	filter := bson.M{"author_id": uid}
-	findOptions := options.Find().SetLimit(int64(limit)).SetSkip(int64(offset))
-	cursor, err := m.col.Find(ctx, filter, findOptions)
+	opts := options.Find().
+		SetSort(bson.D{{Key: "utime", Value: -1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+	return findArticles(ctx, m.col, bson.D{{Key: "author_id", Value: uid}}, opts)
+}
+
+func (m *MongoDBArticleDAO) Insert(ctx context.Context, art Article) (int64, error) {
+	var id int64
+	err := m.withTx(ctx, func(ctx context.Context) error {
+		var err error
+		id, err = m.insertArticle(ctx, art, time.Now().UnixMilli())
+		if err != nil {
+			return err
+		}
+		art.Id = id
+		_, err = m.insertHistory(ctx, art, historyActionSave, time.Now().UnixMilli())
+		return err
+	})
+	return id, err
+}
+
+func (m *MongoDBArticleDAO) UpdateById(ctx context.Context, art Article) error {
+	return m.withTx(ctx, func(ctx context.Context) error {
+		now := time.Now().UnixMilli()
+		err := m.updateArticle(ctx, art, now)
+		if err != nil {
+			return err
+		}
+		_, err = m.insertHistory(ctx, art, historyActionSave, now)
+		return err
+	})
+}
+
+func (m *MongoDBArticleDAO) Sync(ctx context.Context, art Article, outbox OutboxMessageFunc) (int64, error) {
+	id := art.Id
+	err := m.withTx(ctx, func(ctx context.Context) error {
+		now := time.Now().UnixMilli()
+		var err error
+		if id > 0 {
+			err = m.updateArticle(ctx, art, now)
+		} else {
+			id, err = m.insertArticle(ctx, art, now)
+		}
+		if err != nil {
+			return err
+		}
+		art.Id = id
+		// 同步线上库，字段要一个个写，Article 上面的 omitempty 会把清空的字段漏掉
+		filter := bson.D{{Key: "id", Value: id}, {Key: "author_id", Value: art.AuthorId}}
+		update := bson.D{
+			{Key: "$set", Value: bson.D{
+				{Key: "title", Value: art.Title},
+				{Key: "content", Value: art.Content},
+				{Key: "category", Value: art.Category},
+				{Key: "tags", Value: art.Tags},
+				{Key: "status", Value: art.Status},
+				{Key: "utime", Value: now},
+			}},
+			{Key: "$setOnInsert", Value: bson.D{{Key: "ctime", Value: now}}},
+		}
+		_, err = m.liveCol.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+		if err != nil {
+			return err
+		}
+		_, err = m.insertHistory(ctx, art, historyActionPublish, now)
+		if err != nil {
+			return err
+		}
+		return m.insertOutbox(ctx, id, outbox, now)
+	})
+	return id, err
+}
+
+func (m *MongoDBArticleDAO) SyncStatus(ctx context.Context, id int64, authorId int64, status uint8, outbox OutboxMessageFunc) error {
+	return m.withTx(ctx, func(ctx context.Context) error {
+		now := time.Now().UnixMilli()
+		filter := bson.D{{Key: "id", Value: id}, {Key: "author_id", Value: authorId}}
+		update := bson.D{{Key: "$set", Value: bson.D{
+			{Key: "status", Value: status},
+			{Key: "utime", Value: now},
+		}}}
+		res, err := m.col.UpdateOne(ctx, filter, update)
+		if err != nil {
+			return err
+		}
+		if res.MatchedCount != 1 {
+			return fmt.Errorf("更新失败, 可能创作者非法, id: %d, author_id: %d", id, authorId)
+		}
+		_, err = m.liveCol.UpdateOne(ctx, filter, update)
+		if err != nil {
+			return err
+		}
+		return m.insertOutbox(ctx, id, outbox, now)
+	})
+}
+
+func (m *MongoDBArticleDAO) TransferAuthor(ctx context.Context, srcUid int64, dstUid int64, outbox ArticleOutboxFunc) error {
+	return m.withTx(ctx, func(ctx context.Context) error {
+		now := time.Now().UnixMilli()
+		pubs, err := findArticles(ctx, m.liveCol, bson.D{
+			{Key: "author_id", Value: srcUid},
+			{Key: "status", Value: articleStatusPublished},
+		})
+		if err != nil {
+			return err
+		}
+		filter := bson.D{{Key: "author_id", Value: srcUid}}
+		update := bson.D{{Key: "$set", Value: bson.D{{Key: "author_id", Value: dstUid}}}}
+		for _, col := range []*mongo.Collection{m.col, m.liveCol, m.hisCol} {
+			_, err = col.UpdateMany(ctx, filter, update)
+			if err != nil {
+				return err
+			}
+		}
+		for _, pub := range pubs {
+			err = m.insertOutbox(ctx, pub.Id, transferOutbox(pub, dstUid, outbox), now)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (m *MongoDBArticleDAO) CompareAndSetStatus(ctx context.Context, id int64, authorId int64, old uint8, status uint8) (bool, error) {
+	filter := bson.D{{Key: "id", Value: id}, {Key: "author_id", Value: authorId}, {Key: "status", Value: old}}
+	update := bson.D{{Key: "$set", Value: bson.D{
+		{Key: "status", Value: status},
+		{Key: "utime", Value: time.Now().UnixMilli()},
+	}}}
+	res, err := m.col.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+	return res.ModifiedCount > 0, nil
+}
+
+func (m *MongoDBArticleDAO) ListPubByAuthor(ctx context.Context, uid int64, maxUtime int64, maxId int64, limit int) ([]PublishedArticle, error) {
+	filter := bson.D{
+		{Key: "author_id", Value: uid},
+		{Key: "status", Value: articleStatusPublished},
+		{Key: "$or", Value: bson.A{
+			bson.D{{Key: "utime", Value: bson.D{{Key: "$lt", Value: maxUtime}}}},
+			bson.D{{Key: "utime", Value: maxUtime}, {Key: "id", Value: bson.D{{Key: "$lt", Value: maxId}}}},
+		}},
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "utime", Value: -1}, {Key: "id", Value: -1}}).
+		SetLimit(int64(limit))
+	arts, err := findArticles(ctx, m.liveCol, filter, opts)
+	return toPublished(arts), err
+}
+
+func (m *MongoDBArticleDAO) ListPubByTag(ctx context.Context, tag string, cursor int64, limit int) ([]PublishedArticle, error) {
+	filter := bson.D{{Key: "tags", Value: tag}, {Key: "status", Value: articleStatusPublished}}
+	if cursor > 0 {
+		filter = append(filter, bson.E{Key: "id", Value: bson.D{{Key: "$lt", Value: cursor}}})
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "id", Value: -1}}).SetLimit(int64(limit))
+	arts, err := findArticles(ctx, m.liveCol, filter, opts)
+	return toPublished(arts), err
+}
+
+func (m *MongoDBArticleDAO) SearchTags(ctx context.Context, prefix string, limit int) ([]Tag, error) {
+	// 标签直接存放在文档里面，没有单独的集合，只能从已发表的文章里面去重
+	filter := bson.D{{Key: "tags", Value: bson.D{{Key: "$regex", Value: "^" + regexp.QuoteMeta(prefix)}}}}
+	var names []string
+	err := m.liveCol.Distinct(ctx, "tags", filter).Decode(&names)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+	res := make([]Tag, 0, limit)
+	for _, name := range names {
+		if len(res) >= limit {
+			break
+		}
+		if strings.HasPrefix(name, prefix) {
+			res = append(res, Tag{Name: name})
+		}
+	}
+	return res, nil
+}
+
+func (m *MongoDBArticleDAO) ListHistory(ctx context.Context, id int64, authorId int64, offset int, limit int) ([]ArticleHistory, error) {
+	filter := bson.D{{Key: "article_id", Value: id}, {Key: "author_id", Value: authorId}}
+	// 列表不需要内容，内容在 diff 的时候再查
+	opts := options.Find().
+		SetProjection(bson.D{{Key: "content", Value: 0}}).
+		SetSort(bson.D{{Key: "version", Value: -1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+	cur, err := m.hisCol.Find(ctx, filter, opts)
 	if err != nil {
 		return nil, err
 	}
-	defer func(cursor *mongo.Cursor, ctx context.Context) {
-		err = cursor.Close(ctx)
+	res := make([]ArticleHistory, 0, limit)
+	err = cur.All(ctx, &res)
+	return res, err
+}
+
+func (m *MongoDBArticleDAO) GetHistory(ctx context.Context, id int64, authorId int64, version int64) (ArticleHistory, error) {
+	filter := bson.D{{Key: "article_id", Value: id}, {Key: "author_id", Value: authorId}, {Key: "version", Value: version}}
+	var res ArticleHistory
+	err := m.hisCol.FindOne(ctx, filter).Decode(&res)
+	if err != nil {
+		return ArticleHistory{}, mongoErr(err)
+	}
+	return res, nil
+}
+
+func (m *MongoDBArticleDAO) Restore(ctx context.Context, id int64, authorId int64, version int64) (int64, error) {
+	var newVersion int64
+	err := m.withTx(ctx, func(ctx context.Context) error {
+		his, err := m.GetHistory(ctx, id, authorId, version)
 		if err != nil {
-			zap.L().Error("close cursor error", zap.Error(err))
+			return err
 		}
-	}(cursor, ctx)
-	var articles []Article
-	for cursor.Next(ctx) {
-		var article Article
-		err = cursor.Decode(&article)
+		var cur Article
+		err = m.col.FindOne(ctx, bson.D{{Key: "id", Value: id}, {Key: "author_id", Value: authorId}}).Decode(&cur)
 		if err != nil {
-			return nil, err
+			return mongoErr(err)
 		}
-		articles = append(articles, article)
+		// 只恢复标题和内容到制作库，分类和标签保持不变，线上库要等作者重新发表
+		art := Article{
+			Id:       id,
+			Title:    his.Title,
+			Content:  his.Content,
+			AuthorId: authorId,
+			Category: cur.Category,
+			Tags:     cur.Tags,
+			Status:   articleStatusUnpublished,
+		}
+		now := time.Now().UnixMilli()
+		err = m.updateArticle(ctx, art, now)
+		if err != nil {
+			return err
+		}
+		newVersion, err = m.insertHistory(ctx, art, historyActionRestore, now)
+		return err
+	})
+	return newVersion, err
+}
+
+// withTx fn 里面要使用传进去的 ctx，才会在同一个事务里面
+func (m *MongoDBArticleDAO) withTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	sess, err := m.client.StartSession()
+	if err != nil {
+		return err
 	}
-	return articles, nil
+	defer sess.EndSession(ctx)
+	_, err = sess.WithTransaction(ctx, func(ctx context.Context) (interface{}, error) {
+		return nil, fn(ctx)
+	})
+	return err
 }
 
-func (m *MongoDBArticleDAO) Insert(ctx context.Context, art Article) (int64, error) {
-	now := time.Now().UnixMilli()
+func (m *MongoDBArticleDAO) insertArticle(ctx context.Context, art Article, now int64) (int64, error) {
+	art.Id = m.node.Generate().Int64()
 	art.Ctime = now
 	art.Utime = now
-	art.Id = m.node.Generate().Int64()
 	_, err := m.col.InsertOne(ctx, &art)
 	return art.Id, err
 }
 
-func (m *MongoDBArticleDAO) UpdateById(ctx context.Context, art Article) error {
-	now := time.Now().UnixMilli()
-	art.Utime = now
-	filter := bson.D{bson.E{"id", art.Id}, bson.E{"author_id", art.AuthorId}}
-	set := bson.D{bson.E{"$set", bson.M{
-		"title":   art.Title,
-		"content": art.Content,
-		"status":  art.Status,
-		"utime":   art.Utime,
+func (m *MongoDBArticleDAO) updateArticle(ctx context.Context, art Article, now int64) error {
+	filter := bson.D{{Key: "id", Value: art.Id}, {Key: "author_id", Value: art.AuthorId}}
+	update := bson.D{{Key: "$set", Value: bson.D{
+		{Key: "title", Value: art.Title},
+		{Key: "content", Value: art.Content},
+		{Key: "category", Value: art.Category},
+		{Key: "tags", Value: art.Tags},
+		{Key: "status", Value: art.Status},
+		{Key: "utime", Value: now},
 	}}}
-
-	res, err := m.col.UpdateOne(ctx, filter, set)
+	res, err := m.col.UpdateOne(ctx, filter, update)
 	if err != nil {
 		return err
 	}
 	if res.MatchedCount == 0 {
-		return errors.New("ID 不对或创作者不对")
+		return fmt.Errorf("更新失败, 可能创作者非法, id: %d, author_id: %d", art.Id, art.AuthorId)
 	}
 	return nil
 }
 
-func (m *MongoDBArticleDAO) Sync(ctx context.Context, art Article) (int64, error) {
-	var (
-		id  = art.Id
-		err error
-	)
-	if id > 0 {
-		err = m.UpdateById(ctx, art)
-	} else {
-		id, err = m.Insert(ctx, art)
-	}
-	if err != nil {
+// insertHistory 在事务里面调用。两个事务同时给一篇文章写版本的时候，
+// 后提交的会因为 article_id + version 的唯一索引冲突而失败
+func (m *MongoDBArticleDAO) insertHistory(ctx context.Context, art Article, action uint8, now int64) (int64, error) {
+	var last ArticleHistory
+	opts := options.FindOne().
+		SetSort(bson.D{{Key: "version", Value: -1}}).
+		SetProjection(bson.D{{Key: "version", Value: 1}})
+	err := m.hisCol.FindOne(ctx, bson.D{{Key: "article_id", Value: art.Id}}, opts).Decode(&last)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
 		return 0, err
 	}
-
-	// 同步线上库
-	art.Id = id
-	now := time.Now().UnixMilli()
-	art.Utime = now
-	filter := bson.D{bson.E{"id", art.Id},
-		bson.E{"author_id", art.AuthorId}}
-	set := bson.D{bson.E{"$set", art},
-		bson.E{"$setOnInsert", bson.D{bson.E{"ctime", now}}}}
-	_, err = m.liveCol.UpdateOne(ctx, filter, set, options.Update().SetUpsert(true))
-	return id, err
+	his := ArticleHistory{
+		Id:        m.node.Generate().Int64(),
+		ArticleId: art.Id,
+		Version:   last.Version + 1,
+		Title:     art.Title,
+		Content:   art.Content,
+		AuthorId:  art.AuthorId,
+		Action:    action,
+		Ctime:     now,
+	}
+	_, err = m.hisCol.InsertOne(ctx, &his)
+	return his.Version, err
 }
 
-func (m *MongoDBArticleDAO) SyncStatus(ctx context.Context, id int64, authorId int64, status uint8) error {
-	filter := bson.D{bson.E{"id", id}, bson.E{"author_id", authorId}}
-	set := bson.D{bson.E{"$set", bson.D{bson.E{"status", status}}}}
-	// upsert 语义
-	res, err := m.col.UpdateOne(ctx, filter, set, options.Update().SetUpsert(true))
-	if err != nil {
+func (m *MongoDBArticleDAO) insertOutbox(ctx context.Context, id int64, fn OutboxMessageFunc, now int64) error {
+	if fn == nil {
+		return nil
+	}
+	msgs, err := fn(id)
+	if err != nil || len(msgs) == 0 {
 		return err
 	}
-	if res.MatchedCount != 1 {
-		return errors.New("ID 或者创作者不对")
+	docs := make([]any, 0, len(msgs))
+	for _, msg := range msgs {
+		msg.Id = m.node.Generate().Int64()
+		msg.Status = outbox.OutboxStatusPending
+		msg.NextTime = now
+		msg.Ctime = now
+		msg.Utime = now
+		docs = append(docs, msg)
 	}
-	_, err = m.liveCol.UpdateOne(ctx, filter, set)
+	_, err = m.outboxCol.InsertMany(ctx, docs)
 	return err
 }
 
-func NewMongoDBArticleDAO(mdb *mongo.Database, node *snowflake.Node) ArticleDAO {
-	return &MongoDBArticleDAO{
-		col:     mdb.Collection("articles"),
-		liveCol: mdb.Collection("published_articles"),
-		node:    node,
+func findArticles(ctx context.Context, col *mongo.Collection, filter any,
+	opts ...options.Lister[options.FindOptions]) ([]Article, error) {
+	cur, err := col.Find(ctx, filter, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]Article, 0)
+	err = cur.All(ctx, &res)
+	return res, err
+}
+
+func toPublished(arts []Article) []PublishedArticle {
+	res := make([]PublishedArticle, 0, len(arts))
+	for _, art := range arts {
+		res = append(res, PublishedArticle{art})
 	}
+	return res
+}
+
+// mongoErr 查不到的时候和 GORM 的实现返回一样的错误，上层不需要区分
+func mongoErr(err error) error {
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return gorm.ErrRecordNotFound
+	}
+	return err
 }
@@ -0,0 +1,57 @@
+package article
+
+import (
+	"context"
+	"github.com/basic-go-project-webook/webook/internal/repository/dao/outbox"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+	"time"
+)
+
+const (
+	mongoArticleCollection          = "articles"
+	mongoPublishedArticleCollection = "published_articles"
+	mongoArticleHistoryCollection   = "article_histories"
+)
+
+// InitCollections 创建 MongoDB 需要的索引，索引已经存在的时候什么也不做。
+// 对应 GORM 实现里面 AutoMigrate 建的表和索引
+func InitCollections(mdb *mongo.Database) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	indexes := map[string][]mongo.IndexModel{
+		mongoArticleCollection: {
+			{Keys: bson.D{{Key: "id", Value: 1}}, Options: options.Index().SetUnique(true)},
+			// 作者的草稿列表
+			{Keys: bson.D{{Key: "author_id", Value: 1}, {Key: "utime", Value: -1}}},
+		},
+		mongoPublishedArticleCollection: {
+			{Keys: bson.D{{Key: "id", Value: 1}}, Options: options.Index().SetUnique(true)},
+			// 热榜
+			{Keys: bson.D{{Key: "status", Value: 1}, {Key: "utime", Value: -1}}},
+			// 作者主页
+			{Keys: bson.D{{Key: "author_id", Value: 1}, {Key: "status", Value: 1},
+				{Key: "utime", Value: -1}, {Key: "id", Value: -1}}},
+			// feed 流
+			{Keys: bson.D{{Key: "author_id", Value: 1}, {Key: "status", Value: 1}, {Key: "ctime", Value: -1}}},
+			// 标签列表和补全
+			{Keys: bson.D{{Key: "tags", Value: 1}, {Key: "id", Value: -1}}},
+		},
+		mongoArticleHistoryCollection: {
+			{Keys: bson.D{{Key: "article_id", Value: 1}, {Key: "version", Value: -1}},
+				Options: options.Index().SetUnique(true)},
+		},
+		outbox.MongoCollection: {
+			{Keys: bson.D{{Key: "id", Value: 1}}, Options: options.Index().SetUnique(true)},
+			{Keys: bson.D{{Key: "status", Value: 1}, {Key: "next_time", Value: 1}}},
+		},
+	}
+	for name, models := range indexes {
+		_, err := mdb.Collection(name).Indexes().CreateMany(ctx, models)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
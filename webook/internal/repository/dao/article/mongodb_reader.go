@@ -0,0 +1,73 @@
+package article
+
+import (
+	"context"
+	"fmt"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+	"time"
+)
+
+// MongoDBArticleReaderDAO 读者这边只访问线上库
+type MongoDBArticleReaderDAO struct {
+	col *mongo.Collection
+}
+
+func NewMongoDBArticleReaderDAO(mdb *mongo.Database) ArticleReaderDAO {
+	return &MongoDBArticleReaderDAO{
+		col: mdb.Collection(mongoPublishedArticleCollection),
+	}
+}
+
+func (dao *MongoDBArticleReaderDAO) UpdateById(ctx context.Context, art PublishedArticle) error {
+	res, err := dao.col.UpdateOne(ctx, bson.D{{Key: "id", Value: art.Id}}, bson.D{{Key: "$set", Value: bson.D{
+		{Key: "title", Value: art.Title},
+		{Key: "content", Value: art.Content},
+		{Key: "utime", Value: time.Now().UnixMilli()},
+	}}})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return fmt.Errorf("更新失败, id: %d", art.Id)
+	}
+	return nil
+}
+
+func (dao *MongoDBArticleReaderDAO) Insert(ctx context.Context, art PublishedArticle) error {
+	now := time.Now().UnixMilli()
+	art.Utime = now
+	art.Ctime = now
+	_, err := dao.col.InsertOne(ctx, &art.Article)
+	return err
+}
+
+func (dao *MongoDBArticleReaderDAO) ListPubByAuthors(ctx context.Context, authorIds []int64, maxCtime int64, maxId int64, limit int) ([]PublishedArticle, error) {
+	if len(authorIds) == 0 {
+		return []PublishedArticle{}, nil
+	}
+	filter := bson.D{
+		{Key: "author_id", Value: bson.D{{Key: "$in", Value: authorIds}}},
+		{Key: "status", Value: articleStatusPublished},
+		{Key: "$or", Value: bson.A{
+			bson.D{{Key: "ctime", Value: bson.D{{Key: "$lt", Value: maxCtime}}}},
+			bson.D{{Key: "ctime", Value: maxCtime}, {Key: "id", Value: bson.D{{Key: "$lt", Value: maxId}}}},
+		}},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "ctime", Value: -1}, {Key: "id", Value: -1}}).SetLimit(int64(limit))
+	arts, err := findArticles(ctx, dao.col, filter, opts)
+	return toPublished(arts), err
+}
+
+func (dao *MongoDBArticleReaderDAO) GetPubByIds(ctx context.Context, ids []int64) ([]PublishedArticle, error) {
+	if len(ids) == 0 {
+		return []PublishedArticle{}, nil
+	}
+	filter := bson.D{
+		{Key: "id", Value: bson.D{{Key: "$in", Value: ids}}},
+		{Key: "status", Value: articleStatusPublished},
+	}
+	arts, err := findArticles(ctx, dao.col, filter)
+	return toPublished(arts), err
+}
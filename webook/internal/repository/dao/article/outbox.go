@@ -0,0 +1,21 @@
+package article
+
+import (
+	"github.com/basic-go-project-webook/webook/internal/repository/dao/outbox"
+	"gorm.io/gorm"
+)
+
+// OutboxMessageFunc 在 Sync 和 SyncStatus 的事务里面调用，参数是文章 id
+type OutboxMessageFunc func(id int64) ([]outbox.OutboxMessage, error)
+
+// insertOutbox 在事务里面写入消息
+func insertOutbox(tx *gorm.DB, id int64, fn OutboxMessageFunc, now int64) error {
+	if fn == nil {
+		return nil
+	}
+	msgs, err := fn(id)
+	if err != nil {
+		return err
+	}
+	return outbox.InsertOutboxMessages(tx, msgs, now)
+}
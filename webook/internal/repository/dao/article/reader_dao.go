@@ -10,13 +10,17 @@ import (
 type ArticleReaderDAO interface {
 	UpdateById(ctx context.Context, art PublishedArticle) error
 	Insert(ctx context.Context, art PublishedArticle) error
+	// ListPubByAuthors 按照 (ctime, id) 倒序查询一批作者的已发表文章，只返回排在 (maxCtime, maxId) 之后的
+	ListPubByAuthors(ctx context.Context, authorIds []int64, maxCtime int64, maxId int64, limit int) ([]PublishedArticle, error)
+	// GetPubByIds 只返回已发表的文章，不保证顺序
+	GetPubByIds(ctx context.Context, ids []int64) ([]PublishedArticle, error)
 }
 
 type GORMArticleReaderDAO struct {
 	db *gorm.DB
 }
 
-func NewGORMArticleReaderDAO(db *gorm.DB) *GORMArticleReaderDAO {
+func NewGORMArticleReaderDAO(db *gorm.DB) ArticleReaderDAO {
 	return &GORMArticleReaderDAO{
 		db: db,
 	}
@@ -43,3 +47,28 @@ func (dao *GORMArticleReaderDAO) Insert(ctx context.Context, art PublishedArticl
 	art.Ctime = now
 	return dao.db.WithContext(ctx).Create(&art).Error
 }
+
+func (dao *GORMArticleReaderDAO) ListPubByAuthors(ctx context.Context, authorIds []int64, maxCtime int64, maxId int64, limit int) ([]PublishedArticle, error) {
+	var res []PublishedArticle
+	if len(authorIds) == 0 {
+		return res, nil
+	}
+	err := dao.db.WithContext(ctx).
+		Where("author_id IN ? AND status = ? AND (ctime < ? OR (ctime = ? AND id < ?))",
+			authorIds, articleStatusPublished, maxCtime, maxCtime, maxId).
+		Order("ctime DESC, id DESC").
+		Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMArticleReaderDAO) GetPubByIds(ctx context.Context, ids []int64) ([]PublishedArticle, error) {
+	var res []PublishedArticle
+	if len(ids) == 0 {
+		return res, nil
+	}
+	err := dao.db.WithContext(ctx).
+		Where("id IN ? AND status = ?", ids, articleStatusPublished).
+		Find(&res).Error
+	return res, err
+}
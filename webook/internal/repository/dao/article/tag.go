@@ -0,0 +1,156 @@
+package article
+
+import (
+	"context"
+	"fmt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"math"
+	"strings"
+)
+
+func (dao *GORMArticleDAO) ListPubByTag(ctx context.Context, tag string, cursor int64, limit int) ([]PublishedArticle, error) {
+	if cursor <= 0 {
+		cursor = math.MaxInt64
+	}
+	var res []PublishedArticle
+	err := dao.db.WithContext(ctx).
+		Joins("JOIN published_article_tags pat ON pat.aid = published_articles.id").
+		Joins("JOIN tags ON tags.id = pat.tag_id").
+		Where("tags.name = ? AND pat.aid < ? AND published_articles.status = ?",
+			tag, cursor, articleStatusPublished).
+		Order("pat.aid DESC").
+		Limit(limit).
+		Find(&res).Error
+	if err != nil || len(res) == 0 {
+		return res, err
+	}
+	ids := make([]int64, 0, len(res))
+	for _, art := range res {
+		ids = append(ids, art.Id)
+	}
+	tags, err := loadTags(dao.db.WithContext(ctx), &PublishedArticleTag{}, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range res {
+		res[i].Tags = tags[res[i].Id]
+	}
+	return res, nil
+}
+
+func (dao *GORMArticleDAO) SearchTags(ctx context.Context, prefix string, limit int) ([]Tag, error) {
+	var res []Tag
+	err := dao.db.WithContext(ctx).
+		Where("name LIKE ?", escapeLike(prefix)+"%").
+		Order("name ASC").
+		Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+// replaceTags 在事务里面把文章的标签整体替换成 names，
+// relation 是 &ArticleTag{} 或者 &PublishedArticleTag{}
+func replaceTags(tx *gorm.DB, relation any, aid int64, names []string, now int64) error {
+	err := tx.Model(relation).Where("aid = ?", aid).Delete(relation).Error
+	if err != nil || len(names) == 0 {
+		return err
+	}
+	tags := make([]Tag, 0, len(names))
+	for _, name := range names {
+		tags = append(tags, Tag{Name: name, Ctime: now, Utime: now})
+	}
+	// 标签是所有文章共享的，已经存在就直接用
+	err = tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&tags).Error
+	if err != nil {
+		return err
+	}
+	var saved []Tag
+	err = tx.Where("name IN ?", names).Find(&saved).Error
+	if err != nil {
+		return err
+	}
+	// 数据库的排序规则不区分大小写，也忽略末尾的空格，
+	// 查回来的名字可能和 names 不完全一样，所以按照同样的规则折叠之后再对应
+	ids := make(map[string]int64, len(saved))
+	for _, t := range saved {
+		ids[foldTagName(t.Name)] = t.Id
+	}
+	// 按照作者填写的顺序保存
+	relations := make([]map[string]any, 0, len(names))
+	for _, name := range names {
+		id, ok := ids[foldTagName(name)]
+		if !ok {
+			return fmt.Errorf("标签 %s 找不到对应的 id", name)
+		}
+		relations = append(relations, map[string]any{
+			"aid":    aid,
+			"tag_id": id,
+			"ctime":  now,
+		})
+	}
+	return tx.Model(relation).Create(relations).Error
+}
+
+// foldTagName 和数据库比较标签名字的规则保持一致
+func foldTagName(name string) string {
+	return strings.ToLower(strings.TrimRight(name, " "))
+}
+
+// loadTags 批量查询文章的标签，返回 aid 到标签名字的映射
+func loadTags(db *gorm.DB, relation any, aids []int64) (map[int64][]string, error) {
+	type row struct {
+		Aid  int64
+		Name string
+	}
+	var rows []row
+	err := db.Model(relation).
+		Select("aid, tags.name").
+		Joins("JOIN tags ON tags.id = tag_id").
+		Where("aid IN ?", aids).
+		Order(clause.OrderByColumn{Column: clause.Column{Table: clause.CurrentTable, Name: "id"}}).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[int64][]string, len(aids))
+	for _, r := range rows {
+		res[r.Aid] = append(res[r.Aid], r.Name)
+	}
+	return res, nil
+}
+
+func escapeLike(s string) string {
+	res := make([]rune, 0, len(s))
+	for _, c := range s {
+		if c == '%' || c == '_' || c == '\\' {
+			res = append(res, '\\')
+		}
+		res = append(res, c)
+	}
+	return string(res)
+}
+
+// Tag 所有文章共享的标签
+type Tag struct {
+	Id    int64  `gorm:"primaryKey;autoIncrement"`
+	Name  string `gorm:"type:varchar(64);uniqueIndex"`
+	Ctime int64
+	Utime int64
+}
+
+// ArticleTag 制作库的文章和标签的关系
+type ArticleTag struct {
+	Id    int64 `gorm:"primaryKey;autoIncrement"`
+	Aid   int64 `gorm:"uniqueIndex:aid_tag_id"`
+	TagId int64 `gorm:"uniqueIndex:aid_tag_id"`
+	Ctime int64
+}
+
+// PublishedArticleTag 线上库的文章和标签的关系，按照标签查询文章的时候使用
+type PublishedArticleTag struct {
+	Id    int64 `gorm:"primaryKey;autoIncrement"`
+	Aid   int64 `gorm:"uniqueIndex:aid_tag_id;index:tag_id_aid,priority:2"`
+	TagId int64 `gorm:"uniqueIndex:aid_tag_id;index:tag_id_aid,priority:1"`
+	Ctime int64
+}
@@ -0,0 +1,70 @@
+package article
+
+import (
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"testing"
+)
+
+func Test_replaceTags(t *testing.T) {
+	testCases := []struct {
+		name  string
+		mock  func(mock sqlmock.Sqlmock)
+		names []string
+
+		wantErr error
+	}{
+		{
+			name: "数据库里面的名字大小写不一样",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("DELETE FROM `article_tags`").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("INSERT INTO `tags`").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				// 之前别的作者用过 go 和 "mysql "
+				mock.ExpectQuery("SELECT \\* FROM `tags`").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
+						AddRow(7, "go").AddRow(8, "mysql "))
+				mock.ExpectExec("INSERT INTO `article_tags`").
+					WithArgs(int64(1), int64(100), int64(7), int64(1), int64(100), int64(8)).
+					WillReturnResult(sqlmock.NewResult(1, 2))
+			},
+			names: []string{"Go", "MySQL"},
+		},
+		{
+			name: "找不到标签的 id",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("DELETE FROM `article_tags`").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("INSERT INTO `tags`").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery("SELECT \\* FROM `tags`").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(7, "go"))
+			},
+			names:   []string{"Go", "MySQL"},
+			wantErr: errors.New("标签 MySQL 找不到对应的 id"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sqlDB, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			tc.mock(mock)
+			db, err := gorm.Open(mysql.New(mysql.Config{
+				Conn:                      sqlDB,
+				SkipInitializeWithVersion: true,
+			}), &gorm.Config{
+				DisableAutomaticPing:   true,
+				SkipDefaultTransaction: true,
+			})
+			require.NoError(t, err)
+			err = replaceTags(db, &ArticleTag{}, 1, tc.names, 100)
+			assert.Equal(t, tc.wantErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
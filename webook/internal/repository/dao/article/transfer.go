@@ -0,0 +1,48 @@
+package article
+
+import (
+	"context"
+	"github.com/basic-go-project-webook/webook/internal/repository/dao/outbox"
+	"gorm.io/gorm"
+	"time"
+)
+
+// ArticleOutboxFunc 在 TransferAuthor 的事务里面对每一篇已发表的文章调用一次，参数里面已经是新的作者
+type ArticleOutboxFunc func(art Article) ([]outbox.OutboxMessage, error)
+
+func (dao *GORMArticleDAO) TransferAuthor(ctx context.Context, srcUid int64, dstUid int64, outbox ArticleOutboxFunc) error {
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now().UnixMilli()
+		var pubs []PublishedArticle
+		err := tx.Where("author_id = ? AND status = ?", srcUid, articleStatusPublished).
+			Find(&pubs).Error
+		if err != nil {
+			return err
+		}
+		// utime 不动，作者主页的排序还是按照原来的时间
+		for _, model := range []any{&Article{}, &PublishedArticle{}, &ArticleHistory{}} {
+			err = tx.Model(model).Where("author_id = ?", srcUid).
+				Update("author_id", dstUid).Error
+			if err != nil {
+				return err
+			}
+		}
+		for _, pub := range pubs {
+			err = insertOutbox(tx, pub.Id, transferOutbox(pub.Article, dstUid, outbox), now)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func transferOutbox(art Article, dstUid int64, fn ArticleOutboxFunc) OutboxMessageFunc {
+	if fn == nil {
+		return nil
+	}
+	art.AuthorId = dstUid
+	return func(id int64) ([]outbox.OutboxMessage, error) {
+		return fn(art)
+	}
+}
@@ -2,14 +2,25 @@ package dao
 
 import (
 	"github.com/basic-go-project-webook/webook/internal/repository/dao/article"
+	"github.com/basic-go-project-webook/webook/internal/repository/dao/outbox"
 	"gorm.io/gorm"
 )
 
 func InitTable(db *gorm.DB) error {
-	return db.AutoMigrate(
+	err := db.AutoMigrate(
 		&User{},
+		&UserIdentity{},
 		&article.Article{},
 		&article.PublishedArticle{},
+		&article.ArticleHistory{},
+		&article.Tag{},
+		&article.ArticleTag{},
+		&article.PublishedArticleTag{},
+		&outbox.OutboxMessage{},
 		&Job{},
 	)
+	if err != nil {
+		return err
+	}
+	return migrateWechatIdentities(db)
 }
@@ -3,6 +3,7 @@ package dao
 import (
 	"context"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"time"
 )
 
@@ -11,6 +12,12 @@ type JobDAO interface {
 	UpdateTime(ctx context.Context, id int64) error
 	UpdateNextTime(ctx context.Context, id int64, next time.Time) error
 	Preempt(ctx context.Context) (Job, error)
+	// Upsert 按照 name 创建或者覆盖任务，覆盖之后任务重新进入等待状态
+	Upsert(ctx context.Context, job Job) error
+	// Finish 一次性任务执行成功之后不再调度
+	Finish(ctx context.Context, id int64) error
+	// Stop 取消还没有开始执行的任务
+	Stop(ctx context.Context, name string) error
 }
 
 type GORMJobDAO struct {
@@ -19,8 +26,9 @@ type GORMJobDAO struct {
 
 func (dao *GORMJobDAO) Release(ctx context.Context, id int64) error {
 	now := time.Now().UnixMilli()
+	// 只释放还在运行的任务，已经结束的一次性任务不能回到等待状态
 	return dao.db.WithContext(ctx).Model(&Job{}).
-		Where("id = ?", id).
+		Where("id = ? AND status = ?", id, jobStatusRunning).
 		Updates(map[string]interface{}{
 			"utime":  now,
 			"status": jobStatusWaiting,
@@ -49,11 +57,15 @@ func (dao *GORMJobDAO) Preempt(ctx context.Context) (Job, error) {
 	for {
 		var job Job
 		now := time.Now().UnixMilli()
-		err := db.Where("status = ? AND next_time < ?", jobStatusWaiting, now).First(&job).Error
+		// 运行中但是很久没有续约的，说明执行的实例已经崩溃了，可以重新抢占
+		err := db.Where("(status = ? AND next_time < ?) OR (status = ? AND utime < ?)",
+			jobStatusWaiting, now, jobStatusRunning, now-jobLeaseTimeout.Milliseconds()).
+			Order("next_time ASC").
+			First(&job).Error
 		if err != nil {
 			return job, err
 		}
-		res := db.Where("id = ? AND version = ?", job.Id, job.Version).
+		res := db.Model(&Job{}).Where("id = ? AND version = ?", job.Id, job.Version).
 			Updates(map[string]interface{}{
 				"status":  jobStatusRunning,
 				"version": job.Version + 1,
@@ -70,6 +82,42 @@ func (dao *GORMJobDAO) Preempt(ctx context.Context) (Job, error) {
 	}
 }
 
+func (dao *GORMJobDAO) Upsert(ctx context.Context, job Job) error {
+	now := time.Now().UnixMilli()
+	job.Status = jobStatusWaiting
+	job.Ctime = now
+	job.Utime = now
+	return dao.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "name"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"expression": job.Expression,
+			"executor":   job.Executor,
+			"cfg":        job.Cfg,
+			"next_time":  job.NextTime,
+			"status":     jobStatusWaiting,
+			"utime":      now,
+		}),
+	}).Create(&job).Error
+}
+
+func (dao *GORMJobDAO) Finish(ctx context.Context, id int64) error {
+	return dao.db.WithContext(ctx).Model(&Job{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status": jobStatusFinished,
+			"utime":  time.Now().UnixMilli(),
+		}).Error
+}
+
+func (dao *GORMJobDAO) Stop(ctx context.Context, name string) error {
+	return dao.db.WithContext(ctx).Model(&Job{}).
+		Where("name = ? AND status = ?", name, jobStatusWaiting).
+		Updates(map[string]interface{}{
+			"status": jobStatusPaused,
+			"utime":  time.Now().UnixMilli(),
+		}).Error
+}
+
 func NewGORMJobDAO(db *gorm.DB) JobDAO {
 	return &GORMJobDAO{
 		db: db,
@@ -93,4 +141,8 @@ const (
 	jobStatusWaiting = iota
 	jobStatusRunning
 	jobStatusPaused
+	jobStatusFinished
 )
+
+// jobLeaseTimeout 运行中的任务超过这个时间没有续约就认为执行的实例已经崩溃
+const jobLeaseTimeout = time.Minute * 3
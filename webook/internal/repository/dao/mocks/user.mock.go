@@ -11,9 +11,10 @@ package daomocks
 
 import (
 	context "context"
-	dao "github.com/basic-go-project-webook/webook/internal/repository/dao"
 	reflect "reflect"
 
+	dao "github.com/basic-go-project-webook/webook/internal/repository/dao"
+	outbox "github.com/basic-go-project-webook/webook/internal/repository/dao/outbox"
 	gin "github.com/gin-gonic/gin"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -42,6 +43,34 @@ func (m *MockUserDAO) EXPECT() *MockUserDAOMockRecorder {
 	return m.recorder
 }
 
+// AddIdentity mocks base method.
+func (m *MockUserDAO) AddIdentity(ctx context.Context, identity dao.UserIdentity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddIdentity", ctx, identity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddIdentity indicates an expected call of AddIdentity.
+func (mr *MockUserDAOMockRecorder) AddIdentity(ctx, identity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddIdentity", reflect.TypeOf((*MockUserDAO)(nil).AddIdentity), ctx, identity)
+}
+
+// DeleteIdentity mocks base method.
+func (m *MockUserDAO) DeleteIdentity(ctx context.Context, uid int64, provider string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteIdentity", ctx, uid, provider)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteIdentity indicates an expected call of DeleteIdentity.
+func (mr *MockUserDAOMockRecorder) DeleteIdentity(ctx, uid, provider any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIdentity", reflect.TypeOf((*MockUserDAO)(nil).DeleteIdentity), ctx, uid, provider)
+}
+
 // FindByEmail mocks base method.
 func (m *MockUserDAO) FindByEmail(ctx context.Context, email string) (dao.User, error) {
 	m.ctrl.T.Helper()
@@ -72,6 +101,21 @@ func (mr *MockUserDAOMockRecorder) FindById(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockUserDAO)(nil).FindById), ctx, id)
 }
 
+// FindByIdentity mocks base method.
+func (m *MockUserDAO) FindByIdentity(ctx context.Context, provider, externalId string) (dao.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIdentity", ctx, provider, externalId)
+	ret0, _ := ret[0].(dao.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIdentity indicates an expected call of FindByIdentity.
+func (mr *MockUserDAOMockRecorder) FindByIdentity(ctx, provider, externalId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIdentity", reflect.TypeOf((*MockUserDAO)(nil).FindByIdentity), ctx, provider, externalId)
+}
+
 // FindByPhone mocks base method.
 func (m *MockUserDAO) FindByPhone(ctx context.Context, phone string) (dao.User, error) {
 	m.ctrl.T.Helper()
@@ -87,19 +131,19 @@ func (mr *MockUserDAOMockRecorder) FindByPhone(ctx, phone any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByPhone", reflect.TypeOf((*MockUserDAO)(nil).FindByPhone), ctx, phone)
 }
 
-// FindByWechat mocks base method.
-func (m *MockUserDAO) FindByWechat(ctx *gin.Context, openId string) (dao.User, error) {
+// FindIdentities mocks base method.
+func (m *MockUserDAO) FindIdentities(ctx context.Context, uid int64) ([]dao.UserIdentity, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindByWechat", ctx, openId)
-	ret0, _ := ret[0].(dao.User)
+	ret := m.ctrl.Call(m, "FindIdentities", ctx, uid)
+	ret0, _ := ret[0].([]dao.UserIdentity)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FindByWechat indicates an expected call of FindByWechat.
-func (mr *MockUserDAOMockRecorder) FindByWechat(ctx, openId any) *gomock.Call {
+// FindIdentities indicates an expected call of FindIdentities.
+func (mr *MockUserDAOMockRecorder) FindIdentities(ctx, uid any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByWechat", reflect.TypeOf((*MockUserDAO)(nil).FindByWechat), ctx, openId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindIdentities", reflect.TypeOf((*MockUserDAO)(nil).FindIdentities), ctx, uid)
 }
 
 // Insert mocks base method.
@@ -116,16 +160,87 @@ func (mr *MockUserDAOMockRecorder) Insert(ctx, user any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockUserDAO)(nil).Insert), ctx, user)
 }
 
+// InsertWithIdentity mocks base method.
+func (m *MockUserDAO) InsertWithIdentity(ctx context.Context, user dao.User, identity dao.UserIdentity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertWithIdentity", ctx, user, identity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertWithIdentity indicates an expected call of InsertWithIdentity.
+func (mr *MockUserDAOMockRecorder) InsertWithIdentity(ctx, user, identity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertWithIdentity", reflect.TypeOf((*MockUserDAO)(nil).InsertWithIdentity), ctx, user, identity)
+}
+
+// ListAfter mocks base method.
+func (m *MockUserDAO) ListAfter(ctx context.Context, minId int64, limit int) ([]dao.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAfter", ctx, minId, limit)
+	ret0, _ := ret[0].([]dao.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAfter indicates an expected call of ListAfter.
+func (mr *MockUserDAOMockRecorder) ListAfter(ctx, minId, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAfter", reflect.TypeOf((*MockUserDAO)(nil).ListAfter), ctx, minId, limit)
+}
+
+// Merge mocks base method.
+func (m *MockUserDAO) Merge(ctx context.Context, srcUid, dstUid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Merge", ctx, srcUid, dstUid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Merge indicates an expected call of Merge.
+func (mr *MockUserDAOMockRecorder) Merge(ctx, srcUid, dstUid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Merge", reflect.TypeOf((*MockUserDAO)(nil).Merge), ctx, srcUid, dstUid)
+}
+
 // UpdateById mocks base method.
-func (m *MockUserDAO) UpdateById(ctx *gin.Context, user dao.User) error {
+func (m *MockUserDAO) UpdateById(ctx *gin.Context, user dao.User, msgs []outbox.OutboxMessage) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateById", ctx, user)
+	ret := m.ctrl.Call(m, "UpdateById", ctx, user, msgs)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateById indicates an expected call of UpdateById.
-func (mr *MockUserDAOMockRecorder) UpdateById(ctx, user any) *gomock.Call {
+func (mr *MockUserDAOMockRecorder) UpdateById(ctx, user, msgs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateById", reflect.TypeOf((*MockUserDAO)(nil).UpdateById), ctx, user, msgs)
+}
+
+// UpdateEmail mocks base method.
+func (m *MockUserDAO) UpdateEmail(ctx context.Context, uid int64, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEmail", ctx, uid, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateEmail indicates an expected call of UpdateEmail.
+func (mr *MockUserDAOMockRecorder) UpdateEmail(ctx, uid, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEmail", reflect.TypeOf((*MockUserDAO)(nil).UpdateEmail), ctx, uid, email)
+}
+
+// UpdatePhone mocks base method.
+func (m *MockUserDAO) UpdatePhone(ctx context.Context, uid int64, phone string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePhone", ctx, uid, phone)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePhone indicates an expected call of UpdatePhone.
+func (mr *MockUserDAOMockRecorder) UpdatePhone(ctx, uid, phone any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateById", reflect.TypeOf((*MockUserDAO)(nil).UpdateById), ctx, user)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePhone", reflect.TypeOf((*MockUserDAO)(nil).UpdatePhone), ctx, uid, phone)
 }
@@ -0,0 +1,81 @@
+package outbox
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+	"time"
+)
+
+type MongoDBOutboxDAO struct {
+	col *mongo.Collection
+}
+
+func NewMongoDBOutboxDAO(mdb *mongo.Database) OutboxDAO {
+	return &MongoDBOutboxDAO{
+		col: mdb.Collection(MongoCollection),
+	}
+}
+
+func (dao *MongoDBOutboxDAO) Claim(ctx context.Context, now int64, lease int64, limit int) ([]OutboxMessage, error) {
+	filter := bson.D{
+		{Key: "status", Value: OutboxStatusPending},
+		{Key: "next_time", Value: bson.D{{Key: "$lte", Value: now}}},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "next_time", Value: 1}}).SetLimit(int64(limit))
+	cur, err := dao.col.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var candidates []OutboxMessage
+	err = cur.All(ctx, &candidates)
+	if err != nil {
+		return nil, err
+	}
+	// 没有 SKIP LOCKED，用 next_time 做乐观锁，被别的实例抢先推迟了的就跳过
+	res := make([]OutboxMessage, 0, len(candidates))
+	for _, msg := range candidates {
+		ur, err := dao.col.UpdateOne(ctx, bson.D{
+			{Key: "id", Value: msg.Id},
+			{Key: "status", Value: OutboxStatusPending},
+			{Key: "next_time", Value: msg.NextTime},
+		}, bson.D{{Key: "$set", Value: bson.D{
+			{Key: "next_time", Value: now + lease},
+			{Key: "utime", Value: now},
+		}}})
+		if err != nil {
+			return res, err
+		}
+		if ur.ModifiedCount == 1 {
+			res = append(res, msg)
+		}
+	}
+	return res, nil
+}
+
+func (dao *MongoDBOutboxDAO) MarkSent(ctx context.Context, id int64) error {
+	_, err := dao.col.UpdateOne(ctx, bson.D{{Key: "id", Value: id}}, bson.D{{Key: "$set", Value: bson.D{
+		{Key: "status", Value: OutboxStatusSent},
+		{Key: "utime", Value: time.Now().UnixMilli()},
+	}}})
+	return err
+}
+
+func (dao *MongoDBOutboxDAO) MarkFailed(ctx context.Context, id int64, retries int, nextTime int64, errMsg string, dead bool) error {
+	status := OutboxStatusPending
+	if dead {
+		status = OutboxStatusFailed
+	}
+	if len(errMsg) > 1024 {
+		errMsg = errMsg[:1024]
+	}
+	_, err := dao.col.UpdateOne(ctx, bson.D{{Key: "id", Value: id}}, bson.D{{Key: "$set", Value: bson.D{
+		{Key: "status", Value: status},
+		{Key: "retries", Value: retries},
+		{Key: "next_time", Value: nextTime},
+		{Key: "last_error", Value: errMsg},
+		{Key: "utime", Value: time.Now().UnixMilli()},
+	}}})
+	return err
+}
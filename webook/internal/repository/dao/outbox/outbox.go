@@ -0,0 +1,121 @@
+package outbox
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type OutboxDAO interface {
+	// Claim 取出到期的待投递消息，并且把它们的下一次投递时间推迟到 now + lease，
+	// 这样多个实例同时运行的时候同一条消息在 lease 内只会被一个实例取到
+	Claim(ctx context.Context, now int64, lease int64, limit int) ([]OutboxMessage, error)
+	MarkSent(ctx context.Context, id int64) error
+	// MarkFailed 记录一次投递失败，dead 为 true 的时候不会再重试
+	MarkFailed(ctx context.Context, id int64, retries int, nextTime int64, errMsg string, dead bool) error
+}
+
+type GORMOutboxDAO struct {
+	db *gorm.DB
+}
+
+func NewGORMOutboxDAO(db *gorm.DB) OutboxDAO {
+	return &GORMOutboxDAO{
+		db: db,
+	}
+}
+
+func (dao *GORMOutboxDAO) Claim(ctx context.Context, now int64, lease int64, limit int) ([]OutboxMessage, error) {
+	var res []OutboxMessage
+	err := dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// SKIP LOCKED 避免多个实例互相等待
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_time <= ?", OutboxStatusPending, now).
+			Order("next_time ASC").
+			Limit(limit).
+			Find(&res).Error
+		if err != nil || len(res) == 0 {
+			return err
+		}
+		ids := make([]int64, 0, len(res))
+		for _, msg := range res {
+			ids = append(ids, msg.Id)
+		}
+		return tx.Model(&OutboxMessage{}).
+			Where("id IN ?", ids).
+			Updates(map[string]interface{}{
+				"next_time": now + lease,
+				"utime":     now,
+			}).Error
+	})
+	return res, err
+}
+
+func (dao *GORMOutboxDAO) MarkSent(ctx context.Context, id int64) error {
+	return dao.db.WithContext(ctx).Model(&OutboxMessage{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status": OutboxStatusSent,
+			"utime":  time.Now().UnixMilli(),
+		}).Error
+}
+
+func (dao *GORMOutboxDAO) MarkFailed(ctx context.Context, id int64, retries int, nextTime int64, errMsg string, dead bool) error {
+	status := OutboxStatusPending
+	if dead {
+		status = OutboxStatusFailed
+	}
+	if len(errMsg) > 1024 {
+		errMsg = errMsg[:1024]
+	}
+	return dao.db.WithContext(ctx).Model(&OutboxMessage{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":     status,
+			"retries":    retries,
+			"next_time":  nextTime,
+			"last_error": errMsg,
+			"utime":      time.Now().UnixMilli(),
+		}).Error
+}
+
+// InsertOutboxMessages 在业务数据的事务里面写入已经生成好的消息
+func InsertOutboxMessages(tx *gorm.DB, msgs []OutboxMessage, now int64) error {
+	if len(msgs) == 0 {
+		return nil
+	}
+	for i := range msgs {
+		msgs[i].Status = OutboxStatusPending
+		msgs[i].NextTime = now
+		msgs[i].Ctime = now
+		msgs[i].Utime = now
+	}
+	return tx.Create(&msgs).Error
+}
+
+// OutboxMessage 待投递到 kafka 的消息，文章和用户的事件都在这里
+type OutboxMessage struct {
+	Id      int64  `gorm:"primaryKey,autoIncrement" bson:"id"`
+	Topic   string `gorm:"type:varchar(256)" bson:"topic"`
+	MsgKey  string `gorm:"type:varchar(256)" bson:"msg_key"`
+	Payload []byte `gorm:"type:BLOB" bson:"payload"`
+	Status  uint8  `gorm:"index:status_next_time" bson:"status"`
+	Retries int    `bson:"retries"`
+	// 下一次可以投递的时间
+	NextTime  int64  `gorm:"index:status_next_time" bson:"next_time"`
+	LastError string `gorm:"type:varchar(1024)" bson:"last_error"`
+	Ctime     int64  `bson:"ctime"`
+	Utime     int64  `bson:"utime"`
+}
+
+// MongoCollection 文章存 MongoDB 的时候，outbox 和文章在同一个库里面的这个集合
+const MongoCollection = "outbox_messages"
+
+const (
+	OutboxStatusUnknown uint8 = iota
+	OutboxStatusPending
+	OutboxStatusSent
+	// OutboxStatusFailed 重试次数用完，需要人工处理
+	OutboxStatusFailed
+)
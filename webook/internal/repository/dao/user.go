@@ -4,15 +4,18 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"github.com/basic-go-project-webook/webook/internal/repository/dao/outbox"
 	"github.com/gin-gonic/gin"
 	"github.com/go-sql-driver/mysql"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"time"
 )
 
 var (
 	ErrUserDuplicate  = errors.New("账号已经被注册过了")
 	ErrRecordNotFount = gorm.ErrRecordNotFound
+	ErrUserMerged     = errors.New("账号已经合并到别的账号")
 )
 
 type UserDAO interface {
@@ -20,8 +23,22 @@ type UserDAO interface {
 	FindByEmail(ctx context.Context, email string) (User, error)
 	FindById(ctx context.Context, id int64) (User, error)
 	FindByPhone(ctx context.Context, phone string) (User, error)
-	UpdateById(ctx *gin.Context, user User) error
-	FindByWechat(ctx *gin.Context, openId string) (User, error)
+	// UpdateById outbox 里面的消息和用户资料在同一个事务里面写入
+	UpdateById(ctx *gin.Context, user User, msgs []outbox.OutboxMessage) error
+	FindByIdentity(ctx context.Context, provider string, externalId string) (User, error)
+	// InsertWithIdentity 第三方账号第一次登录的时候，用户和第三方账号一起创建
+	InsertWithIdentity(ctx context.Context, user User, identity UserIdentity) error
+	FindIdentities(ctx context.Context, uid int64) ([]UserIdentity, error)
+	// UpdatePhone 和 UpdateEmail 传空字符串就是解绑
+	UpdatePhone(ctx context.Context, uid int64, phone string) error
+	UpdateEmail(ctx context.Context, uid int64, email string) error
+	AddIdentity(ctx context.Context, identity UserIdentity) error
+	DeleteIdentity(ctx context.Context, uid int64, provider string) error
+	// Merge 把 srcUid 的登录方式转给 dstUid，dstUid 已经有的不覆盖，并且把 srcUid 标记为已合并。
+	// srcUid 已经合并到 dstUid 的时候什么也不做
+	Merge(ctx context.Context, srcUid int64, dstUid int64) error
+	// ListAfter 按照 id 升序返回 id 大于 minId 的用户，跳过已经合并的账号
+	ListAfter(ctx context.Context, minId int64, limit int) ([]User, error)
 }
 
 type GORMUserDAO struct {
@@ -39,14 +56,7 @@ func (dao *GORMUserDAO) Insert(ctx context.Context, user User) error {
 	user.Utime = now
 	user.Ctime = now
 	err := dao.db.WithContext(ctx).Create(&user).Error
-	var mysqlErr *mysql.MySQLError
-	if errors.As(err, &mysqlErr) {
-		const uniqueConflictsErrNo uint16 = 1062
-		if mysqlErr.Number == uniqueConflictsErrNo {
-			return ErrUserDuplicate
-		}
-	}
-	return err
+	return duplicateErr(err)
 }
 
 func (dao *GORMUserDAO) FindByEmail(ctx context.Context, email string) (User, error) {
@@ -61,36 +71,173 @@ func (dao *GORMUserDAO) FindById(ctx context.Context, id int64) (User, error) {
 	return user, err
 }
 
+func (dao *GORMUserDAO) ListAfter(ctx context.Context, minId int64, limit int) ([]User, error) {
+	var users []User
+	err := dao.db.WithContext(ctx).
+		Where("id > ? AND merged_into = ?", minId, 0).
+		Order("id ASC").
+		Limit(limit).
+		Find(&users).Error
+	return users, err
+}
+
 func (dao *GORMUserDAO) FindByPhone(ctx context.Context, phone string) (User, error) {
 	var user User
 	err := dao.db.WithContext(ctx).Where("phone = ?", phone).First(&user).Error
 	return user, err
 }
 
-func (dao *GORMUserDAO) UpdateById(ctx *gin.Context, user User) error {
+func (dao *GORMUserDAO) UpdateById(ctx *gin.Context, user User, msgs []outbox.OutboxMessage) error {
 	now := time.Now().UnixMilli()
 	user.Utime = now
-	return dao.db.WithContext(ctx).Model(&User{}).Where("id = ?", user.Id).Updates(user).Error
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&User{}).Where("id = ?", user.Id).Updates(user).Error
+		if err != nil {
+			return err
+		}
+		return outbox.InsertOutboxMessages(tx, msgs, now)
+	})
 }
 
-func (dao *GORMUserDAO) FindByWechat(ctx *gin.Context, openId string) (User, error) {
-	var user User
-	err := dao.db.WithContext(ctx).Where("wechat_open_id = ?", openId).First(&user).Error
-	return user, err
+func (dao *GORMUserDAO) FindByIdentity(ctx context.Context, provider string, externalId string) (User, error) {
+	var identity UserIdentity
+	err := dao.db.WithContext(ctx).
+		Where("provider = ? AND external_id = ?", provider, externalId).
+		First(&identity).Error
+	if err != nil {
+		return User{}, err
+	}
+	return dao.FindById(ctx, identity.Uid)
+}
+
+func (dao *GORMUserDAO) InsertWithIdentity(ctx context.Context, user User, identity UserIdentity) error {
+	now := time.Now().UnixMilli()
+	user.Ctime, user.Utime = now, now
+	identity.Ctime, identity.Utime = now, now
+	err := dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Create(&user).Error
+		if err != nil {
+			return err
+		}
+		identity.Uid = user.Id
+		return tx.Create(&identity).Error
+	})
+	return duplicateErr(err)
+}
+
+func (dao *GORMUserDAO) FindIdentities(ctx context.Context, uid int64) ([]UserIdentity, error) {
+	var res []UserIdentity
+	err := dao.db.WithContext(ctx).Where("uid = ?", uid).Order("id").Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMUserDAO) UpdatePhone(ctx context.Context, uid int64, phone string) error {
+	err := dao.db.WithContext(ctx).Model(&User{}).Where("id = ?", uid).
+		Updates(map[string]any{
+			"phone": sql.NullString{String: phone, Valid: phone != ""},
+			"utime": time.Now().UnixMilli(),
+		}).Error
+	return duplicateErr(err)
+}
+
+func (dao *GORMUserDAO) UpdateEmail(ctx context.Context, uid int64, email string) error {
+	err := dao.db.WithContext(ctx).Model(&User{}).Where("id = ?", uid).
+		Updates(map[string]any{
+			"email": sql.NullString{String: email, Valid: email != ""},
+			"utime": time.Now().UnixMilli(),
+		}).Error
+	return duplicateErr(err)
+}
+
+func (dao *GORMUserDAO) AddIdentity(ctx context.Context, identity UserIdentity) error {
+	now := time.Now().UnixMilli()
+	identity.Ctime, identity.Utime = now, now
+	err := dao.db.WithContext(ctx).Create(&identity).Error
+	return duplicateErr(err)
+}
+
+func (dao *GORMUserDAO) DeleteIdentity(ctx context.Context, uid int64, provider string) error {
+	return dao.db.WithContext(ctx).
+		Where("uid = ? AND provider = ?", uid, provider).
+		Delete(&UserIdentity{}).Error
+}
+
+func (dao *GORMUserDAO) Merge(ctx context.Context, srcUid int64, dstUid int64) error {
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var src, dst User
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", srcUid).First(&src).Error
+		if err != nil {
+			return err
+		}
+		if src.MergedInto == dstUid {
+			return nil
+		}
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", dstUid).First(&dst).Error
+		if err != nil {
+			return err
+		}
+		if src.MergedInto != 0 || dst.MergedInto != 0 {
+			return ErrUserMerged
+		}
+		now := time.Now().UnixMilli()
+		dstUpdates := map[string]any{"utime": now}
+		if !dst.Phone.Valid && src.Phone.Valid {
+			dstUpdates["phone"] = src.Phone
+		}
+		// 邮箱登录要用密码，所以邮箱和密码一起转过去
+		if !dst.Email.Valid && src.Email.Valid {
+			dstUpdates["email"] = src.Email
+			dstUpdates["password"] = src.Password
+		}
+		// 先清空 src，不然 dst 写入的时候唯一索引冲突
+		err = tx.Model(&User{}).Where("id = ?", srcUid).
+			Updates(map[string]any{
+				"phone":       sql.NullString{},
+				"email":       sql.NullString{},
+				"password":    "",
+				"merged_into": dstUid,
+				"utime":       now,
+			}).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Model(&User{}).Where("id = ?", dstUid).Updates(dstUpdates).Error
+		if err != nil {
+			return err
+		}
+		return tx.Model(&UserIdentity{}).Where("uid = ?", srcUid).
+			Updates(map[string]any{
+				"uid":   dstUid,
+				"utime": now,
+			}).Error
+	})
+}
+
+func duplicateErr(err error) error {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		const uniqueConflictsErrNo uint16 = 1062
+		if mysqlErr.Number == uniqueConflictsErrNo {
+			return ErrUserDuplicate
+		}
+	}
+	return err
 }
 
 // User 直接对应数据库表
 type User struct {
 	Id int64 `gorm:"primaryKey,autoIncrement"`
 	// 唯一索引允许有多个null, 不允许有多个 ""
-	Email         sql.NullString `gorm:"type:varchar(255);unique"`
-	Password      string         `gorm:"type:varchar(255)"`
-	Phone         sql.NullString `gorm:"type:char(11);unique"`
-	WechatUnionID sql.NullString `gorm:"type:varchar(255)"`
-	WechatOpenID  sql.NullString `gorm:"type:varchar(255);unique"`
-	Nickname      string         `gorm:"type:varchar(128)"`
-	AboutMe       string         `gorm:"type:varchar(4096)"`
-	Birthday      int64
-	Ctime         int64
-	Utime         int64
+	Email    sql.NullString `gorm:"type:varchar(255);unique"`
+	Password string         `gorm:"type:varchar(255)"`
+	Phone    sql.NullString `gorm:"type:char(11);unique"`
+	Nickname string         `gorm:"type:varchar(128)"`
+	AboutMe  string         `gorm:"type:varchar(4096)"`
+	Birthday int64
+	// MergedInto 合并之后这个账号不能再登录，记录合并到了哪个账号
+	MergedInto int64
+	Ctime      int64
+	Utime      int64
 }
@@ -0,0 +1,29 @@
+package dao
+
+import (
+	"gorm.io/gorm"
+)
+
+// UserIdentity 第三方登录的账号，同一个第三方里面的同一个账号只能属于一个用户
+type UserIdentity struct {
+	Id         int64  `gorm:"primaryKey,autoIncrement"`
+	Uid        int64  `gorm:"index"`
+	Provider   string `gorm:"type:varchar(64);uniqueIndex:uk_provider_external_id"`
+	ExternalId string `gorm:"type:varchar(255);uniqueIndex:uk_provider_external_id"`
+	UnionId    string `gorm:"type:varchar(255)"`
+	Nickname   string `gorm:"type:varchar(128)"`
+	Email      string `gorm:"type:varchar(255)"`
+	Ctime      int64
+	Utime      int64
+}
+
+// migrateWechatIdentities 以前微信的 openid 放在 users 表里面，把它们搬到 user_identities 里面。
+// INSERT IGNORE 保证重复执行没有问题，users 表里面的这两列之后可以手动删掉
+func migrateWechatIdentities(db *gorm.DB) error {
+	if !db.Migrator().HasColumn(&User{}, "wechat_open_id") {
+		return nil
+	}
+	return db.Exec(`INSERT IGNORE INTO user_identities (uid, provider, external_id, union_id, nickname, email, ctime, utime)
+SELECT id, 'wechat', wechat_open_id, IFNULL(wechat_union_id, ''), '', '', ctime, utime
+FROM users WHERE wechat_open_id IS NOT NULL`).Error
+}
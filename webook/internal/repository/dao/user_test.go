@@ -5,6 +5,8 @@ import (
 	"database/sql"
 	"errors"
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/basic-go-project-webook/webook/internal/repository/dao/outbox"
+	"github.com/gin-gonic/gin"
 	mysqlDriver "github.com/go-sql-driver/mysql"
 	"github.com/stretchr/testify/assert"
 	"gorm.io/driver/mysql"
@@ -88,3 +90,59 @@ func TestGORMUserDAO_Insert(t *testing.T) {
 		})
 	}
 }
+
+func TestGORMUserDAO_UpdateById(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(t *testing.T) *sql.DB
+		wantErr error
+	}{
+		{
+			name: "资料和消息一起提交",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				assert.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `users` .*").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO `outbox_messages` .*").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+				return db
+			},
+		},
+		{
+			name: "写消息失败的时候资料也回滚",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				assert.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `users` .*").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO `outbox_messages` .*").
+					WillReturnError(errors.New("数据库错误"))
+				mock.ExpectRollback()
+				return db
+			},
+			wantErr: errors.New("数据库错误"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sqlDB := tc.mock(t)
+			db, err := gorm.Open(mysql.New(mysql.Config{
+				Conn:                      sqlDB,
+				SkipInitializeWithVersion: true,
+			}), &gorm.Config{
+				DisableAutomaticPing:   true,
+				SkipDefaultTransaction: true,
+			})
+			assert.NoError(t, err)
+			dao := NewUserDAO(db)
+			err = dao.UpdateById(&gin.Context{}, User{Id: 1, Nickname: "Tom"}, []outbox.OutboxMessage{
+				{Topic: "updated-user", MsgKey: "1", Payload: []byte(`{}`)},
+			})
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/basic-go-project-webook/webook/internal/domain"
+	"github.com/basic-go-project-webook/webook/internal/repository/cache"
+	"github.com/basic-go-project-webook/webook/internal/repository/dao/article"
+	"time"
+)
+
+type FeedRepository interface {
+	// GetPubArticle 查询已发表的文章，文章不存在或者已经撤回的时候返回 ErrArticleNotPublished
+	GetPubArticle(ctx context.Context, aid int64) (domain.Article, error)
+	PushToInbox(ctx context.Context, uids []int64, art domain.Article) error
+	// InboxArticles 推模式，从收件箱里面按照 (ctime, id) 倒序读取排在 (maxCtime, maxId) 之后的文章。
+	// 已经撤回的文章会被跳过，返回的数量少于 limit 说明收件箱里面没有更多了
+	InboxArticles(ctx context.Context, uid int64, maxCtime time.Time, maxId int64, limit int) ([]domain.Article, error)
+	// ListPubByAuthors 拉模式，直接从线上库读取作者的文章，顺序和 InboxArticles 一样
+	ListPubByAuthors(ctx context.Context, authorIds []int64, maxCtime time.Time, maxId int64, limit int) ([]domain.Article, error)
+	MarkPullAuthor(ctx context.Context, uid int64) error
+	PullAuthors(ctx context.Context, uids []int64) ([]int64, error)
+}
+
+var ErrArticleNotPublished = errors.New("文章未发表")
+
+type CachedFeedRepository struct {
+	dao   article.ArticleReaderDAO
+	cache cache.FeedCache
+}
+
+func NewCachedFeedRepository(dao article.ArticleReaderDAO, cache cache.FeedCache) FeedRepository {
+	return &CachedFeedRepository{
+		dao:   dao,
+		cache: cache,
+	}
+}
+
+func (c *CachedFeedRepository) GetPubArticle(ctx context.Context, aid int64) (domain.Article, error) {
+	arts, err := c.dao.GetPubByIds(ctx, []int64{aid})
+	if err != nil {
+		return domain.Article{}, err
+	}
+	if len(arts) == 0 {
+		return domain.Article{}, ErrArticleNotPublished
+	}
+	return c.toDomain(arts[0]), nil
+}
+
+func (c *CachedFeedRepository) PushToInbox(ctx context.Context, uids []int64, art domain.Article) error {
+	return c.cache.AddToInbox(ctx, uids, art.Id, art.Ctime)
+}
+
+func (c *CachedFeedRepository) InboxArticles(ctx context.Context, uid int64, maxCtime time.Time, maxId int64, limit int) ([]domain.Article, error) {
+	res := make([]domain.Article, 0, limit)
+	for len(res) < limit {
+		items, err := c.cache.Inbox(ctx, uid, maxCtime, maxId, limit-len(res))
+		if err != nil {
+			return nil, err
+		}
+		if len(items) == 0 {
+			break
+		}
+		aids := make([]int64, 0, len(items))
+		for _, item := range items {
+			aids = append(aids, item.Aid)
+		}
+		arts, err := c.dao.GetPubByIds(ctx, aids)
+		if err != nil {
+			return nil, err
+		}
+		// 撤回的文章还留在收件箱里面，查不到，按照收件箱的顺序跳过
+		pubs := make(map[int64]article.PublishedArticle, len(arts))
+		for _, art := range arts {
+			pubs[art.Id] = art
+		}
+		for _, item := range items {
+			if art, ok := pubs[item.Aid]; ok {
+				res = append(res, c.toDomain(art))
+			}
+		}
+		last := items[len(items)-1]
+		maxCtime, maxId = time.UnixMilli(last.Ctime), last.Aid
+	}
+	return res, nil
+}
+
+func (c *CachedFeedRepository) ListPubByAuthors(ctx context.Context, authorIds []int64, maxCtime time.Time, maxId int64, limit int) ([]domain.Article, error) {
+	arts, err := c.dao.ListPubByAuthors(ctx, authorIds, maxCtime.UnixMilli(), maxId, limit)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.Article, 0, len(arts))
+	for _, art := range arts {
+		res = append(res, c.toDomain(art))
+	}
+	return res, nil
+}
+
+func (c *CachedFeedRepository) MarkPullAuthor(ctx context.Context, uid int64) error {
+	return c.cache.MarkPullAuthors(ctx, uid)
+}
+
+func (c *CachedFeedRepository) PullAuthors(ctx context.Context, uids []int64) ([]int64, error) {
+	return c.cache.PullAuthors(ctx, uids)
+}
+
+func (c *CachedFeedRepository) toDomain(art article.PublishedArticle) domain.Article {
+	return domain.Article{
+		Id:      art.Id,
+		Title:   art.Title,
+		Content: art.Content,
+		Author: domain.Author{
+			Id: art.AuthorId,
+		},
+		Ctime:  time.UnixMilli(art.Ctime),
+		Utime:  time.UnixMilli(art.Utime),
+		Status: domain.ArticleStatus(art.Status),
+	}
+}
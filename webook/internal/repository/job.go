@@ -12,6 +12,9 @@ type CronJobRepository interface {
 	UpdateTime(ctx context.Context, id int64) error
 	Release(ctx context.Context, id int64) error
 	UpdateNextTime(ctx context.Context, id int64, next time.Time) error
+	AddJob(ctx context.Context, job domain.Job, next time.Time) error
+	Finish(ctx context.Context, id int64) error
+	Stop(ctx context.Context, name string) error
 }
 
 type PreemptJobRepository struct {
@@ -39,10 +42,29 @@ func (p *PreemptJobRepository) Preempt(ctx context.Context) (domain.Job, error)
 		Id:         j.Id,
 		Name:       j.Name,
 		Expression: j.Expression,
+		Executor:   j.Executor,
 		Cfg:        j.Cfg,
 	}, nil
 }
 
+func (p *PreemptJobRepository) AddJob(ctx context.Context, job domain.Job, next time.Time) error {
+	return p.dao.Upsert(ctx, dao.Job{
+		Name:       job.Name,
+		Expression: job.Expression,
+		Executor:   job.Executor,
+		Cfg:        job.Cfg,
+		NextTime:   next.UnixMilli(),
+	})
+}
+
+func (p *PreemptJobRepository) Finish(ctx context.Context, id int64) error {
+	return p.dao.Finish(ctx, id)
+}
+
+func (p *PreemptJobRepository) Stop(ctx context.Context, name string) error {
+	return p.dao.Stop(ctx, name)
+}
+
 func NewPreemptJobRepository(dao dao.JobDAO) CronJobRepository {
 	return &PreemptJobRepository{
 		dao: dao,
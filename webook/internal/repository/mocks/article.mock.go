@@ -11,9 +11,10 @@ package repomocks
 
 import (
 	context "context"
-	domain "github.com/basic-go-project-webook/webook/internal/domain"
 	reflect "reflect"
+	time "time"
 
+	domain "github.com/basic-go-project-webook/webook/internal/domain"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -41,6 +42,21 @@ func (m *MockArticleRepository) EXPECT() *MockArticleRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CompareAndSetStatus mocks base method.
+func (m *MockArticleRepository) CompareAndSetStatus(ctx context.Context, id, authorId int64, old, status domain.ArticleStatus) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompareAndSetStatus", ctx, id, authorId, old, status)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompareAndSetStatus indicates an expected call of CompareAndSetStatus.
+func (mr *MockArticleRepositoryMockRecorder) CompareAndSetStatus(ctx, id, authorId, old, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompareAndSetStatus", reflect.TypeOf((*MockArticleRepository)(nil).CompareAndSetStatus), ctx, id, authorId, old, status)
+}
+
 // Create mocks base method.
 func (m *MockArticleRepository) Create(ctx context.Context, art domain.Article) (int64, error) {
 	m.ctrl.T.Helper()
@@ -71,6 +87,21 @@ func (mr *MockArticleRepositoryMockRecorder) GetById(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockArticleRepository)(nil).GetById), ctx, id)
 }
 
+// GetHistory mocks base method.
+func (m *MockArticleRepository) GetHistory(ctx context.Context, id, authorId, version int64) (domain.ArticleHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistory", ctx, id, authorId, version)
+	ret0, _ := ret[0].(domain.ArticleHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHistory indicates an expected call of GetHistory.
+func (mr *MockArticleRepositoryMockRecorder) GetHistory(ctx, id, authorId, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistory", reflect.TypeOf((*MockArticleRepository)(nil).GetHistory), ctx, id, authorId, version)
+}
+
 // GetPubById mocks base method.
 func (m *MockArticleRepository) GetPubById(ctx context.Context, id int64) (domain.Article, error) {
 	m.ctrl.T.Helper()
@@ -86,6 +117,21 @@ func (mr *MockArticleRepositoryMockRecorder) GetPubById(ctx, id any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPubById", reflect.TypeOf((*MockArticleRepository)(nil).GetPubById), ctx, id)
 }
 
+// GetPubByIds mocks base method.
+func (m *MockArticleRepository) GetPubByIds(ctx context.Context, ids []int64) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPubByIds", ctx, ids)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPubByIds indicates an expected call of GetPubByIds.
+func (mr *MockArticleRepositoryMockRecorder) GetPubByIds(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPubByIds", reflect.TypeOf((*MockArticleRepository)(nil).GetPubByIds), ctx, ids)
+}
+
 // List mocks base method.
 func (m *MockArticleRepository) List(ctx context.Context, uid int64, limit, offset int) ([]domain.Article, error) {
 	m.ctrl.T.Helper()
@@ -101,33 +147,137 @@ func (mr *MockArticleRepositoryMockRecorder) List(ctx, uid, limit, offset any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockArticleRepository)(nil).List), ctx, uid, limit, offset)
 }
 
+// ListHistory mocks base method.
+func (m *MockArticleRepository) ListHistory(ctx context.Context, id, authorId int64, offset, limit int) ([]domain.ArticleHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListHistory", ctx, id, authorId, offset, limit)
+	ret0, _ := ret[0].([]domain.ArticleHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListHistory indicates an expected call of ListHistory.
+func (mr *MockArticleRepositoryMockRecorder) ListHistory(ctx, id, authorId, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHistory", reflect.TypeOf((*MockArticleRepository)(nil).ListHistory), ctx, id, authorId, offset, limit)
+}
+
+// ListPub mocks base method.
+func (m *MockArticleRepository) ListPub(ctx context.Context, start time.Time, offset, limit int) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPub", ctx, start, offset, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPub indicates an expected call of ListPub.
+func (mr *MockArticleRepositoryMockRecorder) ListPub(ctx, start, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPub", reflect.TypeOf((*MockArticleRepository)(nil).ListPub), ctx, start, offset, limit)
+}
+
+// ListPubByAuthor mocks base method.
+func (m *MockArticleRepository) ListPubByAuthor(ctx context.Context, uid int64, maxUtime time.Time, maxId int64, limit int) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPubByAuthor", ctx, uid, maxUtime, maxId, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPubByAuthor indicates an expected call of ListPubByAuthor.
+func (mr *MockArticleRepositoryMockRecorder) ListPubByAuthor(ctx, uid, maxUtime, maxId, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPubByAuthor", reflect.TypeOf((*MockArticleRepository)(nil).ListPubByAuthor), ctx, uid, maxUtime, maxId, limit)
+}
+
+// ListPubByTag mocks base method.
+func (m *MockArticleRepository) ListPubByTag(ctx context.Context, tag string, cursor int64, limit int) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPubByTag", ctx, tag, cursor, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPubByTag indicates an expected call of ListPubByTag.
+func (mr *MockArticleRepositoryMockRecorder) ListPubByTag(ctx, tag, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPubByTag", reflect.TypeOf((*MockArticleRepository)(nil).ListPubByTag), ctx, tag, cursor, limit)
+}
+
+// Restore mocks base method.
+func (m *MockArticleRepository) Restore(ctx context.Context, id, authorId, version int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, id, authorId, version)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockArticleRepositoryMockRecorder) Restore(ctx, id, authorId, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockArticleRepository)(nil).Restore), ctx, id, authorId, version)
+}
+
+// SearchTags mocks base method.
+func (m *MockArticleRepository) SearchTags(ctx context.Context, prefix string, limit int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchTags", ctx, prefix, limit)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchTags indicates an expected call of SearchTags.
+func (mr *MockArticleRepositoryMockRecorder) SearchTags(ctx, prefix, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchTags", reflect.TypeOf((*MockArticleRepository)(nil).SearchTags), ctx, prefix, limit)
+}
+
 // Sync mocks base method.
-func (m *MockArticleRepository) Sync(ctx context.Context, art domain.Article) (int64, error) {
+func (m *MockArticleRepository) Sync(ctx context.Context, art domain.Article, outbox domain.OutboxMessageFunc) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Sync", ctx, art)
+	ret := m.ctrl.Call(m, "Sync", ctx, art, outbox)
 	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Sync indicates an expected call of Sync.
-func (mr *MockArticleRepositoryMockRecorder) Sync(ctx, art any) *gomock.Call {
+func (mr *MockArticleRepositoryMockRecorder) Sync(ctx, art, outbox any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sync", reflect.TypeOf((*MockArticleRepository)(nil).Sync), ctx, art)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sync", reflect.TypeOf((*MockArticleRepository)(nil).Sync), ctx, art, outbox)
 }
 
 // SyncStatus mocks base method.
-func (m *MockArticleRepository) SyncStatus(ctx context.Context, id, authorId int64, status domain.ArticleStatus) error {
+func (m *MockArticleRepository) SyncStatus(ctx context.Context, id, authorId int64, status domain.ArticleStatus, outbox domain.OutboxMessageFunc) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SyncStatus", ctx, id, authorId, status)
+	ret := m.ctrl.Call(m, "SyncStatus", ctx, id, authorId, status, outbox)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // SyncStatus indicates an expected call of SyncStatus.
-func (mr *MockArticleRepositoryMockRecorder) SyncStatus(ctx, id, authorId, status any) *gomock.Call {
+func (mr *MockArticleRepositoryMockRecorder) SyncStatus(ctx, id, authorId, status, outbox any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncStatus", reflect.TypeOf((*MockArticleRepository)(nil).SyncStatus), ctx, id, authorId, status, outbox)
+}
+
+// TransferAuthor mocks base method.
+func (m *MockArticleRepository) TransferAuthor(ctx context.Context, srcUid, dstUid int64, outbox domain.ArticleOutboxFunc) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransferAuthor", ctx, srcUid, dstUid, outbox)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TransferAuthor indicates an expected call of TransferAuthor.
+func (mr *MockArticleRepositoryMockRecorder) TransferAuthor(ctx, srcUid, dstUid, outbox any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncStatus", reflect.TypeOf((*MockArticleRepository)(nil).SyncStatus), ctx, id, authorId, status)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferAuthor", reflect.TypeOf((*MockArticleRepository)(nil).TransferAuthor), ctx, srcUid, dstUid, outbox)
 }
 
 // Update mocks base method.
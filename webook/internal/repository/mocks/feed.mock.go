@@ -0,0 +1,131 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./webook/internal/repository/feed.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./webook/internal/repository/feed.go -package=repomocks -destination=./webook/internal/repository/mocks/feed.mock.go
+//
+
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/basic-go-project-webook/webook/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFeedRepository is a mock of FeedRepository interface.
+type MockFeedRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockFeedRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockFeedRepositoryMockRecorder is the mock recorder for MockFeedRepository.
+type MockFeedRepositoryMockRecorder struct {
+	mock *MockFeedRepository
+}
+
+// NewMockFeedRepository creates a new mock instance.
+func NewMockFeedRepository(ctrl *gomock.Controller) *MockFeedRepository {
+	mock := &MockFeedRepository{ctrl: ctrl}
+	mock.recorder = &MockFeedRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFeedRepository) EXPECT() *MockFeedRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetPubArticle mocks base method.
+func (m *MockFeedRepository) GetPubArticle(ctx context.Context, aid int64) (domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPubArticle", ctx, aid)
+	ret0, _ := ret[0].(domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPubArticle indicates an expected call of GetPubArticle.
+func (mr *MockFeedRepositoryMockRecorder) GetPubArticle(ctx, aid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPubArticle", reflect.TypeOf((*MockFeedRepository)(nil).GetPubArticle), ctx, aid)
+}
+
+// InboxArticles mocks base method.
+func (m *MockFeedRepository) InboxArticles(ctx context.Context, uid int64, maxCtime time.Time, maxId int64, limit int) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InboxArticles", ctx, uid, maxCtime, maxId, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InboxArticles indicates an expected call of InboxArticles.
+func (mr *MockFeedRepositoryMockRecorder) InboxArticles(ctx, uid, maxCtime, maxId, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InboxArticles", reflect.TypeOf((*MockFeedRepository)(nil).InboxArticles), ctx, uid, maxCtime, maxId, limit)
+}
+
+// ListPubByAuthors mocks base method.
+func (m *MockFeedRepository) ListPubByAuthors(ctx context.Context, authorIds []int64, maxCtime time.Time, maxId int64, limit int) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPubByAuthors", ctx, authorIds, maxCtime, maxId, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPubByAuthors indicates an expected call of ListPubByAuthors.
+func (mr *MockFeedRepositoryMockRecorder) ListPubByAuthors(ctx, authorIds, maxCtime, maxId, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPubByAuthors", reflect.TypeOf((*MockFeedRepository)(nil).ListPubByAuthors), ctx, authorIds, maxCtime, maxId, limit)
+}
+
+// MarkPullAuthor mocks base method.
+func (m *MockFeedRepository) MarkPullAuthor(ctx context.Context, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkPullAuthor", ctx, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkPullAuthor indicates an expected call of MarkPullAuthor.
+func (mr *MockFeedRepositoryMockRecorder) MarkPullAuthor(ctx, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkPullAuthor", reflect.TypeOf((*MockFeedRepository)(nil).MarkPullAuthor), ctx, uid)
+}
+
+// PullAuthors mocks base method.
+func (m *MockFeedRepository) PullAuthors(ctx context.Context, uids []int64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PullAuthors", ctx, uids)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PullAuthors indicates an expected call of PullAuthors.
+func (mr *MockFeedRepositoryMockRecorder) PullAuthors(ctx, uids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PullAuthors", reflect.TypeOf((*MockFeedRepository)(nil).PullAuthors), ctx, uids)
+}
+
+// PushToInbox mocks base method.
+func (m *MockFeedRepository) PushToInbox(ctx context.Context, uids []int64, art domain.Article) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PushToInbox", ctx, uids, art)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PushToInbox indicates an expected call of PushToInbox.
+func (mr *MockFeedRepositoryMockRecorder) PushToInbox(ctx, uids, art any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PushToInbox", reflect.TypeOf((*MockFeedRepository)(nil).PushToInbox), ctx, uids, art)
+}
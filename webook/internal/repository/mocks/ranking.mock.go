@@ -0,0 +1,115 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./webook/internal/repository/ranking.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./webook/internal/repository/ranking.go -package=repomocks -destination=./webook/internal/repository/mocks/ranking.mock.go
+//
+
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/basic-go-project-webook/webook/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRankingRepository is a mock of RankingRepository interface.
+type MockRankingRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRankingRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRankingRepositoryMockRecorder is the mock recorder for MockRankingRepository.
+type MockRankingRepositoryMockRecorder struct {
+	mock *MockRankingRepository
+}
+
+// NewMockRankingRepository creates a new mock instance.
+func NewMockRankingRepository(ctrl *gomock.Controller) *MockRankingRepository {
+	mock := &MockRankingRepository{ctrl: ctrl}
+	mock.recorder = &MockRankingRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRankingRepository) EXPECT() *MockRankingRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetBoard mocks base method.
+func (m *MockRankingRepository) GetBoard(ctx context.Context, board string, offset, limit int) ([]domain.RankedArticle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoard", ctx, board, offset, limit)
+	ret0, _ := ret[0].([]domain.RankedArticle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoard indicates an expected call of GetBoard.
+func (mr *MockRankingRepositoryMockRecorder) GetBoard(ctx, board, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoard", reflect.TypeOf((*MockRankingRepository)(nil).GetBoard), ctx, board, offset, limit)
+}
+
+// GetRank mocks base method.
+func (m *MockRankingRepository) GetRank(ctx context.Context, board string, aid int64) (domain.RankedArticle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRank", ctx, board, aid)
+	ret0, _ := ret[0].(domain.RankedArticle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRank indicates an expected call of GetRank.
+func (mr *MockRankingRepositoryMockRecorder) GetRank(ctx, board, aid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRank", reflect.TypeOf((*MockRankingRepository)(nil).GetRank), ctx, board, aid)
+}
+
+// GetTopN mocks base method.
+func (m *MockRankingRepository) GetTopN(ctx context.Context) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTopN", ctx)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTopN indicates an expected call of GetTopN.
+func (mr *MockRankingRepositoryMockRecorder) GetTopN(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTopN", reflect.TypeOf((*MockRankingRepository)(nil).GetTopN), ctx)
+}
+
+// ReplaceBoard mocks base method.
+func (m *MockRankingRepository) ReplaceBoard(ctx context.Context, board domain.RankingBoard, arts []domain.RankedArticle) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceBoard", ctx, board, arts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceBoard indicates an expected call of ReplaceBoard.
+func (mr *MockRankingRepositoryMockRecorder) ReplaceBoard(ctx, board, arts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceBoard", reflect.TypeOf((*MockRankingRepository)(nil).ReplaceBoard), ctx, board, arts)
+}
+
+// ReplaceTopN mocks base method.
+func (m *MockRankingRepository) ReplaceTopN(ctx context.Context, arts []domain.Article) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceTopN", ctx, arts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceTopN indicates an expected call of ReplaceTopN.
+func (mr *MockRankingRepositoryMockRecorder) ReplaceTopN(ctx, arts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceTopN", reflect.TypeOf((*MockRankingRepository)(nil).ReplaceTopN), ctx, arts)
+}
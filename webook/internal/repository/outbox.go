@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"github.com/basic-go-project-webook/webook/internal/domain"
+	"github.com/basic-go-project-webook/webook/internal/repository/dao/outbox"
+	"time"
+)
+
+type OutboxRepository interface {
+	// Claim 取出到期的消息，在 lease 时间内不会被再次取出
+	Claim(ctx context.Context, lease time.Duration, limit int) ([]domain.OutboxMessage, error)
+	MarkSent(ctx context.Context, id int64) error
+	// MarkFailed 记录投递失败，dead 为 true 的时候不再重试，否则 nextTime 之后重试
+	MarkFailed(ctx context.Context, msg domain.OutboxMessage, nextTime time.Time, cause error, dead bool) error
+}
+
+type outboxRepository struct {
+	dao outbox.OutboxDAO
+}
+
+func NewOutboxRepository(dao outbox.OutboxDAO) OutboxRepository {
+	return &outboxRepository{
+		dao: dao,
+	}
+}
+
+func (o *outboxRepository) Claim(ctx context.Context, lease time.Duration, limit int) ([]domain.OutboxMessage, error) {
+	msgs, err := o.dao.Claim(ctx, time.Now().UnixMilli(), lease.Milliseconds(), limit)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.OutboxMessage, 0, len(msgs))
+	for _, msg := range msgs {
+		res = append(res, domain.OutboxMessage{
+			Id:      msg.Id,
+			Topic:   msg.Topic,
+			Key:     msg.MsgKey,
+			Payload: msg.Payload,
+			Retries: msg.Retries,
+		})
+	}
+	return res, nil
+}
+
+func (o *outboxRepository) MarkSent(ctx context.Context, id int64) error {
+	return o.dao.MarkSent(ctx, id)
+}
+
+func (o *outboxRepository) MarkFailed(ctx context.Context, msg domain.OutboxMessage, nextTime time.Time, cause error, dead bool) error {
+	return o.dao.MarkFailed(ctx, msg.Id, msg.Retries, nextTime.UnixMilli(), cause.Error(), dead)
+}
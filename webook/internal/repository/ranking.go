@@ -2,18 +2,45 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"github.com/basic-go-project-webook/webook/internal/domain"
 	"github.com/basic-go-project-webook/webook/internal/repository/cache"
+	"github.com/redis/go-redis/v9"
+	"time"
 )
 
 type RankingRepository interface {
 	ReplaceTopN(ctx context.Context, arts []domain.Article) error
 	GetTopN(ctx context.Context) ([]domain.Article, error)
+	ReplaceBoard(ctx context.Context, board domain.RankingBoard, arts []domain.RankedArticle) error
+	GetBoard(ctx context.Context, board string, offset, limit int) ([]domain.RankedArticle, error)
+	GetRank(ctx context.Context, board string, aid int64) (domain.RankedArticle, error)
 }
 
+// ErrArticleNotRanked 文章不在榜单上
+var ErrArticleNotRanked = cache.ErrArticleNotRanked
+
 type OnlyCachedRankingRepository struct {
 	redisCache *cache.RankingRedisCache
 	localCache *cache.RankingLocalCache
+	boardCache cache.RankingBoardCache
+}
+
+func (c *OnlyCachedRankingRepository) ReplaceBoard(ctx context.Context, board domain.RankingBoard, arts []domain.RankedArticle) error {
+	// 多留几个周期，计算失败一两次也还有榜单可以看
+	expiration := board.Interval * 3
+	if expiration < time.Minute*10 {
+		expiration = time.Minute * 10
+	}
+	return c.boardCache.Replace(ctx, board.Name, arts, expiration)
+}
+
+func (c *OnlyCachedRankingRepository) GetBoard(ctx context.Context, board string, offset, limit int) ([]domain.RankedArticle, error) {
+	return c.boardCache.Range(ctx, board, offset, limit)
+}
+
+func (c *OnlyCachedRankingRepository) GetRank(ctx context.Context, board string, aid int64) (domain.RankedArticle, error) {
+	return c.boardCache.Rank(ctx, board, aid)
 }
 
 func (c *OnlyCachedRankingRepository) GetTopN(ctx context.Context) ([]domain.Article, error) {
@@ -23,7 +50,15 @@ func (c *OnlyCachedRankingRepository) GetTopN(ctx context.Context) ([]domain.Art
 	}
 	data, err = c.redisCache.Get(ctx)
 	if err != nil {
-		return c.localCache.Get(ctx)
+		// Redis 出问题或者还没有计算过，尽量用本地缓存兜底
+		forced, er := c.localCache.GetForce(ctx)
+		if er == nil {
+			return forced, nil
+		}
+		if errors.Is(err, redis.Nil) {
+			return []domain.Article{}, nil
+		}
+		return nil, err
 	}
 	_ = c.localCache.Set(ctx, data)
 	return data, nil
@@ -34,9 +69,11 @@ func (c *OnlyCachedRankingRepository) ReplaceTopN(ctx context.Context, arts []do
 	return c.redisCache.Set(ctx, arts)
 }
 
-func NewOnlyCachedRankingRepository(redis *cache.RankingRedisCache, local *cache.RankingLocalCache) RankingRepository {
+func NewOnlyCachedRankingRepository(redis *cache.RankingRedisCache, local *cache.RankingLocalCache,
+	board cache.RankingBoardCache) RankingRepository {
 	return &OnlyCachedRankingRepository{
 		redisCache: redis,
 		localCache: local,
+		boardCache: board,
 	}
 }
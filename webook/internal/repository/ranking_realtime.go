@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"github.com/basic-go-project-webook/webook/internal/domain"
+	"github.com/basic-go-project-webook/webook/internal/repository/cache"
+)
+
+// RealtimeRankingRepository 实时热榜，只有文章 id 和分数
+type RealtimeRankingRepository interface {
+	IncrScore(ctx context.Context, aid int64, weight float64) error
+	// IncrActionScore 点赞、收藏加分，每个用户只加一次
+	IncrActionScore(ctx context.Context, aid int64, uid int64, action string, weight float64) error
+	// UndoActionScore 取消点赞、收藏，减掉当时加的分
+	UndoActionScore(ctx context.Context, aid int64, uid int64, action string, weight float64) error
+	GetTopN(ctx context.Context, offset, limit int) ([]domain.RankedArticle, error)
+	Compact(ctx context.Context) error
+}
+
+type CachedRealtimeRankingRepository struct {
+	cache cache.RankingRealtimeCache
+}
+
+func NewCachedRealtimeRankingRepository(cache cache.RankingRealtimeCache) RealtimeRankingRepository {
+	return &CachedRealtimeRankingRepository{
+		cache: cache,
+	}
+}
+
+func (c *CachedRealtimeRankingRepository) IncrScore(ctx context.Context, aid int64, weight float64) error {
+	return c.cache.Incr(ctx, aid, weight)
+}
+
+func (c *CachedRealtimeRankingRepository) IncrActionScore(ctx context.Context, aid int64, uid int64, action string, weight float64) error {
+	return c.cache.IncrAction(ctx, aid, uid, action, weight)
+}
+
+func (c *CachedRealtimeRankingRepository) UndoActionScore(ctx context.Context, aid int64, uid int64, action string, weight float64) error {
+	return c.cache.UndoAction(ctx, aid, uid, action, weight)
+}
+
+func (c *CachedRealtimeRankingRepository) GetTopN(ctx context.Context, offset, limit int) ([]domain.RankedArticle, error) {
+	return c.cache.Top(ctx, offset, limit)
+}
+
+func (c *CachedRealtimeRankingRepository) Compact(ctx context.Context) error {
+	return c.cache.Compact(ctx)
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/basic-go-project-webook/webook/internal/domain"
 	"github.com/basic-go-project-webook/webook/internal/repository/cache"
 	"github.com/basic-go-project-webook/webook/internal/repository/dao"
+	"github.com/basic-go-project-webook/webook/internal/repository/dao/outbox"
 	"github.com/gin-gonic/gin"
 	"time"
 )
@@ -13,6 +14,7 @@ import (
 var (
 	ErrUserDuplicateEmail = dao.ErrUserDuplicate
 	ErrUserNotFound       = dao.ErrRecordNotFount
+	ErrUserMerged         = dao.ErrUserMerged
 )
 
 type UserRepository interface {
@@ -20,8 +22,19 @@ type UserRepository interface {
 	FindByEmail(ctx context.Context, email string) (domain.User, error)
 	FindByPhone(ctx context.Context, phone string) (domain.User, error)
 	FindById(ctx context.Context, id int64) (domain.User, error)
-	UpdateById(ctx *gin.Context, user domain.User) error
-	FindByWechat(ctx *gin.Context, openId string) (domain.User, error)
+	// UpdateById outbox 里面的消息和用户资料在同一个事务里面写入
+	UpdateById(ctx *gin.Context, user domain.User, outbox []domain.OutboxMessage) error
+	FindByIdentity(ctx context.Context, provider string, externalId string) (domain.User, error)
+	CreateWithIdentity(ctx context.Context, user domain.User, identity domain.Identity) error
+	FindIdentities(ctx context.Context, uid int64) ([]domain.Identity, error)
+	// UpdatePhone 和 UpdateEmail 传空字符串就是解绑，已经被别人绑定了返回 ErrUserDuplicateEmail
+	UpdatePhone(ctx context.Context, uid int64, phone string) error
+	UpdateEmail(ctx context.Context, uid int64, email string) error
+	AddIdentity(ctx context.Context, identity domain.Identity) error
+	DeleteIdentity(ctx context.Context, uid int64, provider string) error
+	Merge(ctx context.Context, srcUid int64, dstUid int64) error
+	// ListAfter 按照 id 升序翻页，minId 传上一页最后一个用户的 id
+	ListAfter(ctx context.Context, minId int64, limit int) ([]domain.User, error)
 }
 
 type CachedUserRepository struct {
@@ -79,21 +92,92 @@ func (r *CachedUserRepository) FindById(ctx context.Context, id int64) (domain.U
 	return user, nil
 }
 
-func (r *CachedUserRepository) FindByWechat(ctx *gin.Context, openId string) (domain.User, error) {
-	user, err := r.dao.FindByWechat(ctx, openId)
+func (r *CachedUserRepository) FindByIdentity(ctx context.Context, provider string, externalId string) (domain.User, error) {
+	user, err := r.dao.FindByIdentity(ctx, provider, externalId)
 	if err != nil {
 		return domain.User{}, err
 	}
-	u := r.entityToDomain(user)
-	return u, nil
+	return r.entityToDomain(user), nil
+}
+
+func (r *CachedUserRepository) CreateWithIdentity(ctx context.Context, user domain.User, identity domain.Identity) error {
+	return r.dao.InsertWithIdentity(ctx, r.domainToEntity(user), r.identityToEntity(identity))
+}
+
+func (r *CachedUserRepository) FindIdentities(ctx context.Context, uid int64) ([]domain.Identity, error) {
+	identities, err := r.dao.FindIdentities(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.Identity, 0, len(identities))
+	for _, identity := range identities {
+		res = append(res, r.identityToDomain(identity))
+	}
+	return res, nil
+}
+
+func (r *CachedUserRepository) UpdatePhone(ctx context.Context, uid int64, phone string) error {
+	err := r.dao.UpdatePhone(ctx, uid, phone)
+	if err != nil {
+		return err
+	}
+	return r.cache.Del(ctx, uid)
+}
+
+func (r *CachedUserRepository) UpdateEmail(ctx context.Context, uid int64, email string) error {
+	err := r.dao.UpdateEmail(ctx, uid, email)
+	if err != nil {
+		return err
+	}
+	return r.cache.Del(ctx, uid)
+}
+
+func (r *CachedUserRepository) AddIdentity(ctx context.Context, identity domain.Identity) error {
+	return r.dao.AddIdentity(ctx, r.identityToEntity(identity))
+}
+
+func (r *CachedUserRepository) DeleteIdentity(ctx context.Context, uid int64, provider string) error {
+	return r.dao.DeleteIdentity(ctx, uid, provider)
 }
 
-func (r *CachedUserRepository) UpdateById(ctx *gin.Context, user domain.User) error {
+func (r *CachedUserRepository) Merge(ctx context.Context, srcUid int64, dstUid int64) error {
+	err := r.dao.Merge(ctx, srcUid, dstUid)
+	if err != nil {
+		return err
+	}
+	err = r.cache.Del(ctx, srcUid)
+	if err != nil {
+		return err
+	}
+	return r.cache.Del(ctx, dstUid)
+}
+
+func (r *CachedUserRepository) ListAfter(ctx context.Context, minId int64, limit int) ([]domain.User, error) {
+	users, err := r.dao.ListAfter(ctx, minId, limit)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.User, 0, len(users))
+	for _, user := range users {
+		res = append(res, r.entityToDomain(user))
+	}
+	return res, nil
+}
+
+func (r *CachedUserRepository) UpdateById(ctx *gin.Context, user domain.User, events []domain.OutboxMessage) error {
 	_, err := r.cache.Get(ctx, user.Id)
 	if err == nil {
 		_ = r.cache.Del(ctx, user.Id)
 	}
-	return r.dao.UpdateById(ctx, r.domainToEntity(user))
+	msgs := make([]outbox.OutboxMessage, 0, len(events))
+	for _, msg := range events {
+		msgs = append(msgs, outbox.OutboxMessage{
+			Topic:   msg.Topic,
+			MsgKey:  msg.Key,
+			Payload: msg.Payload,
+		})
+	}
+	return r.dao.UpdateById(ctx, r.domainToEntity(user), msgs)
 }
 
 func (r *CachedUserRepository) entityToDomain(ud dao.User) domain.User {
@@ -103,10 +187,6 @@ func (r *CachedUserRepository) entityToDomain(ud dao.User) domain.User {
 		Password: ud.Password,
 		Nickname: ud.Nickname,
 		Phone:    ud.Phone.String,
-		WechatInfo: domain.WechatInfo{
-			OpenId:  ud.WechatOpenID.String,
-			UnionId: ud.WechatUnionID.String,
-		},
 		Birthday: time.UnixMilli(ud.Birthday),
 		Ctime:    time.UnixMilli(ud.Ctime),
 		Utime:    time.UnixMilli(ud.Utime),
@@ -127,17 +207,32 @@ func (r *CachedUserRepository) domainToEntity(u domain.User) dao.User {
 			String: u.Phone,
 			Valid:  u.Phone != "",
 		},
-		WechatOpenID: sql.NullString{
-			String: u.WechatInfo.OpenId,
-			Valid:  u.WechatInfo.OpenId != "",
-		},
-		WechatUnionID: sql.NullString{
-			String: u.WechatInfo.OpenId,
-			Valid:  u.WechatInfo.OpenId != "",
-		},
 		AboutMe:  u.AboutMe,
 		Birthday: u.Birthday.UnixMilli(),
 		Ctime:    u.Ctime.UnixMilli(),
 		Utime:    u.Utime.UnixMilli(),
 	}
 }
+
+func (r *CachedUserRepository) identityToEntity(identity domain.Identity) dao.UserIdentity {
+	return dao.UserIdentity{
+		Uid:        identity.Uid,
+		Provider:   identity.Provider,
+		ExternalId: identity.ExternalId,
+		UnionId:    identity.UnionId,
+		Nickname:   identity.Nickname,
+		Email:      identity.Email,
+	}
+}
+
+func (r *CachedUserRepository) identityToDomain(identity dao.UserIdentity) domain.Identity {
+	return domain.Identity{
+		Uid:        identity.Uid,
+		Provider:   identity.Provider,
+		ExternalId: identity.ExternalId,
+		UnionId:    identity.UnionId,
+		Nickname:   identity.Nickname,
+		Email:      identity.Email,
+		Ctime:      time.UnixMilli(identity.Ctime),
+	}
+}
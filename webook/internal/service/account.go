@@ -0,0 +1,260 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	followv1 "github.com/basic-go-project-webook/webook/api/proto/gen/follow/v1"
+	intrv1 "github.com/basic-go-project-webook/webook/api/proto/gen/intr/v1"
+	"github.com/basic-go-project-webook/webook/internal/domain"
+	"github.com/basic-go-project-webook/webook/internal/repository"
+)
+
+var (
+	ErrMergeConflict   = errors.New("两个账号绑定了同一种登录方式，不能合并")
+	ErrLastLoginMethod = errors.New("至少要保留一种登录方式")
+	ErrProviderBound   = errors.New("已经绑定过这个第三方的另外一个账号")
+	ErrUserMerged      = repository.ErrUserMerged
+)
+
+// ConflictError 要绑定的手机号、邮箱或者第三方账号已经属于别的用户。
+// 绑定的时候已经验证过所有权了，可以把 Uid 这个账号合并过来
+type ConflictError struct {
+	Uid int64
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("已经被用户 %d 绑定", e.Uid)
+}
+
+// AccountService 管理一个用户的登录方式：手机号、邮箱和第三方账号。
+// 调用之前 web 层已经验证过验证码或者第三方登录了
+type AccountService interface {
+	BindPhone(ctx context.Context, uid int64, phone string) error
+	UnbindPhone(ctx context.Context, uid int64) error
+	BindEmail(ctx context.Context, uid int64, email string) error
+	UnbindEmail(ctx context.Context, uid int64) error
+	// BindIdentity 同一个第三方只能绑定一个账号
+	BindIdentity(ctx context.Context, uid int64, identity domain.Identity) error
+	UnbindIdentity(ctx context.Context, uid int64, provider string) error
+	Identities(ctx context.Context, uid int64) ([]domain.Identity, error)
+	// Merge 把 srcUid 合并到 dstUid：登录方式、文章、点赞收藏和关注全部转给 dstUid。
+	// 每一步都可以重复执行，中间失败了用同样的参数重试就可以
+	Merge(ctx context.Context, dstUid int64, srcUid int64) error
+}
+
+type accountService struct {
+	repo      repository.UserRepository
+	artSvc    ArticleService
+	intrSvc   intrv1.InteractiveServiceClient
+	followSvc followv1.FollowServiceClient
+}
+
+func NewAccountService(repo repository.UserRepository, artSvc ArticleService,
+	intrSvc intrv1.InteractiveServiceClient, followSvc followv1.FollowServiceClient) AccountService {
+	return &accountService{
+		repo:      repo,
+		artSvc:    artSvc,
+		intrSvc:   intrSvc,
+		followSvc: followSvc,
+	}
+}
+
+func (svc *accountService) BindPhone(ctx context.Context, uid int64, phone string) error {
+	return svc.bind(ctx, uid, func() (domain.User, error) {
+		return svc.repo.FindByPhone(ctx, phone)
+	}, func() error {
+		return svc.repo.UpdatePhone(ctx, uid, phone)
+	})
+}
+
+func (svc *accountService) BindEmail(ctx context.Context, uid int64, email string) error {
+	return svc.bind(ctx, uid, func() (domain.User, error) {
+		return svc.repo.FindByEmail(ctx, email)
+	}, func() error {
+		return svc.repo.UpdateEmail(ctx, uid, email)
+	})
+}
+
+func (svc *accountService) BindIdentity(ctx context.Context, uid int64, identity domain.Identity) error {
+	identity.Uid = uid
+	return svc.bind(ctx, uid, func() (domain.User, error) {
+		return svc.repo.FindByIdentity(ctx, identity.Provider, identity.ExternalId)
+	}, func() error {
+		identities, err := svc.repo.FindIdentities(ctx, uid)
+		if err != nil {
+			return err
+		}
+		for _, i := range identities {
+			if i.Provider == identity.Provider {
+				return ErrProviderBound
+			}
+		}
+		return svc.repo.AddIdentity(ctx, identity)
+	})
+}
+
+// bind find 查询现在是谁绑定了，没有人绑定的时候才调用 update
+func (svc *accountService) bind(ctx context.Context, uid int64,
+	find func() (domain.User, error), update func() error) error {
+	owner, err := find()
+	if err == nil {
+		if owner.Id == uid {
+			return nil
+		}
+		return &ConflictError{Uid: owner.Id}
+	}
+	if !errors.Is(err, repository.ErrUserNotFound) {
+		return err
+	}
+	err = update()
+	if !errors.Is(err, repository.ErrUserDuplicateEmail) {
+		return err
+	}
+	// 并发的时候被别人抢先绑定了
+	owner, err = find()
+	if err != nil {
+		return err
+	}
+	if owner.Id == uid {
+		return nil
+	}
+	return &ConflictError{Uid: owner.Id}
+}
+
+func (svc *accountService) UnbindPhone(ctx context.Context, uid int64) error {
+	user, identities, err := svc.loginMethods(ctx, uid)
+	if err != nil {
+		return err
+	}
+	if user.Phone == "" {
+		return nil
+	}
+	user.Phone = ""
+	if countLoginMethods(user, identities) == 0 {
+		return ErrLastLoginMethod
+	}
+	return svc.repo.UpdatePhone(ctx, uid, "")
+}
+
+func (svc *accountService) UnbindEmail(ctx context.Context, uid int64) error {
+	user, identities, err := svc.loginMethods(ctx, uid)
+	if err != nil {
+		return err
+	}
+	if user.Email == "" {
+		return nil
+	}
+	user.Email = ""
+	if countLoginMethods(user, identities) == 0 {
+		return ErrLastLoginMethod
+	}
+	return svc.repo.UpdateEmail(ctx, uid, "")
+}
+
+func (svc *accountService) UnbindIdentity(ctx context.Context, uid int64, provider string) error {
+	user, identities, err := svc.loginMethods(ctx, uid)
+	if err != nil {
+		return err
+	}
+	remain := make([]domain.Identity, 0, len(identities))
+	for _, identity := range identities {
+		if identity.Provider != provider {
+			remain = append(remain, identity)
+		}
+	}
+	if len(remain) == len(identities) {
+		return nil
+	}
+	if countLoginMethods(user, remain) == 0 {
+		return ErrLastLoginMethod
+	}
+	return svc.repo.DeleteIdentity(ctx, uid, provider)
+}
+
+func (svc *accountService) Identities(ctx context.Context, uid int64) ([]domain.Identity, error) {
+	return svc.repo.FindIdentities(ctx, uid)
+}
+
+func (svc *accountService) loginMethods(ctx context.Context, uid int64) (domain.User, []domain.Identity, error) {
+	user, err := svc.repo.FindById(ctx, uid)
+	if err != nil {
+		return domain.User{}, nil, err
+	}
+	identities, err := svc.repo.FindIdentities(ctx, uid)
+	return user, identities, err
+}
+
+// countLoginMethods 邮箱要有密码才能登录
+func countLoginMethods(user domain.User, identities []domain.Identity) int {
+	cnt := len(identities)
+	if user.Phone != "" {
+		cnt++
+	}
+	if user.Email != "" && user.Password != "" {
+		cnt++
+	}
+	return cnt
+}
+
+func (svc *accountService) Merge(ctx context.Context, dstUid int64, srcUid int64) error {
+	if dstUid == srcUid {
+		return ErrMergeConflict
+	}
+	err := svc.checkMerge(ctx, dstUid, srcUid)
+	if err != nil {
+		return err
+	}
+	// 先转登录方式，src 就不能再登录了，后面的步骤失败了也不会有新的数据写到 src 上面
+	err = svc.repo.Merge(ctx, srcUid, dstUid)
+	if err != nil {
+		return err
+	}
+	err = svc.artSvc.TransferAuthor(ctx, srcUid, dstUid)
+	if err != nil {
+		return fmt.Errorf("转移文章失败: %w", err)
+	}
+	_, err = svc.intrSvc.MergeUser(ctx, &intrv1.MergeUserRequest{
+		SrcUid: srcUid,
+		DstUid: dstUid,
+	})
+	if err != nil {
+		return fmt.Errorf("合并点赞收藏失败: %w", err)
+	}
+	_, err = svc.followSvc.MergeUser(ctx, &followv1.MergeUserRequest{
+		SrcUid: srcUid,
+		DstUid: dstUid,
+	})
+	if err != nil {
+		return fmt.Errorf("合并关注失败: %w", err)
+	}
+	return nil
+}
+
+// checkMerge 两个账号有同一种登录方式的时候，合并之后必须丢掉一个，这种情况要用户自己先解绑
+func (svc *accountService) checkMerge(ctx context.Context, dstUid int64, srcUid int64) error {
+	dst, dstIdentities, err := svc.loginMethods(ctx, dstUid)
+	if err != nil {
+		return err
+	}
+	src, srcIdentities, err := svc.loginMethods(ctx, srcUid)
+	if err != nil {
+		return err
+	}
+	if dst.Phone != "" && src.Phone != "" {
+		return ErrMergeConflict
+	}
+	if dst.Email != "" && src.Email != "" {
+		return ErrMergeConflict
+	}
+	providers := make(map[string]struct{}, len(dstIdentities))
+	for _, identity := range dstIdentities {
+		providers[identity.Provider] = struct{}{}
+	}
+	for _, identity := range srcIdentities {
+		if _, ok := providers[identity.Provider]; ok {
+			return ErrMergeConflict
+		}
+	}
+	return nil
+}
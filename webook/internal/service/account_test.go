@@ -0,0 +1,264 @@
+package service
+
+import (
+	"context"
+	"errors"
+	followv1 "github.com/basic-go-project-webook/webook/api/proto/gen/follow/v1"
+	intrv1 "github.com/basic-go-project-webook/webook/api/proto/gen/intr/v1"
+	"github.com/basic-go-project-webook/webook/internal/domain"
+	"github.com/basic-go-project-webook/webook/internal/repository"
+	repomocks "github.com/basic-go-project-webook/webook/internal/repository/mocks"
+	svcmocks "github.com/basic-go-project-webook/webook/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"testing"
+)
+
+func Test_accountService_BindPhone(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) repository.UserRepository
+		wantErr error
+	}{
+		{
+			name: "绑定成功",
+			mock: func(ctrl *gomock.Controller) repository.UserRepository {
+				repo := repomocks.NewMockUserRepository(ctrl)
+				repo.EXPECT().FindByPhone(gomock.Any(), "15212345678").
+					Return(domain.User{}, repository.ErrUserNotFound)
+				repo.EXPECT().UpdatePhone(gomock.Any(), int64(1), "15212345678").Return(nil)
+				return repo
+			},
+		},
+		{
+			name: "已经绑定在自己身上",
+			mock: func(ctrl *gomock.Controller) repository.UserRepository {
+				repo := repomocks.NewMockUserRepository(ctrl)
+				repo.EXPECT().FindByPhone(gomock.Any(), "15212345678").
+					Return(domain.User{Id: 1}, nil)
+				return repo
+			},
+		},
+		{
+			name: "被别人绑定了",
+			mock: func(ctrl *gomock.Controller) repository.UserRepository {
+				repo := repomocks.NewMockUserRepository(ctrl)
+				repo.EXPECT().FindByPhone(gomock.Any(), "15212345678").
+					Return(domain.User{Id: 2}, nil)
+				return repo
+			},
+			wantErr: &ConflictError{Uid: 2},
+		},
+		{
+			name: "并发被别人抢先绑定",
+			mock: func(ctrl *gomock.Controller) repository.UserRepository {
+				repo := repomocks.NewMockUserRepository(ctrl)
+				repo.EXPECT().FindByPhone(gomock.Any(), "15212345678").
+					Return(domain.User{}, repository.ErrUserNotFound)
+				repo.EXPECT().UpdatePhone(gomock.Any(), int64(1), "15212345678").
+					Return(repository.ErrUserDuplicateEmail)
+				repo.EXPECT().FindByPhone(gomock.Any(), "15212345678").
+					Return(domain.User{Id: 3}, nil)
+				return repo
+			},
+			wantErr: &ConflictError{Uid: 3},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := NewAccountService(tc.mock(ctrl), nil, nil, nil)
+			err := svc.BindPhone(context.Background(), 1, "15212345678")
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func Test_accountService_Unbind(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) repository.UserRepository
+		unbind  func(svc AccountService) error
+		wantErr error
+	}{
+		{
+			name: "解绑手机号，还有第三方账号",
+			mock: func(ctrl *gomock.Controller) repository.UserRepository {
+				repo := repomocks.NewMockUserRepository(ctrl)
+				repo.EXPECT().FindById(gomock.Any(), int64(1)).Return(domain.User{Id: 1, Phone: "15212345678"}, nil)
+				repo.EXPECT().FindIdentities(gomock.Any(), int64(1)).
+					Return([]domain.Identity{{Provider: "wechat"}}, nil)
+				repo.EXPECT().UpdatePhone(gomock.Any(), int64(1), "").Return(nil)
+				return repo
+			},
+			unbind: func(svc AccountService) error {
+				return svc.UnbindPhone(context.Background(), 1)
+			},
+		},
+		{
+			name: "解绑最后一个手机号",
+			mock: func(ctrl *gomock.Controller) repository.UserRepository {
+				repo := repomocks.NewMockUserRepository(ctrl)
+				// 邮箱没有密码不能登录
+				repo.EXPECT().FindById(gomock.Any(), int64(1)).
+					Return(domain.User{Id: 1, Phone: "15212345678", Email: "a@qq.com"}, nil)
+				repo.EXPECT().FindIdentities(gomock.Any(), int64(1)).Return(nil, nil)
+				return repo
+			},
+			unbind: func(svc AccountService) error {
+				return svc.UnbindPhone(context.Background(), 1)
+			},
+			wantErr: ErrLastLoginMethod,
+		},
+		{
+			name: "解绑最后一个第三方账号",
+			mock: func(ctrl *gomock.Controller) repository.UserRepository {
+				repo := repomocks.NewMockUserRepository(ctrl)
+				repo.EXPECT().FindById(gomock.Any(), int64(1)).Return(domain.User{Id: 1}, nil)
+				repo.EXPECT().FindIdentities(gomock.Any(), int64(1)).
+					Return([]domain.Identity{{Provider: "wechat"}}, nil)
+				return repo
+			},
+			unbind: func(svc AccountService) error {
+				return svc.UnbindIdentity(context.Background(), 1, "wechat")
+			},
+			wantErr: ErrLastLoginMethod,
+		},
+		{
+			name: "解绑第三方账号，还有邮箱",
+			mock: func(ctrl *gomock.Controller) repository.UserRepository {
+				repo := repomocks.NewMockUserRepository(ctrl)
+				repo.EXPECT().FindById(gomock.Any(), int64(1)).
+					Return(domain.User{Id: 1, Email: "a@qq.com", Password: "hash"}, nil)
+				repo.EXPECT().FindIdentities(gomock.Any(), int64(1)).
+					Return([]domain.Identity{{Provider: "wechat"}, {Provider: "github"}}, nil)
+				repo.EXPECT().DeleteIdentity(gomock.Any(), int64(1), "wechat").Return(nil)
+				return repo
+			},
+			unbind: func(svc AccountService) error {
+				return svc.UnbindIdentity(context.Background(), 1, "wechat")
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := NewAccountService(tc.mock(ctrl), nil, nil, nil)
+			err := tc.unbind(svc)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func Test_accountService_BindIdentity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := repomocks.NewMockUserRepository(ctrl)
+	repo.EXPECT().FindByIdentity(gomock.Any(), "wechat", "openid-2").
+		Return(domain.User{}, repository.ErrUserNotFound)
+	repo.EXPECT().FindIdentities(gomock.Any(), int64(1)).
+		Return([]domain.Identity{{Uid: 1, Provider: "wechat", ExternalId: "openid-1"}}, nil)
+	svc := NewAccountService(repo, nil, nil, nil)
+	err := svc.BindIdentity(context.Background(), 1, domain.Identity{Provider: "wechat", ExternalId: "openid-2"})
+	assert.Equal(t, ErrProviderBound, err)
+}
+
+type intrClientStub struct {
+	intrv1.InteractiveServiceClient
+	err error
+}
+
+func (s intrClientStub) MergeUser(ctx context.Context, in *intrv1.MergeUserRequest, opts ...grpc.CallOption) (*intrv1.MergeUserResponse, error) {
+	return &intrv1.MergeUserResponse{}, s.err
+}
+
+type followClientStub struct {
+	followv1.FollowServiceClient
+	err error
+}
+
+func (s followClientStub) MergeUser(ctx context.Context, in *followv1.MergeUserRequest, opts ...grpc.CallOption) (*followv1.MergeUserResponse, error) {
+	return &followv1.MergeUserResponse{}, s.err
+}
+
+func Test_accountService_Merge(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) (repository.UserRepository, ArticleService)
+		intrErr error
+		wantErr error
+	}{
+		{
+			name: "合并成功",
+			mock: func(ctrl *gomock.Controller) (repository.UserRepository, ArticleService) {
+				repo := repomocks.NewMockUserRepository(ctrl)
+				repo.EXPECT().FindById(gomock.Any(), int64(1)).Return(domain.User{Id: 1, Phone: "15212345678"}, nil)
+				repo.EXPECT().FindIdentities(gomock.Any(), int64(1)).Return(nil, nil)
+				repo.EXPECT().FindById(gomock.Any(), int64(2)).Return(domain.User{Id: 2, Email: "a@qq.com"}, nil)
+				repo.EXPECT().FindIdentities(gomock.Any(), int64(2)).
+					Return([]domain.Identity{{Provider: "wechat"}}, nil)
+				repo.EXPECT().Merge(gomock.Any(), int64(2), int64(1)).Return(nil)
+				artSvc := svcmocks.NewMockArticleService(ctrl)
+				artSvc.EXPECT().TransferAuthor(gomock.Any(), int64(2), int64(1)).Return(nil)
+				return repo, artSvc
+			},
+		},
+		{
+			name: "两个账号都有手机号",
+			mock: func(ctrl *gomock.Controller) (repository.UserRepository, ArticleService) {
+				repo := repomocks.NewMockUserRepository(ctrl)
+				repo.EXPECT().FindById(gomock.Any(), int64(1)).Return(domain.User{Id: 1, Phone: "15212345678"}, nil)
+				repo.EXPECT().FindIdentities(gomock.Any(), int64(1)).Return(nil, nil)
+				repo.EXPECT().FindById(gomock.Any(), int64(2)).Return(domain.User{Id: 2, Phone: "15287654321"}, nil)
+				repo.EXPECT().FindIdentities(gomock.Any(), int64(2)).Return(nil, nil)
+				return repo, svcmocks.NewMockArticleService(ctrl)
+			},
+			wantErr: ErrMergeConflict,
+		},
+		{
+			name: "两个账号绑定了同一个第三方",
+			mock: func(ctrl *gomock.Controller) (repository.UserRepository, ArticleService) {
+				repo := repomocks.NewMockUserRepository(ctrl)
+				repo.EXPECT().FindById(gomock.Any(), int64(1)).Return(domain.User{Id: 1}, nil)
+				repo.EXPECT().FindIdentities(gomock.Any(), int64(1)).
+					Return([]domain.Identity{{Provider: "github", ExternalId: "1"}}, nil)
+				repo.EXPECT().FindById(gomock.Any(), int64(2)).Return(domain.User{Id: 2}, nil)
+				repo.EXPECT().FindIdentities(gomock.Any(), int64(2)).
+					Return([]domain.Identity{{Provider: "github", ExternalId: "2"}}, nil)
+				return repo, svcmocks.NewMockArticleService(ctrl)
+			},
+			wantErr: ErrMergeConflict,
+		},
+		{
+			name: "合并点赞收藏失败",
+			mock: func(ctrl *gomock.Controller) (repository.UserRepository, ArticleService) {
+				repo := repomocks.NewMockUserRepository(ctrl)
+				repo.EXPECT().FindById(gomock.Any(), gomock.Any()).Return(domain.User{}, nil).Times(2)
+				repo.EXPECT().FindIdentities(gomock.Any(), gomock.Any()).Return(nil, nil).Times(2)
+				repo.EXPECT().Merge(gomock.Any(), int64(2), int64(1)).Return(nil)
+				artSvc := svcmocks.NewMockArticleService(ctrl)
+				artSvc.EXPECT().TransferAuthor(gomock.Any(), int64(2), int64(1)).Return(nil)
+				return repo, artSvc
+			},
+			intrErr: errors.New("mock error"),
+			wantErr: errors.New("mock error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, artSvc := tc.mock(ctrl)
+			svc := NewAccountService(repo, artSvc, intrClientStub{err: tc.intrErr}, followClientStub{})
+			err := svc.Merge(context.Background(), 1, 2)
+			if tc.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tc.wantErr.Error())
+		})
+	}
+}
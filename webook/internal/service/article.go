@@ -15,26 +15,50 @@ type ArticleService interface {
 	Save(ctx context.Context, art domain.Article) (int64, error)
 	Publish(ctx context.Context, art domain.Article) (int64, error)
 	PublishV1(ctx context.Context, art domain.Article) (int64, error)
+	// Schedule 保存草稿，并且在 publishTime 的时候自动发表
+	Schedule(ctx context.Context, art domain.Article, publishTime time.Time) (int64, error)
+	// CancelSchedule 取消还没有执行的定时发表，文章回到未发表状态
+	CancelSchedule(ctx context.Context, id, uid int64) error
+	// PublishScheduled 由定时任务调用，文章已经不是定时发表状态的时候什么也不做
+	PublishScheduled(ctx context.Context, id, uid int64) error
 	Withdraw(ctx *gin.Context, art domain.Article) error
 	List(ctx *gin.Context, uid int64, limit int, offset int) ([]domain.Article, error)
 	ListPub(ctx context.Context, start time.Time, offset int, limit int) ([]domain.Article, error)
 	GetById(ctx *gin.Context, id int64) (domain.Article, error)
 	GetPubById(ctx context.Context, id, uid int64) (domain.Article, error)
+	// GetPubByIds 按照 ids 的顺序返回已发表的文章，查不到的跳过。不算阅读
+	GetPubByIds(ctx context.Context, ids []int64) ([]domain.Article, error)
+	// ListHistory 作者查看自己文章的历史版本
+	ListHistory(ctx context.Context, id, uid int64, offset int, limit int) ([]domain.ArticleHistory, error)
+	// DiffHistory 按行比较两个历史版本
+	DiffHistory(ctx context.Context, id, uid int64, from, to int64) (domain.ArticleDiff, error)
+	// RestoreHistory 把历史版本恢复到制作库，线上的文章不受影响，返回新的版本号
+	RestoreHistory(ctx context.Context, id, uid int64, version int64) (int64, error)
+	// ListPubByTag 按照文章 id 倒序返回带有这个标签的已发表文章，cursor 是上一页最后一篇文章的 id
+	ListPubByTag(ctx context.Context, tag string, cursor int64, limit int) ([]domain.Article, error)
+	// ListPubByAuthor 作者主页，按照 (utime, id) 倒序翻页，第一页 maxUtime 传当前时间
+	ListPubByAuthor(ctx context.Context, uid int64, maxUtime time.Time, maxId int64, limit int) ([]domain.Article, error)
+	// SearchTags 标签补全
+	SearchTags(ctx context.Context, prefix string, limit int) ([]string, error)
+	// TransferAuthor 合并账号的时候把 srcUid 的文章全部转给 dstUid
+	TransferAuthor(ctx context.Context, srcUid int64, dstUid int64) error
 }
 
 type articleService struct {
 	repo     article.ArticleRepository
 	producer events.Producer
+	jobSvc   CronJobService
 
 	// v1
 	authorRepo article.ArticleAuthorRepository
 	readerRepo article.ArticleReaderRepository
 }
 
-func NewArticleService(repo article.ArticleRepository, producer events.Producer) ArticleService {
+func NewArticleService(repo article.ArticleRepository, producer events.Producer, jobSvc CronJobService) ArticleService {
 	return &articleService{
 		repo:     repo,
 		producer: producer,
+		jobSvc:   jobSvc,
 	}
 }
 
@@ -42,14 +66,45 @@ func (a *articleService) ListPub(ctx context.Context, start time.Time, offset in
 	return a.repo.ListPub(ctx, start, offset, limit)
 }
 
+func (a *articleService) ListPubByAuthor(ctx context.Context, uid int64, maxUtime time.Time, maxId int64, limit int) ([]domain.Article, error) {
+	return a.repo.ListPubByAuthor(ctx, uid, maxUtime, maxId, limit)
+}
+
+func (a *articleService) GetPubByIds(ctx context.Context, ids []int64) ([]domain.Article, error) {
+	if len(ids) == 0 {
+		return []domain.Article{}, nil
+	}
+	arts, err := a.repo.GetPubByIds(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	artMap := make(map[int64]domain.Article, len(arts))
+	for _, art := range arts {
+		artMap[art.Id] = art
+	}
+	res := make([]domain.Article, 0, len(arts))
+	for _, id := range ids {
+		if art, ok := artMap[id]; ok {
+			res = append(res, art)
+		}
+	}
+	return res, nil
+}
+
 func (a *articleService) GetPubById(ctx context.Context, id, uid int64) (domain.Article, error) {
 	art, err := a.repo.GetPubById(ctx, id)
 	if err == nil {
 		go func() {
-			a.producer.ProduceReadEvent(ctx, events.ReadEvent{
+			// 请求结束之后 ctx 就被取消了，不能直接用
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			er := a.producer.ProduceReadEvent(ctx, events.ReadEvent{
 				Uid: uid,
 				Aid: id,
 			})
+			if er != nil {
+				zap.L().Error("发送文章阅读消息失败", zap.Error(er), zap.Int64("aid", id))
+			}
 		}()
 	}
 	return art, err
@@ -64,11 +119,77 @@ func (a *articleService) List(ctx *gin.Context, uid int64, limit int, offset int
 }
 
 func (a *articleService) Withdraw(ctx *gin.Context, art domain.Article) error {
-	return a.repo.SyncStatus(ctx, art.Id, art.Author.Id, domain.ArticleStatusPrivate)
+	return a.repo.SyncStatus(ctx, art.Id, art.Author.Id, domain.ArticleStatusPrivate,
+		func(aid int64) ([]domain.OutboxMessage, error) {
+			msg, err := events.NewOutboxMessage(events.TopicWithdrawnArticle, aid, events.ArticleWithdrawn{
+				Version: events.ArticleEventVersion,
+				Aid:     aid,
+				Uid:     art.Author.Id,
+				Utime:   time.Now().UnixMilli(),
+			})
+			return []domain.OutboxMessage{msg}, err
+		})
 }
 func (a *articleService) Publish(ctx context.Context, art domain.Article) (int64, error) {
+	art, err := normalizeTags(art)
+	if err != nil {
+		return 0, err
+	}
 	art.Status = domain.ArticleStatusPublished
-	return a.repo.Sync(ctx, art)
+	updated := a.isPublished(ctx, art.Id)
+	// 发表消息和文章在同一个事务里面写入 outbox，由 relay 任务投递
+	return a.repo.Sync(ctx, art, func(aid int64) ([]domain.OutboxMessage, error) {
+		var (
+			msg domain.OutboxMessage
+			err error
+			now = time.Now().UnixMilli()
+		)
+		if updated {
+			msg, err = events.NewOutboxMessage(events.TopicUpdatedArticle, aid, events.ArticleUpdated{
+				Version: events.ArticleEventVersion,
+				Aid:     aid,
+				Uid:     art.Author.Id,
+				Title:   art.Title,
+				Content: art.Content,
+				Utime:   now,
+			})
+		} else {
+			msg, err = events.NewOutboxMessage(events.TopicPublishedArticle, aid, events.ArticlePublished{
+				Version: events.ArticleEventVersion,
+				Aid:     aid,
+				Uid:     art.Author.Id,
+				Title:   art.Title,
+				Content: art.Content,
+				Utime:   now,
+			})
+		}
+		return []domain.OutboxMessage{msg}, err
+	})
+}
+
+func (a *articleService) TransferAuthor(ctx context.Context, srcUid int64, dstUid int64) error {
+	// 已发表的文章作者变了，搜索之类的下游要重新索引
+	return a.repo.TransferAuthor(ctx, srcUid, dstUid, func(art domain.Article) ([]domain.OutboxMessage, error) {
+		msg, err := events.NewOutboxMessage(events.TopicUpdatedArticle, art.Id, events.ArticleUpdated{
+			Version: events.ArticleEventVersion,
+			Aid:     art.Id,
+			Uid:     art.Author.Id,
+			Title:   art.Title,
+			Content: art.Content,
+			Utime:   time.Now().UnixMilli(),
+		})
+		return []domain.OutboxMessage{msg}, err
+	})
+}
+
+// isPublished 文章当前是否在线上可见，用来区分第一次发表和修改。
+// 查询失败的时候当作第一次发表，下游需要能够处理重复的发表事件
+func (a *articleService) isPublished(ctx context.Context, id int64) bool {
+	if id <= 0 {
+		return false
+	}
+	art, err := a.repo.GetPubById(ctx, id)
+	return err == nil && art.Status == domain.ArticleStatusPublished
 }
 
 func (a *articleService) PublishV1(ctx context.Context, art domain.Article) (int64, error) {
@@ -98,9 +219,13 @@ func (a *articleService) PublishV1(ctx context.Context, art domain.Article) (int
 }
 
 func (a *articleService) Save(ctx context.Context, art domain.Article) (int64, error) {
+	art, err := normalizeTags(art)
+	if err != nil {
+		return 0, err
+	}
 	art.Status = domain.ArticleStatusUnpublished
 	if art.Id > 0 {
-		err := a.repo.Update(ctx, art)
+		err = a.repo.Update(ctx, art)
 		return art.Id, err
 	}
 	return a.repo.Create(ctx, art)
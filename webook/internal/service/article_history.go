@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"github.com/basic-go-project-webook/webook/internal/domain"
+	"github.com/basic-go-project-webook/webook/internal/repository/article"
+	"golang.org/x/sync/errgroup"
+	"strings"
+)
+
+var ErrArticleHistoryNotFound = article.ErrHistoryNotFound
+
+// maxDiffCells 按行比较的时候 DP 表的最大规模，超过之后退化成整段删除再整段插入。
+// 每一格 4 个字节，最多占用 1MB 内存，去掉相同的开头和结尾之后大概是两边各 500 行的修改
+const maxDiffCells = 1 << 18
+
+func (a *articleService) ListHistory(ctx context.Context, id, uid int64, offset int, limit int) ([]domain.ArticleHistory, error) {
+	return a.repo.ListHistory(ctx, id, uid, offset, limit)
+}
+
+func (a *articleService) DiffHistory(ctx context.Context, id, uid int64, from, to int64) (domain.ArticleDiff, error) {
+	var (
+		eg       errgroup.Group
+		old, cur domain.ArticleHistory
+	)
+	eg.Go(func() error {
+		var er error
+		old, er = a.repo.GetHistory(ctx, id, uid, from)
+		return er
+	})
+	eg.Go(func() error {
+		var er error
+		cur, er = a.repo.GetHistory(ctx, id, uid, to)
+		return er
+	})
+	err := eg.Wait()
+	if err != nil {
+		return domain.ArticleDiff{}, err
+	}
+	return domain.ArticleDiff{
+		ArticleId: id,
+		From:      from,
+		To:        to,
+		Title:     diffLines(splitLines(old.Title), splitLines(cur.Title)),
+		Content:   diffLines(splitLines(old.Content), splitLines(cur.Content)),
+	}, nil
+}
+
+func (a *articleService) RestoreHistory(ctx context.Context, id, uid int64, version int64) (int64, error) {
+	return a.repo.Restore(ctx, id, uid, version)
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
+
+// diffLines 用最长公共子序列计算从 a 到 b 的按行差异
+func diffLines(a, b []string) []domain.DiffLine {
+	// 先去掉相同的开头和结尾，大部分修改只涉及中间的几行
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix &&
+		a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	res := make([]domain.DiffLine, 0, len(a)+len(b))
+	for _, line := range a[:prefix] {
+		res = append(res, domain.DiffLine{Op: domain.DiffOpEqual, Text: line})
+	}
+	res = append(res, diffMiddle(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, line := range a[len(a)-suffix:] {
+		res = append(res, domain.DiffLine{Op: domain.DiffOpEqual, Text: line})
+	}
+	return res
+}
+
+func diffMiddle(a, b []string) []domain.DiffLine {
+	res := make([]domain.DiffLine, 0, len(a)+len(b))
+	if (len(a)+1)*(len(b)+1) > maxDiffCells {
+		for _, line := range a {
+			res = append(res, domain.DiffLine{Op: domain.DiffOpDelete, Text: line})
+		}
+		for _, line := range b {
+			res = append(res, domain.DiffLine{Op: domain.DiffOpInsert, Text: line})
+		}
+		return res
+	}
+	// lcs[i*w+j] 是 a[i:] 和 b[j:] 的最长公共子序列长度
+	w := len(b) + 1
+	lcs := make([]int32, (len(a)+1)*w)
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i*w+j] = lcs[(i+1)*w+j+1] + 1
+			} else {
+				lcs[i*w+j] = max(lcs[(i+1)*w+j], lcs[i*w+j+1])
+			}
+		}
+	}
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			res = append(res, domain.DiffLine{Op: domain.DiffOpEqual, Text: a[i]})
+			i++
+			j++
+		case lcs[(i+1)*w+j] >= lcs[i*w+j+1]:
+			res = append(res, domain.DiffLine{Op: domain.DiffOpDelete, Text: a[i]})
+			i++
+		default:
+			res = append(res, domain.DiffLine{Op: domain.DiffOpInsert, Text: b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		res = append(res, domain.DiffLine{Op: domain.DiffOpDelete, Text: a[i]})
+	}
+	for ; j < len(b); j++ {
+		res = append(res, domain.DiffLine{Op: domain.DiffOpInsert, Text: b[j]})
+	}
+	return res
+}
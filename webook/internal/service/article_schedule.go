@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/basic-go-project-webook/webook/internal/domain"
+	"go.uber.org/zap"
+	"time"
+)
+
+// ArticlePublishExecutor 定时发表任务的执行器名字
+const ArticlePublishExecutor = "publish-article"
+
+// maxScheduleAhead 最多可以提前多久设置定时发表
+const maxScheduleAhead = time.Hour * 24 * 30
+
+var (
+	ErrInvalidPublishTime  = errors.New("定时发表的时间不合法")
+	ErrArticleNotScheduled = errors.New("文章不是定时发表状态")
+)
+
+// ArticlePublishJobCfg 定时发表任务的配置，序列化之后放在 domain.Job 的 Cfg 里面
+type ArticlePublishJobCfg struct {
+	Aid int64 `json:"aid"`
+	Uid int64 `json:"uid"`
+}
+
+func (a *articleService) Schedule(ctx context.Context, art domain.Article, publishTime time.Time) (int64, error) {
+	now := time.Now()
+	if !publishTime.After(now) || publishTime.After(now.Add(maxScheduleAhead)) {
+		return 0, ErrInvalidPublishTime
+	}
+	art, err := normalizeTags(art)
+	if err != nil {
+		return 0, err
+	}
+	art.Status = domain.ArticleStatusScheduled
+	if art.Id > 0 {
+		err = a.repo.Update(ctx, art)
+	} else {
+		art.Id, err = a.repo.Create(ctx, art)
+	}
+	if err != nil {
+		return 0, err
+	}
+	cfg, err := json.Marshal(ArticlePublishJobCfg{
+		Aid: art.Id,
+		Uid: art.Author.Id,
+	})
+	if err == nil {
+		// 同一篇文章只有一个任务，重新设置时间会覆盖之前的任务
+		err = a.jobSvc.AddJob(ctx, domain.Job{
+			Name:     articlePublishJobName(art.Id),
+			Executor: ArticlePublishExecutor,
+			Cfg:      string(cfg),
+		}, publishTime)
+	}
+	if err != nil {
+		// 任务没有建起来，文章不能一直停在定时发表状态，改回未发表。
+		// 之前设置过的任务如果还在，执行的时候也会因为状态不对跳过
+		_, er := a.repo.CompareAndSetStatus(ctx, art.Id, art.Author.Id,
+			domain.ArticleStatusScheduled, domain.ArticleStatusUnpublished)
+		if er != nil {
+			zap.L().Error("恢复定时发表前的状态失败", zap.Error(er), zap.Int64("aid", art.Id))
+		}
+		return 0, err
+	}
+	return art.Id, nil
+}
+
+func (a *articleService) CancelSchedule(ctx context.Context, id, uid int64) error {
+	ok, err := a.repo.CompareAndSetStatus(ctx, id, uid,
+		domain.ArticleStatusScheduled, domain.ArticleStatusUnpublished)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrArticleNotScheduled
+	}
+	// 状态已经改掉了，就算任务没能停掉，执行的时候也会跳过
+	err = a.jobSvc.StopJob(ctx, articlePublishJobName(id))
+	if err != nil {
+		zap.L().Warn("停止定时发表任务失败", zap.Error(err), zap.Int64("aid", id))
+	}
+	return nil
+}
+
+func (a *articleService) PublishScheduled(ctx context.Context, id, uid int64) error {
+	// 先抢占状态，和取消、重复执行互斥
+	ok, err := a.repo.CompareAndSetStatus(ctx, id, uid,
+		domain.ArticleStatusScheduled, domain.ArticleStatusPublished)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		zap.L().Info("文章已经不是定时发表状态，跳过", zap.Int64("aid", id))
+		return nil
+	}
+	art, err := a.repo.GetById(ctx, id)
+	if err == nil {
+		_, err = a.Publish(ctx, art)
+	}
+	if err != nil {
+		// 改回去，下一次调度的时候重试
+		_, er := a.repo.CompareAndSetStatus(ctx, id, uid,
+			domain.ArticleStatusPublished, domain.ArticleStatusScheduled)
+		if er != nil {
+			zap.L().Error("恢复定时发表状态失败", zap.Error(er), zap.Int64("aid", id))
+		}
+		return err
+	}
+	return nil
+}
+
+func articlePublishJobName(aid int64) string {
+	return fmt.Sprintf("publish-article-%d", aid)
+}
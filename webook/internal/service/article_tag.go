@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/basic-go-project-webook/webook/internal/domain"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	maxArticleTags    = 10
+	maxTagLength      = 32
+	maxCategoryLength = 32
+)
+
+var ErrInvalidArticleTags = errors.New("文章的标签或者分类不合法")
+
+func (a *articleService) ListPubByTag(ctx context.Context, tag string, cursor int64, limit int) ([]domain.Article, error) {
+	return a.repo.ListPubByTag(ctx, strings.TrimSpace(tag), cursor, limit)
+}
+
+func (a *articleService) SearchTags(ctx context.Context, prefix string, limit int) ([]string, error) {
+	prefix = strings.TrimSpace(prefix)
+	if prefix == "" {
+		return []string{}, nil
+	}
+	return a.repo.SearchTags(ctx, prefix, limit)
+}
+
+// normalizeTags 去掉标签两边的空白和重复的标签，并且校验标签和分类的长度。
+// 数据库里面标签名字不区分大小写，所以只差大小写的标签也算重复，保留第一个
+func normalizeTags(art domain.Article) (domain.Article, error) {
+	art.Category = strings.TrimSpace(art.Category)
+	if utf8.RuneCountInString(art.Category) > maxCategoryLength {
+		return art, ErrInvalidArticleTags
+	}
+	tags := make([]string, 0, len(art.Tags))
+	seen := make(map[string]struct{}, len(art.Tags))
+	for _, tag := range art.Tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			continue
+		}
+		if utf8.RuneCountInString(tag) > maxTagLength {
+			return art, ErrInvalidArticleTags
+		}
+		key := strings.ToLower(tag)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		tags = append(tags, tag)
+	}
+	if len(tags) > maxArticleTags {
+		return art, ErrInvalidArticleTags
+	}
+	art.Tags = tags
+	return art, nil
+}
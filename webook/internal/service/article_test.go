@@ -2,28 +2,53 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"github.com/basic-go-project-webook/webook/internal/domain"
+	events "github.com/basic-go-project-webook/webook/internal/events/article"
+	evtmocks "github.com/basic-go-project-webook/webook/internal/events/article/mocks"
 	"github.com/basic-go-project-webook/webook/internal/repository/article"
 	repomocks "github.com/basic-go-project-webook/webook/internal/repository/mocks"
+	svcmocks "github.com/basic-go-project-webook/webook/internal/service/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 	"testing"
+	"time"
 )
 
 func Test_articleService_Publish(t *testing.T) {
 	testCases := []struct {
 		name    string
-		mock    func(ctrl *gomock.Controller) article.ArticleRepository
+		mock    func(t *testing.T, ctrl *gomock.Controller) (article.ArticleRepository, events.Producer)
 		art     domain.Article
 		wantId  int64
 		wantErr error
 	}{
 		{
 			name: "发表成功",
-			mock: func(ctrl *gomock.Controller) article.ArticleRepository {
+			mock: func(t *testing.T, ctrl *gomock.Controller) (article.ArticleRepository, events.Producer) {
 				repo := repomocks.NewMockArticleRepository(ctrl)
-				repo.EXPECT().Sync(gomock.Any(), gomock.Any()).Return(int64(123), nil)
-				return repo
+				repo.EXPECT().Sync(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, art domain.Article, outbox domain.OutboxMessageFunc) (int64, error) {
+						// 发表消息要和文章在同一个事务里面写入 outbox
+						msgs, err := outbox(123)
+						if err != nil {
+							return 0, err
+						}
+						assert.Len(t, msgs, 1)
+						assert.Equal(t, events.TopicPublishedArticle, msgs[0].Topic)
+						var evt events.ArticlePublished
+						err = json.Unmarshal(msgs[0].Payload, &evt)
+						assert.NoError(t, err)
+						assert.Equal(t, events.ArticleEventVersion, evt.Version)
+						assert.Equal(t, int64(123), evt.Aid)
+						assert.Equal(t, int64(123), evt.Uid)
+						return 123, nil
+					})
+				producer := evtmocks.NewMockProducer(ctrl)
+				return repo, producer
 			},
 			art: domain.Article{
 				Title:   "我的标题",
@@ -41,11 +66,262 @@ func Test_articleService_Publish(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
-			repo := tc.mock(ctrl)
-			svc := NewArticleService(repo, nil)
+			repo, producer := tc.mock(t, ctrl)
+			svc := NewArticleService(repo, producer, nil)
 			artId, err := svc.Publish(context.Background(), tc.art)
 			assert.Equal(t, tc.wantErr, err)
 			assert.Equal(t, tc.wantId, artId)
 		})
 	}
 }
+
+func Test_articleService_DiffHistory(t *testing.T) {
+	testCases := []struct {
+		name     string
+		mock     func(ctrl *gomock.Controller) article.ArticleRepository
+		wantDiff domain.ArticleDiff
+		wantErr  error
+	}{
+		{
+			name: "按行比较",
+			mock: func(ctrl *gomock.Controller) article.ArticleRepository {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().GetHistory(gomock.Any(), int64(1), int64(123), int64(1)).
+					Return(domain.ArticleHistory{
+						Version: 1,
+						Title:   "我的标题",
+						Content: "第一行\n第二行\n第三行",
+					}, nil)
+				repo.EXPECT().GetHistory(gomock.Any(), int64(1), int64(123), int64(2)).
+					Return(domain.ArticleHistory{
+						Version: 2,
+						Title:   "我的标题",
+						Content: "第一行\n新的第二行\n第三行\n第四行",
+					}, nil)
+				return repo
+			},
+			wantDiff: domain.ArticleDiff{
+				ArticleId: 1,
+				From:      1,
+				To:        2,
+				Title: []domain.DiffLine{
+					{Op: domain.DiffOpEqual, Text: "我的标题"},
+				},
+				Content: []domain.DiffLine{
+					{Op: domain.DiffOpEqual, Text: "第一行"},
+					{Op: domain.DiffOpDelete, Text: "第二行"},
+					{Op: domain.DiffOpInsert, Text: "新的第二行"},
+					{Op: domain.DiffOpEqual, Text: "第三行"},
+					{Op: domain.DiffOpInsert, Text: "第四行"},
+				},
+			},
+		},
+		{
+			name: "版本不存在",
+			mock: func(ctrl *gomock.Controller) article.ArticleRepository {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().GetHistory(gomock.Any(), int64(1), int64(123), int64(1)).
+					Return(domain.ArticleHistory{}, article.ErrHistoryNotFound)
+				repo.EXPECT().GetHistory(gomock.Any(), int64(1), int64(123), int64(2)).
+					Return(domain.ArticleHistory{Version: 2}, nil)
+				return repo
+			},
+			wantErr: article.ErrHistoryNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := NewArticleService(tc.mock(ctrl), nil, nil)
+			diff, err := svc.DiffHistory(context.Background(), 1, 123, 1, 2)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantDiff, diff)
+		})
+	}
+}
+
+func Test_diffLines_TooLarge(t *testing.T) {
+	a := make([]string, 0, 600)
+	b := make([]string, 0, 600)
+	for i := 0; i < 600; i++ {
+		a = append(a, fmt.Sprintf("旧的第 %d 行", i))
+		b = append(b, fmt.Sprintf("新的第 %d 行", i))
+	}
+	a = append(a, "相同的结尾")
+	b = append(b, "相同的结尾")
+	// 中间的修改超过了 DP 表的上限，整段删除再整段插入
+	res := diffLines(a, b)
+	require.Len(t, res, 1201)
+	for i := 0; i < 600; i++ {
+		assert.Equal(t, domain.DiffLine{Op: domain.DiffOpDelete, Text: a[i]}, res[i])
+		assert.Equal(t, domain.DiffLine{Op: domain.DiffOpInsert, Text: b[i]}, res[600+i])
+	}
+	assert.Equal(t, domain.DiffLine{Op: domain.DiffOpEqual, Text: "相同的结尾"}, res[1200])
+}
+
+func Test_articleService_PublishScheduled(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) article.ArticleRepository
+		wantErr error
+	}{
+		{
+			name: "已经取消，跳过",
+			mock: func(ctrl *gomock.Controller) article.ArticleRepository {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().CompareAndSetStatus(gomock.Any(), int64(1), int64(123),
+					domain.ArticleStatusScheduled, domain.ArticleStatusPublished).Return(false, nil)
+				return repo
+			},
+		},
+		{
+			name: "发表失败，恢复定时发表状态",
+			mock: func(ctrl *gomock.Controller) article.ArticleRepository {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().CompareAndSetStatus(gomock.Any(), int64(1), int64(123),
+					domain.ArticleStatusScheduled, domain.ArticleStatusPublished).Return(true, nil)
+				repo.EXPECT().GetById(gomock.Any(), int64(1)).
+					Return(domain.Article{}, errors.New("db 错误"))
+				repo.EXPECT().CompareAndSetStatus(gomock.Any(), int64(1), int64(123),
+					domain.ArticleStatusPublished, domain.ArticleStatusScheduled).Return(true, nil)
+				return repo
+			},
+			wantErr: errors.New("db 错误"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := NewArticleService(tc.mock(ctrl), nil, nil)
+			err := svc.PublishScheduled(context.Background(), 1, 123)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func Test_articleService_ScheduleInvalidTime(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	svc := NewArticleService(repomocks.NewMockArticleRepository(ctrl), nil, nil)
+	_, err := svc.Schedule(context.Background(), domain.Article{}, time.Now().Add(-time.Minute))
+	assert.Equal(t, ErrInvalidPublishTime, err)
+	_, err = svc.Schedule(context.Background(), domain.Article{}, time.Now().Add(time.Hour*24*31))
+	assert.Equal(t, ErrInvalidPublishTime, err)
+}
+
+func Test_normalizeTags(t *testing.T) {
+	testCases := []struct {
+		name     string
+		art      domain.Article
+		wantTags []string
+		wantErr  error
+	}{
+		{
+			name: "去掉空白和重复",
+			art: domain.Article{
+				Category: " 后端 ",
+				Tags:     []string{" Go ", "", "Go", "MySQL"},
+			},
+			wantTags: []string{"Go", "MySQL"},
+		},
+		{
+			name: "只差大小写也算重复",
+			art: domain.Article{
+				Category: "后端",
+				Tags:     []string{"Go", "go ", "GO", "mysql", "MySQL"},
+			},
+			wantTags: []string{"Go", "mysql"},
+		},
+		{
+			name: "标签太多",
+			art: domain.Article{
+				Tags: []string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11"},
+			},
+			wantErr: ErrInvalidArticleTags,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			art, err := normalizeTags(tc.art)
+			assert.Equal(t, tc.wantErr, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tc.wantTags, art.Tags)
+			assert.Equal(t, "后端", art.Category)
+		})
+	}
+}
+
+func Test_articleService_GetPubByIds(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := repomocks.NewMockArticleRepository(ctrl)
+	// 数据库返回的顺序和 ids 不一样，3 已经撤回了查不到
+	repo.EXPECT().GetPubByIds(gomock.Any(), []int64{3, 1, 2}).Return([]domain.Article{
+		{Id: 1}, {Id: 2},
+	}, nil)
+	svc := NewArticleService(repo, nil, nil)
+	arts, err := svc.GetPubByIds(context.Background(), []int64{3, 1, 2})
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Article{{Id: 1}, {Id: 2}}, arts)
+}
+
+func Test_articleService_Schedule(t *testing.T) {
+	publishTime := time.Now().Add(time.Hour)
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) (article.ArticleRepository, CronJobService)
+		art     domain.Article
+		wantId  int64
+		wantErr error
+	}{
+		{
+			name: "设置成功",
+			mock: func(ctrl *gomock.Controller) (article.ArticleRepository, CronJobService) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				jobSvc := svcmocks.NewMockCronJobService(ctrl)
+				repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(int64(1), nil)
+				jobSvc.EXPECT().AddJob(gomock.Any(), domain.Job{
+					Name:     "publish-article-1",
+					Executor: ArticlePublishExecutor,
+					Cfg:      `{"aid":1,"uid":123}`,
+				}, publishTime).Return(nil)
+				return repo, jobSvc
+			},
+			art:    domain.Article{Title: "标题", Author: domain.Author{Id: 123}},
+			wantId: 1,
+		},
+		{
+			name: "创建任务失败，改回未发表",
+			mock: func(ctrl *gomock.Controller) (article.ArticleRepository, CronJobService) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				jobSvc := svcmocks.NewMockCronJobService(ctrl)
+				repo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+				jobSvc.EXPECT().AddJob(gomock.Any(), gomock.Any(), publishTime).
+					Return(errors.New("db 错误"))
+				repo.EXPECT().CompareAndSetStatus(gomock.Any(), int64(1), int64(123),
+					domain.ArticleStatusScheduled, domain.ArticleStatusUnpublished).Return(true, nil)
+				return repo, jobSvc
+			},
+			art:     domain.Article{Id: 1, Title: "标题", Author: domain.Author{Id: 123}},
+			wantErr: errors.New("db 错误"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, jobSvc := tc.mock(ctrl)
+			svc := NewArticleService(repo, nil, jobSvc)
+			id, err := svc.Schedule(context.Background(), tc.art, publishTime)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantId, id)
+		})
+	}
+}
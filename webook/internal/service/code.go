@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/basic-go-project-webook/webook/internal/repository"
+	"github.com/basic-go-project-webook/webook/internal/service/email"
 	"github.com/basic-go-project-webook/webook/internal/service/sms"
 	"math/rand"
 )
@@ -34,7 +35,7 @@ func NewCodeService(repo repository.CodeRepository, smsSvc sms.Service) CodeServ
 
 // Send biz 区别使用的业务
 func (svc *codeService) Send(ctx context.Context, biz string, phone string) error {
-	code := svc.generateCode()
+	code := generateCode()
 	// 放入 redis
 	err := svc.repo.Store(ctx, biz, phone, code)
 	if err != nil {
@@ -52,7 +53,39 @@ func (svc *codeService) Verify(ctx context.Context, biz string, phong string, co
 	return svc.repo.Verify(ctx, biz, phong, code)
 }
 
-func (svc *codeService) generateCode() string {
+// EmailCodeService 邮箱验证码，和短信验证码共用 CodeRepository，biz 不要重复
+type EmailCodeService interface {
+	Send(ctx context.Context, biz string, email string) error
+	Verify(ctx context.Context, biz string, email string, code string) (bool, error)
+}
+
+type emailCodeService struct {
+	repo     repository.CodeRepository
+	emailSvc email.Service
+}
+
+func NewEmailCodeService(repo repository.CodeRepository, emailSvc email.Service) EmailCodeService {
+	return &emailCodeService{
+		repo:     repo,
+		emailSvc: emailSvc,
+	}
+}
+
+func (svc *emailCodeService) Send(ctx context.Context, biz string, email string) error {
+	code := generateCode()
+	err := svc.repo.Store(ctx, biz, email, code)
+	if err != nil {
+		return err
+	}
+	return svc.emailSvc.Send(ctx, email, "webook 验证码",
+		fmt.Sprintf("你的验证码是 %s，10 分钟内有效", code))
+}
+
+func (svc *emailCodeService) Verify(ctx context.Context, biz string, email string, code string) (bool, error) {
+	return svc.repo.Verify(ctx, biz, email, code)
+}
+
+func generateCode() string {
 	num := rand.Intn(1000000)
 	return fmt.Sprintf("%06d", num)
 }
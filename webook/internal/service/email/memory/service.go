@@ -0,0 +1,20 @@
+package memory
+
+import (
+	"context"
+	"go.uber.org/zap"
+)
+
+// Service 开发环境用，邮件内容直接打到日志里面
+type Service struct {
+}
+
+func NewService() *Service {
+	return &Service{}
+}
+
+func (s *Service) Send(ctx context.Context, to string, subject string, content string) error {
+	zap.L().Info("发送邮件", zap.String("to", to),
+		zap.String("subject", subject), zap.String("content", content))
+	return nil
+}
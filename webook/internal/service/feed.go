@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"errors"
+	followv1 "github.com/basic-go-project-webook/webook/api/proto/gen/follow/v1"
+	"github.com/basic-go-project-webook/webook/internal/domain"
+	"github.com/basic-go-project-webook/webook/internal/repository"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+	"sort"
+	"time"
+)
+
+// FeedService 关注的人发表的文章。
+// 粉丝数不超过 pushThreshold 的作者发表文章的时候推到粉丝的收件箱里面（推模式），
+// 粉丝多的作者只会被标记，读 feed 的时候直接查线上库（拉模式），最后把两边的结果合并。
+type FeedService interface {
+	// Push 处理文章发表，决定是推送给粉丝还是留给粉丝来拉
+	Push(ctx context.Context, aid int64) error
+	// GetFeed 按照 (发表时间, id) 倒序返回排在 (maxCtime, maxId) 之后的文章，第一页 maxCtime 传当前时间。
+	// 返回的数量少于 limit 说明没有更多了
+	GetFeed(ctx context.Context, uid int64, maxCtime time.Time, maxId int64, limit int) ([]domain.Article, error)
+}
+
+type feedService struct {
+	repo      repository.FeedRepository
+	followSvc followv1.FollowServiceClient
+	// 作者的粉丝数超过这个值就走拉模式
+	pushThreshold int64
+	// 推送的时候每一批粉丝的数量
+	batchSize int64
+	// 读 feed 的时候最多考虑多少个关注的人
+	maxFollowees int64
+}
+
+func NewFeedService(repo repository.FeedRepository, followSvc followv1.FollowServiceClient, pushThreshold int64) FeedService {
+	return &feedService{
+		repo:          repo,
+		followSvc:     followSvc,
+		pushThreshold: pushThreshold,
+		batchSize:     500,
+		maxFollowees:  2000,
+	}
+}
+
+func (f *feedService) Push(ctx context.Context, aid int64) error {
+	art, err := f.repo.GetPubArticle(ctx, aid)
+	if errors.Is(err, repository.ErrArticleNotPublished) {
+		// 消息到达之前就撤回了
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	author := art.Author.Id
+	pullAuthors, err := f.repo.PullAuthors(ctx, []int64{author})
+	if err != nil {
+		return err
+	}
+	if len(pullAuthors) > 0 {
+		return nil
+	}
+	statics, err := f.followSvc.GetFollowStatics(ctx, &followv1.GetFollowStaticsRequest{Uid: author})
+	if err != nil {
+		return err
+	}
+	if statics.GetFollowerCnt() > f.pushThreshold {
+		return f.repo.MarkPullAuthor(ctx, author)
+	}
+
+	var minId int64
+	for {
+		resp, err := f.followSvc.GetFollower(ctx, &followv1.GetFollowerRequest{
+			Followee: author,
+			MinId:    minId,
+			Limit:    f.batchSize,
+		})
+		if err != nil {
+			return err
+		}
+		relations := resp.GetFollowRelation()
+		if len(relations) == 0 {
+			return nil
+		}
+		uids := make([]int64, 0, len(relations))
+		for _, r := range relations {
+			uids = append(uids, r.GetFollower())
+		}
+		err = f.repo.PushToInbox(ctx, uids, art)
+		if err != nil {
+			return err
+		}
+		if int64(len(relations)) < f.batchSize {
+			return nil
+		}
+		minId = relations[len(relations)-1].GetId()
+	}
+}
+
+func (f *feedService) GetFeed(ctx context.Context, uid int64, maxCtime time.Time, maxId int64, limit int) ([]domain.Article, error) {
+	followees, err := f.followees(ctx, uid)
+	if err != nil || len(followees) == 0 {
+		return []domain.Article{}, err
+	}
+	pullAuthors, err := f.repo.PullAuthors(ctx, followees)
+	if err != nil {
+		return nil, err
+	}
+	following := make(map[int64]struct{}, len(followees))
+	for _, followee := range followees {
+		following[followee] = struct{}{}
+	}
+
+	// 收件箱里面可能还有已经取消关注的作者的文章，过滤掉之后不够一页就接着往后取。
+	// 收件箱的大小是有限的，最多取到收件箱的底
+	res := make([]domain.Article, 0, limit)
+	seen := make(map[int64]struct{}, limit)
+	for len(res) < limit {
+		var (
+			eg     errgroup.Group
+			pushed []domain.Article
+			pulled []domain.Article
+		)
+		eg.Go(func() error {
+			var er error
+			pushed, er = f.repo.InboxArticles(ctx, uid, maxCtime, maxId, limit)
+			return er
+		})
+		if len(pullAuthors) > 0 {
+			eg.Go(func() error {
+				var er error
+				pulled, er = f.repo.ListPubByAuthors(ctx, pullAuthors, maxCtime, maxId, limit)
+				return er
+			})
+		}
+		err = eg.Wait()
+		if err != nil {
+			return nil, err
+		}
+
+		// 取满了的一边，比它最后一篇更早的文章还没有取出来，这一轮只能合并到这里
+		var bound *domain.Article
+		for _, arts := range [][]domain.Article{pushed, pulled} {
+			if len(arts) == limit && (bound == nil || feedBefore(*bound, arts[len(arts)-1])) {
+				last := arts[len(arts)-1]
+				bound = &last
+			}
+		}
+		candidates := make([]domain.Article, 0, len(pushed)+len(pulled))
+		candidates = append(append(candidates, pushed...), pulled...)
+		sort.Slice(candidates, func(i, j int) bool {
+			return feedBefore(candidates[j], candidates[i])
+		})
+		for _, art := range candidates {
+			if len(res) == limit || (bound != nil && feedBefore(art, *bound)) {
+				break
+			}
+			maxCtime, maxId = art.Ctime, art.Id
+			if _, ok := following[art.Author.Id]; !ok {
+				continue
+			}
+			if _, ok := seen[art.Id]; ok {
+				continue
+			}
+			seen[art.Id] = struct{}{}
+			res = append(res, art)
+		}
+		if bound == nil {
+			// 两边都取完了
+			break
+		}
+	}
+	return res, nil
+}
+
+// feedBefore a 在 feed 里面是不是排在 b 后面，也就是 (ctime, id) 更小
+func feedBefore(a, b domain.Article) bool {
+	if a.Ctime.Equal(b.Ctime) {
+		return a.Id < b.Id
+	}
+	return a.Ctime.Before(b.Ctime)
+}
+
+func (f *feedService) followees(ctx context.Context, uid int64) ([]int64, error) {
+	var (
+		res    []int64
+		offset int64
+	)
+	for offset < f.maxFollowees {
+		resp, err := f.followSvc.GetFollowee(ctx, &followv1.GetFolloweeRequest{
+			Follower: uid,
+			Offset:   offset,
+			Limit:    f.batchSize,
+		})
+		if err != nil {
+			return nil, err
+		}
+		relations := resp.GetFollowRelation()
+		for _, r := range relations {
+			res = append(res, r.GetFollowee())
+		}
+		if int64(len(relations)) < f.batchSize {
+			break
+		}
+		offset += int64(len(relations))
+	}
+	if offset >= f.maxFollowees {
+		zap.L().Warn("关注的人太多，feed 只包含部分关注的人", zap.Int64("uid", uid))
+	}
+	return res, nil
+}
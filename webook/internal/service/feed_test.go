@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	followv1 "github.com/basic-go-project-webook/webook/api/proto/gen/follow/v1"
+	"github.com/basic-go-project-webook/webook/internal/domain"
+	"github.com/basic-go-project-webook/webook/internal/repository"
+	repomocks "github.com/basic-go-project-webook/webook/internal/repository/mocks"
+	svcmocks "github.com/basic-go-project-webook/webook/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"math"
+	"testing"
+	"time"
+)
+
+func Test_feedService_GetFeed(t *testing.T) {
+	now := time.UnixMilli(10000)
+	art := func(id, author, ctime int64) domain.Article {
+		return domain.Article{Id: id, Author: domain.Author{Id: author}, Ctime: time.UnixMilli(ctime)}
+	}
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) (repository.FeedRepository, followv1.FollowServiceClient)
+		limit   int
+		wantRes []domain.Article
+	}{
+		{
+			name: "取消关注的作者被过滤之后接着往后取，发表时间相同的按照 id 翻页",
+			mock: func(ctrl *gomock.Controller) (repository.FeedRepository, followv1.FollowServiceClient) {
+				repo := repomocks.NewMockFeedRepository(ctrl)
+				followSvc := svcmocks.NewMockFollowServiceClient(ctrl)
+				followSvc.EXPECT().GetFollowee(gomock.Any(), gomock.Any()).
+					Return(&followv1.GetFolloweeResponse{FollowRelation: []*followv1.FollowRelation{
+						{Followee: 1}, {Followee: 2},
+					}}, nil)
+				repo.EXPECT().PullAuthors(gomock.Any(), []int64{1, 2}).Return(nil, nil)
+				// 作者 3 已经取消关注了，这一页只剩下一篇
+				repo.EXPECT().InboxArticles(gomock.Any(), int64(123), now, int64(math.MaxInt64), 2).
+					Return([]domain.Article{art(10, 3, 100), art(9, 1, 100)}, nil)
+				// 从同一毫秒里面 id 更小的文章开始
+				repo.EXPECT().InboxArticles(gomock.Any(), int64(123), time.UnixMilli(100), int64(9), 2).
+					Return([]domain.Article{art(8, 2, 100)}, nil)
+				return repo, followSvc
+			},
+			limit:   2,
+			wantRes: []domain.Article{art(9, 1, 100), art(8, 2, 100)},
+		},
+		{
+			name: "合并推拉两边的文章",
+			mock: func(ctrl *gomock.Controller) (repository.FeedRepository, followv1.FollowServiceClient) {
+				repo := repomocks.NewMockFeedRepository(ctrl)
+				followSvc := svcmocks.NewMockFollowServiceClient(ctrl)
+				followSvc.EXPECT().GetFollowee(gomock.Any(), gomock.Any()).
+					Return(&followv1.GetFolloweeResponse{FollowRelation: []*followv1.FollowRelation{
+						{Followee: 1}, {Followee: 2},
+					}}, nil)
+				repo.EXPECT().PullAuthors(gomock.Any(), []int64{1, 2}).Return([]int64{2}, nil)
+				repo.EXPECT().InboxArticles(gomock.Any(), int64(123), now, int64(math.MaxInt64), 2).
+					Return([]domain.Article{art(5, 1, 200), art(4, 1, 100)}, nil)
+				repo.EXPECT().ListPubByAuthors(gomock.Any(), []int64{2}, now, int64(math.MaxInt64), 2).
+					Return([]domain.Article{art(7, 2, 150)}, nil)
+				return repo, followSvc
+			},
+			limit:   2,
+			wantRes: []domain.Article{art(5, 1, 200), art(7, 2, 150)},
+		},
+		{
+			name: "没有关注任何人",
+			mock: func(ctrl *gomock.Controller) (repository.FeedRepository, followv1.FollowServiceClient) {
+				repo := repomocks.NewMockFeedRepository(ctrl)
+				followSvc := svcmocks.NewMockFollowServiceClient(ctrl)
+				followSvc.EXPECT().GetFollowee(gomock.Any(), gomock.Any()).
+					Return(&followv1.GetFolloweeResponse{}, nil)
+				return repo, followSvc
+			},
+			limit:   2,
+			wantRes: []domain.Article{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, followSvc := tc.mock(ctrl)
+			svc := NewFeedService(repo, followSvc, 1000)
+			res, err := svc.GetFeed(context.Background(), 123, now, math.MaxInt64, tc.limit)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantRes, res)
+		})
+	}
+}
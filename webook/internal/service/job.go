@@ -10,7 +10,14 @@ import (
 
 type CronJobService interface {
 	Preempt(ctx context.Context) (domain.Job, error)
+	// ResetNextTime 执行成功之后调用，一次性任务会直接结束
 	ResetNextTime(ctx context.Context, job domain.Job) error
+	// Delay 执行失败或者这个实例没有对应的执行器的时候调用，d 之后才能再次被抢占
+	Delay(ctx context.Context, job domain.Job, d time.Duration) error
+	// AddJob 按照名字创建或者覆盖任务，next 是第一次执行的时间
+	AddJob(ctx context.Context, job domain.Job, next time.Time) error
+	// StopJob 取消还没有开始执行的任务
+	StopJob(ctx context.Context, name string) error
 }
 
 type cronJobService struct {
@@ -24,13 +31,20 @@ func (c *cronJobService) Preempt(ctx context.Context) (domain.Job, error) {
 		return domain.Job{}, err
 	}
 	ticker := time.NewTicker(c.refreshInterval)
+	done := make(chan struct{})
 	go func() {
-		for range ticker.C {
-			c.refresh(j.Id)
+		for {
+			select {
+			case <-ticker.C:
+				c.refresh(j.Id)
+			case <-done:
+				return
+			}
 		}
 	}()
 	j.CancelFunc = func() {
 		ticker.Stop()
+		close(done)
 		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 		defer cancel()
 		err := c.repo.Release(ctx, j.Id)
@@ -42,10 +56,25 @@ func (c *cronJobService) Preempt(ctx context.Context) (domain.Job, error) {
 }
 
 func (c *cronJobService) ResetNextTime(ctx context.Context, job domain.Job) error {
+	if job.OneShot() {
+		return c.repo.Finish(ctx, job.Id)
+	}
 	nextTime := job.NextTime()
 	return c.repo.UpdateNextTime(ctx, job.Id, nextTime)
 }
 
+func (c *cronJobService) Delay(ctx context.Context, job domain.Job, d time.Duration) error {
+	return c.repo.UpdateNextTime(ctx, job.Id, time.Now().Add(d))
+}
+
+func (c *cronJobService) AddJob(ctx context.Context, job domain.Job, next time.Time) error {
+	return c.repo.AddJob(ctx, job, next)
+}
+
+func (c *cronJobService) StopJob(ctx context.Context, name string) error {
+	return c.repo.Stop(ctx, name)
+}
+
 func (c *cronJobService) refresh(id int64) {
 	// 更新一下更新时间
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
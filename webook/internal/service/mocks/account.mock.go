@@ -0,0 +1,155 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./webook/internal/service/account.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./webook/internal/service/account.go -package=svcmocks -destination=./webook/internal/service/mocks/account.mock.go
+//
+
+// Package svcmocks is a generated GoMock package.
+package svcmocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/basic-go-project-webook/webook/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAccountService is a mock of AccountService interface.
+type MockAccountService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockAccountServiceMockRecorder is the mock recorder for MockAccountService.
+type MockAccountServiceMockRecorder struct {
+	mock *MockAccountService
+}
+
+// NewMockAccountService creates a new mock instance.
+func NewMockAccountService(ctrl *gomock.Controller) *MockAccountService {
+	mock := &MockAccountService{ctrl: ctrl}
+	mock.recorder = &MockAccountServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountService) EXPECT() *MockAccountServiceMockRecorder {
+	return m.recorder
+}
+
+// BindEmail mocks base method.
+func (m *MockAccountService) BindEmail(ctx context.Context, uid int64, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BindEmail", ctx, uid, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BindEmail indicates an expected call of BindEmail.
+func (mr *MockAccountServiceMockRecorder) BindEmail(ctx, uid, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BindEmail", reflect.TypeOf((*MockAccountService)(nil).BindEmail), ctx, uid, email)
+}
+
+// BindIdentity mocks base method.
+func (m *MockAccountService) BindIdentity(ctx context.Context, uid int64, identity domain.Identity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BindIdentity", ctx, uid, identity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BindIdentity indicates an expected call of BindIdentity.
+func (mr *MockAccountServiceMockRecorder) BindIdentity(ctx, uid, identity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BindIdentity", reflect.TypeOf((*MockAccountService)(nil).BindIdentity), ctx, uid, identity)
+}
+
+// BindPhone mocks base method.
+func (m *MockAccountService) BindPhone(ctx context.Context, uid int64, phone string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BindPhone", ctx, uid, phone)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BindPhone indicates an expected call of BindPhone.
+func (mr *MockAccountServiceMockRecorder) BindPhone(ctx, uid, phone any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BindPhone", reflect.TypeOf((*MockAccountService)(nil).BindPhone), ctx, uid, phone)
+}
+
+// Identities mocks base method.
+func (m *MockAccountService) Identities(ctx context.Context, uid int64) ([]domain.Identity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Identities", ctx, uid)
+	ret0, _ := ret[0].([]domain.Identity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Identities indicates an expected call of Identities.
+func (mr *MockAccountServiceMockRecorder) Identities(ctx, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Identities", reflect.TypeOf((*MockAccountService)(nil).Identities), ctx, uid)
+}
+
+// Merge mocks base method.
+func (m *MockAccountService) Merge(ctx context.Context, dstUid, srcUid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Merge", ctx, dstUid, srcUid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Merge indicates an expected call of Merge.
+func (mr *MockAccountServiceMockRecorder) Merge(ctx, dstUid, srcUid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Merge", reflect.TypeOf((*MockAccountService)(nil).Merge), ctx, dstUid, srcUid)
+}
+
+// UnbindEmail mocks base method.
+func (m *MockAccountService) UnbindEmail(ctx context.Context, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnbindEmail", ctx, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnbindEmail indicates an expected call of UnbindEmail.
+func (mr *MockAccountServiceMockRecorder) UnbindEmail(ctx, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnbindEmail", reflect.TypeOf((*MockAccountService)(nil).UnbindEmail), ctx, uid)
+}
+
+// UnbindIdentity mocks base method.
+func (m *MockAccountService) UnbindIdentity(ctx context.Context, uid int64, provider string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnbindIdentity", ctx, uid, provider)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnbindIdentity indicates an expected call of UnbindIdentity.
+func (mr *MockAccountServiceMockRecorder) UnbindIdentity(ctx, uid, provider any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnbindIdentity", reflect.TypeOf((*MockAccountService)(nil).UnbindIdentity), ctx, uid, provider)
+}
+
+// UnbindPhone mocks base method.
+func (m *MockAccountService) UnbindPhone(ctx context.Context, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnbindPhone", ctx, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnbindPhone indicates an expected call of UnbindPhone.
+func (mr *MockAccountServiceMockRecorder) UnbindPhone(ctx, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnbindPhone", reflect.TypeOf((*MockAccountService)(nil).UnbindPhone), ctx, uid)
+}
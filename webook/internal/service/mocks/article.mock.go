@@ -11,10 +11,10 @@ package svcmocks
 
 import (
 	context "context"
-	domain "github.com/basic-go-project-webook/webook/internal/domain"
 	reflect "reflect"
 	time "time"
 
+	domain "github.com/basic-go-project-webook/webook/internal/domain"
 	gin "github.com/gin-gonic/gin"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -43,6 +43,35 @@ func (m *MockArticleService) EXPECT() *MockArticleServiceMockRecorder {
 	return m.recorder
 }
 
+// CancelSchedule mocks base method.
+func (m *MockArticleService) CancelSchedule(ctx context.Context, id, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelSchedule", ctx, id, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelSchedule indicates an expected call of CancelSchedule.
+func (mr *MockArticleServiceMockRecorder) CancelSchedule(ctx, id, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelSchedule", reflect.TypeOf((*MockArticleService)(nil).CancelSchedule), ctx, id, uid)
+}
+
+// DiffHistory mocks base method.
+func (m *MockArticleService) DiffHistory(ctx context.Context, id, uid, from, to int64) (domain.ArticleDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffHistory", ctx, id, uid, from, to)
+	ret0, _ := ret[0].(domain.ArticleDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiffHistory indicates an expected call of DiffHistory.
+func (mr *MockArticleServiceMockRecorder) DiffHistory(ctx, id, uid, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffHistory", reflect.TypeOf((*MockArticleService)(nil).DiffHistory), ctx, id, uid, from, to)
+}
+
 // GetById mocks base method.
 func (m *MockArticleService) GetById(ctx *gin.Context, id int64) (domain.Article, error) {
 	m.ctrl.T.Helper()
@@ -73,6 +102,21 @@ func (mr *MockArticleServiceMockRecorder) GetPubById(ctx, id, uid any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPubById", reflect.TypeOf((*MockArticleService)(nil).GetPubById), ctx, id, uid)
 }
 
+// GetPubByIds mocks base method.
+func (m *MockArticleService) GetPubByIds(ctx context.Context, ids []int64) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPubByIds", ctx, ids)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPubByIds indicates an expected call of GetPubByIds.
+func (mr *MockArticleServiceMockRecorder) GetPubByIds(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPubByIds", reflect.TypeOf((*MockArticleService)(nil).GetPubByIds), ctx, ids)
+}
+
 // List mocks base method.
 func (m *MockArticleService) List(ctx *gin.Context, uid int64, limit, offset int) ([]domain.Article, error) {
 	m.ctrl.T.Helper()
@@ -88,6 +132,21 @@ func (mr *MockArticleServiceMockRecorder) List(ctx, uid, limit, offset any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockArticleService)(nil).List), ctx, uid, limit, offset)
 }
 
+// ListHistory mocks base method.
+func (m *MockArticleService) ListHistory(ctx context.Context, id, uid int64, offset, limit int) ([]domain.ArticleHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListHistory", ctx, id, uid, offset, limit)
+	ret0, _ := ret[0].([]domain.ArticleHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListHistory indicates an expected call of ListHistory.
+func (mr *MockArticleServiceMockRecorder) ListHistory(ctx, id, uid, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHistory", reflect.TypeOf((*MockArticleService)(nil).ListHistory), ctx, id, uid, offset, limit)
+}
+
 // ListPub mocks base method.
 func (m *MockArticleService) ListPub(ctx context.Context, start time.Time, offset, limit int) ([]domain.Article, error) {
 	m.ctrl.T.Helper()
@@ -103,6 +162,36 @@ func (mr *MockArticleServiceMockRecorder) ListPub(ctx, start, offset, limit any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPub", reflect.TypeOf((*MockArticleService)(nil).ListPub), ctx, start, offset, limit)
 }
 
+// ListPubByAuthor mocks base method.
+func (m *MockArticleService) ListPubByAuthor(ctx context.Context, uid int64, maxUtime time.Time, maxId int64, limit int) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPubByAuthor", ctx, uid, maxUtime, maxId, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPubByAuthor indicates an expected call of ListPubByAuthor.
+func (mr *MockArticleServiceMockRecorder) ListPubByAuthor(ctx, uid, maxUtime, maxId, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPubByAuthor", reflect.TypeOf((*MockArticleService)(nil).ListPubByAuthor), ctx, uid, maxUtime, maxId, limit)
+}
+
+// ListPubByTag mocks base method.
+func (m *MockArticleService) ListPubByTag(ctx context.Context, tag string, cursor int64, limit int) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPubByTag", ctx, tag, cursor, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPubByTag indicates an expected call of ListPubByTag.
+func (mr *MockArticleServiceMockRecorder) ListPubByTag(ctx, tag, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPubByTag", reflect.TypeOf((*MockArticleService)(nil).ListPubByTag), ctx, tag, cursor, limit)
+}
+
 // Publish mocks base method.
 func (m *MockArticleService) Publish(ctx context.Context, art domain.Article) (int64, error) {
 	m.ctrl.T.Helper()
@@ -118,6 +207,20 @@ func (mr *MockArticleServiceMockRecorder) Publish(ctx, art any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockArticleService)(nil).Publish), ctx, art)
 }
 
+// PublishScheduled mocks base method.
+func (m *MockArticleService) PublishScheduled(ctx context.Context, id, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishScheduled", ctx, id, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishScheduled indicates an expected call of PublishScheduled.
+func (mr *MockArticleServiceMockRecorder) PublishScheduled(ctx, id, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishScheduled", reflect.TypeOf((*MockArticleService)(nil).PublishScheduled), ctx, id, uid)
+}
+
 // PublishV1 mocks base method.
 func (m *MockArticleService) PublishV1(ctx context.Context, art domain.Article) (int64, error) {
 	m.ctrl.T.Helper()
@@ -133,6 +236,21 @@ func (mr *MockArticleServiceMockRecorder) PublishV1(ctx, art any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishV1", reflect.TypeOf((*MockArticleService)(nil).PublishV1), ctx, art)
 }
 
+// RestoreHistory mocks base method.
+func (m *MockArticleService) RestoreHistory(ctx context.Context, id, uid, version int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreHistory", ctx, id, uid, version)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreHistory indicates an expected call of RestoreHistory.
+func (mr *MockArticleServiceMockRecorder) RestoreHistory(ctx, id, uid, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreHistory", reflect.TypeOf((*MockArticleService)(nil).RestoreHistory), ctx, id, uid, version)
+}
+
 // Save mocks base method.
 func (m *MockArticleService) Save(ctx context.Context, art domain.Article) (int64, error) {
 	m.ctrl.T.Helper()
@@ -148,6 +266,50 @@ func (mr *MockArticleServiceMockRecorder) Save(ctx, art any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockArticleService)(nil).Save), ctx, art)
 }
 
+// Schedule mocks base method.
+func (m *MockArticleService) Schedule(ctx context.Context, art domain.Article, publishTime time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Schedule", ctx, art, publishTime)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Schedule indicates an expected call of Schedule.
+func (mr *MockArticleServiceMockRecorder) Schedule(ctx, art, publishTime any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Schedule", reflect.TypeOf((*MockArticleService)(nil).Schedule), ctx, art, publishTime)
+}
+
+// SearchTags mocks base method.
+func (m *MockArticleService) SearchTags(ctx context.Context, prefix string, limit int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchTags", ctx, prefix, limit)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchTags indicates an expected call of SearchTags.
+func (mr *MockArticleServiceMockRecorder) SearchTags(ctx, prefix, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchTags", reflect.TypeOf((*MockArticleService)(nil).SearchTags), ctx, prefix, limit)
+}
+
+// TransferAuthor mocks base method.
+func (m *MockArticleService) TransferAuthor(ctx context.Context, srcUid, dstUid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransferAuthor", ctx, srcUid, dstUid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TransferAuthor indicates an expected call of TransferAuthor.
+func (mr *MockArticleServiceMockRecorder) TransferAuthor(ctx, srcUid, dstUid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferAuthor", reflect.TypeOf((*MockArticleService)(nil).TransferAuthor), ctx, srcUid, dstUid)
+}
+
 // Withdraw mocks base method.
 func (m *MockArticleService) Withdraw(ctx *gin.Context, art domain.Article) error {
 	m.ctrl.T.Helper()
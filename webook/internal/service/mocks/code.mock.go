@@ -68,3 +68,56 @@ func (mr *MockCodeServiceMockRecorder) Verify(ctx, biz, phong, code any) *gomock
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockCodeService)(nil).Verify), ctx, biz, phong, code)
 }
+
+// MockEmailCodeService is a mock of EmailCodeService interface.
+type MockEmailCodeService struct {
+	ctrl     *gomock.Controller
+	recorder *MockEmailCodeServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockEmailCodeServiceMockRecorder is the mock recorder for MockEmailCodeService.
+type MockEmailCodeServiceMockRecorder struct {
+	mock *MockEmailCodeService
+}
+
+// NewMockEmailCodeService creates a new mock instance.
+func NewMockEmailCodeService(ctrl *gomock.Controller) *MockEmailCodeService {
+	mock := &MockEmailCodeService{ctrl: ctrl}
+	mock.recorder = &MockEmailCodeServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEmailCodeService) EXPECT() *MockEmailCodeServiceMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MockEmailCodeService) Send(ctx context.Context, biz, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, biz, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockEmailCodeServiceMockRecorder) Send(ctx, biz, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockEmailCodeService)(nil).Send), ctx, biz, email)
+}
+
+// Verify mocks base method.
+func (m *MockEmailCodeService) Verify(ctx context.Context, biz, email, code string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", ctx, biz, email, code)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockEmailCodeServiceMockRecorder) Verify(ctx, biz, email, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockEmailCodeService)(nil).Verify), ctx, biz, email, code)
+}
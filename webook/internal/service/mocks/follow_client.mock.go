@@ -0,0 +1,203 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/basic-go-project-webook/webook/api/proto/gen/follow/v1 (interfaces: FollowServiceClient)
+//
+// Generated by this command:
+//
+//	mockgen -package=svcmocks -destination=./webook/internal/service/mocks/follow_client.mock.go github.com/basic-go-project-webook/webook/api/proto/gen/follow/v1 FollowServiceClient
+//
+
+// Package svcmocks is a generated GoMock package.
+package svcmocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	followv1 "github.com/basic-go-project-webook/webook/api/proto/gen/follow/v1"
+	gomock "go.uber.org/mock/gomock"
+	grpc "google.golang.org/grpc"
+)
+
+// MockFollowServiceClient is a mock of FollowServiceClient interface.
+type MockFollowServiceClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockFollowServiceClientMockRecorder
+	isgomock struct{}
+}
+
+// MockFollowServiceClientMockRecorder is the mock recorder for MockFollowServiceClient.
+type MockFollowServiceClientMockRecorder struct {
+	mock *MockFollowServiceClient
+}
+
+// NewMockFollowServiceClient creates a new mock instance.
+func NewMockFollowServiceClient(ctrl *gomock.Controller) *MockFollowServiceClient {
+	mock := &MockFollowServiceClient{ctrl: ctrl}
+	mock.recorder = &MockFollowServiceClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFollowServiceClient) EXPECT() *MockFollowServiceClientMockRecorder {
+	return m.recorder
+}
+
+// CancelFollow mocks base method.
+func (m *MockFollowServiceClient) CancelFollow(ctx context.Context, in *followv1.CancelFollowRequest, opts ...grpc.CallOption) (*followv1.CancelFollowResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CancelFollow", varargs...)
+	ret0, _ := ret[0].(*followv1.CancelFollowResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelFollow indicates an expected call of CancelFollow.
+func (mr *MockFollowServiceClientMockRecorder) CancelFollow(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelFollow", reflect.TypeOf((*MockFollowServiceClient)(nil).CancelFollow), varargs...)
+}
+
+// Follow mocks base method.
+func (m *MockFollowServiceClient) Follow(ctx context.Context, in *followv1.FollowRequest, opts ...grpc.CallOption) (*followv1.FollowResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Follow", varargs...)
+	ret0, _ := ret[0].(*followv1.FollowResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Follow indicates an expected call of Follow.
+func (mr *MockFollowServiceClientMockRecorder) Follow(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Follow", reflect.TypeOf((*MockFollowServiceClient)(nil).Follow), varargs...)
+}
+
+// FollowInfo mocks base method.
+func (m *MockFollowServiceClient) FollowInfo(ctx context.Context, in *followv1.FollowInfoRequest, opts ...grpc.CallOption) (*followv1.FollowInfoResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FollowInfo", varargs...)
+	ret0, _ := ret[0].(*followv1.FollowInfoResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FollowInfo indicates an expected call of FollowInfo.
+func (mr *MockFollowServiceClientMockRecorder) FollowInfo(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FollowInfo", reflect.TypeOf((*MockFollowServiceClient)(nil).FollowInfo), varargs...)
+}
+
+// GetFollowStatics mocks base method.
+func (m *MockFollowServiceClient) GetFollowStatics(ctx context.Context, in *followv1.GetFollowStaticsRequest, opts ...grpc.CallOption) (*followv1.GetFollowStaticsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetFollowStatics", varargs...)
+	ret0, _ := ret[0].(*followv1.GetFollowStaticsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFollowStatics indicates an expected call of GetFollowStatics.
+func (mr *MockFollowServiceClientMockRecorder) GetFollowStatics(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFollowStatics", reflect.TypeOf((*MockFollowServiceClient)(nil).GetFollowStatics), varargs...)
+}
+
+// GetFollowee mocks base method.
+func (m *MockFollowServiceClient) GetFollowee(ctx context.Context, in *followv1.GetFolloweeRequest, opts ...grpc.CallOption) (*followv1.GetFolloweeResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetFollowee", varargs...)
+	ret0, _ := ret[0].(*followv1.GetFolloweeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFollowee indicates an expected call of GetFollowee.
+func (mr *MockFollowServiceClientMockRecorder) GetFollowee(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFollowee", reflect.TypeOf((*MockFollowServiceClient)(nil).GetFollowee), varargs...)
+}
+
+// GetFollower mocks base method.
+func (m *MockFollowServiceClient) GetFollower(ctx context.Context, in *followv1.GetFollowerRequest, opts ...grpc.CallOption) (*followv1.GetFollowerResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetFollower", varargs...)
+	ret0, _ := ret[0].(*followv1.GetFollowerResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFollower indicates an expected call of GetFollower.
+func (mr *MockFollowServiceClientMockRecorder) GetFollower(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFollower", reflect.TypeOf((*MockFollowServiceClient)(nil).GetFollower), varargs...)
+}
+
+// GetRelations mocks base method.
+func (m *MockFollowServiceClient) GetRelations(ctx context.Context, in *followv1.GetRelationsRequest, opts ...grpc.CallOption) (*followv1.GetRelationsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetRelations", varargs...)
+	ret0, _ := ret[0].(*followv1.GetRelationsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRelations indicates an expected call of GetRelations.
+func (mr *MockFollowServiceClientMockRecorder) GetRelations(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRelations", reflect.TypeOf((*MockFollowServiceClient)(nil).GetRelations), varargs...)
+}
+
+// MergeUser mocks base method.
+func (m *MockFollowServiceClient) MergeUser(ctx context.Context, in *followv1.MergeUserRequest, opts ...grpc.CallOption) (*followv1.MergeUserResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "MergeUser", varargs...)
+	ret0, _ := ret[0].(*followv1.MergeUserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergeUser indicates an expected call of MergeUser.
+func (mr *MockFollowServiceClientMockRecorder) MergeUser(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeUser", reflect.TypeOf((*MockFollowServiceClient)(nil).MergeUser), varargs...)
+}
@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: ./webook/internal/service/interactive.go
+// Source: ./webook/interactive/service/interactive.go
 //
 // Generated by this command:
 //
-//	mockgen -source=./webook/internal/service/interactive.go -package=svcmocks -destination=./webook/internal/service/mocks/interactive.mock.go
+//	mockgen -source=./webook/interactive/service/interactive.go -package=svcmocks -destination=./webook/internal/service/mocks/interactive.mock.go
 //
 
 // Package svcmocks is a generated GoMock package.
@@ -11,9 +11,9 @@ package svcmocks
 
 import (
 	context "context"
-	"github.com/basic-go-project-webook/webook/interactive/domain"
 	reflect "reflect"
 
+	domain "github.com/basic-go-project-webook/webook/interactive/domain"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -41,6 +41,20 @@ func (m *MockInteractiveService) EXPECT() *MockInteractiveServiceMockRecorder {
 	return m.recorder
 }
 
+// CancelCollect mocks base method.
+func (m *MockInteractiveService) CancelCollect(ctx context.Context, biz string, bizId, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelCollect", ctx, biz, bizId, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelCollect indicates an expected call of CancelCollect.
+func (mr *MockInteractiveServiceMockRecorder) CancelCollect(ctx, biz, bizId, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelCollect", reflect.TypeOf((*MockInteractiveService)(nil).CancelCollect), ctx, biz, bizId, uid)
+}
+
 // CancelLike mocks base method.
 func (m *MockInteractiveService) CancelLike(ctx context.Context, biz string, id, uid int64) error {
 	m.ctrl.T.Helper()
@@ -69,6 +83,35 @@ func (mr *MockInteractiveServiceMockRecorder) Collect(ctx, biz, bizId, cid, uid
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Collect", reflect.TypeOf((*MockInteractiveService)(nil).Collect), ctx, biz, bizId, cid, uid)
 }
 
+// CreateCollection mocks base method.
+func (m *MockInteractiveService) CreateCollection(ctx context.Context, c domain.Collection) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCollection", ctx, c)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCollection indicates an expected call of CreateCollection.
+func (mr *MockInteractiveServiceMockRecorder) CreateCollection(ctx, c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCollection", reflect.TypeOf((*MockInteractiveService)(nil).CreateCollection), ctx, c)
+}
+
+// DeleteCollection mocks base method.
+func (m *MockInteractiveService) DeleteCollection(ctx context.Context, id, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCollection", ctx, id, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCollection indicates an expected call of DeleteCollection.
+func (mr *MockInteractiveServiceMockRecorder) DeleteCollection(ctx, id, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCollection", reflect.TypeOf((*MockInteractiveService)(nil).DeleteCollection), ctx, id, uid)
+}
+
 // Get mocks base method.
 func (m *MockInteractiveService) Get(ctx context.Context, biz string, bizId, uid int64) (domain.Interactive, error) {
 	m.ctrl.T.Helper()
@@ -99,6 +142,21 @@ func (mr *MockInteractiveServiceMockRecorder) GetByIds(ctx, biz, bizIds any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIds", reflect.TypeOf((*MockInteractiveService)(nil).GetByIds), ctx, biz, bizIds)
 }
 
+// GetByIdsWithUser mocks base method.
+func (m *MockInteractiveService) GetByIdsWithUser(ctx context.Context, biz string, bizIds []int64, uid int64) (map[int64]domain.Interactive, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIdsWithUser", ctx, biz, bizIds, uid)
+	ret0, _ := ret[0].(map[int64]domain.Interactive)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIdsWithUser indicates an expected call of GetByIdsWithUser.
+func (mr *MockInteractiveServiceMockRecorder) GetByIdsWithUser(ctx, biz, bizIds, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIdsWithUser", reflect.TypeOf((*MockInteractiveService)(nil).GetByIdsWithUser), ctx, biz, bizIds, uid)
+}
+
 // IncrReadCnt mocks base method.
 func (m *MockInteractiveService) IncrReadCnt(ctx context.Context, biz string, bizId int64) error {
 	m.ctrl.T.Helper()
@@ -126,3 +184,75 @@ func (mr *MockInteractiveServiceMockRecorder) Like(ctx, biz, id, uid any) *gomoc
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Like", reflect.TypeOf((*MockInteractiveService)(nil).Like), ctx, biz, id, uid)
 }
+
+// ListCollectionItems mocks base method.
+func (m *MockInteractiveService) ListCollectionItems(ctx context.Context, uid, cid, viewer int64, offset, limit int) ([]domain.CollectionItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCollectionItems", ctx, uid, cid, viewer, offset, limit)
+	ret0, _ := ret[0].([]domain.CollectionItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCollectionItems indicates an expected call of ListCollectionItems.
+func (mr *MockInteractiveServiceMockRecorder) ListCollectionItems(ctx, uid, cid, viewer, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCollectionItems", reflect.TypeOf((*MockInteractiveService)(nil).ListCollectionItems), ctx, uid, cid, viewer, offset, limit)
+}
+
+// ListCollections mocks base method.
+func (m *MockInteractiveService) ListCollections(ctx context.Context, uid, viewer int64, offset, limit int) ([]domain.Collection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCollections", ctx, uid, viewer, offset, limit)
+	ret0, _ := ret[0].([]domain.Collection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCollections indicates an expected call of ListCollections.
+func (mr *MockInteractiveServiceMockRecorder) ListCollections(ctx, uid, viewer, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCollections", reflect.TypeOf((*MockInteractiveService)(nil).ListCollections), ctx, uid, viewer, offset, limit)
+}
+
+// MergeUser mocks base method.
+func (m *MockInteractiveService) MergeUser(ctx context.Context, srcUid, dstUid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeUser", ctx, srcUid, dstUid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MergeUser indicates an expected call of MergeUser.
+func (mr *MockInteractiveServiceMockRecorder) MergeUser(ctx, srcUid, dstUid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeUser", reflect.TypeOf((*MockInteractiveService)(nil).MergeUser), ctx, srcUid, dstUid)
+}
+
+// MoveCollectionItem mocks base method.
+func (m *MockInteractiveService) MoveCollectionItem(ctx context.Context, biz string, bizId, uid, cid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveCollectionItem", ctx, biz, bizId, uid, cid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveCollectionItem indicates an expected call of MoveCollectionItem.
+func (mr *MockInteractiveServiceMockRecorder) MoveCollectionItem(ctx, biz, bizId, uid, cid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveCollectionItem", reflect.TypeOf((*MockInteractiveService)(nil).MoveCollectionItem), ctx, biz, bizId, uid, cid)
+}
+
+// UpdateCollection mocks base method.
+func (m *MockInteractiveService) UpdateCollection(ctx context.Context, c domain.Collection) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCollection", ctx, c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCollection indicates an expected call of UpdateCollection.
+func (mr *MockInteractiveServiceMockRecorder) UpdateCollection(ctx, c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCollection", reflect.TypeOf((*MockInteractiveService)(nil).UpdateCollection), ctx, c)
+}
@@ -0,0 +1,114 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./webook/internal/service/job.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./webook/internal/service/job.go -package=svcmocks -destination=./webook/internal/service/mocks/job.mock.go
+//
+
+// Package svcmocks is a generated GoMock package.
+package svcmocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/basic-go-project-webook/webook/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCronJobService is a mock of CronJobService interface.
+type MockCronJobService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCronJobServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockCronJobServiceMockRecorder is the mock recorder for MockCronJobService.
+type MockCronJobServiceMockRecorder struct {
+	mock *MockCronJobService
+}
+
+// NewMockCronJobService creates a new mock instance.
+func NewMockCronJobService(ctrl *gomock.Controller) *MockCronJobService {
+	mock := &MockCronJobService{ctrl: ctrl}
+	mock.recorder = &MockCronJobServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCronJobService) EXPECT() *MockCronJobServiceMockRecorder {
+	return m.recorder
+}
+
+// AddJob mocks base method.
+func (m *MockCronJobService) AddJob(ctx context.Context, job domain.Job, next time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddJob", ctx, job, next)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddJob indicates an expected call of AddJob.
+func (mr *MockCronJobServiceMockRecorder) AddJob(ctx, job, next any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddJob", reflect.TypeOf((*MockCronJobService)(nil).AddJob), ctx, job, next)
+}
+
+// Delay mocks base method.
+func (m *MockCronJobService) Delay(ctx context.Context, job domain.Job, d time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delay", ctx, job, d)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delay indicates an expected call of Delay.
+func (mr *MockCronJobServiceMockRecorder) Delay(ctx, job, d any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delay", reflect.TypeOf((*MockCronJobService)(nil).Delay), ctx, job, d)
+}
+
+// Preempt mocks base method.
+func (m *MockCronJobService) Preempt(ctx context.Context) (domain.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Preempt", ctx)
+	ret0, _ := ret[0].(domain.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Preempt indicates an expected call of Preempt.
+func (mr *MockCronJobServiceMockRecorder) Preempt(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Preempt", reflect.TypeOf((*MockCronJobService)(nil).Preempt), ctx)
+}
+
+// ResetNextTime mocks base method.
+func (m *MockCronJobService) ResetNextTime(ctx context.Context, job domain.Job) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetNextTime", ctx, job)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetNextTime indicates an expected call of ResetNextTime.
+func (mr *MockCronJobServiceMockRecorder) ResetNextTime(ctx, job any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetNextTime", reflect.TypeOf((*MockCronJobService)(nil).ResetNextTime), ctx, job)
+}
+
+// StopJob mocks base method.
+func (m *MockCronJobService) StopJob(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopJob", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StopJob indicates an expected call of StopJob.
+func (mr *MockCronJobServiceMockRecorder) StopJob(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopJob", reflect.TypeOf((*MockCronJobService)(nil).StopJob), ctx, name)
+}
@@ -0,0 +1,182 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/basic-go-project-webook/webook/internal/domain"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Config 标准的 OAuth2 授权码流程，userinfo 接口返回 JSON 的第三方都可以配置进来。
+// 标准 OIDC 的 SubjectField 是 sub；GitHub 这种不是 OIDC 的，配置成 id 就可以
+type Config struct {
+	Name         string
+	ClientId     string
+	ClientSecret string
+	RedirectURI  string
+	AuthURL      string
+	TokenURL     string
+	UserInfoURL  string
+	Scopes       []string
+	// PKCE 第三方支持的时候打开
+	PKCE bool
+	// SubjectField userinfo 里面代表用户唯一 id 的字段，默认是 sub
+	SubjectField string
+	// NameField 默认是 name
+	NameField string
+	// EmailField 默认是 email
+	EmailField string
+}
+
+type Service struct {
+	cfg    Config
+	client *http.Client
+}
+
+func NewService(cfg Config, client *http.Client) *Service {
+	if cfg.SubjectField == "" {
+		cfg.SubjectField = "sub"
+	}
+	if cfg.NameField == "" {
+		cfg.NameField = "name"
+	}
+	if cfg.EmailField == "" {
+		cfg.EmailField = "email"
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &Service{
+		cfg:    cfg,
+		client: client,
+	}
+}
+
+func (s *Service) Name() string {
+	return s.cfg.Name
+}
+
+func (s *Service) AuthURL(ctx context.Context, state string, codeChallenge string) (string, error) {
+	params := url.Values{}
+	params.Set("client_id", s.cfg.ClientId)
+	params.Set("redirect_uri", s.cfg.RedirectURI)
+	params.Set("response_type", "code")
+	params.Set("state", state)
+	if len(s.cfg.Scopes) > 0 {
+		params.Set("scope", strings.Join(s.cfg.Scopes, " "))
+	}
+	if s.cfg.PKCE {
+		params.Set("code_challenge", codeChallenge)
+		params.Set("code_challenge_method", "S256")
+	}
+	sep := "?"
+	if strings.Contains(s.cfg.AuthURL, "?") {
+		sep = "&"
+	}
+	return s.cfg.AuthURL + sep + params.Encode(), nil
+}
+
+func (s *Service) VerifyCode(ctx context.Context, code string, codeVerifier string) (domain.Identity, error) {
+	accessToken, err := s.exchange(ctx, code, codeVerifier)
+	if err != nil {
+		return domain.Identity{}, err
+	}
+	info, err := s.userInfo(ctx, accessToken)
+	if err != nil {
+		return domain.Identity{}, err
+	}
+	sub := stringField(info, s.cfg.SubjectField)
+	if sub == "" {
+		return domain.Identity{}, fmt.Errorf("%s 的用户信息里面没有 %s", s.cfg.Name, s.cfg.SubjectField)
+	}
+	return domain.Identity{
+		Provider:   s.cfg.Name,
+		ExternalId: sub,
+		Nickname:   stringField(info, s.cfg.NameField),
+		Email:      stringField(info, s.cfg.EmailField),
+	}, nil
+}
+
+func (s *Service) exchange(ctx context.Context, code string, codeVerifier string) (string, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", s.cfg.RedirectURI)
+	form.Set("client_id", s.cfg.ClientId)
+	form.Set("client_secret", s.cfg.ClientSecret)
+	if s.cfg.PKCE {
+		form.Set("code_verifier", codeVerifier)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	// GitHub 默认返回 form 格式
+	req.Header.Set("Accept", "application/json")
+	var res struct {
+		AccessToken      string `json:"access_token"`
+		TokenType        string `json:"token_type"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	err = s.do(req, &res)
+	if err != nil {
+		return "", err
+	}
+	// GitHub 出错的时候状态码也是 200
+	if res.Error != "" {
+		return "", fmt.Errorf("%s 换取 access token 失败: %s %s", s.cfg.Name, res.Error, res.ErrorDescription)
+	}
+	if res.AccessToken == "" {
+		return "", fmt.Errorf("%s 没有返回 access token", s.cfg.Name)
+	}
+	return res.AccessToken, nil
+}
+
+func (s *Service) userInfo(ctx context.Context, accessToken string) (map[string]any, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.cfg.UserInfoURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/json")
+	var info map[string]any
+	err = s.do(req, &info)
+	return info, err
+}
+
+func (s *Service) do(req *http.Request, res any) error {
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("%s 返回错误的状态码: %d, %s", s.cfg.Name, resp.StatusCode, body)
+	}
+	decoder := json.NewDecoder(resp.Body)
+	// 数字类型的 id 不能变成浮点数
+	decoder.UseNumber()
+	err = decoder.Decode(res)
+	if err != nil {
+		return fmt.Errorf("%s 返回的数据格式错误: %w", s.cfg.Name, err)
+	}
+	return nil
+}
+
+func stringField(info map[string]any, field string) string {
+	switch val := info[field].(type) {
+	case string:
+		return val
+	case json.Number:
+		return val.String()
+	default:
+		return ""
+	}
+}
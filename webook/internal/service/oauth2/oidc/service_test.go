@@ -0,0 +1,83 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/basic-go-project-webook/webook/internal/domain"
+	"github.com/basic-go-project-webook/webook/internal/service/oauth2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+// newProvider 模拟 GitHub：token 接口要求 PKCE，userinfo 返回数字类型的 id
+func newProvider(t *testing.T, verifier string) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		w.Header().Set("Content-Type", "application/json")
+		if r.PostForm.Get("code") != "good-code" ||
+			r.PostForm.Get("client_secret") != "secret" ||
+			r.PostForm.Get("code_verifier") != verifier {
+			// 和 GitHub 一样，出错也是 200
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "bad_verification_code"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"access_token": "at", "token_type": "bearer"})
+	})
+	mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer at" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": 9007199254740993, "login": "octocat", "email": null}`))
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestService(t *testing.T) {
+	verifier, err := oauth2.NewCodeVerifier()
+	require.NoError(t, err)
+	server := newProvider(t, verifier)
+	defer server.Close()
+	svc := NewService(Config{
+		Name:         "github",
+		ClientId:     "client",
+		ClientSecret: "secret",
+		RedirectURI:  "https://meoying.com/oauth2/github/callback",
+		AuthURL:      server.URL + "/authorize",
+		TokenURL:     server.URL + "/token",
+		UserInfoURL:  server.URL + "/user",
+		Scopes:       []string{"read:user", "user:email"},
+		PKCE:         true,
+		SubjectField: "id",
+		NameField:    "login",
+	}, server.Client())
+
+	authURL, err := svc.AuthURL(context.Background(), "my-state", oauth2.CodeChallenge(verifier))
+	require.NoError(t, err)
+	u, err := url.Parse(authURL)
+	require.NoError(t, err)
+	assert.Equal(t, "/authorize", u.Path)
+	assert.Equal(t, "my-state", u.Query().Get("state"))
+	assert.Equal(t, "read:user user:email", u.Query().Get("scope"))
+	assert.Equal(t, oauth2.CodeChallenge(verifier), u.Query().Get("code_challenge"))
+	assert.Equal(t, "S256", u.Query().Get("code_challenge_method"))
+
+	identity, err := svc.VerifyCode(context.Background(), "good-code", verifier)
+	require.NoError(t, err)
+	assert.Equal(t, domain.Identity{
+		Provider:   "github",
+		ExternalId: "9007199254740993",
+		Nickname:   "octocat",
+	}, identity)
+
+	_, err = svc.VerifyCode(context.Background(), "good-code", "wrong-verifier")
+	assert.Error(t, err)
+	_, err = svc.VerifyCode(context.Background(), "bad-code", verifier)
+	assert.Error(t, err)
+}
@@ -0,0 +1,18 @@
+package oauth2
+
+import (
+	"context"
+	"github.com/basic-go-project-webook/webook/internal/domain"
+)
+
+// Provider 一个第三方登录方式
+type Provider interface {
+	// Name 唯一标识，例如 wechat、github，用在回调路径和 user_identities 表里面
+	Name() string
+	// AuthURL 构造跳转到第三方的授权地址。
+	// codeChallenge 是 PKCE 的 S256 challenge，不支持 PKCE 的第三方直接忽略
+	AuthURL(ctx context.Context, state string, codeChallenge string) (string, error)
+	// VerifyCode 用回调里面的 code 换 access token，再拿到第三方的用户信息。
+	// codeVerifier 是生成 codeChallenge 用的原文
+	VerifyCode(ctx context.Context, code string, codeVerifier string) (domain.Identity, error)
+}
@@ -9,58 +9,129 @@ import (
 	"net/url"
 )
 
-var (
-	redirectURI = url.PathEscape("https://meoying.com/oauth2/wechat/callback")
+const (
+	ProviderName = "wechat"
+
+	defaultAuthEndpoint = "https://open.weixin.qq.com/connect/qrconnect"
+	defaultAPIEndpoint  = "https://api.weixin.qq.com"
 )
 
-type Service interface {
-	AuthURL(ctx context.Context, state string) (string, error)
-	VerifyCode(ctx context.Context, code string) (domain.WechatInfo, error)
+type Config struct {
+	AppId       string
+	AppSecret   string
+	RedirectURI string
+	// AuthEndpoint 和 APIEndpoint 不配置就用微信的地址，测试的时候换成 httptest 的地址
+	AuthEndpoint string
+	APIEndpoint  string
 }
 
-type WechatService struct {
-	appId     string
-	appSecret string
+// Service 微信扫码登录。微信不支持 PKCE，codeChallenge 和 codeVerifier 都会被忽略
+type Service struct {
+	cfg    Config
+	client *http.Client
 }
 
-func NewWechatService(appId string, appSecret string) *WechatService {
-	return &WechatService{
-		appId:     appId,
-		appSecret: appSecret,
+func NewService(cfg Config, client *http.Client) *Service {
+	if cfg.AuthEndpoint == "" {
+		cfg.AuthEndpoint = defaultAuthEndpoint
+	}
+	if cfg.APIEndpoint == "" {
+		cfg.APIEndpoint = defaultAPIEndpoint
+	}
+	if client == nil {
+		client = http.DefaultClient
 	}
+	return &Service{
+		cfg:    cfg,
+		client: client,
+	}
+}
+
+func (s *Service) Name() string {
+	return ProviderName
 }
 
-func (s *WechatService) AuthURL(ctx context.Context, state string) (string, error) {
-	urlPattern := "https://open.weixin.qq.com/connect/qrconnect?appid=%s&redirect_uri=%s&response_type=code&scope=snsapi_login&state=%s#wechat_redirect"
-	return fmt.Sprintf(urlPattern, s.appId, redirectURI, state), nil
+func (s *Service) AuthURL(ctx context.Context, state string, codeChallenge string) (string, error) {
+	params := url.Values{}
+	params.Set("appid", s.cfg.AppId)
+	params.Set("redirect_uri", s.cfg.RedirectURI)
+	params.Set("response_type", "code")
+	params.Set("scope", "snsapi_login")
+	params.Set("state", state)
+	return s.cfg.AuthEndpoint + "?" + params.Encode() + "#wechat_redirect", nil
 }
 
-func (s *WechatService) VerifyCode(ctx context.Context, code string) (domain.WechatInfo, error) {
-	const targetPattern = "https://api.weixin.qq.com/sns/oauth2/access_token?appid=%s&secret=%s&code=%s&grant_type=authorization_code"
-	target := fmt.Sprintf(targetPattern, s.appId, s.appSecret, code)
-	resp, err := http.Get(target)
+func (s *Service) VerifyCode(ctx context.Context, code string, codeVerifier string) (domain.Identity, error) {
+	params := url.Values{}
+	params.Set("appid", s.cfg.AppId)
+	params.Set("secret", s.cfg.AppSecret)
+	params.Set("code", code)
+	params.Set("grant_type", "authorization_code")
+	var token tokenResult
+	err := s.get(ctx, "/sns/oauth2/access_token", params, &token)
 	if err != nil {
-		return domain.WechatInfo{}, err
+		return domain.Identity{}, err
 	}
-	var res Result
-	decoder := json.NewDecoder(resp.Body)
-	err = decoder.Decode(&res)
+
+	params = url.Values{}
+	params.Set("access_token", token.AccessToken)
+	params.Set("openid", token.OpenId)
+	var info userInfoResult
+	err = s.get(ctx, "/sns/userinfo", params, &info)
 	if err != nil {
-		return domain.WechatInfo{}, err
+		return domain.Identity{}, err
 	}
-	if res.ErrCode != 0 {
-		return domain.WechatInfo{}, fmt.Errorf("微信返回错误响应, 错误码: %d, 错误信息: %s", res.ErrCode, res.ErrMsg)
+	unionId := info.UnionId
+	if unionId == "" {
+		unionId = token.UnionId
 	}
-	return domain.WechatInfo{
-		OpenId:  res.OpenId,
-		UnionId: res.UnionId,
+	return domain.Identity{
+		Provider:   ProviderName,
+		ExternalId: token.OpenId,
+		UnionId:    unionId,
+		Nickname:   info.Nickname,
 	}, nil
 }
 
+// get 微信的接口出错的时候 HTTP 状态码也是 200，要看 errcode
+func (s *Service) get(ctx context.Context, path string, params url.Values, res errResult) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.cfg.APIEndpoint+path+"?"+params.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("微信返回错误的状态码: %d", resp.StatusCode)
+	}
+	err = json.NewDecoder(resp.Body).Decode(res)
+	if err != nil {
+		return err
+	}
+	if code, msg := res.errInfo(); code != 0 {
+		return fmt.Errorf("微信返回错误响应, 错误码: %d, 错误信息: %s", code, msg)
+	}
+	return nil
+}
+
+type errResult interface {
+	errInfo() (int64, string)
+}
+
 type Result struct {
 	ErrCode int64  `json:"errcode"`
 	ErrMsg  string `json:"errmsg"`
+}
+
+func (r Result) errInfo() (int64, string) {
+	return r.ErrCode, r.ErrMsg
+}
 
+type tokenResult struct {
+	Result
 	AccessToken  string `json:"access_token"`
 	ExpiresIn    int64  `json:"expires_in"`
 	RefreshToken string `json:"refresh_token"`
@@ -68,3 +139,10 @@ type Result struct {
 	Scope        string `json:"scope"`
 	UnionId      string `json:"unionid"`
 }
+
+type userInfoResult struct {
+	Result
+	OpenId   string `json:"openid"`
+	Nickname string `json:"nickname"`
+	UnionId  string `json:"unionid"`
+}
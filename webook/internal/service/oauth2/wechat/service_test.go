@@ -0,0 +1,65 @@
+package wechat
+
+import (
+	"context"
+	"github.com/basic-go-project-webook/webook/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestService(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/sns/oauth2/access_token", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("code") != "good-code" || q.Get("secret") != "secret" {
+			// 微信出错的时候也是 200
+			_, _ = w.Write([]byte(`{"errcode": 40029, "errmsg": "invalid code"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"access_token": "at", "openid": "open-1", "unionid": "union-1"}`))
+	})
+	mux.HandleFunc("/sns/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("access_token") != "at" || q.Get("openid") != "open-1" {
+			_, _ = w.Write([]byte(`{"errcode": 40001, "errmsg": "invalid credential"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"openid": "open-1", "nickname": "小明"}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	svc := NewService(Config{
+		AppId:        "app",
+		AppSecret:    "secret",
+		RedirectURI:  "https://meoying.com/oauth2/wechat/callback",
+		AuthEndpoint: server.URL + "/connect/qrconnect",
+		APIEndpoint:  server.URL,
+	}, server.Client())
+
+	authURL, err := svc.AuthURL(context.Background(), "my-state", "ignored")
+	require.NoError(t, err)
+	assert.True(t, strings.HasSuffix(authURL, "#wechat_redirect"))
+	u, err := url.Parse(authURL)
+	require.NoError(t, err)
+	assert.Equal(t, "https://meoying.com/oauth2/wechat/callback", u.Query().Get("redirect_uri"))
+	assert.Equal(t, "my-state", u.Query().Get("state"))
+	assert.Empty(t, u.Query().Get("code_challenge"))
+
+	identity, err := svc.VerifyCode(context.Background(), "good-code", "")
+	require.NoError(t, err)
+	assert.Equal(t, domain.Identity{
+		Provider:   ProviderName,
+		ExternalId: "open-1",
+		UnionId:    "union-1",
+		Nickname:   "小明",
+	}, identity)
+
+	_, err = svc.VerifyCode(context.Background(), "bad-code", "")
+	assert.ErrorContains(t, err, "40029")
+}
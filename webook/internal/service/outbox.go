@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/basic-go-project-webook/webook/internal/domain"
+	events "github.com/basic-go-project-webook/webook/internal/events/article"
+	"github.com/basic-go-project-webook/webook/internal/repository"
+	"go.uber.org/zap"
+	"time"
+)
+
+// OutboxRelayService 把 outbox 里面的消息投递到 kafka。
+// 投递成功之后才标记为已发送，所以是 at-least-once，消费者需要自己处理重复消息
+type OutboxRelayService interface {
+	// Relay 投递一批到期的消息，返回这一批的消息数量
+	Relay(ctx context.Context) (int, error)
+}
+
+type outboxRelayService struct {
+	repo     repository.OutboxRepository
+	producer events.Producer
+	// 每一批取出的消息数
+	batchSize int
+	// 取出之后多久没有结果就允许其它实例再次投递，
+	// 必须比 sendTimeout 加上 markTimeout 长，不然一批还没处理完就被别的实例取走了
+	lease time.Duration
+	// 投递一批消息的超时时间
+	sendTimeout time.Duration
+	// 记录这一批投递结果的超时时间
+	markTimeout time.Duration
+	maxRetries  int
+	maxBackoff  time.Duration
+}
+
+func NewOutboxRelayService(repo repository.OutboxRepository, producer events.Producer) OutboxRelayService {
+	return &outboxRelayService{
+		repo:        repo,
+		producer:    producer,
+		batchSize:   100,
+		lease:       time.Minute,
+		sendTimeout: time.Second * 10,
+		markTimeout: time.Second * 10,
+		maxRetries:  10,
+		maxBackoff:  time.Minute * 10,
+	}
+}
+
+func (o *outboxRelayService) Relay(ctx context.Context) (int, error) {
+	msgs, err := o.repo.Claim(ctx, o.lease, o.batchSize)
+	if err != nil {
+		return 0, err
+	}
+	if len(msgs) == 0 {
+		return 0, nil
+	}
+	// 投递和记录结果都不跟着调用方的 ctx 走：job 超时的时候已经发出去的这一批也要记下来，
+	// 否则 lease 过期之后又会整批重新投递
+	sendCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), o.sendTimeout)
+	err = o.producer.ProduceOutboxMessages(sendCtx, msgs)
+	cancel()
+	errs := make([]error, len(msgs))
+	var we events.WriteErrors
+	switch {
+	case err == nil:
+	case errors.As(err, &we) && len(we) == len(msgs):
+		copy(errs, we)
+	default:
+		for i := range errs {
+			errs[i] = err
+		}
+	}
+
+	markCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), o.markTimeout)
+	defer cancel()
+	for i, msg := range msgs {
+		if errs[i] != nil {
+			o.fail(markCtx, msg, errs[i])
+			continue
+		}
+		err = o.repo.MarkSent(markCtx, msg.Id)
+		if err != nil {
+			// lease 过期之后会重新投递一次
+			zap.L().Error("标记 outbox 消息已发送失败", zap.Error(err), zap.Int64("id", msg.Id))
+		}
+	}
+	return len(msgs), nil
+}
+
+func (o *outboxRelayService) fail(ctx context.Context, msg domain.OutboxMessage, cause error) {
+	msg.Retries++
+	dead := msg.Retries >= o.maxRetries
+	backoff := min(time.Second<<msg.Retries, o.maxBackoff)
+	if dead {
+		zap.L().Error("outbox 消息重试次数用完", zap.Error(cause),
+			zap.Int64("id", msg.Id), zap.String("topic", msg.Topic))
+	} else {
+		zap.L().Warn("投递 outbox 消息失败", zap.Error(cause),
+			zap.Int64("id", msg.Id), zap.Int("retries", msg.Retries))
+	}
+	err := o.repo.MarkFailed(ctx, msg, time.Now().Add(backoff), cause, dead)
+	if err != nil {
+		zap.L().Error("记录 outbox 消息投递失败出错", zap.Error(err), zap.Int64("id", msg.Id))
+	}
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/basic-go-project-webook/webook/internal/domain"
+	events "github.com/basic-go-project-webook/webook/internal/events/article"
+	evtmocks "github.com/basic-go-project-webook/webook/internal/events/article/mocks"
+	"github.com/basic-go-project-webook/webook/internal/repository"
+	repomocks "github.com/basic-go-project-webook/webook/internal/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+func Test_outboxRelayService_Relay(t *testing.T) {
+	msgs := []domain.OutboxMessage{
+		{Id: 1, Topic: "updated-article", Key: "1"},
+		{Id: 2, Topic: "updated-article", Key: "2", Retries: 1},
+		{Id: 3, Topic: "updated-user", Key: "3"},
+	}
+	// 投递和记录结果用的 ctx 不能是已经取消的
+	alive := gomock.Cond(func(ctx context.Context) bool {
+		return ctx.Err() == nil
+	})
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) (repository.OutboxRepository, events.Producer)
+		wantCnt int
+		wantErr error
+	}{
+		{
+			name: "整批投递成功",
+			mock: func(ctrl *gomock.Controller) (repository.OutboxRepository, events.Producer) {
+				repo := repomocks.NewMockOutboxRepository(ctrl)
+				producer := evtmocks.NewMockProducer(ctrl)
+				repo.EXPECT().Claim(gomock.Any(), time.Minute, 100).Return(msgs, nil)
+				producer.EXPECT().ProduceOutboxMessages(alive, msgs).Return(nil)
+				for _, msg := range msgs {
+					repo.EXPECT().MarkSent(alive, msg.Id).Return(nil)
+				}
+				return repo, producer
+			},
+			wantCnt: 3,
+		},
+		{
+			name: "部分消息投递失败",
+			mock: func(ctrl *gomock.Controller) (repository.OutboxRepository, events.Producer) {
+				repo := repomocks.NewMockOutboxRepository(ctrl)
+				producer := evtmocks.NewMockProducer(ctrl)
+				repo.EXPECT().Claim(gomock.Any(), time.Minute, 100).Return(msgs, nil)
+				cause := errors.New("分区不可用")
+				producer.EXPECT().ProduceOutboxMessages(alive, msgs).
+					Return(events.WriteErrors{nil, cause, nil})
+				repo.EXPECT().MarkSent(alive, int64(1)).Return(nil)
+				repo.EXPECT().MarkSent(alive, int64(3)).Return(nil)
+				failed := msgs[1]
+				failed.Retries = 2
+				repo.EXPECT().MarkFailed(alive, failed, gomock.Any(), cause, false).Return(nil)
+				return repo, producer
+			},
+			wantCnt: 3,
+		},
+		{
+			name: "整批投递失败",
+			mock: func(ctrl *gomock.Controller) (repository.OutboxRepository, events.Producer) {
+				repo := repomocks.NewMockOutboxRepository(ctrl)
+				producer := evtmocks.NewMockProducer(ctrl)
+				repo.EXPECT().Claim(gomock.Any(), time.Minute, 100).Return(msgs, nil)
+				cause := errors.New("连不上 kafka")
+				producer.EXPECT().ProduceOutboxMessages(alive, msgs).Return(cause)
+				repo.EXPECT().MarkFailed(alive, gomock.Any(), gomock.Any(), cause, false).
+					Return(nil).Times(len(msgs))
+				return repo, producer
+			},
+			wantCnt: 3,
+		},
+		{
+			name: "没有到期的消息",
+			mock: func(ctrl *gomock.Controller) (repository.OutboxRepository, events.Producer) {
+				repo := repomocks.NewMockOutboxRepository(ctrl)
+				producer := evtmocks.NewMockProducer(ctrl)
+				repo.EXPECT().Claim(gomock.Any(), time.Minute, 100).Return(nil, nil)
+				return repo, producer
+			},
+		},
+		{
+			name: "取消息失败",
+			mock: func(ctrl *gomock.Controller) (repository.OutboxRepository, events.Producer) {
+				repo := repomocks.NewMockOutboxRepository(ctrl)
+				producer := evtmocks.NewMockProducer(ctrl)
+				repo.EXPECT().Claim(gomock.Any(), time.Minute, 100).Return(nil, errors.New("db 错误"))
+				return repo, producer
+			},
+			wantErr: errors.New("db 错误"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := NewOutboxRelayService(tc.mock(ctrl))
+			cnt, err := svc.Relay(context.Background())
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantCnt, cnt)
+		})
+	}
+}
+
+func Test_outboxRelayService_RelayAfterDeadline(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := repomocks.NewMockOutboxRepository(ctrl)
+	producer := evtmocks.NewMockProducer(ctrl)
+	msgs := []domain.OutboxMessage{{Id: 1, Topic: "updated-user", Key: "1"}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	repo.EXPECT().Claim(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(context.Context, time.Duration, int) ([]domain.OutboxMessage, error) {
+			// 取出消息之后调用方的 ctx 就过期了
+			cancel()
+			return msgs, nil
+		})
+	producer.EXPECT().ProduceOutboxMessages(gomock.Any(), msgs).
+		DoAndReturn(func(ctx context.Context, _ []domain.OutboxMessage) error {
+			return ctx.Err()
+		})
+	repo.EXPECT().MarkSent(gomock.Any(), int64(1)).
+		DoAndReturn(func(ctx context.Context, _ int64) error {
+			return ctx.Err()
+		})
+
+	svc := NewOutboxRelayService(repo, producer).(*outboxRelayService)
+	require.Less(t, svc.sendTimeout+svc.markTimeout, svc.lease)
+	cnt, err := svc.Relay(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, cnt)
+}
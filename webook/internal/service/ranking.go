@@ -3,59 +3,126 @@ package service
 import (
 	"context"
 	"errors"
+	commentv1 "github.com/basic-go-project-webook/webook/api/proto/gen/comment/v1"
 	intrv1 "github.com/basic-go-project-webook/webook/api/proto/gen/intr/v1"
 	"github.com/basic-go-project-webook/webook/internal/domain"
 	"github.com/basic-go-project-webook/webook/internal/repository"
 	"github.com/ecodeclub/ekit/queue"
-	"math"
+	"slices"
 	"time"
 )
 
+var (
+	ErrUnknownRankingBoard = errors.New("榜单不存在")
+	ErrArticleNotRanked    = repository.ErrArticleNotRanked
+)
+
 type RankingService interface {
 	TopN(ctx context.Context) error
+	// GetTopN 返回计算好的热榜，还没有计算过的时候返回空
+	GetTopN(ctx context.Context) ([]domain.Article, error)
+	// Boards 配置的所有榜单
+	Boards() []domain.RankingBoard
+	// ComputeBoard 重新计算一个榜单
+	ComputeBoard(ctx context.Context, board string) error
+	// GetBoard 按照名次翻页
+	GetBoard(ctx context.Context, board string, offset, limit int) ([]domain.RankedArticle, error)
+	// GetRank 查询文章在榜单上的名次，不在榜单上返回 ErrArticleNotRanked
+	GetRank(ctx context.Context, board string, aid int64) (domain.RankedArticle, error)
 }
 
 type BatchRankingService struct {
-	artSvc    ArticleService
-	intrSvc   intrv1.InteractiveServiceClient
-	repo      repository.RankingRepository
-	batchSize int
-	n         int
-	scoreFunc func(t time.Time, likeCnt int64) float64
+	artSvc     ArticleService
+	intrSvc    intrv1.InteractiveServiceClient
+	commentSvc commentv1.CommentServiceClient
+	repo       repository.RankingRepository
+	batchSize  int
+	n          int
+	scorer     RankingScorer
+	boards     []domain.RankingBoard
+	boardMap   map[string]domain.RankingBoard
 }
 
-func NewBatchRankingService(artSvc ArticleService, intrSvc intrv1.InteractiveServiceClient, repo repository.RankingRepository) RankingService {
+func NewBatchRankingService(artSvc ArticleService, intrSvc intrv1.InteractiveServiceClient,
+	commentSvc commentv1.CommentServiceClient, repo repository.RankingRepository,
+	scorer RankingScorer, boards []domain.RankingBoard) RankingService {
+	boardMap := make(map[string]domain.RankingBoard, len(boards))
+	for _, b := range boards {
+		boardMap[b.Name] = b
+	}
 	return &BatchRankingService{
-		artSvc:    artSvc,
-		intrSvc:   intrSvc,
-		repo:      repo,
-		batchSize: 100,
-		n:         100,
-		scoreFunc: func(t time.Time, likeCnt int64) float64 {
-			sec := time.Since(t).Seconds()
-			return float64(likeCnt-1) / math.Pow(sec+2, 1.5)
-		},
+		artSvc:     artSvc,
+		intrSvc:    intrSvc,
+		commentSvc: commentSvc,
+		repo:       repo,
+		batchSize:  100,
+		n:          100,
+		scorer:     scorer,
+		boards:     boards,
+		boardMap:   boardMap,
 	}
 }
 
+func (svc *BatchRankingService) GetTopN(ctx context.Context) ([]domain.Article, error) {
+	return svc.repo.GetTopN(ctx)
+}
+
 func (svc *BatchRankingService) TopN(ctx context.Context) error {
-	arts, err := svc.topN(ctx)
+	ranked, err := svc.topN(ctx, domain.RankingBoard{
+		Window: 7 * 24 * time.Hour,
+		N:      svc.n,
+	})
 	if err != nil {
 		return err
 	}
+	arts := make([]domain.Article, len(ranked))
+	for i, r := range ranked {
+		arts[i] = r.Article
+	}
 	// 存入缓存中
 	return svc.repo.ReplaceTopN(ctx, arts)
 }
 
-func (svc *BatchRankingService) topN(ctx context.Context) ([]domain.Article, error) {
+func (svc *BatchRankingService) Boards() []domain.RankingBoard {
+	return svc.boards
+}
+
+func (svc *BatchRankingService) ComputeBoard(ctx context.Context, board string) error {
+	b, ok := svc.boardMap[board]
+	if !ok {
+		return ErrUnknownRankingBoard
+	}
+	arts, err := svc.topN(ctx, b)
+	if err != nil {
+		return err
+	}
+	return svc.repo.ReplaceBoard(ctx, b, arts)
+}
+
+func (svc *BatchRankingService) GetBoard(ctx context.Context, board string, offset, limit int) ([]domain.RankedArticle, error) {
+	if _, ok := svc.boardMap[board]; !ok {
+		return nil, ErrUnknownRankingBoard
+	}
+	return svc.repo.GetBoard(ctx, board, offset, limit)
+}
+
+func (svc *BatchRankingService) GetRank(ctx context.Context, board string, aid int64) (domain.RankedArticle, error) {
+	if _, ok := svc.boardMap[board]; !ok {
+		return domain.RankedArticle{}, ErrUnknownRankingBoard
+	}
+	return svc.repo.GetRank(ctx, board, aid)
+}
+
+// topN 计算 board.Window 内更新过的文章里面热度最高的 board.N 篇，按照名次排好
+func (svc *BatchRankingService) topN(ctx context.Context, board domain.RankingBoard) ([]domain.RankedArticle, error) {
 	start := time.Now()
 	offset := 0
-	ddl := start.Add(-7 * 24 * time.Hour)
+	ddl := start.Add(-board.Window)
 	type Score struct {
 		art   domain.Article
 		score float64
 	}
-	topN := queue.NewPriorityQueue[Score](svc.n, func(src, dst Score) int {
+	topN := queue.NewPriorityQueue[Score](board.N, func(src, dst Score) int {
 		if src.score > dst.score {
 			return 1
 		} else if src.score == dst.score {
@@ -71,25 +138,55 @@ func (svc *BatchRankingService) topN(ctx context.Context) ([]domain.Article, err
 			return nil, err
 		}
 
-		ids := make([]int64, len(arts))
-		for i, art := range arts {
-			ids[i] = art.Id
+		ids := make([]int64, 0, len(arts))
+		for _, art := range arts {
+			if art.Utime.Before(ddl) {
+				continue
+			}
+			if board.Category != "" && art.Category != board.Category {
+				continue
+			}
+			if board.Tag != "" && !slices.Contains(art.Tags, board.Tag) {
+				continue
+			}
+			ids = append(ids, art.Id)
 		}
 
-		intrs, err := svc.intrSvc.GetByIds(ctx, &intrv1.GetByIdsRequest{
-			Biz: "article",
-			Ids: ids,
-		})
-		if err != nil {
-			return nil, err
+		var intrs map[int64]*intrv1.Interactive
+		var comments map[int64]int64
+		if len(ids) > 0 {
+			resp, err := svc.intrSvc.GetByIds(ctx, &intrv1.GetByIdsRequest{
+				Biz: "article",
+				Ids: ids,
+			})
+			if err != nil {
+				return nil, err
+			}
+			intrs = resp.GetIntrs()
+			if svc.scorer.NeedCommentCnt() {
+				cresp, err := svc.commentSvc.CountByBizIds(ctx, &commentv1.CountByBizIdsRequest{
+					Biz:    "article",
+					BizIds: ids,
+				})
+				if err != nil {
+					return nil, err
+				}
+				comments = cresp.GetCounts()
+			}
 		}
 
 		for _, art := range arts {
-			intr, ok := intrs.Intrs[art.Id]
+			intr, ok := intrs[art.Id]
 			if !ok {
 				continue
 			}
-			score := svc.scoreFunc(art.Utime, intr.LikeCnt)
+			score := svc.scorer.Score(RankingItem{
+				Utime:      art.Utime,
+				ReadCnt:    intr.GetReadCnt(),
+				LikeCnt:    intr.GetLikeCnt(),
+				CollectCnt: intr.GetCollectCnt(),
+				CommentCnt: comments[art.Id],
+			})
 			ele := Score{
 				art:   art,
 				score: score,
@@ -109,10 +206,14 @@ func (svc *BatchRankingService) topN(ctx context.Context) ([]domain.Article, err
 			break
 		}
 	}
-	res := make([]domain.Article, topN.Len())
+	res := make([]domain.RankedArticle, topN.Len())
 	for i := topN.Len() - 1; i >= 0; i-- {
 		ele, _ := topN.Dequeue()
-		res[i] = ele.art
+		res[i] = domain.RankedArticle{
+			Article: ele.art,
+			Rank:    int64(i + 1),
+			Score:   ele.score,
+		}
 	}
 	return res, nil
 }
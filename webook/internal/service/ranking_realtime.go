@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"github.com/basic-go-project-webook/webook/internal/domain"
+	"github.com/basic-go-project-webook/webook/internal/repository"
+)
+
+type RankingAction uint8
+
+const (
+	RankingActionUnknown RankingAction = iota
+	RankingActionRead
+	RankingActionLike
+	RankingActionCancelLike
+	RankingActionCollect
+	RankingActionCancelCollect
+)
+
+// RankingWeights 实时热榜每种互动加多少分，取消的时候减掉当时加的分
+type RankingWeights struct {
+	Read    float64
+	Like    float64
+	Collect float64
+}
+
+// RealtimeRankingService 实时热榜，互动事件来了就加分，不需要扫全部文章
+type RealtimeRankingService interface {
+	// Record uid 是互动的用户，取消点赞、收藏的时候用来找到当时加的分
+	Record(ctx context.Context, aid int64, uid int64, action RankingAction) error
+	// Compact 裁掉排在后面的文章，需要定时调用
+	Compact(ctx context.Context) error
+	GetTopN(ctx context.Context, n int) ([]domain.Article, error)
+}
+
+type realtimeRankingService struct {
+	artSvc  ArticleService
+	repo    repository.RealtimeRankingRepository
+	weights RankingWeights
+}
+
+func NewRealtimeRankingService(artSvc ArticleService, repo repository.RealtimeRankingRepository,
+	weights RankingWeights) RealtimeRankingService {
+	return &realtimeRankingService{
+		artSvc:  artSvc,
+		repo:    repo,
+		weights: weights,
+	}
+}
+
+func (svc *realtimeRankingService) Record(ctx context.Context, aid int64, uid int64, action RankingAction) error {
+	switch action {
+	case RankingActionRead:
+		if svc.weights.Read == 0 {
+			return nil
+		}
+		return svc.repo.IncrScore(ctx, aid, svc.weights.Read)
+	case RankingActionLike:
+		return svc.repo.IncrActionScore(ctx, aid, uid, "like", svc.weights.Like)
+	case RankingActionCancelLike:
+		return svc.repo.UndoActionScore(ctx, aid, uid, "like", svc.weights.Like)
+	case RankingActionCollect:
+		return svc.repo.IncrActionScore(ctx, aid, uid, "collect", svc.weights.Collect)
+	case RankingActionCancelCollect:
+		return svc.repo.UndoActionScore(ctx, aid, uid, "collect", svc.weights.Collect)
+	}
+	return nil
+}
+
+func (svc *realtimeRankingService) Compact(ctx context.Context) error {
+	return svc.repo.Compact(ctx)
+}
+
+func (svc *realtimeRankingService) GetTopN(ctx context.Context, n int) ([]domain.Article, error) {
+	ranked, err := svc.repo.GetTopN(ctx, 0, n)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int64, 0, len(ranked))
+	for _, r := range ranked {
+		ids = append(ids, r.Article.Id)
+	}
+	// 撤回了的文章查不到，直接跳过，等 Compact 的时候慢慢掉出去
+	return svc.artSvc.GetPubByIds(ctx, ids)
+}
+
+// realtimeTopNRankingService 热榜用实时计算的结果，其他榜单还是批量计算
+type realtimeTopNRankingService struct {
+	RankingService
+	realtime RealtimeRankingService
+	n        int
+}
+
+// NewRealtimeTopNRankingService 把 batch 的热榜换成实时计算的，
+// 定时任务调用 TopN 的时候只做压缩
+func NewRealtimeTopNRankingService(batch RankingService, realtime RealtimeRankingService) RankingService {
+	return &realtimeTopNRankingService{
+		RankingService: batch,
+		realtime:       realtime,
+		n:              100,
+	}
+}
+
+func (svc *realtimeTopNRankingService) TopN(ctx context.Context) error {
+	return svc.realtime.Compact(ctx)
+}
+
+func (svc *realtimeTopNRankingService) GetTopN(ctx context.Context) ([]domain.Article, error) {
+	return svc.realtime.GetTopN(ctx, svc.n)
+}
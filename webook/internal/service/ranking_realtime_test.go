@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"github.com/basic-go-project-webook/webook/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// recordRankingRepository 记录调用了哪个方法
+type recordRankingRepository struct {
+	repository.RealtimeRankingRepository
+	calls []string
+}
+
+func (r *recordRankingRepository) IncrScore(ctx context.Context, aid int64, weight float64) error {
+	r.calls = append(r.calls, "incr")
+	return nil
+}
+
+func (r *recordRankingRepository) IncrActionScore(ctx context.Context, aid int64, uid int64, action string, weight float64) error {
+	r.calls = append(r.calls, "incr:"+action)
+	return nil
+}
+
+func (r *recordRankingRepository) UndoActionScore(ctx context.Context, aid int64, uid int64, action string, weight float64) error {
+	r.calls = append(r.calls, "undo:"+action)
+	return nil
+}
+
+func TestRealtimeRankingService_Record(t *testing.T) {
+	testCases := []struct {
+		name    string
+		action  RankingAction
+		weights RankingWeights
+
+		wantCalls []string
+	}{
+		{
+			name:      "阅读",
+			action:    RankingActionRead,
+			weights:   RankingWeights{Read: 1},
+			wantCalls: []string{"incr"},
+		},
+		{
+			name:   "阅读不加分",
+			action: RankingActionRead,
+		},
+		{
+			name:      "点赞",
+			action:    RankingActionLike,
+			weights:   RankingWeights{Like: 3},
+			wantCalls: []string{"incr:like"},
+		},
+		{
+			// 不能直接减掉现在的权重，旧文章会被减成负数
+			name:      "取消点赞减掉当时加的分",
+			action:    RankingActionCancelLike,
+			weights:   RankingWeights{Like: 3},
+			wantCalls: []string{"undo:like"},
+		},
+		{
+			name:      "取消收藏减掉当时加的分",
+			action:    RankingActionCancelCollect,
+			weights:   RankingWeights{Collect: 5},
+			wantCalls: []string{"undo:collect"},
+		},
+		{
+			name:   "不认识的互动",
+			action: RankingActionUnknown,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &recordRankingRepository{}
+			svc := NewRealtimeRankingService(nil, repo, tc.weights)
+			err := svc.Record(context.Background(), 1, 2, tc.action)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantCalls, repo.calls)
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"math"
+	"time"
+)
+
+// RankingItem 计算热度需要的数据
+type RankingItem struct {
+	Utime      time.Time
+	ReadCnt    int64
+	LikeCnt    int64
+	CollectCnt int64
+	CommentCnt int64
+}
+
+// RankingScorer 热榜的打分策略
+type RankingScorer interface {
+	Score(item RankingItem) float64
+	// NeedCommentCnt 是否需要评论数，不需要的话就不用去查评论服务了
+	NeedCommentCnt() bool
+}
+
+// LikeRankingScorer 只看点赞数和发表时间
+type LikeRankingScorer struct {
+	gravity float64
+}
+
+func NewLikeRankingScorer() RankingScorer {
+	return &LikeRankingScorer{
+		gravity: 1.5,
+	}
+}
+
+func (s *LikeRankingScorer) Score(item RankingItem) float64 {
+	sec := time.Since(item.Utime).Seconds()
+	return float64(item.LikeCnt-1) / math.Pow(sec+2, s.gravity)
+}
+
+func (s *LikeRankingScorer) NeedCommentCnt() bool {
+	return false
+}
+
+// WeightedRankingScorer 阅读、点赞、收藏、评论按照权重加起来，
+// 再按照 HackerNews 的方式随时间衰减。Gravity 越大，旧文章掉得越快
+type WeightedRankingScorer struct {
+	ReadWeight    float64
+	LikeWeight    float64
+	CollectWeight float64
+	CommentWeight float64
+	Gravity       float64
+}
+
+func (s *WeightedRankingScorer) Score(item RankingItem) float64 {
+	hours := time.Since(item.Utime).Hours()
+	if hours < 0 {
+		hours = 0
+	}
+	points := s.ReadWeight*float64(item.ReadCnt) +
+		s.LikeWeight*float64(item.LikeCnt) +
+		s.CollectWeight*float64(item.CollectCnt) +
+		s.CommentWeight*float64(item.CommentCnt)
+	return points / math.Pow(hours+2, s.Gravity)
+}
+
+func (s *WeightedRankingScorer) NeedCommentCnt() bool {
+	return s.CommentWeight != 0
+}
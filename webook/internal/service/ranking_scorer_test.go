@@ -0,0 +1,32 @@
+package service
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestWeightedRankingScorer(t *testing.T) {
+	now := time.Now()
+	scorer := &WeightedRankingScorer{
+		ReadWeight:    0.1,
+		LikeWeight:    1,
+		CollectWeight: 2,
+		CommentWeight: 1.5,
+		Gravity:       1.8,
+	}
+	assert.True(t, scorer.NeedCommentCnt())
+
+	base := RankingItem{Utime: now, ReadCnt: 100, LikeCnt: 10, CollectCnt: 5, CommentCnt: 4}
+	// 互动越多分越高
+	more := base
+	more.CommentCnt = 10
+	assert.Greater(t, scorer.Score(more), scorer.Score(base))
+	// 一样的互动，越旧分越低
+	old := base
+	old.Utime = now.Add(-24 * time.Hour)
+	assert.Less(t, scorer.Score(old), scorer.Score(base))
+
+	scorer.CommentWeight = 0
+	assert.False(t, scorer.NeedCommentCnt())
+}
@@ -1,11 +1,17 @@
 package service
 
 import (
+	"context"
+	intrv1 "github.com/basic-go-project-webook/webook/api/proto/gen/intr/v1"
 	domain2 "github.com/basic-go-project-webook/webook/interactive/domain"
 	"github.com/basic-go-project-webook/webook/interactive/service"
 	"github.com/basic-go-project-webook/webook/internal/domain"
+	"github.com/basic-go-project-webook/webook/internal/repository"
+	repomocks "github.com/basic-go-project-webook/webook/internal/repository/mocks"
 	svcmocks "github.com/basic-go-project-webook/webook/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
 	"testing"
 	"time"
 )
@@ -63,3 +69,144 @@ func TestRankingTopN(t *testing.T) {
 		})
 	}
 }
+
+// likeCntScorer 直接用点赞数当分数，方便断言名次
+type likeCntScorer struct{}
+
+func (likeCntScorer) Score(item RankingItem) float64 {
+	return float64(item.LikeCnt)
+}
+
+func (likeCntScorer) NeedCommentCnt() bool {
+	return false
+}
+
+func TestBatchRankingService_ComputeBoard(t *testing.T) {
+	now := time.Now()
+	board := domain.RankingBoard{Name: "go-kafka", Window: time.Hour, N: 2, Category: "Go", Tag: "kafka", Interval: time.Minute}
+	arts := []domain.Article{
+		{Id: 1, Category: "Go", Tags: []string{"kafka"}, Utime: now},
+		{Id: 2, Category: "Go", Tags: []string{"redis", "kafka"}, Utime: now},
+		{Id: 3, Category: "Java", Tags: []string{"kafka"}, Utime: now},
+		{Id: 4, Category: "Go", Utime: now},
+		{Id: 6, Category: "Go", Tags: []string{"kafka"}, Utime: now.Add(-time.Minute)},
+		// 超出了统计的时间范围
+		{Id: 5, Category: "Go", Tags: []string{"kafka"}, Utime: now.Add(-time.Hour * 2)},
+	}
+	testCases := []struct {
+		name    string
+		board   string
+		mock    func(ctrl *gomock.Controller) (ArticleService, intrv1.InteractiveServiceClient, repository.RankingRepository)
+		wantErr error
+	}{
+		{
+			name:  "按照分类和标签过滤之后取前 N 篇",
+			board: "go-kafka",
+			mock: func(ctrl *gomock.Controller) (ArticleService, intrv1.InteractiveServiceClient, repository.RankingRepository) {
+				artSvc := svcmocks.NewMockArticleService(ctrl)
+				intrSvc := svcmocks.NewMockInteractiveServiceClient(ctrl)
+				repo := repomocks.NewMockRankingRepository(ctrl)
+				artSvc.EXPECT().ListPub(gomock.Any(), gomock.Any(), 0, 100).Return(arts, nil)
+				intrSvc.EXPECT().GetByIds(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, req *intrv1.GetByIdsRequest, _ ...grpc.CallOption) (*intrv1.GetByIdsResponse, error) {
+						assert.Equal(t, []int64{1, 2, 6}, req.GetIds())
+						return &intrv1.GetByIdsResponse{Intrs: map[int64]*intrv1.Interactive{
+							1: {BizId: 1, LikeCnt: 1},
+							2: {BizId: 2, LikeCnt: 5},
+							6: {BizId: 6, LikeCnt: 3},
+						}}, nil
+					})
+				repo.EXPECT().ReplaceBoard(gomock.Any(), board, []domain.RankedArticle{
+					{Article: arts[1], Rank: 1, Score: 5},
+					{Article: arts[4], Rank: 2, Score: 3},
+				}).Return(nil)
+				return artSvc, intrSvc, repo
+			},
+		},
+		{
+			name:  "榜单不存在",
+			board: "monthly",
+			mock: func(ctrl *gomock.Controller) (ArticleService, intrv1.InteractiveServiceClient, repository.RankingRepository) {
+				return svcmocks.NewMockArticleService(ctrl), svcmocks.NewMockInteractiveServiceClient(ctrl),
+					repomocks.NewMockRankingRepository(ctrl)
+			},
+			wantErr: ErrUnknownRankingBoard,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			artSvc, intrSvc, repo := tc.mock(ctrl)
+			svc := NewBatchRankingService(artSvc, intrSvc, nil, repo, likeCntScorer{}, []domain.RankingBoard{board})
+			err := svc.ComputeBoard(context.Background(), tc.board)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func TestBatchRankingService_GetBoard(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := repomocks.NewMockRankingRepository(ctrl)
+	ranked := []domain.RankedArticle{{Article: domain.Article{Id: 3}, Rank: 11, Score: 2}}
+	repo.EXPECT().GetBoard(gomock.Any(), "daily", 10, 10).Return(ranked, nil)
+
+	svc := NewBatchRankingService(nil, nil, nil, repo, likeCntScorer{}, []domain.RankingBoard{{Name: "daily"}})
+	res, err := svc.GetBoard(context.Background(), "daily", 10, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, ranked, res)
+	_, err = svc.GetBoard(context.Background(), "monthly", 0, 10)
+	assert.Equal(t, ErrUnknownRankingBoard, err)
+}
+
+func TestBatchRankingService_GetRank(t *testing.T) {
+	testCases := []struct {
+		name    string
+		board   string
+		mock    func(ctrl *gomock.Controller) repository.RankingRepository
+		wantRes domain.RankedArticle
+		wantErr error
+	}{
+		{
+			name:  "在榜单上",
+			board: "daily",
+			mock: func(ctrl *gomock.Controller) repository.RankingRepository {
+				repo := repomocks.NewMockRankingRepository(ctrl)
+				repo.EXPECT().GetRank(gomock.Any(), "daily", int64(7)).
+					Return(domain.RankedArticle{Article: domain.Article{Id: 7}, Rank: 3, Score: 6}, nil)
+				return repo
+			},
+			wantRes: domain.RankedArticle{Article: domain.Article{Id: 7}, Rank: 3, Score: 6},
+		},
+		{
+			name:  "不在榜单上",
+			board: "daily",
+			mock: func(ctrl *gomock.Controller) repository.RankingRepository {
+				repo := repomocks.NewMockRankingRepository(ctrl)
+				repo.EXPECT().GetRank(gomock.Any(), "daily", int64(7)).
+					Return(domain.RankedArticle{}, repository.ErrArticleNotRanked)
+				return repo
+			},
+			wantErr: ErrArticleNotRanked,
+		},
+		{
+			name:  "榜单不存在",
+			board: "monthly",
+			mock: func(ctrl *gomock.Controller) repository.RankingRepository {
+				return repomocks.NewMockRankingRepository(ctrl)
+			},
+			wantErr: ErrUnknownRankingBoard,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := NewBatchRankingService(nil, nil, nil, tc.mock(ctrl), likeCntScorer{}, []domain.RankingBoard{{Name: "daily"}})
+			res, err := svc.GetRank(context.Background(), tc.board, 7)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantRes, res)
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"github.com/basic-go-project-webook/webook/internal/domain"
+	events "github.com/basic-go-project-webook/webook/internal/events/user"
 	"github.com/basic-go-project-webook/webook/internal/repository"
 	"github.com/gin-gonic/gin"
 	"golang.org/x/crypto/bcrypt"
@@ -12,6 +13,7 @@ import (
 var (
 	ErrUserDuplicateEmail    = repository.ErrUserDuplicateEmail
 	ErrInvalidUserOrPassword = errors.New("邮箱或密码错误")
+	ErrUserNotFound          = repository.ErrUserNotFound
 )
 
 type UserService interface {
@@ -20,7 +22,10 @@ type UserService interface {
 	Profile(ctx context.Context, id int64) (domain.User, error)
 	FindOrCreate(ctx *gin.Context, phone string) (domain.User, error)
 	Edit(ctx *gin.Context, user domain.User) error
-	FindOrCreateByWechat(ctx *gin.Context, info domain.WechatInfo) (domain.User, error)
+	// FindOrCreateByIdentity 第三方登录，第一次登录的时候创建用户
+	FindOrCreateByIdentity(ctx context.Context, identity domain.Identity) (domain.User, error)
+	// ListAfter 按照 id 升序翻页，全量导入搜索索引的时候用
+	ListAfter(ctx context.Context, minId int64, limit int) ([]domain.User, error)
 }
 
 type userService struct {
@@ -58,6 +63,10 @@ func (svc *userService) Profile(ctx context.Context, id int64) (domain.User, err
 	return user, err
 }
 
+func (svc *userService) ListAfter(ctx context.Context, minId int64, limit int) ([]domain.User, error) {
+	return svc.repo.ListAfter(ctx, minId, limit)
+}
+
 func (svc *userService) FindOrCreate(ctx *gin.Context, phone string) (domain.User, error) {
 	user, err := svc.repo.FindByPhone(ctx, phone)
 	if !errors.Is(err, repository.ErrUserNotFound) {
@@ -73,21 +82,32 @@ func (svc *userService) FindOrCreate(ctx *gin.Context, phone string) (domain.Use
 	return svc.repo.FindByPhone(ctx, phone)
 }
 
-func (svc *userService) FindOrCreateByWechat(ctx *gin.Context, info domain.WechatInfo) (domain.User, error) {
-	user, err := svc.repo.FindByWechat(ctx, info.OpenId)
+func (svc *userService) FindOrCreateByIdentity(ctx context.Context, identity domain.Identity) (domain.User, error) {
+	user, err := svc.repo.FindByIdentity(ctx, identity.Provider, identity.ExternalId)
 	if !errors.Is(err, repository.ErrUserNotFound) {
 		return user, err
 	}
+	// 第三方给的邮箱不一定验证过，不能直接当成登录邮箱
 	u := domain.User{
-		WechatInfo: info,
+		Nickname: identity.Nickname,
 	}
-	err = svc.repo.Create(ctx, u)
-	if err != nil {
-		return u, err
+	err = svc.repo.CreateWithIdentity(ctx, u, identity)
+	// 重复说明同一个第三方账号并发登录，另外一个请求已经创建好了
+	if err != nil && !errors.Is(err, repository.ErrUserDuplicateEmail) {
+		return domain.User{}, err
 	}
-	return svc.repo.FindByWechat(ctx, info.OpenId)
+	return svc.repo.FindByIdentity(ctx, identity.Provider, identity.ExternalId)
 }
 
+// Edit 资料修改的消息通过 outbox 和资料在同一个事务里面写入，不会出现改了资料搜索却没有更新
 func (svc *userService) Edit(ctx *gin.Context, user domain.User) error {
-	return svc.repo.UpdateById(ctx, user)
+	msg, err := events.NewOutboxMessage(events.UserUpdated{
+		Version:  events.UserEventVersion,
+		Uid:      user.Id,
+		Nickname: user.Nickname,
+	})
+	if err != nil {
+		return err
+	}
+	return svc.repo.UpdateById(ctx, user, []domain.OutboxMessage{msg})
 }
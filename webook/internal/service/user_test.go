@@ -95,6 +95,82 @@ func Test_userService_Login(t *testing.T) {
 	}
 }
 
+func Test_userService_FindOrCreateByIdentity(t *testing.T) {
+	identity := domain.Identity{
+		Provider:   "github",
+		ExternalId: "123",
+		Nickname:   "octocat",
+		Email:      "octocat@github.com",
+	}
+	testCases := []struct {
+		name     string
+		mock     func(ctrl *gomock.Controller) repository.UserRepository
+		wantUser domain.User
+		wantErr  error
+	}{
+		{
+			name: "已经登录过",
+			mock: func(ctrl *gomock.Controller) repository.UserRepository {
+				repo := repomocks.NewMockUserRepository(ctrl)
+				repo.EXPECT().FindByIdentity(gomock.Any(), "github", "123").Return(domain.User{Id: 1}, nil)
+				return repo
+			},
+			wantUser: domain.User{Id: 1},
+		},
+		{
+			name: "第一次登录",
+			mock: func(ctrl *gomock.Controller) repository.UserRepository {
+				repo := repomocks.NewMockUserRepository(ctrl)
+				first := repo.EXPECT().FindByIdentity(gomock.Any(), "github", "123").
+					Return(domain.User{}, repository.ErrUserNotFound)
+				// 第三方的邮箱不能当成登录邮箱
+				create := repo.EXPECT().CreateWithIdentity(gomock.Any(), domain.User{Nickname: "octocat"}, identity).
+					Return(nil).After(first)
+				repo.EXPECT().FindByIdentity(gomock.Any(), "github", "123").
+					Return(domain.User{Id: 2, Nickname: "octocat"}, nil).After(create)
+				return repo
+			},
+			wantUser: domain.User{Id: 2, Nickname: "octocat"},
+		},
+		{
+			name: "并发登录，另外一个请求已经创建了",
+			mock: func(ctrl *gomock.Controller) repository.UserRepository {
+				repo := repomocks.NewMockUserRepository(ctrl)
+				first := repo.EXPECT().FindByIdentity(gomock.Any(), "github", "123").
+					Return(domain.User{}, repository.ErrUserNotFound)
+				create := repo.EXPECT().CreateWithIdentity(gomock.Any(), gomock.Any(), identity).
+					Return(repository.ErrUserDuplicateEmail).After(first)
+				repo.EXPECT().FindByIdentity(gomock.Any(), "github", "123").
+					Return(domain.User{Id: 2}, nil).After(create)
+				return repo
+			},
+			wantUser: domain.User{Id: 2},
+		},
+		{
+			name: "创建失败",
+			mock: func(ctrl *gomock.Controller) repository.UserRepository {
+				repo := repomocks.NewMockUserRepository(ctrl)
+				repo.EXPECT().FindByIdentity(gomock.Any(), "github", "123").
+					Return(domain.User{}, repository.ErrUserNotFound)
+				repo.EXPECT().CreateWithIdentity(gomock.Any(), gomock.Any(), identity).
+					Return(errors.New("DB error"))
+				return repo
+			},
+			wantErr: errors.New("DB error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := NewUserService(tc.mock(ctrl))
+			user, err := svc.FindOrCreateByIdentity(context.Background(), identity)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantUser, user)
+		})
+	}
+}
+
 func TestEncrypted(t *testing.T) {
 	res, err := bcrypt.GenerateFromPassword([]byte("hello#world123"), bcrypt.DefaultCost)
 	if err == nil {
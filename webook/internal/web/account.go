@@ -0,0 +1,401 @@
+package web
+
+import (
+	"errors"
+	"github.com/basic-go-project-webook/webook/internal/service"
+	ijwt "github.com/basic-go-project-webook/webook/internal/web/jwt"
+	regexp "github.com/dlclark/regexp2"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"go.uber.org/zap"
+	"net/http"
+	"time"
+)
+
+const (
+	bindPhoneBiz = "bind_phone"
+	bindEmailBiz = "bind_email"
+)
+
+// MergeKey 签名合并凭证用的，AccountHandler 和 OAuth2Handler 共用
+type MergeKey []byte
+
+// AccountHandler 绑定和解绑登录方式，以及账号合并
+type AccountHandler struct {
+	ijwt.Handler
+	svc          service.AccountService
+	codeSvc      service.CodeService
+	emailCodeSvc service.EmailCodeService
+	emailExp     *regexp.Regexp
+	phoneExp     *regexp.Regexp
+	mergeKey     MergeKey
+}
+
+func NewAccountHandler(svc service.AccountService, codeSvc service.CodeService,
+	emailCodeSvc service.EmailCodeService, jwtHdl ijwt.Handler, mergeKey MergeKey) *AccountHandler {
+	return &AccountHandler{
+		svc:          svc,
+		codeSvc:      codeSvc,
+		emailCodeSvc: emailCodeSvc,
+		emailExp:     regexp.MustCompile(emailRegexPattern, regexp.None),
+		phoneExp:     regexp.MustCompile(phoneRegexPattern, regexp.None),
+		mergeKey:     mergeKey,
+		Handler:      jwtHdl,
+	}
+}
+
+func (h *AccountHandler) RegisterRoutes(server *gin.Engine) {
+	ug := server.Group("/users")
+	ug.POST("/bind/phone/code/send", h.SendBindPhoneCode)
+	ug.POST("/bind/phone", h.BindPhone)
+	ug.POST("/unbind/phone", h.UnbindPhone)
+	ug.POST("/bind/email/code/send", h.SendBindEmailCode)
+	ug.POST("/bind/email", h.BindEmail)
+	ug.POST("/unbind/email", h.UnbindEmail)
+	ug.GET("/identities", h.Identities)
+	ug.POST("/unbind/identity", h.UnbindIdentity)
+	ug.POST("/merge", h.Merge)
+}
+
+func (h *AccountHandler) SendBindPhoneCode(ctx *gin.Context) {
+	type Req struct {
+		Phone string `json:"phone"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	if !h.match(ctx, h.phoneExp, req.Phone, "手机号输入错误") {
+		return
+	}
+	h.sendCodeResult(ctx, h.codeSvc.Send(ctx, bindPhoneBiz, req.Phone))
+}
+
+func (h *AccountHandler) BindPhone(ctx *gin.Context) {
+	type Req struct {
+		Phone string `json:"phone"`
+		Code  string `json:"code"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	claims, ok := h.parseClaims(ctx)
+	if !ok {
+		return
+	}
+	if !h.match(ctx, h.phoneExp, req.Phone, "手机号输入错误") {
+		return
+	}
+	ok, err := h.codeSvc.Verify(ctx, bindPhoneBiz, req.Phone, req.Code)
+	if !h.verifyCodeResult(ctx, ok, err) {
+		return
+	}
+	writeBindResult(ctx, h.mergeKey, claims.Uid, h.svc.BindPhone(ctx, claims.Uid, req.Phone))
+}
+
+func (h *AccountHandler) UnbindPhone(ctx *gin.Context) {
+	claims, ok := h.parseClaims(ctx)
+	if !ok {
+		return
+	}
+	writeUnbindResult(ctx, claims.Uid, h.svc.UnbindPhone(ctx, claims.Uid))
+}
+
+func (h *AccountHandler) SendBindEmailCode(ctx *gin.Context) {
+	type Req struct {
+		Email string `json:"email"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	if !h.match(ctx, h.emailExp, req.Email, "邮箱格式不对") {
+		return
+	}
+	h.sendCodeResult(ctx, h.emailCodeSvc.Send(ctx, bindEmailBiz, req.Email))
+}
+
+func (h *AccountHandler) BindEmail(ctx *gin.Context) {
+	type Req struct {
+		Email string `json:"email"`
+		Code  string `json:"code"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	claims, ok := h.parseClaims(ctx)
+	if !ok {
+		return
+	}
+	if !h.match(ctx, h.emailExp, req.Email, "邮箱格式不对") {
+		return
+	}
+	ok, err := h.emailCodeSvc.Verify(ctx, bindEmailBiz, req.Email, req.Code)
+	if !h.verifyCodeResult(ctx, ok, err) {
+		return
+	}
+	writeBindResult(ctx, h.mergeKey, claims.Uid, h.svc.BindEmail(ctx, claims.Uid, req.Email))
+}
+
+func (h *AccountHandler) UnbindEmail(ctx *gin.Context) {
+	claims, ok := h.parseClaims(ctx)
+	if !ok {
+		return
+	}
+	writeUnbindResult(ctx, claims.Uid, h.svc.UnbindEmail(ctx, claims.Uid))
+}
+
+func (h *AccountHandler) Identities(ctx *gin.Context) {
+	claims, ok := h.parseClaims(ctx)
+	if !ok {
+		return
+	}
+	identities, err := h.svc.Identities(ctx, claims.Uid)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统异常",
+		})
+		zap.L().Error("查询第三方账号失败", zap.Error(err), zap.Int64("uid", claims.Uid))
+		return
+	}
+	res := make([]IdentityVO, 0, len(identities))
+	for _, identity := range identities {
+		res = append(res, IdentityVO{
+			Provider: identity.Provider,
+			Nickname: identity.Nickname,
+			Ctime:    identity.Ctime.Format(time.DateTime),
+		})
+	}
+	ctx.JSON(http.StatusOK, Result{
+		Data: res,
+	})
+}
+
+func (h *AccountHandler) UnbindIdentity(ctx *gin.Context) {
+	type Req struct {
+		Provider string `json:"provider"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	claims, ok := h.parseClaims(ctx)
+	if !ok {
+		return
+	}
+	writeUnbindResult(ctx, claims.Uid, h.svc.UnbindIdentity(ctx, claims.Uid, req.Provider))
+}
+
+// Merge 绑定的时候发现已经属于别的账号，前端确认之后带着凭证过来合并。
+// 凭证里面的两个账号都已经验证过所有权了，失败了可以用同一个凭证重试
+func (h *AccountHandler) Merge(ctx *gin.Context) {
+	type Req struct {
+		Ticket string `json:"ticket"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	claims, ok := h.parseClaims(ctx)
+	if !ok {
+		return
+	}
+	var mc MergeClaims
+	token, err := jwt.ParseWithClaims(req.Ticket, &mc, func(token *jwt.Token) (interface{}, error) {
+		return []byte(h.mergeKey), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	if err != nil || !token.Valid || mc.Dst != claims.Uid {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "合并凭证无效",
+		})
+		zap.L().Warn("合并凭证无效", zap.Error(err), zap.Int64("uid", claims.Uid))
+		return
+	}
+	err = h.svc.Merge(ctx, mc.Dst, mc.Src)
+	switch {
+	case err == nil:
+	case errors.Is(err, service.ErrMergeConflict):
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "两个账号绑定了同一种登录方式，请先解绑再合并",
+		})
+		return
+	case errors.Is(err, service.ErrUserMerged):
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "账号已经合并过了",
+		})
+		return
+	default:
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统异常",
+		})
+		zap.L().Error("合并账号失败", zap.Error(err),
+			zap.Int64("src", mc.Src), zap.Int64("dst", mc.Dst))
+		return
+	}
+	// 被合并的账号不能再用了，已经登录的设备全部踢掉
+	err = h.Handler.RevokeOtherSessions(ctx, mc.Src, "")
+	// 被合并的账号没有登录的设备也算成功
+	if err != nil && !errors.Is(err, ijwt.ErrSessionNotFound) {
+		zap.L().Error("踢掉被合并账号的设备失败", zap.Error(err), zap.Int64("src", mc.Src))
+	}
+	ctx.JSON(http.StatusOK, Result{
+		Msg: "合并成功",
+	})
+}
+
+func (h *AccountHandler) match(ctx *gin.Context, exp *regexp.Regexp, val string, msg string) bool {
+	ok, err := exp.MatchString(val)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统异常",
+		})
+		return false
+	}
+	if !ok {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  msg,
+		})
+	}
+	return ok
+}
+
+func (h *AccountHandler) sendCodeResult(ctx *gin.Context, err error) {
+	switch {
+	case err == nil:
+		ctx.JSON(http.StatusOK, Result{
+			Msg: "发送成功",
+		})
+	case errors.Is(err, service.ErrCodeSendTooMany):
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "发送太频繁，请稍后再试",
+		})
+	default:
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统异常",
+		})
+		zap.L().Error("发送绑定验证码失败", zap.Error(err))
+	}
+}
+
+func (h *AccountHandler) verifyCodeResult(ctx *gin.Context, ok bool, err error) bool {
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统异常",
+		})
+		zap.L().Error("验证码校验异常", zap.Error(err))
+		return false
+	}
+	if !ok {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "验证码有误",
+		})
+	}
+	return ok
+}
+
+func (h *AccountHandler) parseClaims(ctx *gin.Context) (ijwt.UserClaims, bool) {
+	var claims ijwt.UserClaims
+	token, err := jwt.ParseWithClaims(h.ExtractToken(ctx), &claims, h.AccessKey)
+	if err != nil || !token.Valid {
+		ctx.AbortWithStatus(http.StatusUnauthorized)
+		return claims, false
+	}
+	return claims, true
+}
+
+// writeBindResult 已经被别的账号绑定的时候返回合并凭证，OAuth2Handler 绑定第三方账号的时候也用
+func writeBindResult(ctx *gin.Context, mergeKey MergeKey, uid int64, err error) {
+	var conflict *service.ConflictError
+	switch {
+	case err == nil:
+		ctx.JSON(http.StatusOK, Result{
+			Msg: "绑定成功",
+		})
+	case errors.As(err, &conflict):
+		ticket, er := signMergeTicket(mergeKey, uid, conflict.Uid)
+		if er != nil {
+			ctx.JSON(http.StatusOK, Result{
+				Code: 5,
+				Msg:  "系统异常",
+			})
+			zap.L().Error("生成合并凭证失败", zap.Error(er))
+			return
+		}
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "已经被另外一个账号绑定，可以把那个账号合并过来",
+			Data: MergeTicketVO{Ticket: ticket},
+		})
+	case errors.Is(err, service.ErrProviderBound):
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "已经绑定过这个第三方的另外一个账号，请先解绑",
+		})
+	default:
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统异常",
+		})
+		zap.L().Error("绑定失败", zap.Error(err), zap.Int64("uid", uid))
+	}
+}
+
+func writeUnbindResult(ctx *gin.Context, uid int64, err error) {
+	switch {
+	case err == nil:
+		ctx.JSON(http.StatusOK, Result{
+			Msg: "解绑成功",
+		})
+	case errors.Is(err, service.ErrLastLoginMethod):
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "至少要保留一种登录方式",
+		})
+	default:
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统异常",
+		})
+		zap.L().Error("解绑失败", zap.Error(err), zap.Int64("uid", uid))
+	}
+}
+
+func signMergeTicket(mergeKey MergeKey, dst int64, src int64) (string, error) {
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, MergeClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute * 10)),
+		},
+		Src: src,
+		Dst: dst,
+	}).SignedString([]byte(mergeKey))
+}
+
+// MergeClaims 合并凭证，只有 Dst 自己可以使用
+type MergeClaims struct {
+	jwt.RegisteredClaims
+	Src int64 `json:"src"`
+	Dst int64 `json:"dst"`
+}
+
+type IdentityVO struct {
+	Provider string `json:"provider"`
+	Nickname string `json:"nickname"`
+	Ctime    string `json:"ctime"`
+}
+
+type MergeTicketVO struct {
+	Ticket string `json:"ticket"`
+}
@@ -2,6 +2,7 @@ package web
 
 import (
 	"context"
+	"errors"
 	intrv1 "github.com/basic-go-project-webook/webook/api/proto/gen/intr/v1"
 	"github.com/basic-go-project-webook/webook/internal/domain"
 	"github.com/basic-go-project-webook/webook/internal/service"
@@ -10,6 +11,8 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"net/http"
 	"strconv"
 	"time"
@@ -36,13 +39,24 @@ func (h *ArticleHandle) RegisterRoutes(server *gin.Engine) {
 	g.POST("/edit", h.Edit)
 	g.POST("/publish", h.Publish)
 	g.POST("/withdraw", h.Withdraw)
+	g.POST("/schedule", h.Schedule)
+	g.POST("/schedule/cancel", h.CancelSchedule)
 	g.POST("/list", h.List)
 	g.GET("/detail/:id", h.Detail)
+	g.GET("/tags", h.SearchTags)
+
+	his := g.Group("/history")
+	his.POST("/list", h.ListHistory)
+	his.POST("/diff", h.DiffHistory)
+	his.POST("/restore", h.RestoreHistory)
 
 	pub := g.Group("/pub")
 	pub.GET("/:id", h.PubDetail)
+	pub.GET("/tag", h.ListPubByTag)
 	pub.POST("/like", h.Like)
 	pub.POST("/collect", h.Collect)
+	pub.POST("/cancel_collect", h.CancelCollect)
+	pub.POST("/move_collect", h.MoveCollect)
 }
 
 func (h *ArticleHandle) List(ctx *gin.Context) {
@@ -55,9 +69,7 @@ func (h *ArticleHandle) List(ctx *gin.Context) {
 
 	var claims ijwt.UserClaims
 	tokenStr := h.ExtractToken(ctx)
-	token, err := jwt.ParseWithClaims(tokenStr, &claims, func(token *jwt.Token) (interface{}, error) {
-		return ijwt.AtKey, nil
-	})
+	token, err := jwt.ParseWithClaims(tokenStr, &claims, h.AccessKey)
 	if err != nil || !token.Valid {
 		ctx.JSON(http.StatusOK, &Result{
 			Code: 5,
@@ -97,9 +109,7 @@ func (h *ArticleHandle) Withdraw(ctx *gin.Context) {
 
 	var claims ijwt.UserClaims
 	tokenStr := h.ExtractToken(ctx)
-	token, err := jwt.ParseWithClaims(tokenStr, &claims, func(token *jwt.Token) (interface{}, error) {
-		return ijwt.AtKey, nil
-	})
+	token, err := jwt.ParseWithClaims(tokenStr, &claims, h.AccessKey)
 	if err != nil || !token.Valid {
 		ctx.JSON(http.StatusOK, &Result{
 			Code: 5,
@@ -140,9 +150,7 @@ func (h *ArticleHandle) Publish(ctx *gin.Context) {
 	}
 	var claims ijwt.UserClaims
 	tokenStr := h.ExtractToken(ctx)
-	token, err := jwt.ParseWithClaims(tokenStr, &claims, func(token *jwt.Token) (interface{}, error) {
-		return ijwt.AtKey, nil
-	})
+	token, err := jwt.ParseWithClaims(tokenStr, &claims, h.AccessKey)
 	if err != nil || !token.Valid {
 		ctx.JSON(http.StatusOK, &Result{
 			Code: 5,
@@ -153,6 +161,13 @@ func (h *ArticleHandle) Publish(ctx *gin.Context) {
 	}
 
 	artId, err := h.svc.Publish(ctx, req.toDomain(claims.Uid))
+	if errors.Is(err, service.ErrInvalidArticleTags) {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "标签或者分类不合法",
+		})
+		return
+	}
 	if err != nil {
 		zap.L().Error("发表帖子出错", zap.Error(err))
 		ctx.JSON(http.StatusOK, Result{
@@ -179,9 +194,7 @@ func (h *ArticleHandle) Edit(ctx *gin.Context) {
 	// 调用 service 代码
 	var claims ijwt.UserClaims
 	tokenStr := h.ExtractToken(ctx)
-	token, err := jwt.ParseWithClaims(tokenStr, &claims, func(token *jwt.Token) (interface{}, error) {
-		return ijwt.AtKey, nil
-	})
+	token, err := jwt.ParseWithClaims(tokenStr, &claims, h.AccessKey)
 	if err != nil || !token.Valid {
 		ctx.JSON(http.StatusOK, &Result{
 			Code: 5,
@@ -191,6 +204,13 @@ func (h *ArticleHandle) Edit(ctx *gin.Context) {
 		return
 	}
 	artId, err := h.svc.Save(ctx, req.toDomain(claims.Uid))
+	if errors.Is(err, service.ErrInvalidArticleTags) {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "标签或者分类不合法",
+		})
+		return
+	}
 	if err != nil {
 		zap.L().Error("文章保存或更新出错", zap.Error(err))
 		ctx.JSON(http.StatusOK, Result{
@@ -228,9 +248,7 @@ func (h *ArticleHandle) Detail(ctx *gin.Context) {
 	}
 	var claims ijwt.UserClaims
 	tokenStr := h.ExtractToken(ctx)
-	token, err := jwt.ParseWithClaims(tokenStr, &claims, func(token *jwt.Token) (interface{}, error) {
-		return ijwt.AtKey, nil
-	})
+	token, err := jwt.ParseWithClaims(tokenStr, &claims, h.AccessKey)
 	if err != nil || !token.Valid || claims.Uid != art.Author.Id {
 		ctx.JSON(http.StatusOK, &Result{
 			Code: 5,
@@ -250,6 +268,8 @@ func (h *ArticleHandle) Detail(ctx *gin.Context) {
 			Content:    art.Content,
 			AuthorId:   art.Author.Id,
 			AuthorName: art.Author.Name,
+			Category:   art.Category,
+			Tags:       art.Tags,
 			Status:     art.Status.ToUint8(),
 			Ctime:      art.Ctime.Format("2006-01-02 15:04:05"),
 			Utime:      art.Utime.Format("2006-01-02 15:04:05"),
@@ -277,9 +297,7 @@ func (h *ArticleHandle) PubDetail(ctx *gin.Context) {
 
 	var claims ijwt.UserClaims
 	tokenStr := h.ExtractToken(ctx)
-	token, err := jwt.ParseWithClaims(tokenStr, &claims, func(token *jwt.Token) (interface{}, error) {
-		return ijwt.AtKey, nil
-	})
+	token, err := jwt.ParseWithClaims(tokenStr, &claims, h.AccessKey)
 	if err != nil || !token.Valid {
 		ctx.JSON(http.StatusOK, &Result{
 			Code: 5,
@@ -338,6 +356,8 @@ func (h *ArticleHandle) PubDetail(ctx *gin.Context) {
 			Content:    art.Content,
 			AuthorId:   art.Author.Id,
 			AuthorName: art.Author.Name,
+			Category:   art.Category,
+			Tags:       art.Tags,
 			Status:     art.Status.ToUint8(),
 			Ctime:      art.Ctime.Format("2006-01-02 15:04:05"),
 			Utime:      art.Utime.Format("2006-01-02 15:04:05"),
@@ -364,9 +384,7 @@ func (h *ArticleHandle) Like(ctx *gin.Context) {
 
 	var claims ijwt.UserClaims
 	tokenStr := h.ExtractToken(ctx)
-	token, err := jwt.ParseWithClaims(tokenStr, &claims, func(token *jwt.Token) (interface{}, error) {
-		return ijwt.AtKey, nil
-	})
+	token, err := jwt.ParseWithClaims(tokenStr, &claims, h.AccessKey)
 	if err != nil || !token.Valid {
 		ctx.JSON(http.StatusOK, &Result{
 			Code: 5,
@@ -418,9 +436,7 @@ func (h *ArticleHandle) Collect(ctx *gin.Context) {
 	}
 	var claims ijwt.UserClaims
 	tokenStr := h.ExtractToken(ctx)
-	token, err := jwt.ParseWithClaims(tokenStr, &claims, func(token *jwt.Token) (interface{}, error) {
-		return ijwt.AtKey, nil
-	})
+	token, err := jwt.ParseWithClaims(tokenStr, &claims, h.AccessKey)
 	if err != nil || !token.Valid {
 		ctx.JSON(http.StatusOK, &Result{
 			Code: 5,
@@ -436,6 +452,13 @@ func (h *ArticleHandle) Collect(ctx *gin.Context) {
 		Cid:   req.Cid,
 		Uid:   claims.Uid,
 	})
+	if status.Code(err) == codes.NotFound {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "收藏夹不存在",
+		})
+		return
+	}
 	if err != nil {
 		ctx.JSON(http.StatusOK, Result{
 			Code: 5,
@@ -450,10 +473,106 @@ func (h *ArticleHandle) Collect(ctx *gin.Context) {
 	})
 }
 
+func (h *ArticleHandle) CancelCollect(ctx *gin.Context) {
+	type Req struct {
+		Id string `json:"id"`
+	}
+
+	var req Req
+	err := ctx.Bind(&req)
+	if err != nil {
+		zap.L().Error("Article CancelCollect Bind 错误", zap.Error(err))
+		return
+	}
+	var claims ijwt.UserClaims
+	tokenStr := h.ExtractToken(ctx)
+	token, err := jwt.ParseWithClaims(tokenStr, &claims, h.AccessKey)
+	if err != nil || !token.Valid {
+		ctx.JSON(http.StatusOK, &Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		zap.L().Error("未发现用户信息，用户未登录", zap.Error(err))
+		return
+	}
+	id, _ := strconv.ParseInt(req.Id, 10, 64)
+	_, err = h.intrSvc.CancelCollect(ctx, &intrv1.CancelCollectRequest{
+		Biz:   h.biz,
+		BizId: id,
+		Uid:   claims.Uid,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		zap.L().Error("取消收藏失败", zap.Error(err), zap.Int64("id", id))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{
+		Code: 0,
+		Msg:  "OK",
+	})
+}
+
+// MoveCollect 把收藏的文章移动到另外一个收藏夹
+func (h *ArticleHandle) MoveCollect(ctx *gin.Context) {
+	type Req struct {
+		Id  string `json:"id"`
+		Cid int64  `json:"cid"`
+	}
+
+	var req Req
+	err := ctx.Bind(&req)
+	if err != nil {
+		zap.L().Error("Article MoveCollect Bind 错误", zap.Error(err))
+		return
+	}
+	var claims ijwt.UserClaims
+	tokenStr := h.ExtractToken(ctx)
+	token, err := jwt.ParseWithClaims(tokenStr, &claims, h.AccessKey)
+	if err != nil || !token.Valid {
+		ctx.JSON(http.StatusOK, &Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		zap.L().Error("未发现用户信息，用户未登录", zap.Error(err))
+		return
+	}
+	id, _ := strconv.ParseInt(req.Id, 10, 64)
+	_, err = h.intrSvc.MoveCollectionItem(ctx, &intrv1.MoveCollectionItemRequest{
+		Biz:   h.biz,
+		BizId: id,
+		Uid:   claims.Uid,
+		Cid:   req.Cid,
+	})
+	if status.Code(err) == codes.NotFound {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "收藏夹不存在或者没有收藏",
+		})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		zap.L().Error("移动收藏失败", zap.Error(err), zap.Int64("cid", req.Cid), zap.Int64("id", id))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{
+		Code: 0,
+		Msg:  "OK",
+	})
+}
+
 type ArticleReq struct {
-	Id      string `json:"id"`
-	Title   string `json:"title"`
-	Content string `json:"content"`
+	Id       string   `json:"id"`
+	Title    string   `json:"title"`
+	Content  string   `json:"content"`
+	Category string   `json:"category"`
+	Tags     []string `json:"tags"`
 }
 
 func (req *ArticleReq) toDomain(uid int64) domain.Article {
@@ -465,5 +584,7 @@ func (req *ArticleReq) toDomain(uid int64) domain.Article {
 		Author: domain.Author{
 			Id: uid,
 		},
+		Category: req.Category,
+		Tags:     req.Tags,
 	}
 }
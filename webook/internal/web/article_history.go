@@ -0,0 +1,171 @@
+package web
+
+import (
+	"errors"
+	"github.com/basic-go-project-webook/webook/internal/service"
+	ijwt "github.com/basic-go-project-webook/webook/internal/web/jwt"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"go.uber.org/zap"
+	"net/http"
+	"strconv"
+)
+
+// ListHistory 列出文章的历史版本，最新的在前面
+func (h *ArticleHandle) ListHistory(ctx *gin.Context) {
+	type Req struct {
+		Id     string `json:"id"`
+		Offset int    `json:"offset"`
+		Limit  int    `json:"limit"`
+	}
+	var req Req
+	err := ctx.Bind(&req)
+	if err != nil {
+		zap.L().Error("Article ListHistory Bind 错误", zap.Error(err))
+		return
+	}
+	var claims ijwt.UserClaims
+	tokenStr := h.ExtractToken(ctx)
+	token, err := jwt.ParseWithClaims(tokenStr, &claims, h.AccessKey)
+	if err != nil || !token.Valid {
+		ctx.JSON(http.StatusOK, &Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		zap.L().Error("未发现用户信息，用户未登录", zap.Error(err))
+		return
+	}
+	id, err := strconv.ParseInt(req.Id, 10, 64)
+	if err != nil || req.Offset < 0 || req.Limit <= 0 || req.Limit > 100 {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "参数错误",
+		})
+		return
+	}
+	his, err := h.svc.ListHistory(ctx, id, claims.Uid, req.Offset, req.Limit)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		zap.L().Error("查询文章历史版本失败", zap.Error(err), zap.Int64("id", id))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{
+		Code: 0,
+		Msg:  "OK",
+		Data: toArticleHistoryVOs(his),
+	})
+}
+
+// DiffHistory 比较两个历史版本
+func (h *ArticleHandle) DiffHistory(ctx *gin.Context) {
+	type Req struct {
+		Id   string `json:"id"`
+		From int64  `json:"from"`
+		To   int64  `json:"to"`
+	}
+	var req Req
+	err := ctx.Bind(&req)
+	if err != nil {
+		zap.L().Error("Article DiffHistory Bind 错误", zap.Error(err))
+		return
+	}
+	var claims ijwt.UserClaims
+	tokenStr := h.ExtractToken(ctx)
+	token, err := jwt.ParseWithClaims(tokenStr, &claims, h.AccessKey)
+	if err != nil || !token.Valid {
+		ctx.JSON(http.StatusOK, &Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		zap.L().Error("未发现用户信息，用户未登录", zap.Error(err))
+		return
+	}
+	id, err := strconv.ParseInt(req.Id, 10, 64)
+	if err != nil || req.From <= 0 || req.To <= 0 {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "参数错误",
+		})
+		return
+	}
+	diff, err := h.svc.DiffHistory(ctx, id, claims.Uid, req.From, req.To)
+	if errors.Is(err, service.ErrArticleHistoryNotFound) {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "版本不存在",
+		})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		zap.L().Error("比较文章历史版本失败", zap.Error(err), zap.Int64("id", id),
+			zap.Int64("from", req.From), zap.Int64("to", req.To))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{
+		Code: 0,
+		Msg:  "OK",
+		Data: toArticleDiffVO(diff),
+	})
+}
+
+// RestoreHistory 把历史版本恢复成草稿，已经发表的内容要重新发表才会更新
+func (h *ArticleHandle) RestoreHistory(ctx *gin.Context) {
+	type Req struct {
+		Id      string `json:"id"`
+		Version int64  `json:"version"`
+	}
+	var req Req
+	err := ctx.Bind(&req)
+	if err != nil {
+		zap.L().Error("Article RestoreHistory Bind 错误", zap.Error(err))
+		return
+	}
+	var claims ijwt.UserClaims
+	tokenStr := h.ExtractToken(ctx)
+	token, err := jwt.ParseWithClaims(tokenStr, &claims, h.AccessKey)
+	if err != nil || !token.Valid {
+		ctx.JSON(http.StatusOK, &Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		zap.L().Error("未发现用户信息，用户未登录", zap.Error(err))
+		return
+	}
+	id, err := strconv.ParseInt(req.Id, 10, 64)
+	if err != nil || req.Version <= 0 {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "参数错误",
+		})
+		return
+	}
+	version, err := h.svc.RestoreHistory(ctx, id, claims.Uid, req.Version)
+	if errors.Is(err, service.ErrArticleHistoryNotFound) {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "版本不存在",
+		})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		zap.L().Error("恢复文章历史版本失败", zap.Error(err), zap.Int64("id", id),
+			zap.Int64("version", req.Version))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{
+		Code: 0,
+		Msg:  "OK",
+		Data: version,
+	})
+}
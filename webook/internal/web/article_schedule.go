@@ -0,0 +1,111 @@
+package web
+
+import (
+	"errors"
+	"github.com/basic-go-project-webook/webook/internal/service"
+	ijwt "github.com/basic-go-project-webook/webook/internal/web/jwt"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"go.uber.org/zap"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Schedule 保存文章并且设置定时发表，重复调用会覆盖之前设置的时间
+func (h *ArticleHandle) Schedule(ctx *gin.Context) {
+	type Req struct {
+		ArticleReq
+		// PublishTime 发表时间，毫秒时间戳
+		PublishTime int64 `json:"publish_time"`
+	}
+	var req Req
+	err := ctx.Bind(&req)
+	if err != nil {
+		zap.L().Error("article schedule 绑定失败", zap.Error(err))
+		return
+	}
+	var claims ijwt.UserClaims
+	tokenStr := h.ExtractToken(ctx)
+	token, err := jwt.ParseWithClaims(tokenStr, &claims, h.AccessKey)
+	if err != nil || !token.Valid {
+		ctx.JSON(http.StatusOK, &Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		zap.L().Error("未发现用户信息，用户未登录", zap.Error(err))
+		return
+	}
+	artId, err := h.svc.Schedule(ctx, req.toDomain(claims.Uid), time.UnixMilli(req.PublishTime))
+	if errors.Is(err, service.ErrInvalidPublishTime) {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "发表时间必须在未来 30 天以内",
+		})
+		return
+	}
+	if errors.Is(err, service.ErrInvalidArticleTags) {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "标签或者分类不合法",
+		})
+		return
+	}
+	if err != nil {
+		zap.L().Error("设置定时发表出错", zap.Error(err))
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{
+		Code: 0,
+		Msg:  "OK",
+		Data: strconv.FormatInt(artId, 10),
+	})
+}
+
+func (h *ArticleHandle) CancelSchedule(ctx *gin.Context) {
+	type Req struct {
+		Id string `json:"id"`
+	}
+	var req Req
+	err := ctx.Bind(&req)
+	if err != nil {
+		zap.L().Error("article cancel schedule 绑定失败", zap.Error(err))
+		return
+	}
+	var claims ijwt.UserClaims
+	tokenStr := h.ExtractToken(ctx)
+	token, err := jwt.ParseWithClaims(tokenStr, &claims, h.AccessKey)
+	if err != nil || !token.Valid {
+		ctx.JSON(http.StatusOK, &Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		zap.L().Error("未发现用户信息，用户未登录", zap.Error(err))
+		return
+	}
+	id, _ := strconv.ParseInt(req.Id, 10, 64)
+	err = h.svc.CancelSchedule(ctx, id, claims.Uid)
+	if errors.Is(err, service.ErrArticleNotScheduled) {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "文章没有设置定时发表或者已经发表",
+		})
+		return
+	}
+	if err != nil {
+		zap.L().Error("取消定时发表出错", zap.Error(err), zap.Int64("id", id))
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{
+		Code: 0,
+		Msg:  "OK",
+	})
+}
@@ -0,0 +1,88 @@
+package web
+
+import (
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"net/http"
+	"strconv"
+)
+
+// ListPubByTag 按照标签查询已发表的文章，第一页不传 cursor，之后使用上一页返回的 cursor 翻页
+func (h *ArticleHandle) ListPubByTag(ctx *gin.Context) {
+	tag := ctx.Query("tag")
+	if tag == "" {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "tag 参数错误",
+		})
+		return
+	}
+	cursor, err := strconv.ParseInt(ctx.DefaultQuery("cursor", "0"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "cursor 参数错误",
+		})
+		return
+	}
+	limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+	if err != nil || limit <= 0 || limit > 100 {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "limit 参数错误",
+		})
+		return
+	}
+	arts, err := h.svc.ListPubByTag(ctx, tag, cursor, limit)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		zap.L().Error("按照标签查询文章失败", zap.Error(err), zap.String("tag", tag))
+		return
+	}
+	var next int64
+	if len(arts) == limit {
+		next = arts[len(arts)-1].Id
+	}
+	vos := toFeedArticleVOs(arts)
+	for i := range vos {
+		vos[i].Category = arts[i].Category
+		vos[i].Tags = arts[i].Tags
+	}
+	ctx.JSON(http.StatusOK, Result{
+		Code: 0,
+		Msg:  "OK",
+		Data: TagArticlesVO{
+			Articles: vos,
+			Cursor:   next,
+		},
+	})
+}
+
+// SearchTags 标签补全
+func (h *ArticleHandle) SearchTags(ctx *gin.Context) {
+	limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+	if err != nil || limit <= 0 || limit > 50 {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "limit 参数错误",
+		})
+		return
+	}
+	tags, err := h.svc.SearchTags(ctx, ctx.Query("prefix"), limit)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		zap.L().Error("标签补全失败", zap.Error(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{
+		Code: 0,
+		Msg:  "OK",
+		Data: tags,
+	})
+}
@@ -8,8 +8,8 @@ import (
 	"github.com/basic-go-project-webook/webook/internal/service"
 	svcmocks "github.com/basic-go-project-webook/webook/internal/service/mocks"
 	ijwt "github.com/basic-go-project-webook/webook/internal/web/jwt"
+	"github.com/basic-go-project-webook/webook/pkg/jwtx"
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v5"
 	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -49,7 +49,7 @@ func TestArticleHandle_Publish(t *testing.T) {
 			wantRes: Result{
 				Code: 0,
 				Msg:  "OK",
-				Data: float64(1),
+				Data: "1",
 			},
 		},
 		{
@@ -84,7 +84,8 @@ func TestArticleHandle_Publish(t *testing.T) {
 			defer ctrl.Finish()
 			articleService := tc.mock(ctrl)
 			cmd := InitRedis()
-			handle := NewArticleHandle(articleService, ijwt.NewRedisJwtHandler(cmd))
+			handle := NewArticleHandle(articleService, ijwt.NewRedisJwtHandler(cmd, 5, testAtKeyring, testRtKeyring),
+				svcmocks.NewMockInteractiveServiceClient(ctrl))
 			server := gin.Default()
 			handle.RegisterRoutes(server)
 			tokenStr := generateToken(123)
@@ -103,12 +104,20 @@ func TestArticleHandle_Publish(t *testing.T) {
 	}
 }
 
+var (
+	testAtKeyring, _ = jwtx.NewKeyringFromConfig([]jwtx.KeyConfig{
+		{Kid: "test-at", Secret: "BTv_D7]5q+f)9MTLwAA'5N!PJ6d6PNQQ"},
+	}, ijwt.AccessTokenExpiration)
+	testRtKeyring, _ = jwtx.NewKeyringFromConfig([]jwtx.KeyConfig{
+		{Kid: "test-rt", Secret: "BTv_D7]5q+f)9MTLwAA'5N!PJ6d6xyad"},
+	}, ijwt.RefreshTokenExpiration)
+)
+
 func generateToken(uid int64) string {
 	claims := ijwt.UserClaims{
 		Uid: uid,
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenStr, _ := token.SignedString(ijwt.AtKey)
+	tokenStr, _ := testAtKeyring.Sign(claims)
 	return tokenStr
 }
 
@@ -11,16 +11,18 @@ type Page struct {
 }
 
 type ArticleVO struct {
-	Id         string `json:"id"`
-	Title      string `json:"title"`
-	Abstract   string `json:"abstract"`
-	Content    string `json:"content"`
-	AuthorId   int64  `json:"author_id"`
-	AuthorName string `json:"author_name"`
-	Status     uint8  `json:"status"`
-	ReadCnt    int64  `json:"read_cnt"`
-	LikeCnt    int64  `json:"like_cnt"`
-	CollectCnt int64  `json:"collect_cnt"`
+	Id         string   `json:"id"`
+	Title      string   `json:"title"`
+	Abstract   string   `json:"abstract"`
+	Content    string   `json:"content"`
+	AuthorId   int64    `json:"author_id"`
+	AuthorName string   `json:"author_name"`
+	Category   string   `json:"category"`
+	Tags       []string `json:"tags"`
+	Status     uint8    `json:"status"`
+	ReadCnt    int64    `json:"read_cnt"`
+	LikeCnt    int64    `json:"like_cnt"`
+	CollectCnt int64    `json:"collect_cnt"`
 
 	Liked     bool `json:"liked"`
 	Collected bool `json:"collected"`
@@ -36,6 +38,8 @@ func toArticleVOs(arts []domain.Article) []ArticleVO {
 			Id:       strconv.FormatInt(art.Id, 10),
 			Title:    art.Title,
 			Abstract: art.Abstract(),
+			Category: art.Category,
+			Tags:     art.Tags,
 			Status:   art.Status.ToUint8(),
 			Ctime:    art.Ctime.Format("2006-01-02 15:04:05"),
 			Utime:    art.Utime.Format("2006-01-02 15:04:05"),
@@ -43,3 +47,123 @@ func toArticleVOs(arts []domain.Article) []ArticleVO {
 	}
 	return result
 }
+
+// TagArticlesVO 按照标签查询的一页文章
+type TagArticlesVO struct {
+	Articles []ArticleVO `json:"articles"`
+	// 下一页的 cursor，为 0 的时候说明没有更多了
+	Cursor int64 `json:"cursor,string"`
+}
+
+type FeedVO struct {
+	Articles []ArticleVO `json:"articles"`
+	// 下一页的 cursor，为空的时候说明没有更多了
+	Cursor string `json:"cursor"`
+}
+
+func toFeedArticleVOs(arts []domain.Article) []ArticleVO {
+	result := make([]ArticleVO, 0, len(arts))
+	for _, art := range arts {
+		result = append(result, ArticleVO{
+			Id:       strconv.FormatInt(art.Id, 10),
+			Title:    art.Title,
+			Abstract: art.Abstract(),
+			AuthorId: art.Author.Id,
+			Ctime:    art.Ctime.Format("2006-01-02 15:04:05"),
+			Utime:    art.Utime.Format("2006-01-02 15:04:05"),
+		})
+	}
+	return result
+}
+
+type ArticleHistoryVO struct {
+	Version int64  `json:"version"`
+	Title   string `json:"title"`
+	// Action 产生这个版本的操作，Save、Publish 或者 Restore
+	Action string `json:"action"`
+	Ctime  string `json:"ctime"`
+}
+
+func toArticleHistoryVOs(his []domain.ArticleHistory) []ArticleHistoryVO {
+	result := make([]ArticleHistoryVO, 0, len(his))
+	for _, h := range his {
+		result = append(result, ArticleHistoryVO{
+			Version: h.Version,
+			Title:   h.Title,
+			Action:  h.Action.String(),
+			Ctime:   h.Ctime.Format("2006-01-02 15:04:05"),
+		})
+	}
+	return result
+}
+
+type ArticleDiffVO struct {
+	Id      string       `json:"id"`
+	From    int64        `json:"from"`
+	To      int64        `json:"to"`
+	Title   []DiffLineVO `json:"title"`
+	Content []DiffLineVO `json:"content"`
+}
+
+type DiffLineVO struct {
+	// Op 是 "="、"+" 或者 "-"
+	Op   string `json:"op"`
+	Text string `json:"text"`
+}
+
+func toArticleDiffVO(diff domain.ArticleDiff) ArticleDiffVO {
+	return ArticleDiffVO{
+		Id:      strconv.FormatInt(diff.ArticleId, 10),
+		From:    diff.From,
+		To:      diff.To,
+		Title:   toDiffLineVOs(diff.Title),
+		Content: toDiffLineVOs(diff.Content),
+	}
+}
+
+func toDiffLineVOs(lines []domain.DiffLine) []DiffLineVO {
+	result := make([]DiffLineVO, 0, len(lines))
+	for _, line := range lines {
+		result = append(result, DiffLineVO{
+			Op:   line.Op.String(),
+			Text: line.Text,
+		})
+	}
+	return result
+}
+
+type AuthorVO struct {
+	Id          int64  `json:"id"`
+	Nickname    string `json:"nickname"`
+	AboutMe     string `json:"about_me"`
+	FollowerCnt int64  `json:"follower_cnt"`
+	FolloweeCnt int64  `json:"followee_cnt"`
+}
+
+// AuthorArticlesVO 作者主页
+type AuthorArticlesVO struct {
+	Author   AuthorVO    `json:"author"`
+	Articles []ArticleVO `json:"articles"`
+	// 下一页的 cursor，为空的时候说明没有更多了
+	Cursor string `json:"cursor"`
+}
+
+type RankingBoardVO struct {
+	Name     string `json:"name"`
+	Window   string `json:"window"`
+	N        int    `json:"n"`
+	Category string `json:"category"`
+	Tag      string `json:"tag"`
+}
+
+type RankedArticleVO struct {
+	ArticleVO
+	Rank  int64   `json:"rank"`
+	Score float64 `json:"score"`
+}
+
+type RankVO struct {
+	Aid   int64   `json:"aid"`
+	Rank  int64   `json:"rank"`
+	Score float64 `json:"score"`
+}
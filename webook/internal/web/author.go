@@ -0,0 +1,183 @@
+package web
+
+import (
+	"errors"
+	"fmt"
+	followv1 "github.com/basic-go-project-webook/webook/api/proto/gen/follow/v1"
+	intrv1 "github.com/basic-go-project-webook/webook/api/proto/gen/intr/v1"
+	"github.com/basic-go-project-webook/webook/internal/domain"
+	"github.com/basic-go-project-webook/webook/internal/service"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AuthorHandler 作者主页
+type AuthorHandler struct {
+	artSvc    service.ArticleService
+	userSvc   service.UserService
+	followSvc followv1.FollowServiceClient
+	intrSvc   intrv1.InteractiveServiceClient
+	biz       string
+}
+
+func NewAuthorHandler(artSvc service.ArticleService, userSvc service.UserService,
+	followSvc followv1.FollowServiceClient, intrSvc intrv1.InteractiveServiceClient) *AuthorHandler {
+	return &AuthorHandler{
+		artSvc:    artSvc,
+		userSvc:   userSvc,
+		followSvc: followSvc,
+		intrSvc:   intrSvc,
+		biz:       "article",
+	}
+}
+
+func (h *AuthorHandler) RegisterRoutes(server *gin.Engine) {
+	g := server.Group("/authors")
+	g.GET("/:id/articles", h.Articles)
+}
+
+// Articles 作者的资料和已发表的文章。
+// 第一页不传 cursor，之后使用上一页返回的 cursor 翻页，cursor 为空说明没有更多了
+func (h *AuthorHandler) Articles(ctx *gin.Context) {
+	uid, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "id 参数错误",
+		})
+		return
+	}
+	maxUtime, maxId, err := parseCursor(ctx.Query("cursor"))
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "cursor 参数错误",
+		})
+		return
+	}
+	limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+	if err != nil || limit <= 0 || limit > 100 {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "limit 参数错误",
+		})
+		return
+	}
+
+	var (
+		eg      errgroup.Group
+		author  domain.User
+		statics *followv1.GetFollowStaticsResponse
+		arts    []domain.Article
+	)
+	eg.Go(func() error {
+		var er error
+		author, er = h.userSvc.Profile(ctx, uid)
+		return er
+	})
+	eg.Go(func() error {
+		var er error
+		statics, er = h.followSvc.GetFollowStatics(ctx, &followv1.GetFollowStaticsRequest{Uid: uid})
+		if er != nil {
+			// 关注数据查不到不影响主页本身
+			zap.L().Error("查询作者关注数据失败", zap.Error(er), zap.Int64("uid", uid))
+		}
+		return nil
+	})
+	eg.Go(func() error {
+		var er error
+		arts, er = h.artSvc.ListPubByAuthor(ctx, uid, maxUtime, maxId, limit)
+		return er
+	})
+	err = eg.Wait()
+	if errors.Is(err, service.ErrUserNotFound) {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "作者不存在",
+		})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		zap.L().Error("查询作者主页失败", zap.Error(err), zap.Int64("uid", uid))
+		return
+	}
+
+	vos := toFeedArticleVOs(arts)
+	for i := range vos {
+		vos[i].AuthorName = author.Nickname
+		vos[i].Category = arts[i].Category
+		vos[i].Tags = arts[i].Tags
+	}
+	if len(arts) > 0 {
+		ids := make([]int64, 0, len(arts))
+		for _, art := range arts {
+			ids = append(ids, art.Id)
+		}
+		resp, er := h.intrSvc.GetByIds(ctx, &intrv1.GetByIdsRequest{
+			Biz: h.biz,
+			Ids: ids,
+		})
+		if er != nil {
+			zap.L().Error("查询作者文章互动数据失败", zap.Error(er), zap.Int64("uid", uid))
+		} else {
+			intrs := resp.GetIntrs()
+			for i := range vos {
+				intr := intrs[arts[i].Id]
+				vos[i].ReadCnt = intr.GetReadCnt()
+				vos[i].LikeCnt = intr.GetLikeCnt()
+				vos[i].CollectCnt = intr.GetCollectCnt()
+			}
+		}
+	}
+	var next string
+	if len(arts) == limit {
+		last := arts[len(arts)-1]
+		next = fmt.Sprintf("%d_%d", last.Utime.UnixMilli(), last.Id)
+	}
+	ctx.JSON(http.StatusOK, Result{
+		Code: 0,
+		Msg:  "OK",
+		Data: AuthorArticlesVO{
+			Author: AuthorVO{
+				Id:          uid,
+				Nickname:    author.Nickname,
+				AboutMe:     author.AboutMe,
+				FollowerCnt: statics.GetFollowerCnt(),
+				FolloweeCnt: statics.GetFollowingCnt(),
+			},
+			Articles: vos,
+			Cursor:   next,
+		},
+	})
+}
+
+// parseCursor 按照 (时间, id) 翻页的 cursor，格式是 "毫秒数_id"，作者主页用 utime，feed 用 ctime。
+// 第一页不传，返回当前时间和最大的 id
+func parseCursor(cursor string) (time.Time, int64, error) {
+	if cursor == "" {
+		return time.Now(), math.MaxInt64, nil
+	}
+	utimeStr, idStr, ok := strings.Cut(cursor, "_")
+	if !ok {
+		return time.Time{}, 0, errors.New("cursor 格式错误")
+	}
+	utime, err := strconv.ParseInt(utimeStr, 10, 64)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+	return time.UnixMilli(utime), id, nil
+}
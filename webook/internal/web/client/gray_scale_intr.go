@@ -41,6 +41,34 @@ func (g *GrayScaleInteractiveServiceClient) Collect(ctx context.Context, in *int
 	return g.client().Collect(ctx, in, opts...)
 }
 
+func (g *GrayScaleInteractiveServiceClient) CancelCollect(ctx context.Context, in *intrv1.CancelCollectRequest, opts ...grpc.CallOption) (*intrv1.CancelCollectResponse, error) {
+	return g.client().CancelCollect(ctx, in, opts...)
+}
+
+func (g *GrayScaleInteractiveServiceClient) MoveCollectionItem(ctx context.Context, in *intrv1.MoveCollectionItemRequest, opts ...grpc.CallOption) (*intrv1.MoveCollectionItemResponse, error) {
+	return g.client().MoveCollectionItem(ctx, in, opts...)
+}
+
+func (g *GrayScaleInteractiveServiceClient) CreateCollection(ctx context.Context, in *intrv1.CreateCollectionRequest, opts ...grpc.CallOption) (*intrv1.CreateCollectionResponse, error) {
+	return g.client().CreateCollection(ctx, in, opts...)
+}
+
+func (g *GrayScaleInteractiveServiceClient) UpdateCollection(ctx context.Context, in *intrv1.UpdateCollectionRequest, opts ...grpc.CallOption) (*intrv1.UpdateCollectionResponse, error) {
+	return g.client().UpdateCollection(ctx, in, opts...)
+}
+
+func (g *GrayScaleInteractiveServiceClient) DeleteCollection(ctx context.Context, in *intrv1.DeleteCollectionRequest, opts ...grpc.CallOption) (*intrv1.DeleteCollectionResponse, error) {
+	return g.client().DeleteCollection(ctx, in, opts...)
+}
+
+func (g *GrayScaleInteractiveServiceClient) ListCollections(ctx context.Context, in *intrv1.ListCollectionsRequest, opts ...grpc.CallOption) (*intrv1.ListCollectionsResponse, error) {
+	return g.client().ListCollections(ctx, in, opts...)
+}
+
+func (g *GrayScaleInteractiveServiceClient) ListCollectionItems(ctx context.Context, in *intrv1.ListCollectionItemsRequest, opts ...grpc.CallOption) (*intrv1.ListCollectionItemsResponse, error) {
+	return g.client().ListCollectionItems(ctx, in, opts...)
+}
+
 func (g *GrayScaleInteractiveServiceClient) Get(ctx context.Context, in *intrv1.GetRequest, opts ...grpc.CallOption) (*intrv1.GetResponse, error) {
 	return g.client().Get(ctx, in, opts...)
 }
@@ -49,6 +77,14 @@ func (g *GrayScaleInteractiveServiceClient) GetByIds(ctx context.Context, in *in
 	return g.client().GetByIds(ctx, in, opts...)
 }
 
+func (g *GrayScaleInteractiveServiceClient) GetByIdsWithUser(ctx context.Context, in *intrv1.GetByIdsWithUserRequest, opts ...grpc.CallOption) (*intrv1.GetByIdsWithUserResponse, error) {
+	return g.client().GetByIdsWithUser(ctx, in, opts...)
+}
+
+func (g *GrayScaleInteractiveServiceClient) MergeUser(ctx context.Context, in *intrv1.MergeUserRequest, opts ...grpc.CallOption) (*intrv1.MergeUserResponse, error) {
+	return g.client().MergeUser(ctx, in, opts...)
+}
+
 func (g *GrayScaleInteractiveServiceClient) UpdateThreshold(threshold int32) {
 	g.threshold.Store(threshold)
 }
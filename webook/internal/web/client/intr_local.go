@@ -2,10 +2,13 @@ package client
 
 import (
 	"context"
+	"errors"
 	intrv1 "github.com/basic-go-project-webook/webook/api/proto/gen/intr/v1"
 	"github.com/basic-go-project-webook/webook/interactive/domain"
 	"github.com/basic-go-project-webook/webook/interactive/service"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // InteractiveServiceAdapter 将本地实现的接口，适配成 grpc 接口
@@ -34,7 +37,84 @@ func (i *InteractiveServiceAdapter) IncrReadCnt(ctx context.Context, in *intrv1.
 
 func (i *InteractiveServiceAdapter) Collect(ctx context.Context, in *intrv1.CollectRequest, opts ...grpc.CallOption) (*intrv1.CollectResponse, error) {
 	err := i.svc.Collect(ctx, in.GetBiz(), in.GetBizId(), in.GetCid(), in.GetUid())
-	return &intrv1.CollectResponse{}, err
+	if err != nil {
+		return nil, i.toStatusErr(err)
+	}
+	return &intrv1.CollectResponse{}, nil
+}
+
+func (i *InteractiveServiceAdapter) CancelCollect(ctx context.Context, in *intrv1.CancelCollectRequest, opts ...grpc.CallOption) (*intrv1.CancelCollectResponse, error) {
+	err := i.svc.CancelCollect(ctx, in.GetBiz(), in.GetBizId(), in.GetUid())
+	return &intrv1.CancelCollectResponse{}, err
+}
+
+func (i *InteractiveServiceAdapter) MoveCollectionItem(ctx context.Context, in *intrv1.MoveCollectionItemRequest, opts ...grpc.CallOption) (*intrv1.MoveCollectionItemResponse, error) {
+	err := i.svc.MoveCollectionItem(ctx, in.GetBiz(), in.GetBizId(), in.GetUid(), in.GetCid())
+	if err != nil {
+		return nil, i.toStatusErr(err)
+	}
+	return &intrv1.MoveCollectionItemResponse{}, nil
+}
+
+func (i *InteractiveServiceAdapter) CreateCollection(ctx context.Context, in *intrv1.CreateCollectionRequest, opts ...grpc.CallOption) (*intrv1.CreateCollectionResponse, error) {
+	id, err := i.svc.CreateCollection(ctx, i.toCollectionDomain(in.GetCollection()))
+	if err != nil {
+		return nil, err
+	}
+	return &intrv1.CreateCollectionResponse{Id: id}, nil
+}
+
+func (i *InteractiveServiceAdapter) UpdateCollection(ctx context.Context, in *intrv1.UpdateCollectionRequest, opts ...grpc.CallOption) (*intrv1.UpdateCollectionResponse, error) {
+	err := i.svc.UpdateCollection(ctx, i.toCollectionDomain(in.GetCollection()))
+	if err != nil {
+		return nil, i.toStatusErr(err)
+	}
+	return &intrv1.UpdateCollectionResponse{}, nil
+}
+
+func (i *InteractiveServiceAdapter) DeleteCollection(ctx context.Context, in *intrv1.DeleteCollectionRequest, opts ...grpc.CallOption) (*intrv1.DeleteCollectionResponse, error) {
+	err := i.svc.DeleteCollection(ctx, in.GetId(), in.GetUid())
+	if err != nil {
+		return nil, i.toStatusErr(err)
+	}
+	return &intrv1.DeleteCollectionResponse{}, nil
+}
+
+func (i *InteractiveServiceAdapter) ListCollections(ctx context.Context, in *intrv1.ListCollectionsRequest, opts ...grpc.CallOption) (*intrv1.ListCollectionsResponse, error) {
+	collections, err := i.svc.ListCollections(ctx, in.GetUid(), in.GetViewer(), int(in.GetOffset()), int(in.GetLimit()))
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*intrv1.Collection, 0, len(collections))
+	for _, c := range collections {
+		res = append(res, &intrv1.Collection{
+			Id:      c.Id,
+			Uid:     c.Uid,
+			Name:    c.Name,
+			Private: c.Private,
+			Ctime:   c.Ctime.UnixMilli(),
+			Utime:   c.Utime.UnixMilli(),
+		})
+	}
+	return &intrv1.ListCollectionsResponse{Collections: res}, nil
+}
+
+func (i *InteractiveServiceAdapter) ListCollectionItems(ctx context.Context, in *intrv1.ListCollectionItemsRequest, opts ...grpc.CallOption) (*intrv1.ListCollectionItemsResponse, error) {
+	items, err := i.svc.ListCollectionItems(ctx, in.GetUid(), in.GetCid(), in.GetViewer(), int(in.GetOffset()), int(in.GetLimit()))
+	if err != nil {
+		return nil, i.toStatusErr(err)
+	}
+	res := make([]*intrv1.CollectionItem, 0, len(items))
+	for _, item := range items {
+		res = append(res, &intrv1.CollectionItem{
+			Id:    item.Id,
+			Cid:   item.Cid,
+			Biz:   item.Biz,
+			BizId: item.BizId,
+			Ctime: item.Ctime.UnixMilli(),
+		})
+	}
+	return &intrv1.ListCollectionItemsResponse{Items: res}, nil
 }
 
 func (i *InteractiveServiceAdapter) Get(ctx context.Context, in *intrv1.GetRequest, opts ...grpc.CallOption) (*intrv1.GetResponse, error) {
@@ -61,6 +141,47 @@ func (i *InteractiveServiceAdapter) GetByIds(ctx context.Context, in *intrv1.Get
 	}, nil
 }
 
+func (i *InteractiveServiceAdapter) GetByIdsWithUser(ctx context.Context, in *intrv1.GetByIdsWithUserRequest, opts ...grpc.CallOption) (*intrv1.GetByIdsWithUserResponse, error) {
+	intrs, err := i.svc.GetByIdsWithUser(ctx, in.GetBiz(), in.GetIds(), in.GetUid())
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[int64]*intrv1.Interactive, len(intrs))
+	for k, v := range intrs {
+		m[k] = i.toDTO(v)
+	}
+	return &intrv1.GetByIdsWithUserResponse{
+		Intrs: m,
+	}, nil
+}
+
+func (i *InteractiveServiceAdapter) MergeUser(ctx context.Context, in *intrv1.MergeUserRequest, opts ...grpc.CallOption) (*intrv1.MergeUserResponse, error) {
+	err := i.svc.MergeUser(ctx, in.GetSrcUid(), in.GetDstUid())
+	if err != nil {
+		return nil, err
+	}
+	return &intrv1.MergeUserResponse{}, nil
+}
+
+// toStatusErr 和远程调用保持一致，调用方统一按照 grpc 错误码判断
+func (i *InteractiveServiceAdapter) toStatusErr(err error) error {
+	switch {
+	case errors.Is(err, service.ErrCollectionNotFound), errors.Is(err, service.ErrNotCollected):
+		return status.Error(codes.NotFound, err.Error())
+	default:
+		return err
+	}
+}
+
+func (i *InteractiveServiceAdapter) toCollectionDomain(c *intrv1.Collection) domain.Collection {
+	return domain.Collection{
+		Id:      c.GetId(),
+		Uid:     c.GetUid(),
+		Name:    c.GetName(),
+		Private: c.GetPrivate(),
+	}
+}
+
 func (i *InteractiveServiceAdapter) toDTO(intr domain.Interactive) *intrv1.Interactive {
 	return &intrv1.Interactive{
 		Biz:        intr.Biz,
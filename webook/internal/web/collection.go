@@ -0,0 +1,326 @@
+package web
+
+import (
+	intrv1 "github.com/basic-go-project-webook/webook/api/proto/gen/intr/v1"
+	ijwt "github.com/basic-go-project-webook/webook/internal/web/jwt"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net/http"
+)
+
+// CollectionHandler 收藏夹，收藏和取消收藏在 ArticleHandle 里面
+type CollectionHandler struct {
+	ijwt.Handler
+	intrSvc intrv1.InteractiveServiceClient
+}
+
+func NewCollectionHandler(intrSvc intrv1.InteractiveServiceClient, hdl ijwt.Handler) *CollectionHandler {
+	return &CollectionHandler{
+		intrSvc: intrSvc,
+		Handler: hdl,
+	}
+}
+
+func (h *CollectionHandler) RegisterRoutes(server *gin.Engine) {
+	g := server.Group("/collections")
+	g.POST("/create", h.Create)
+	g.POST("/update", h.Update)
+	g.POST("/delete", h.Delete)
+	g.POST("/list", h.List)
+	g.POST("/items", h.Items)
+}
+
+func (h *CollectionHandler) Create(ctx *gin.Context) {
+	type Req struct {
+		Name    string `json:"name"`
+		Private bool   `json:"private"`
+	}
+	var req Req
+	err := ctx.Bind(&req)
+	if err != nil {
+		zap.L().Error("collection create 绑定失败", zap.Error(err))
+		return
+	}
+
+	var claims ijwt.UserClaims
+	tokenStr := h.ExtractToken(ctx)
+	token, err := jwt.ParseWithClaims(tokenStr, &claims, h.AccessKey)
+	if err != nil || !token.Valid {
+		ctx.JSON(http.StatusOK, &Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		zap.L().Error("未发现用户信息，用户未登录", zap.Error(err))
+		return
+	}
+
+	if req.Name == "" {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "收藏夹名字不能为空",
+		})
+		return
+	}
+	resp, err := h.intrSvc.CreateCollection(ctx, &intrv1.CreateCollectionRequest{
+		Collection: &intrv1.Collection{
+			Uid:     claims.Uid,
+			Name:    req.Name,
+			Private: req.Private,
+		},
+	})
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		zap.L().Error("创建收藏夹失败", zap.Error(err), zap.Int64("uid", claims.Uid))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{
+		Code: 0,
+		Msg:  "OK",
+		Data: resp.GetId(),
+	})
+}
+
+// Update 重命名或者修改是否私密
+func (h *CollectionHandler) Update(ctx *gin.Context) {
+	type Req struct {
+		Id      int64  `json:"id"`
+		Name    string `json:"name"`
+		Private bool   `json:"private"`
+	}
+	var req Req
+	err := ctx.Bind(&req)
+	if err != nil {
+		zap.L().Error("collection update 绑定失败", zap.Error(err))
+		return
+	}
+
+	var claims ijwt.UserClaims
+	tokenStr := h.ExtractToken(ctx)
+	token, err := jwt.ParseWithClaims(tokenStr, &claims, h.AccessKey)
+	if err != nil || !token.Valid {
+		ctx.JSON(http.StatusOK, &Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		zap.L().Error("未发现用户信息，用户未登录", zap.Error(err))
+		return
+	}
+
+	if req.Name == "" {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "收藏夹名字不能为空",
+		})
+		return
+	}
+	_, err = h.intrSvc.UpdateCollection(ctx, &intrv1.UpdateCollectionRequest{
+		Collection: &intrv1.Collection{
+			Id:      req.Id,
+			Uid:     claims.Uid,
+			Name:    req.Name,
+			Private: req.Private,
+		},
+	})
+	if status.Code(err) == codes.NotFound {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "收藏夹不存在",
+		})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		zap.L().Error("修改收藏夹失败", zap.Error(err),
+			zap.Int64("id", req.Id), zap.Int64("uid", claims.Uid))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{
+		Code: 0,
+		Msg:  "OK",
+	})
+}
+
+// Delete 删除收藏夹，里面的收藏也会被取消
+func (h *CollectionHandler) Delete(ctx *gin.Context) {
+	type Req struct {
+		Id int64 `json:"id"`
+	}
+	var req Req
+	err := ctx.Bind(&req)
+	if err != nil {
+		zap.L().Error("collection delete 绑定失败", zap.Error(err))
+		return
+	}
+
+	var claims ijwt.UserClaims
+	tokenStr := h.ExtractToken(ctx)
+	token, err := jwt.ParseWithClaims(tokenStr, &claims, h.AccessKey)
+	if err != nil || !token.Valid {
+		ctx.JSON(http.StatusOK, &Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		zap.L().Error("未发现用户信息，用户未登录", zap.Error(err))
+		return
+	}
+
+	_, err = h.intrSvc.DeleteCollection(ctx, &intrv1.DeleteCollectionRequest{
+		Id:  req.Id,
+		Uid: claims.Uid,
+	})
+	if status.Code(err) == codes.NotFound {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "收藏夹不存在",
+		})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		zap.L().Error("删除收藏夹失败", zap.Error(err),
+			zap.Int64("id", req.Id), zap.Int64("uid", claims.Uid))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{
+		Code: 0,
+		Msg:  "OK",
+	})
+}
+
+// List 收藏夹列表，不传 uid 的时候查询自己的收藏夹
+func (h *CollectionHandler) List(ctx *gin.Context) {
+	type Req struct {
+		Uid    int64 `json:"uid"`
+		Offset int64 `json:"offset"`
+		Limit  int64 `json:"limit"`
+	}
+	var req Req
+	err := ctx.Bind(&req)
+	if err != nil {
+		zap.L().Error("collection list 绑定失败", zap.Error(err))
+		return
+	}
+	if req.Offset < 0 {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "offset 不能是负数",
+		})
+		return
+	}
+
+	var claims ijwt.UserClaims
+	tokenStr := h.ExtractToken(ctx)
+	token, err := jwt.ParseWithClaims(tokenStr, &claims, h.AccessKey)
+	if err != nil || !token.Valid {
+		ctx.JSON(http.StatusOK, &Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		zap.L().Error("未发现用户信息，用户未登录", zap.Error(err))
+		return
+	}
+
+	uid := req.Uid
+	if uid <= 0 {
+		uid = claims.Uid
+	}
+	resp, err := h.intrSvc.ListCollections(ctx, &intrv1.ListCollectionsRequest{
+		Uid:    uid,
+		Viewer: claims.Uid,
+		Offset: req.Offset,
+		Limit:  req.Limit,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		zap.L().Error("查询收藏夹列表失败", zap.Error(err), zap.Int64("uid", uid))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{
+		Code: 0,
+		Msg:  "OK",
+		Data: toCollectionVOs(resp.GetCollections()),
+	})
+}
+
+// Items 收藏夹里面的收藏，cid 为 0 的时候是默认收藏夹
+func (h *CollectionHandler) Items(ctx *gin.Context) {
+	type Req struct {
+		Uid    int64 `json:"uid"`
+		Cid    int64 `json:"cid"`
+		Offset int64 `json:"offset"`
+		Limit  int64 `json:"limit"`
+	}
+	var req Req
+	err := ctx.Bind(&req)
+	if err != nil {
+		zap.L().Error("collection items 绑定失败", zap.Error(err))
+		return
+	}
+	if req.Offset < 0 {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "offset 不能是负数",
+		})
+		return
+	}
+
+	var claims ijwt.UserClaims
+	tokenStr := h.ExtractToken(ctx)
+	token, err := jwt.ParseWithClaims(tokenStr, &claims, h.AccessKey)
+	if err != nil || !token.Valid {
+		ctx.JSON(http.StatusOK, &Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		zap.L().Error("未发现用户信息，用户未登录", zap.Error(err))
+		return
+	}
+
+	uid := req.Uid
+	if uid <= 0 {
+		uid = claims.Uid
+	}
+	resp, err := h.intrSvc.ListCollectionItems(ctx, &intrv1.ListCollectionItemsRequest{
+		Uid:    uid,
+		Cid:    req.Cid,
+		Viewer: claims.Uid,
+		Offset: req.Offset,
+		Limit:  req.Limit,
+	})
+	if status.Code(err) == codes.NotFound {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "收藏夹不存在",
+		})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		zap.L().Error("查询收藏夹内容失败", zap.Error(err),
+			zap.Int64("uid", uid), zap.Int64("cid", req.Cid))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{
+		Code: 0,
+		Msg:  "OK",
+		Data: toCollectionItemVOs(resp.GetItems()),
+	})
+}
@@ -0,0 +1,53 @@
+package web
+
+import (
+	intrv1 "github.com/basic-go-project-webook/webook/api/proto/gen/intr/v1"
+	"strconv"
+	"time"
+)
+
+type CollectionVO struct {
+	Id      int64  `json:"id"`
+	Uid     int64  `json:"uid"`
+	Name    string `json:"name"`
+	Private bool   `json:"private"`
+	Ctime   string `json:"ctime"`
+	Utime   string `json:"utime"`
+}
+
+type CollectionItemVO struct {
+	Id    int64  `json:"id"`
+	Cid   int64  `json:"cid"`
+	Biz   string `json:"biz"`
+	BizId string `json:"biz_id"`
+	Ctime string `json:"ctime"`
+}
+
+func toCollectionVOs(collections []*intrv1.Collection) []CollectionVO {
+	result := make([]CollectionVO, 0, len(collections))
+	for _, c := range collections {
+		result = append(result, CollectionVO{
+			Id:      c.GetId(),
+			Uid:     c.GetUid(),
+			Name:    c.GetName(),
+			Private: c.GetPrivate(),
+			Ctime:   time.UnixMilli(c.GetCtime()).Format("2006-01-02 15:04:05"),
+			Utime:   time.UnixMilli(c.GetUtime()).Format("2006-01-02 15:04:05"),
+		})
+	}
+	return result
+}
+
+func toCollectionItemVOs(items []*intrv1.CollectionItem) []CollectionItemVO {
+	result := make([]CollectionItemVO, 0, len(items))
+	for _, item := range items {
+		result = append(result, CollectionItemVO{
+			Id:    item.GetId(),
+			Cid:   item.GetCid(),
+			Biz:   item.GetBiz(),
+			BizId: strconv.FormatInt(item.GetBizId(), 10),
+			Ctime: time.UnixMilli(item.GetCtime()).Format("2006-01-02 15:04:05"),
+		})
+	}
+	return result
+}
@@ -0,0 +1,212 @@
+package web
+
+import (
+	commentv1 "github.com/basic-go-project-webook/webook/api/proto/gen/comment/v1"
+	ijwt "github.com/basic-go-project-webook/webook/internal/web/jwt"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net/http"
+)
+
+type CommentHandler struct {
+	ijwt.Handler
+	svc commentv1.CommentServiceClient
+}
+
+func NewCommentHandler(svc commentv1.CommentServiceClient, hdl ijwt.Handler) *CommentHandler {
+	return &CommentHandler{
+		svc:     svc,
+		Handler: hdl,
+	}
+}
+
+func (h *CommentHandler) RegisterRoutes(server *gin.Engine) {
+	g := server.Group("/comments")
+	g.POST("/list", h.List)
+	g.POST("/create", h.Create)
+	g.POST("/delete", h.Delete)
+	g.POST("/replies", h.Replies)
+}
+
+func (h *CommentHandler) List(ctx *gin.Context) {
+	type Req struct {
+		Biz   string `json:"biz"`
+		BizId int64  `json:"biz_id"`
+		MinId int64  `json:"min_id"`
+		Limit int64  `json:"limit"`
+	}
+	var req Req
+	err := ctx.Bind(&req)
+	if err != nil {
+		zap.L().Error("comment list 绑定失败", zap.Error(err))
+		return
+	}
+	resp, err := h.svc.GetCommentList(ctx, &commentv1.GetCommentListRequest{
+		Biz:   req.Biz,
+		BizId: req.BizId,
+		MinId: req.MinId,
+		Limit: req.Limit,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		zap.L().Error("查询评论列表失败", zap.Error(err),
+			zap.String("biz", req.Biz), zap.Int64("biz_id", req.BizId))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{
+		Code: 0,
+		Msg:  "OK",
+		Data: toCommentVOs(resp.GetComments()),
+	})
+}
+
+func (h *CommentHandler) Create(ctx *gin.Context) {
+	type Req struct {
+		Biz     string `json:"biz"`
+		BizId   int64  `json:"biz_id"`
+		Content string `json:"content"`
+		// 根评论和父评论，一级评论的时候都为 0
+		RootId   int64 `json:"root_id"`
+		ParentId int64 `json:"parent_id"`
+	}
+	var req Req
+	err := ctx.Bind(&req)
+	if err != nil {
+		zap.L().Error("comment create 绑定失败", zap.Error(err))
+		return
+	}
+
+	var claims ijwt.UserClaims
+	tokenStr := h.ExtractToken(ctx)
+	token, err := jwt.ParseWithClaims(tokenStr, &claims, h.AccessKey)
+	if err != nil || !token.Valid {
+		ctx.JSON(http.StatusOK, &Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		zap.L().Error("未发现用户信息，用户未登录", zap.Error(err))
+		return
+	}
+
+	if req.Content == "" {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "评论内容不能为空",
+		})
+		return
+	}
+	comment := &commentv1.Comment{
+		Uid:     claims.Uid,
+		Biz:     req.Biz,
+		BizId:   req.BizId,
+		Content: req.Content,
+	}
+	if req.RootId > 0 {
+		comment.RootComment = &commentv1.Comment{Id: req.RootId}
+	}
+	if req.ParentId > 0 {
+		comment.ParentComment = &commentv1.Comment{Id: req.ParentId}
+	}
+	_, err = h.svc.CreateComment(ctx, &commentv1.CreateCommentRequest{
+		Comment: comment,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		zap.L().Error("创建评论失败", zap.Error(err), zap.Int64("uid", claims.Uid))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{
+		Code: 0,
+		Msg:  "OK",
+	})
+}
+
+func (h *CommentHandler) Delete(ctx *gin.Context) {
+	type Req struct {
+		Id int64 `json:"id"`
+	}
+	var req Req
+	err := ctx.Bind(&req)
+	if err != nil {
+		zap.L().Error("comment delete 绑定失败", zap.Error(err))
+		return
+	}
+
+	var claims ijwt.UserClaims
+	tokenStr := h.ExtractToken(ctx)
+	token, err := jwt.ParseWithClaims(tokenStr, &claims, h.AccessKey)
+	if err != nil || !token.Valid {
+		ctx.JSON(http.StatusOK, &Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		zap.L().Error("未发现用户信息，用户未登录", zap.Error(err))
+		return
+	}
+
+	_, err = h.svc.DeleteComment(ctx, &commentv1.DeleteCommentRequest{
+		Id:  req.Id,
+		Uid: claims.Uid,
+	})
+	if status.Code(err) == codes.NotFound {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "评论不存在",
+		})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		zap.L().Error("删除评论失败", zap.Error(err),
+			zap.Int64("id", req.Id), zap.Int64("uid", claims.Uid))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{
+		Code: 0,
+		Msg:  "OK",
+	})
+}
+
+func (h *CommentHandler) Replies(ctx *gin.Context) {
+	type Req struct {
+		Rid   int64 `json:"rid"`
+		MaxId int64 `json:"max_id"`
+		Limit int64 `json:"limit"`
+	}
+	var req Req
+	err := ctx.Bind(&req)
+	if err != nil {
+		zap.L().Error("comment replies 绑定失败", zap.Error(err))
+		return
+	}
+	resp, err := h.svc.GetMoreReplies(ctx, &commentv1.GetMoreRepliesRequest{
+		Rid:   req.Rid,
+		MaxId: req.MaxId,
+		Limit: req.Limit,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		zap.L().Error("查询评论回复失败", zap.Error(err), zap.Int64("rid", req.Rid))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{
+		Code: 0,
+		Msg:  "OK",
+		Data: toCommentVOs(resp.GetComments()),
+	})
+}
@@ -0,0 +1,33 @@
+package web
+
+import commentv1 "github.com/basic-go-project-webook/webook/api/proto/gen/comment/v1"
+
+type CommentVO struct {
+	Id       int64  `json:"id"`
+	Uid      int64  `json:"uid"`
+	Biz      string `json:"biz"`
+	BizId    int64  `json:"biz_id"`
+	Content  string `json:"content"`
+	RootId   int64  `json:"root_id"`
+	ParentId int64  `json:"parent_id"`
+	Ctime    string `json:"ctime"`
+	Utime    string `json:"utime"`
+}
+
+func toCommentVOs(comments []*commentv1.Comment) []CommentVO {
+	result := make([]CommentVO, 0, len(comments))
+	for _, c := range comments {
+		result = append(result, CommentVO{
+			Id:       c.GetId(),
+			Uid:      c.GetUid(),
+			Biz:      c.GetBiz(),
+			BizId:    c.GetBizId(),
+			Content:  c.GetContent(),
+			RootId:   c.GetRootComment().GetId(),
+			ParentId: c.GetParentComment().GetId(),
+			Ctime:    c.GetCtime().AsTime().Local().Format("2006-01-02 15:04:05"),
+			Utime:    c.GetUtime().AsTime().Local().Format("2006-01-02 15:04:05"),
+		})
+	}
+	return result
+}
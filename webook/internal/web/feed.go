@@ -0,0 +1,114 @@
+package web
+
+import (
+	"fmt"
+	intrv1 "github.com/basic-go-project-webook/webook/api/proto/gen/intr/v1"
+	"github.com/basic-go-project-webook/webook/internal/service"
+	ijwt "github.com/basic-go-project-webook/webook/internal/web/jwt"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"go.uber.org/zap"
+	"net/http"
+	"strconv"
+)
+
+type FeedHandler struct {
+	ijwt.Handler
+	svc     service.FeedService
+	intrSvc intrv1.InteractiveServiceClient
+	biz     string
+}
+
+func NewFeedHandler(svc service.FeedService, intrSvc intrv1.InteractiveServiceClient, hdl ijwt.Handler) *FeedHandler {
+	return &FeedHandler{
+		svc:     svc,
+		intrSvc: intrSvc,
+		Handler: hdl,
+		biz:     "article",
+	}
+}
+
+func (h *FeedHandler) RegisterRoutes(server *gin.Engine) {
+	server.GET("/feed", h.Feed)
+}
+
+// Feed 关注的人发表的文章，第一页不传 cursor，之后使用上一页返回的 cursor 翻页，cursor 为空说明没有更多了
+func (h *FeedHandler) Feed(ctx *gin.Context) {
+	maxCtime, maxId, err := parseCursor(ctx.Query("cursor"))
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "cursor 参数错误",
+		})
+		return
+	}
+	limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+	if err != nil || limit <= 0 || limit > 100 {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "limit 参数错误",
+		})
+		return
+	}
+
+	var claims ijwt.UserClaims
+	tokenStr := h.ExtractToken(ctx)
+	token, err := jwt.ParseWithClaims(tokenStr, &claims, h.AccessKey)
+	if err != nil || !token.Valid {
+		ctx.JSON(http.StatusOK, &Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		zap.L().Error("未发现用户信息，用户未登录", zap.Error(err))
+		return
+	}
+
+	arts, err := h.svc.GetFeed(ctx, claims.Uid, maxCtime, maxId, limit)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		zap.L().Error("查询 feed 失败", zap.Error(err), zap.Int64("uid", claims.Uid))
+		return
+	}
+	var next string
+	if len(arts) == limit {
+		last := arts[len(arts)-1]
+		next = fmt.Sprintf("%d_%d", last.Ctime.UnixMilli(), last.Id)
+	}
+	vos := toFeedArticleVOs(arts)
+	if len(arts) > 0 {
+		ids := make([]int64, 0, len(arts))
+		for _, art := range arts {
+			ids = append(ids, art.Id)
+		}
+		resp, er := h.intrSvc.GetByIdsWithUser(ctx, &intrv1.GetByIdsWithUserRequest{
+			Biz: h.biz,
+			Ids: ids,
+			Uid: claims.Uid,
+		})
+		if er != nil {
+			// 互动数据查不到不影响 feed 本身
+			zap.L().Error("查询 feed 互动数据失败", zap.Error(er), zap.Int64("uid", claims.Uid))
+		} else {
+			intrs := resp.GetIntrs()
+			for i := range vos {
+				intr := intrs[arts[i].Id]
+				vos[i].ReadCnt = intr.GetReadCnt()
+				vos[i].LikeCnt = intr.GetLikeCnt()
+				vos[i].CollectCnt = intr.GetCollectCnt()
+				vos[i].Liked = intr.GetLiked()
+				vos[i].Collected = intr.GetCollected()
+			}
+		}
+	}
+	ctx.JSON(http.StatusOK, Result{
+		Code: 0,
+		Msg:  "OK",
+		Data: FeedVO{
+			Articles: vos,
+			Cursor:   next,
+		},
+	})
+}
@@ -0,0 +1,315 @@
+package web
+
+import (
+	followv1 "github.com/basic-go-project-webook/webook/api/proto/gen/follow/v1"
+	ijwt "github.com/basic-go-project-webook/webook/internal/web/jwt"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net/http"
+	"strconv"
+)
+
+type FollowHandler struct {
+	ijwt.Handler
+	svc followv1.FollowServiceClient
+}
+
+func NewFollowHandler(svc followv1.FollowServiceClient, hdl ijwt.Handler) *FollowHandler {
+	return &FollowHandler{
+		svc:     svc,
+		Handler: hdl,
+	}
+}
+
+func (h *FollowHandler) RegisterRoutes(server *gin.Engine) {
+	g := server.Group("/follows")
+	g.POST("/follow", h.Follow)
+	g.POST("/cancel", h.CancelFollow)
+	g.POST("/followee", h.Followee)
+	g.POST("/follower", h.Follower)
+	g.GET("/statics/:uid", h.Statics)
+	g.POST("/relations", h.Relations)
+}
+
+func (h *FollowHandler) Follow(ctx *gin.Context) {
+	type Req struct {
+		Followee int64 `json:"followee"`
+	}
+	var req Req
+	err := ctx.Bind(&req)
+	if err != nil {
+		zap.L().Error("follow 绑定失败", zap.Error(err))
+		return
+	}
+
+	var claims ijwt.UserClaims
+	tokenStr := h.ExtractToken(ctx)
+	token, err := jwt.ParseWithClaims(tokenStr, &claims, h.AccessKey)
+	if err != nil || !token.Valid {
+		ctx.JSON(http.StatusOK, &Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		zap.L().Error("未发现用户信息，用户未登录", zap.Error(err))
+		return
+	}
+
+	if req.Followee <= 0 || req.Followee == claims.Uid {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "关注对象错误",
+		})
+		return
+	}
+	_, err = h.svc.Follow(ctx, &followv1.FollowRequest{
+		Followee: req.Followee,
+		Follower: claims.Uid,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		zap.L().Error("关注失败", zap.Error(err),
+			zap.Int64("followee", req.Followee), zap.Int64("follower", claims.Uid))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{
+		Code: 0,
+		Msg:  "OK",
+	})
+}
+
+func (h *FollowHandler) CancelFollow(ctx *gin.Context) {
+	type Req struct {
+		Followee int64 `json:"followee"`
+	}
+	var req Req
+	err := ctx.Bind(&req)
+	if err != nil {
+		zap.L().Error("cancel follow 绑定失败", zap.Error(err))
+		return
+	}
+
+	var claims ijwt.UserClaims
+	tokenStr := h.ExtractToken(ctx)
+	token, err := jwt.ParseWithClaims(tokenStr, &claims, h.AccessKey)
+	if err != nil || !token.Valid {
+		ctx.JSON(http.StatusOK, &Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		zap.L().Error("未发现用户信息，用户未登录", zap.Error(err))
+		return
+	}
+
+	_, err = h.svc.CancelFollow(ctx, &followv1.CancelFollowRequest{
+		Followee: req.Followee,
+		Follower: claims.Uid,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		zap.L().Error("取消关注失败", zap.Error(err),
+			zap.Int64("followee", req.Followee), zap.Int64("follower", claims.Uid))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{
+		Code: 0,
+		Msg:  "OK",
+	})
+}
+
+// Followee 关注列表，不传 follower 的时候查询自己的关注列表
+func (h *FollowHandler) Followee(ctx *gin.Context) {
+	type Req struct {
+		Follower int64 `json:"follower"`
+		Offset   int64 `json:"offset"`
+		Limit    int64 `json:"limit"`
+	}
+	var req Req
+	err := ctx.Bind(&req)
+	if err != nil {
+		zap.L().Error("followee 绑定失败", zap.Error(err))
+		return
+	}
+	if req.Offset < 0 {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "offset 不能是负数",
+		})
+		return
+	}
+
+	var claims ijwt.UserClaims
+	tokenStr := h.ExtractToken(ctx)
+	token, err := jwt.ParseWithClaims(tokenStr, &claims, h.AccessKey)
+	if err != nil || !token.Valid {
+		ctx.JSON(http.StatusOK, &Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		zap.L().Error("未发现用户信息，用户未登录", zap.Error(err))
+		return
+	}
+
+	follower := req.Follower
+	if follower <= 0 {
+		follower = claims.Uid
+	}
+	resp, err := h.svc.GetFollowee(ctx, &followv1.GetFolloweeRequest{
+		Follower: follower,
+		Offset:   req.Offset,
+		Limit:    req.Limit,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		zap.L().Error("查询关注列表失败", zap.Error(err), zap.Int64("follower", follower))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{
+		Code: 0,
+		Msg:  "OK",
+		Data: toFollowRelationVOs(resp.GetFollowRelation()),
+	})
+}
+
+// Follower 粉丝列表，按照关注时间倒序，使用上一页最后一条的 id 作为 min_id 翻页
+func (h *FollowHandler) Follower(ctx *gin.Context) {
+	type Req struct {
+		Followee int64 `json:"followee"`
+		MinId    int64 `json:"min_id"`
+		Limit    int64 `json:"limit"`
+	}
+	var req Req
+	err := ctx.Bind(&req)
+	if err != nil {
+		zap.L().Error("follower 绑定失败", zap.Error(err))
+		return
+	}
+
+	var claims ijwt.UserClaims
+	tokenStr := h.ExtractToken(ctx)
+	token, err := jwt.ParseWithClaims(tokenStr, &claims, h.AccessKey)
+	if err != nil || !token.Valid {
+		ctx.JSON(http.StatusOK, &Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		zap.L().Error("未发现用户信息，用户未登录", zap.Error(err))
+		return
+	}
+
+	followee := req.Followee
+	if followee <= 0 {
+		followee = claims.Uid
+	}
+	resp, err := h.svc.GetFollower(ctx, &followv1.GetFollowerRequest{
+		Followee: followee,
+		MinId:    req.MinId,
+		Limit:    req.Limit,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		zap.L().Error("查询粉丝列表失败", zap.Error(err), zap.Int64("followee", followee))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{
+		Code: 0,
+		Msg:  "OK",
+		Data: toFollowRelationVOs(resp.GetFollowRelation()),
+	})
+}
+
+// Relations 批量查询当前用户和一批用户之间的关注关系
+func (h *FollowHandler) Relations(ctx *gin.Context) {
+	type Req struct {
+		Uids []int64 `json:"uids"`
+	}
+	var req Req
+	err := ctx.Bind(&req)
+	if err != nil {
+		zap.L().Error("relations 绑定失败", zap.Error(err))
+		return
+	}
+
+	var claims ijwt.UserClaims
+	tokenStr := h.ExtractToken(ctx)
+	token, err := jwt.ParseWithClaims(tokenStr, &claims, h.AccessKey)
+	if err != nil || !token.Valid {
+		ctx.JSON(http.StatusOK, &Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		zap.L().Error("未发现用户信息，用户未登录", zap.Error(err))
+		return
+	}
+
+	resp, err := h.svc.GetRelations(ctx, &followv1.GetRelationsRequest{
+		Viewer: claims.Uid,
+		Uids:   req.Uids,
+	})
+	if status.Code(err) == codes.InvalidArgument {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "uid 数量过多",
+		})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		zap.L().Error("批量查询关注关系失败", zap.Error(err), zap.Int64("viewer", claims.Uid))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{
+		Code: 0,
+		Msg:  "OK",
+		Data: toRelationVOs(resp.GetRelations()),
+	})
+}
+
+func (h *FollowHandler) Statics(ctx *gin.Context) {
+	uidStr := ctx.Param("uid")
+	uid, err := strconv.ParseInt(uidStr, 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "uid 参数错误",
+		})
+		zap.L().Warn("查询关注统计失败，uid参数不对", zap.Error(err))
+		return
+	}
+	resp, err := h.svc.GetFollowStatics(ctx, &followv1.GetFollowStaticsRequest{
+		Uid: uid,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		zap.L().Error("查询关注统计失败", zap.Error(err), zap.Int64("uid", uid))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{
+		Code: 0,
+		Msg:  "OK",
+		Data: FollowStaticsVO{
+			FollowerCnt:  resp.GetFollowerCnt(),
+			FollowingCnt: resp.GetFollowingCnt(),
+		},
+	})
+}
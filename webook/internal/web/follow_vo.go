@@ -0,0 +1,50 @@
+package web
+
+import followv1 "github.com/basic-go-project-webook/webook/api/proto/gen/follow/v1"
+
+type FollowRelationVO struct {
+	Id       int64 `json:"id"`
+	Followee int64 `json:"followee"`
+	Follower int64 `json:"follower"`
+}
+
+type FollowStaticsVO struct {
+	// 粉丝数
+	FollowerCnt int64 `json:"follower_cnt"`
+	// 关注数
+	FollowingCnt int64 `json:"following_cnt"`
+}
+
+type RelationVO struct {
+	Uid int64 `json:"uid"`
+	// 我关注了对方
+	Following bool `json:"following"`
+	// 对方关注了我
+	Followed bool `json:"followed"`
+	Mutual   bool `json:"mutual"`
+}
+
+func toRelationVOs(relations map[int64]*followv1.Relation) []RelationVO {
+	result := make([]RelationVO, 0, len(relations))
+	for _, r := range relations {
+		result = append(result, RelationVO{
+			Uid:       r.GetUid(),
+			Following: r.GetFollowing(),
+			Followed:  r.GetFollowed(),
+			Mutual:    r.GetMutual(),
+		})
+	}
+	return result
+}
+
+func toFollowRelationVOs(relations []*followv1.FollowRelation) []FollowRelationVO {
+	result := make([]FollowRelationVO, 0, len(relations))
+	for _, r := range relations {
+		result = append(result, FollowRelationVO{
+			Id:       r.GetId(),
+			Followee: r.GetFollowee(),
+			Follower: r.GetFollower(),
+		})
+	}
+	return result
+}
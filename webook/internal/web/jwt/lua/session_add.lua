@@ -0,0 +1,21 @@
+-- KEYS[1] 用户的 session 列表 ZSET，KEYS[2] 新的 session
+-- ARGV[1] ssid, ARGV[2] 现在的毫秒数, ARGV[3] 过期时间（秒）, ARGV[4] 最多几台设备, 之后是 session 的字段
+-- 返回需要踢掉的 ssid，脚本只能访问声明过的 key，所以由调用方删除这些 session
+local ssid = ARGV[1]
+local now = tonumber(ARGV[2])
+local ttl = tonumber(ARGV[3])
+local max = tonumber(ARGV[4])
+
+redis.call("HSET", KEYS[2], unpack(ARGV, 5))
+redis.call("EXPIRE", KEYS[2], ttl)
+-- 超过过期时间没有刷新过的 session 已经过期了
+redis.call("ZREMRANGEBYSCORE", KEYS[1], "-inf", now - ttl * 1000)
+redis.call("ZADD", KEYS[1], now, ssid)
+redis.call("EXPIRE", KEYS[1], ttl)
+
+local cnt = redis.call("ZCARD", KEYS[1])
+if max <= 0 or cnt <= max then
+    return {}
+end
+-- 最久没有刷新的设备
+return redis.call("ZRANGE", KEYS[1], 0, cnt - max - 1)
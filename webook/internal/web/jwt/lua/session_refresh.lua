@@ -0,0 +1,11 @@
+-- KEYS[1] 用户的 session 列表 ZSET，KEYS[2] session
+-- ARGV[1] ssid, ARGV[2] 现在的毫秒数, ARGV[3] 过期时间（秒）, ARGV[4] IP
+if redis.call("EXISTS", KEYS[2]) == 0 then
+    -- 已经退出登录或者被踢下线
+    return 0
+end
+redis.call("HSET", KEYS[2], "last", ARGV[2], "ip", ARGV[4])
+redis.call("EXPIRE", KEYS[2], ARGV[3])
+redis.call("ZADD", KEYS[1], ARGV[2], ARGV[1])
+redis.call("EXPIRE", KEYS[1], ARGV[3])
+return 1
@@ -1,31 +1,59 @@
 package jwt
 
 import (
+	"context"
+	_ "embed"
 	"errors"
 	"fmt"
+	"github.com/basic-go-project-webook/webook/pkg/jwtx"
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
+	"strconv"
 	"strings"
 	"time"
 )
 
-var (
-	AtKey = []byte("BTv_D7]5q+f)9MTLwAA'5N!PJ6d6PNQQ")
-	RtKey = []byte("BTv_D7]5q+f)9MTLwAA'5N!PJ6d6xyad")
+//go:embed lua/session_add.lua
+var luaSessionAdd string
+
+//go:embed lua/session_refresh.lua
+var luaSessionRefresh string
+
+const (
+	AccessTokenExpiration = time.Minute * 30
+	// RefreshTokenExpiration 长 token 的有效期，session 这么久没有刷新就过期
+	RefreshTokenExpiration = time.Hour * 24 * 7
 )
 
+// RedisJwtHandler 每个用户登录的设备记录在一个 ZSET 里面，分数是最后刷新时间，
+// 每台设备的详细信息单独一个 HASH。只有登记过的 session 才有效，退出登录、被踢下线就直接删掉
 type RedisJwtHandler struct {
 	cmd redis.Cmdable
+	// maxDevices 最多同时登录几台设备，小于等于 0 不限制
+	maxDevices int
+	atKeys     *jwtx.Keyring
+	rtKeys     *jwtx.Keyring
 }
 
-func NewRedisJwtHandler(cmd redis.Cmdable) Handler {
+func NewRedisJwtHandler(cmd redis.Cmdable, maxDevices int, atKeys *jwtx.Keyring, rtKeys *jwtx.Keyring) Handler {
 	return &RedisJwtHandler{
-		cmd: cmd,
+		cmd:        cmd,
+		maxDevices: maxDevices,
+		atKeys:     atKeys,
+		rtKeys:     rtKeys,
 	}
 }
 
+func (r *RedisJwtHandler) AccessKey(token *jwt.Token) (interface{}, error) {
+	return r.atKeys.Keyfunc(token)
+}
+
+func (r *RedisJwtHandler) RefreshKey(token *jwt.Token) (interface{}, error) {
+	return r.rtKeys.Keyfunc(token)
+}
+
 func (r *RedisJwtHandler) ExtractToken(ctx *gin.Context) string {
 	tokenHeader := ctx.GetHeader("Authorization")
 	segs := strings.Split(tokenHeader, " ")
@@ -35,45 +63,143 @@ func (r *RedisJwtHandler) ExtractToken(ctx *gin.Context) string {
 	return segs[1]
 }
 
-func (r *RedisJwtHandler) CheckSession(ctx *gin.Context, ssid string) error {
-	val, err := r.cmd.Exists(ctx, fmt.Sprintf("users:ssid:%s", ssid)).Result()
-	switch {
-	case errors.Is(err, redis.Nil):
-		return nil
-	case errors.Is(err, nil):
-		if val == 0 {
-			return nil
-		}
-		return errors.New("session 无效")
-	default:
+func (r *RedisJwtHandler) CheckSession(ctx *gin.Context, uid int64, ssid string) error {
+	val, err := r.cmd.Exists(ctx, r.sessionKey(uid, ssid)).Result()
+	if err != nil {
 		return err
 	}
+	if val == 0 {
+		return errors.New("session 无效")
+	}
+	return nil
 }
 
 func (r *RedisJwtHandler) SetLoginToken(ctx *gin.Context, uid int64) error {
-	ssid := uuid.New()
-	err := r.SetJWTToken(ctx, uid, ssid.String())
+	ssid := uuid.New().String()
+	now := time.Now().UnixMilli()
+	ua := ctx.Request.UserAgent()
+	evicted, err := r.cmd.Eval(ctx, luaSessionAdd, []string{r.sessionsKey(uid), r.sessionKey(uid, ssid)},
+		ssid, now, int64(RefreshTokenExpiration.Seconds()), r.maxDevices,
+		"device", deviceOf(ctx), "ua", ua, "ip", ctx.ClientIP(), "ctime", now, "last", now).StringSlice()
 	if err != nil {
 		return err
 	}
-	err = r.SetRefreshToken(ctx, uid, ssid.String())
+	// 踢掉超出的设备。删除失败的话它们还留在列表里面，下次登录会再踢一次
+	err = r.revoke(ctx, uid, evicted...)
+	if err != nil && !errors.Is(err, ErrSessionNotFound) {
+		return err
+	}
+	err = r.SetJWTToken(ctx, uid, ssid)
 	if err != nil {
 		return err
 	}
+	return r.SetRefreshToken(ctx, uid, ssid)
+}
 
+func (r *RedisJwtHandler) RefreshSession(ctx *gin.Context, uid int64, ssid string) error {
+	ok, err := r.cmd.Eval(ctx, luaSessionRefresh, []string{r.sessionsKey(uid), r.sessionKey(uid, ssid)},
+		ssid, time.Now().UnixMilli(), int64(RefreshTokenExpiration.Seconds()), ctx.ClientIP()).Int()
+	if err != nil {
+		return err
+	}
+	if ok == 0 {
+		return ErrSessionNotFound
+	}
+	return nil
+}
+
+func (r *RedisJwtHandler) ListSessions(ctx *gin.Context, uid int64) ([]Session, error) {
+	ssids, err := r.cmd.ZRevRange(ctx, r.sessionsKey(uid), 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+	pipe := r.cmd.Pipeline()
+	cmds := make([]*redis.MapStringStringCmd, 0, len(ssids))
+	for _, ssid := range ssids {
+		cmds = append(cmds, pipe.HGetAll(ctx, r.sessionKey(uid, ssid)))
+	}
+	if len(cmds) > 0 {
+		_, err = pipe.Exec(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	res := make([]Session, 0, len(ssids))
+	var expired []any
+	for i, cmd := range cmds {
+		vals := cmd.Val()
+		if len(vals) == 0 {
+			expired = append(expired, ssids[i])
+			continue
+		}
+		res = append(res, Session{
+			Ssid:        ssids[i],
+			Device:      vals["device"],
+			UserAgent:   vals["ua"],
+			IP:          vals["ip"],
+			Ctime:       parseMilli(vals["ctime"]),
+			LastRefresh: parseMilli(vals["last"]),
+		})
+	}
+	if len(expired) > 0 {
+		// 顺手清理掉已经过期的，失败了下次再清理
+		_ = r.cmd.ZRem(ctx, r.sessionsKey(uid), expired...).Err()
+	}
+	return res, nil
+}
+
+func (r *RedisJwtHandler) RevokeSession(ctx *gin.Context, uid int64, ssid string) error {
+	return r.revoke(ctx, uid, ssid)
+}
+
+func (r *RedisJwtHandler) RevokeOtherSessions(ctx *gin.Context, uid int64, keep string) error {
+	ssids, err := r.cmd.ZRange(ctx, r.sessionsKey(uid), 0, -1).Result()
+	if err != nil {
+		return err
+	}
+	others := make([]string, 0, len(ssids))
+	for _, ssid := range ssids {
+		if ssid != keep {
+			others = append(others, ssid)
+		}
+	}
+	return r.revoke(ctx, uid, others...)
+}
+
+func (r *RedisJwtHandler) revoke(ctx context.Context, uid int64, ssids ...string) error {
+	if len(ssids) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(ssids))
+	members := make([]any, 0, len(ssids))
+	for _, ssid := range ssids {
+		keys = append(keys, r.sessionKey(uid, ssid))
+		members = append(members, ssid)
+	}
+	var zrem *redis.IntCmd
+	_, err := r.cmd.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, keys...)
+		zrem = pipe.ZRem(ctx, r.sessionsKey(uid), members...)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if zrem.Val() == 0 {
+		return ErrSessionNotFound
+	}
 	return nil
 }
 
 func (r *RedisJwtHandler) SetRefreshToken(ctx *gin.Context, uid int64, ssid string) error {
 	claims := RefreshClaims{
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour * 24 * 7)),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(RefreshTokenExpiration)),
 		},
 		Uid:  uid,
 		Ssid: ssid,
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenStr, err := token.SignedString(RtKey)
+	tokenStr, err := r.rtKeys.Sign(claims)
 	if err != nil {
 		return err
 	}
@@ -84,14 +210,13 @@ func (r *RedisJwtHandler) SetRefreshToken(ctx *gin.Context, uid int64, ssid stri
 func (r *RedisJwtHandler) SetJWTToken(ctx *gin.Context, uid int64, ssid string) error {
 	claims := UserClaims{
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute * 30)),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(AccessTokenExpiration)),
 		},
 		Uid:       uid,
 		Ssid:      ssid,
 		UserAgent: ctx.Request.UserAgent(),
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenStr, err := token.SignedString(AtKey)
+	tokenStr, err := r.atKeys.Sign(claims)
 	if err != nil {
 		return err
 	}
@@ -105,17 +230,49 @@ func (r *RedisJwtHandler) ClearToken(ctx *gin.Context) error {
 	ctx.Header("x-refresh-token", "")
 	tokenStr := r.ExtractToken(ctx)
 	var claims UserClaims
-	_, err := jwt.ParseWithClaims(tokenStr, &claims, func(token *jwt.Token) (interface{}, error) {
-		return AtKey, nil
-	})
+	_, err := jwt.ParseWithClaims(tokenStr, &claims, r.AccessKey)
 	if err != nil {
 		return err
 	}
-
-	err = r.cmd.Set(ctx, fmt.Sprintf("users:ssid:%s", claims.Ssid), "", time.Hour*24*7).Err()
+	err = r.revoke(ctx, claims.Uid, claims.Ssid)
+	if errors.Is(err, ErrSessionNotFound) {
+		// 已经被别的设备踢下线了
+		return nil
+	}
 	return err
 }
 
+// sessionsKey 用 uid 做 hash tag，同一个用户的 key 都在一个 slot 里面，lua 脚本才能在集群上跑
+func (r *RedisJwtHandler) sessionsKey(uid int64) string {
+	return fmt.Sprintf("users:{%d}:sessions", uid)
+}
+
+func (r *RedisJwtHandler) sessionKey(uid int64, ssid string) string {
+	return fmt.Sprintf("users:{%d}:session:%s", uid, ssid)
+}
+
+// deviceOf 客户端可以通过 X-Device 告诉我们设备名，没有的话从 User-Agent 里面猜一个
+func deviceOf(ctx *gin.Context) string {
+	if device := ctx.GetHeader("X-Device"); device != "" {
+		if len(device) > 64 {
+			device = device[:64]
+		}
+		return device
+	}
+	ua := ctx.Request.UserAgent()
+	for _, d := range []string{"iPhone", "iPad", "Android", "Windows", "Macintosh", "Linux"} {
+		if strings.Contains(ua, d) {
+			return d
+		}
+	}
+	return "unknown"
+}
+
+func parseMilli(val string) time.Time {
+	ms, _ := strconv.ParseInt(val, 10, 64)
+	return time.UnixMilli(ms)
+}
+
 type UserClaims struct {
 	jwt.RegisteredClaims
 	Uid       int64
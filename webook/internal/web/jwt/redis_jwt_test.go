@@ -0,0 +1,125 @@
+package jwt
+
+import (
+	"context"
+	"github.com/basic-go-project-webook/webook/internal/repository/cache/redismocks"
+	"github.com/basic-go-project-webook/webook/pkg/jwtx"
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRedisJwtHandler_SetLoginToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	cmd := redismocks.NewMockCmdable(ctrl)
+	pipe := redismocks.NewMockPipeliner(ctrl)
+
+	res := redis.NewCmd(context.Background())
+	// 超过了最多设备数，脚本返回需要踢掉的设备
+	res.SetVal([]any{"old"})
+	cmd.EXPECT().Eval(gomock.Any(), luaSessionAdd, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, script string, keys []string, args ...any) *redis.Cmd {
+			require.Len(t, keys, 2)
+			assert.Equal(t, "users:{123}:sessions", keys[0])
+			assert.Equal(t, "users:{123}:session:"+args[0].(string), keys[1])
+			assert.Equal(t, 2, args[3])
+			assert.Equal(t, "device", args[4])
+			return res
+		})
+	// 被踢掉的 session 用声明过的 key 删除
+	cmd.EXPECT().TxPipelined(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(redis.Pipeliner) error) ([]redis.Cmder, error) {
+			return nil, fn(pipe)
+		})
+	pipe.EXPECT().Del(gomock.Any(), "users:{123}:session:old")
+	zrem := redis.NewIntCmd(context.Background())
+	zrem.SetVal(1)
+	pipe.EXPECT().ZRem(gomock.Any(), "users:{123}:sessions", "old").Return(zrem)
+
+	h := newTestHandler(t, cmd, 2)
+	ctx := newTestContext()
+	err := h.SetLoginToken(ctx, 123)
+	require.NoError(t, err)
+	assert.NotEmpty(t, ctx.Writer.Header().Get("x-jwt-token"))
+	assert.NotEmpty(t, ctx.Writer.Header().Get("x-refresh-token"))
+}
+
+func TestRedisJwtHandler_RevokeOtherSessions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	cmd := redismocks.NewMockCmdable(ctrl)
+	pipe := redismocks.NewMockPipeliner(ctrl)
+
+	ssids := redis.NewStringSliceCmd(context.Background())
+	ssids.SetVal([]string{"a", "keep", "b"})
+	cmd.EXPECT().ZRange(gomock.Any(), "users:{123}:sessions", int64(0), int64(-1)).Return(ssids)
+	cmd.EXPECT().TxPipelined(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(redis.Pipeliner) error) ([]redis.Cmder, error) {
+			return nil, fn(pipe)
+		})
+	pipe.EXPECT().Del(gomock.Any(), "users:{123}:session:a", "users:{123}:session:b")
+	zrem := redis.NewIntCmd(context.Background())
+	zrem.SetVal(2)
+	pipe.EXPECT().ZRem(gomock.Any(), "users:{123}:sessions", "a", "b").Return(zrem)
+
+	h := newTestHandler(t, cmd, 5)
+	err := h.RevokeOtherSessions(newTestContext(), 123, "keep")
+	assert.NoError(t, err)
+}
+
+func TestRedisJwtHandler_CheckSessionAfterRevoke(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	cmd := redismocks.NewMockCmdable(ctrl)
+	pipe := redismocks.NewMockPipeliner(ctrl)
+
+	exists := func(val int64) *redis.IntCmd {
+		res := redis.NewIntCmd(context.Background())
+		res.SetVal(val)
+		return res
+	}
+	zrem := redis.NewIntCmd(context.Background())
+	zrem.SetVal(1)
+	gomock.InOrder(
+		cmd.EXPECT().Exists(gomock.Any(), "users:{123}:session:a").Return(exists(1)),
+		cmd.EXPECT().TxPipelined(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(redis.Pipeliner) error) ([]redis.Cmder, error) {
+				return nil, fn(pipe)
+			}),
+		// 踢下线之后 session 就没有了
+		cmd.EXPECT().Exists(gomock.Any(), "users:{123}:session:a").Return(exists(0)),
+	)
+	pipe.EXPECT().Del(gomock.Any(), "users:{123}:session:a")
+	pipe.EXPECT().ZRem(gomock.Any(), "users:{123}:sessions", "a").Return(zrem)
+
+	h := newTestHandler(t, cmd, 5)
+	ctx := newTestContext()
+	assert.NoError(t, h.CheckSession(ctx, 123, "a"))
+	require.NoError(t, h.RevokeSession(ctx, 123, "a"))
+	assert.Error(t, h.CheckSession(ctx, 123, "a"))
+}
+
+func newTestHandler(t *testing.T, cmd redis.Cmdable, maxDevices int) Handler {
+	atKeys, err := jwtx.NewKeyringFromConfig([]jwtx.KeyConfig{
+		{Kid: "test-at", Secret: "BTv_D7]5q+f)9MTLwAA'5N!PJ6d6PNQQ"},
+	}, AccessTokenExpiration)
+	require.NoError(t, err)
+	rtKeys, err := jwtx.NewKeyringFromConfig([]jwtx.KeyConfig{
+		{Kid: "test-rt", Secret: "BTv_D7]5q+f)9MTLwAA'5N!PJ6d6xyad"},
+	}, RefreshTokenExpiration)
+	require.NoError(t, err)
+	return NewRedisJwtHandler(cmd, maxDevices, atKeys, rtKeys)
+}
+
+func newTestContext() *gin.Context {
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/users/login", nil)
+	ctx.Request.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh)")
+	return ctx
+}
@@ -1,12 +1,42 @@
 package jwt
 
-import "github.com/gin-gonic/gin"
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"time"
+)
+
+var ErrSessionNotFound = errors.New("session 不存在")
 
 type Handler interface {
+	// AccessKey 和 RefreshKey 是验证短 token 和长 token 用的 jwt.Keyfunc
+	AccessKey(token *jwt.Token) (interface{}, error)
+	RefreshKey(token *jwt.Token) (interface{}, error)
 	ExtractToken(ctx *gin.Context) string
+	// SetLoginToken 登记一个新的 session，超过最多设备数的时候踢掉最久没有刷新的设备
 	SetLoginToken(ctx *gin.Context, uid int64) error
 	SetJWTToken(ctx *gin.Context, uid int64, ssid string) error
 	ClearToken(ctx *gin.Context) error
 	SetRefreshToken(ctx *gin.Context, uid int64, ssid string) error
-	CheckSession(ctx *gin.Context, ssid string) error
+	// CheckSession 只有登记过并且没有被踢下线的 session 才有效
+	CheckSession(ctx *gin.Context, uid int64, ssid string) error
+	// RefreshSession 刷新 token 的时候调用，更新最后刷新时间和 IP，session 无效的时候返回 ErrSessionNotFound
+	RefreshSession(ctx *gin.Context, uid int64, ssid string) error
+	// ListSessions 按照最后刷新时间倒序返回用户登录的所有设备
+	ListSessions(ctx *gin.Context, uid int64) ([]Session, error)
+	// RevokeSession 踢掉用户的一个设备，不是这个用户的 session 返回 ErrSessionNotFound
+	RevokeSession(ctx *gin.Context, uid int64, ssid string) error
+	// RevokeOtherSessions 踢掉除了 keep 之外的所有设备
+	RevokeOtherSessions(ctx *gin.Context, uid int64, keep string) error
+}
+
+// Session 一次登录，也就是一台设备
+type Session struct {
+	Ssid        string
+	Device      string
+	UserAgent   string
+	IP          string
+	Ctime       time.Time
+	LastRefresh time.Time
 }
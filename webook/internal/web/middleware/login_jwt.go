@@ -18,6 +18,7 @@ func NewLoginJWTMiddleWareBuilder(jwtHdl ijwt.Handler) *LoginJWTMiddleWareBuilde
 	}
 }
 
+// IgnorePaths path 也可以是带参数的路由，例如 /oauth2/:provider/callback
 func (l *LoginJWTMiddleWareBuilder) IgnorePaths(path string) *LoginJWTMiddleWareBuilder {
 	l.paths = append(l.paths, path)
 	return l
@@ -26,7 +27,7 @@ func (l *LoginJWTMiddleWareBuilder) IgnorePaths(path string) *LoginJWTMiddleWare
 func (l *LoginJWTMiddleWareBuilder) Build() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		for _, path := range l.paths {
-			if ctx.Request.URL.Path == path {
+			if ctx.Request.URL.Path == path || ctx.FullPath() == path {
 				return
 			}
 		}
@@ -34,9 +35,7 @@ func (l *LoginJWTMiddleWareBuilder) Build() gin.HandlerFunc {
 		// JWT 校验
 		tokenStr := l.ExtractToken(ctx)
 		claims := ijwt.UserClaims{}
-		token, err := jwt.ParseWithClaims(tokenStr, &claims, func(token *jwt.Token) (interface{}, error) {
-			return []byte("BTv_D7]5q+f)9MTLwAA'5N!PJ6d6PNQQ"), nil
-		})
+		token, err := jwt.ParseWithClaims(tokenStr, &claims, l.AccessKey)
 		if err != nil {
 			ctx.AbortWithStatus(http.StatusUnauthorized)
 			return
@@ -50,7 +49,7 @@ func (l *LoginJWTMiddleWareBuilder) Build() gin.HandlerFunc {
 			ctx.AbortWithStatus(http.StatusUnauthorized)
 			return
 		}
-		err = l.CheckSession(ctx, claims.Ssid)
+		err = l.CheckSession(ctx, claims.Uid, claims.Ssid)
 		if err != nil {
 			ctx.AbortWithStatus(http.StatusUnauthorized)
 			return
@@ -0,0 +1,213 @@
+package web
+
+import (
+	"errors"
+	"fmt"
+	"github.com/basic-go-project-webook/webook/internal/service"
+	"github.com/basic-go-project-webook/webook/internal/service/oauth2"
+	ijwt "github.com/basic-go-project-webook/webook/internal/web/jwt"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	uuid "github.com/lithammer/shortuuid/v4"
+	"go.uber.org/zap"
+	"net/http"
+	"time"
+)
+
+const stateCookieName = "jwt-state"
+
+// OAuth2Handler 所有第三方登录共用，路径里面的 provider 就是 oauth2.Provider 的 Name
+type OAuth2Handler struct {
+	ijwt.Handler
+	providers  map[string]oauth2.Provider
+	userSvc    service.UserService
+	accountSvc service.AccountService
+	stateKey   []byte
+	mergeKey   MergeKey
+}
+
+// NewOAuth2Handler stateKey 用来签名 state，防止 CSRF
+func NewOAuth2Handler(providers []oauth2.Provider, userSvc service.UserService,
+	accountSvc service.AccountService, jwtHdl ijwt.Handler, stateKey []byte, mergeKey MergeKey) *OAuth2Handler {
+	m := make(map[string]oauth2.Provider, len(providers))
+	for _, p := range providers {
+		m[p.Name()] = p
+	}
+	return &OAuth2Handler{
+		providers:  m,
+		userSvc:    userSvc,
+		accountSvc: accountSvc,
+		stateKey:   stateKey,
+		mergeKey:   mergeKey,
+		Handler:    jwtHdl,
+	}
+}
+
+func (h *OAuth2Handler) RegisterRoutes(server *gin.Engine) {
+	g := server.Group("/oauth2/:provider")
+	g.GET("/authurl", h.AuthURL)
+	// 已经登录的用户绑定第三方账号，回调和登录是同一个
+	g.GET("/bindurl", h.BindURL)
+	g.Any("/callback", h.Callback)
+}
+
+func (h *OAuth2Handler) AuthURL(ctx *gin.Context) {
+	h.authURL(ctx, 0)
+}
+
+func (h *OAuth2Handler) BindURL(ctx *gin.Context) {
+	var claims ijwt.UserClaims
+	token, err := jwt.ParseWithClaims(h.ExtractToken(ctx), &claims, h.AccessKey)
+	if err != nil || !token.Valid {
+		ctx.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+	h.authURL(ctx, claims.Uid)
+}
+
+// authURL uid 不为 0 的时候是绑定，uid 放在 state 里面带到回调
+func (h *OAuth2Handler) authURL(ctx *gin.Context, uid int64) {
+	p, ok := h.providers[ctx.Param("provider")]
+	if !ok {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "不支持的登录方式",
+		})
+		return
+	}
+	state := uuid.New()
+	verifier, err := oauth2.NewCodeVerifier()
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统异常",
+		})
+		zap.L().Error("生成 PKCE code_verifier 失败", zap.Error(err))
+		return
+	}
+	url, err := p.AuthURL(ctx, state, oauth2.CodeChallenge(verifier))
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "构造第三方登录url失败",
+		})
+		zap.L().Error("构造第三方登录url失败", zap.Error(err), zap.String("provider", p.Name()))
+		return
+	}
+	if err = h.setStateCookie(ctx, p.Name(), StateClaims{
+		State:        state,
+		CodeVerifier: verifier,
+		Uid:          uid,
+	}); err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统异常",
+		})
+		zap.L().Error("设置 state cookie 失败", zap.Error(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, &Result{
+		Code: 0,
+		Data: url,
+	})
+}
+
+// setStateCookie state 和 PKCE 的 code_verifier 签名之后放在 cookie 里面，只有回调的路径带上这个 cookie
+func (h *OAuth2Handler) setStateCookie(ctx *gin.Context, provider string, sc StateClaims) error {
+	sc.Provider = provider
+	sc.RegisteredClaims = jwt.RegisteredClaims{
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute * 10)),
+	}
+	tokenStr, err := jwt.NewWithClaims(jwt.SigningMethodHS256, sc).SignedString(h.stateKey)
+	if err != nil {
+		return err
+	}
+	ctx.SetCookie(stateCookieName, tokenStr, 600,
+		fmt.Sprintf("/oauth2/%s/callback", provider), "", false, true)
+	return nil
+}
+
+func (h *OAuth2Handler) Callback(ctx *gin.Context) {
+	p, ok := h.providers[ctx.Param("provider")]
+	if !ok {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "不支持的登录方式",
+		})
+		return
+	}
+	sc, err := h.verifyState(ctx, p.Name())
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "登录失败",
+		})
+		zap.L().Warn("第三方登录 state 校验失败", zap.Error(err), zap.String("provider", p.Name()))
+		return
+	}
+	identity, err := p.VerifyCode(ctx, ctx.Query("code"), sc.CodeVerifier)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		zap.L().Error("第三方登录校验 code 失败", zap.Error(err), zap.String("provider", p.Name()))
+		return
+	}
+	if sc.Uid > 0 {
+		writeBindResult(ctx, h.mergeKey, sc.Uid, h.accountSvc.BindIdentity(ctx, sc.Uid, identity))
+		return
+	}
+	user, err := h.userSvc.FindOrCreateByIdentity(ctx, identity)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		zap.L().Error("第三方登录查找或者创建用户失败", zap.Error(err), zap.String("provider", p.Name()))
+		return
+	}
+	err = h.SetLoginToken(ctx, user.Id)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		zap.L().Error("设置登录 token 失败", zap.Error(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{
+		Msg: "登录成功",
+	})
+}
+
+func (h *OAuth2Handler) verifyState(ctx *gin.Context, provider string) (StateClaims, error) {
+	state := ctx.Query("state")
+	ck, err := ctx.Cookie(stateCookieName)
+	if err != nil {
+		return StateClaims{}, fmt.Errorf("拿不到 state 的 cookie, %w", err)
+	}
+	var sc StateClaims
+	token, err := jwt.ParseWithClaims(ck, &sc, func(token *jwt.Token) (interface{}, error) {
+		return h.stateKey, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	if err != nil || !token.Valid {
+		return StateClaims{}, fmt.Errorf("token 已经过期, %w", err)
+	}
+	if sc.State == "" || sc.State != state {
+		return StateClaims{}, errors.New("state 不相等")
+	}
+	if sc.Provider != provider {
+		return StateClaims{}, errors.New("provider 不相等")
+	}
+	return sc, nil
+}
+
+type StateClaims struct {
+	jwt.RegisteredClaims
+	State        string `json:"state"`
+	Provider     string `json:"provider"`
+	CodeVerifier string `json:"code_verifier"`
+	// Uid 绑定的时候是当前登录的用户，登录的时候是 0
+	Uid int64 `json:"uid,omitempty"`
+}
@@ -0,0 +1,202 @@
+package web
+
+import (
+	"errors"
+	intrv1 "github.com/basic-go-project-webook/webook/api/proto/gen/intr/v1"
+	"github.com/basic-go-project-webook/webook/internal/domain"
+	"github.com/basic-go-project-webook/webook/internal/service"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"net/http"
+	"strconv"
+)
+
+// RankingHandler 热榜
+type RankingHandler struct {
+	svc     service.RankingService
+	intrSvc intrv1.InteractiveServiceClient
+	biz     string
+}
+
+func NewRankingHandler(svc service.RankingService, intrSvc intrv1.InteractiveServiceClient) *RankingHandler {
+	return &RankingHandler{
+		svc:     svc,
+		intrSvc: intrSvc,
+		biz:     "article",
+	}
+}
+
+func (h *RankingHandler) RegisterRoutes(server *gin.Engine) {
+	g := server.Group("/articles/ranking")
+	g.GET("", h.TopN)
+	g.GET("/boards", h.Boards)
+	g.GET("/boards/:name", h.Board)
+	g.GET("/boards/:name/rank", h.Rank)
+}
+
+// TopN 热榜是定时任务算好的，这里只读缓存
+func (h *RankingHandler) TopN(ctx *gin.Context) {
+	arts, err := h.svc.GetTopN(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		zap.L().Error("查询热榜失败", zap.Error(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{
+		Code: 0,
+		Msg:  "OK",
+		Data: h.toVOs(ctx, arts),
+	})
+}
+
+// toVOs 互动数据取实时的，查不到的话榜单照样返回
+func (h *RankingHandler) toVOs(ctx *gin.Context, arts []domain.Article) []ArticleVO {
+	vos := toFeedArticleVOs(arts)
+	for i := range vos {
+		vos[i].Category = arts[i].Category
+		vos[i].Tags = arts[i].Tags
+	}
+	if len(arts) > 0 {
+		ids := make([]int64, 0, len(arts))
+		for _, art := range arts {
+			ids = append(ids, art.Id)
+		}
+		resp, er := h.intrSvc.GetByIds(ctx, &intrv1.GetByIdsRequest{
+			Biz: h.biz,
+			Ids: ids,
+		})
+		if er != nil {
+			zap.L().Error("查询热榜互动数据失败", zap.Error(er))
+		} else {
+			intrs := resp.GetIntrs()
+			for i := range vos {
+				intr := intrs[arts[i].Id]
+				vos[i].ReadCnt = intr.GetReadCnt()
+				vos[i].LikeCnt = intr.GetLikeCnt()
+				vos[i].CollectCnt = intr.GetCollectCnt()
+			}
+		}
+	}
+	return vos
+}
+
+// Boards 所有的榜单
+func (h *RankingHandler) Boards(ctx *gin.Context) {
+	boards := h.svc.Boards()
+	vos := make([]RankingBoardVO, 0, len(boards))
+	for _, b := range boards {
+		vos = append(vos, RankingBoardVO{
+			Name:     b.Name,
+			Window:   b.Window.String(),
+			N:        b.N,
+			Category: b.Category,
+			Tag:      b.Tag,
+		})
+	}
+	ctx.JSON(http.StatusOK, Result{
+		Code: 0,
+		Msg:  "OK",
+		Data: vos,
+	})
+}
+
+// Board 按照名次翻页，offset 从 0 开始
+func (h *RankingHandler) Board(ctx *gin.Context) {
+	offset, err := strconv.Atoi(ctx.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "offset 参数错误",
+		})
+		return
+	}
+	limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "20"))
+	if err != nil || limit <= 0 || limit > 100 {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "limit 参数错误",
+		})
+		return
+	}
+	name := ctx.Param("name")
+	ranked, err := h.svc.GetBoard(ctx, name, offset, limit)
+	if errors.Is(err, service.ErrUnknownRankingBoard) {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "榜单不存在",
+		})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		zap.L().Error("查询榜单失败", zap.Error(err), zap.String("board", name))
+		return
+	}
+	arts := make([]domain.Article, 0, len(ranked))
+	for _, r := range ranked {
+		arts = append(arts, r.Article)
+	}
+	vos := h.toVOs(ctx, arts)
+	res := make([]RankedArticleVO, 0, len(ranked))
+	for i, r := range ranked {
+		res = append(res, RankedArticleVO{
+			ArticleVO: vos[i],
+			Rank:      r.Rank,
+			Score:     r.Score,
+		})
+	}
+	ctx.JSON(http.StatusOK, Result{
+		Code: 0,
+		Msg:  "OK",
+		Data: res,
+	})
+}
+
+// Rank 查询文章在榜单上的名次，不在榜单上的时候 Data 为空
+func (h *RankingHandler) Rank(ctx *gin.Context) {
+	aid, err := strconv.ParseInt(ctx.Query("aid"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "aid 参数错误",
+		})
+		return
+	}
+	name := ctx.Param("name")
+	ranked, err := h.svc.GetRank(ctx, name, aid)
+	switch {
+	case errors.Is(err, service.ErrUnknownRankingBoard):
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "榜单不存在",
+		})
+	case errors.Is(err, service.ErrArticleNotRanked):
+		ctx.JSON(http.StatusOK, Result{
+			Code: 0,
+			Msg:  "不在榜单上",
+		})
+	case err != nil:
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		zap.L().Error("查询文章名次失败", zap.Error(err),
+			zap.String("board", name), zap.Int64("aid", aid))
+	default:
+		ctx.JSON(http.StatusOK, Result{
+			Code: 0,
+			Msg:  "OK",
+			Data: RankVO{
+				Aid:   aid,
+				Rank:  ranked.Rank,
+				Score: ranked.Score,
+			},
+		})
+	}
+}
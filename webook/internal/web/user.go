@@ -2,7 +2,6 @@ package web
 
 import (
 	"errors"
-	"fmt"
 	"github.com/basic-go-project-webook/webook/internal/domain"
 	"github.com/basic-go-project-webook/webook/internal/service"
 	ijwt "github.com/basic-go-project-webook/webook/internal/web/jwt"
@@ -58,19 +57,21 @@ func (u *UserHandle) RegisterRoutes(server *gin.Engine) {
 	ug.POST("/login_sms", u.LoginSMS)
 	ug.POST("/logout", u.Logout)
 	ug.POST("/refresh_token", u.RefreshToken)
+	// 登录设备管理
+	ug.GET("/sessions", u.ListSessions)
+	ug.POST("/sessions/revoke", u.RevokeSession)
+	ug.POST("/sessions/revoke_others", u.RevokeOtherSessions)
 }
 
 func (u *UserHandle) RefreshToken(ctx *gin.Context) {
 	refreshTokenStr := u.ExtractToken(ctx)
 	var rc ijwt.RefreshClaims
-	token, err := jwt.ParseWithClaims(refreshTokenStr, &rc, func(*jwt.Token) (interface{}, error) {
-		return ijwt.RtKey, nil
-	})
+	token, err := jwt.ParseWithClaims(refreshTokenStr, &rc, u.RefreshKey)
 	if err != nil || !token.Valid {
 		ctx.AbortWithStatus(http.StatusUnauthorized)
 		return
 	}
-	err = u.CheckSession(ctx, rc.Ssid)
+	err = u.RefreshSession(ctx, rc.Uid, rc.Ssid)
 	if err != nil {
 		ctx.AbortWithStatus(http.StatusUnauthorized)
 		return
@@ -347,9 +348,7 @@ func (u *UserHandle) Edit(ctx *gin.Context) {
 	}
 	tokenStr := u.ExtractToken(ctx)
 	var claims ijwt.UserClaims
-	token, err := jwt.ParseWithClaims(tokenStr, &claims, func(token *jwt.Token) (interface{}, error) {
-		return ijwt.AtKey, nil
-	})
+	token, err := jwt.ParseWithClaims(tokenStr, &claims, u.AccessKey)
 	if err != nil || !token.Valid {
 		ctx.JSON(http.StatusOK, &Result{
 			Code: 5,
@@ -384,9 +383,7 @@ func (u *UserHandle) Edit(ctx *gin.Context) {
 func (u *UserHandle) Profile(ctx *gin.Context) {
 	var claims ijwt.UserClaims
 	tokenStr := u.ExtractToken(ctx)
-	token, err := jwt.ParseWithClaims(tokenStr, &claims, func(token *jwt.Token) (interface{}, error) {
-		return ijwt.AtKey, nil
-	})
+	token, err := jwt.ParseWithClaims(tokenStr, &claims, u.AccessKey)
 	if err != nil || !token.Valid {
 		ctx.JSON(http.StatusOK, &Result{
 			Code: 5,
@@ -420,19 +417,7 @@ func (u *UserHandle) Profile(ctx *gin.Context) {
 }
 
 func (u *UserHandle) Logout(ctx *gin.Context) {
-	// 清除token
-	ctx.Header("x-jwt-token", "")
-	ctx.Header("x-refresh-token", "")
-	tokenStr := u.ExtractToken(ctx)
-	var claims ijwt.UserClaims
-	token, err := jwt.ParseWithClaims(tokenStr, &claims, func(token *jwt.Token) (interface{}, error) {
-		return ijwt.AtKey, nil
-	})
-	if err != nil || !token.Valid {
-		ctx.AbortWithStatus(http.StatusUnauthorized)
-		return
-	}
-	err = u.cmd.Set(ctx, fmt.Sprintf("users:ssid:%s", claims.Ssid), "", time.Hour*24*7).Err()
+	err := u.ClearToken(ctx)
 	if err != nil {
 		ctx.JSON(http.StatusOK, &Result{
 			Code: 5,
@@ -445,3 +430,115 @@ func (u *UserHandle) Logout(ctx *gin.Context) {
 		Msg:  "退出登录成功",
 	})
 }
+
+func (u *UserHandle) ListSessions(ctx *gin.Context) {
+	claims, ok := u.parseClaims(ctx)
+	if !ok {
+		return
+	}
+	sessions, err := u.Handler.ListSessions(ctx, claims.Uid)
+	if err != nil {
+		ctx.JSON(http.StatusOK, &Result{
+			Code: 5,
+			Msg:  "系统异常",
+		})
+		zap.L().Error("查询登录设备失败", zap.Error(err), zap.Int64("uid", claims.Uid))
+		return
+	}
+	vos := make([]SessionVO, 0, len(sessions))
+	for _, sess := range sessions {
+		vos = append(vos, SessionVO{
+			Ssid:        sess.Ssid,
+			Device:      sess.Device,
+			UserAgent:   sess.UserAgent,
+			IP:          sess.IP,
+			Ctime:       sess.Ctime.Format("2006-01-02 15:04:05"),
+			LastRefresh: sess.LastRefresh.Format("2006-01-02 15:04:05"),
+			Current:     sess.Ssid == claims.Ssid,
+		})
+	}
+	ctx.JSON(http.StatusOK, &Result{
+		Data: vos,
+	})
+}
+
+func (u *UserHandle) RevokeSession(ctx *gin.Context) {
+	type Req struct {
+		Ssid string `json:"ssid"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	claims, ok := u.parseClaims(ctx)
+	if !ok {
+		return
+	}
+	if req.Ssid == "" {
+		ctx.JSON(http.StatusOK, &Result{
+			Code: 4,
+			Msg:  "参数错误",
+		})
+		return
+	}
+	err := u.Handler.RevokeSession(ctx, claims.Uid, req.Ssid)
+	switch {
+	case err == nil:
+		ctx.JSON(http.StatusOK, &Result{
+			Msg: "OK",
+		})
+	case errors.Is(err, ijwt.ErrSessionNotFound):
+		ctx.JSON(http.StatusOK, &Result{
+			Code: 4,
+			Msg:  "设备不存在",
+		})
+	default:
+		ctx.JSON(http.StatusOK, &Result{
+			Code: 5,
+			Msg:  "系统异常",
+		})
+		zap.L().Error("踢下线失败", zap.Error(err), zap.Int64("uid", claims.Uid))
+	}
+}
+
+// RevokeOtherSessions 只保留当前设备
+func (u *UserHandle) RevokeOtherSessions(ctx *gin.Context) {
+	claims, ok := u.parseClaims(ctx)
+	if !ok {
+		return
+	}
+	err := u.Handler.RevokeOtherSessions(ctx, claims.Uid, claims.Ssid)
+	if err != nil && !errors.Is(err, ijwt.ErrSessionNotFound) {
+		ctx.JSON(http.StatusOK, &Result{
+			Code: 5,
+			Msg:  "系统异常",
+		})
+		zap.L().Error("踢掉其他设备失败", zap.Error(err), zap.Int64("uid", claims.Uid))
+		return
+	}
+	ctx.JSON(http.StatusOK, &Result{
+		Msg: "OK",
+	})
+}
+
+func (u *UserHandle) parseClaims(ctx *gin.Context) (ijwt.UserClaims, bool) {
+	var claims ijwt.UserClaims
+	tokenStr := u.ExtractToken(ctx)
+	token, err := jwt.ParseWithClaims(tokenStr, &claims, u.AccessKey)
+	if err != nil || !token.Valid {
+		ctx.AbortWithStatus(http.StatusUnauthorized)
+		return claims, false
+	}
+	return claims, true
+}
+
+type SessionVO struct {
+	Ssid        string `json:"ssid"`
+	Device      string `json:"device"`
+	UserAgent   string `json:"userAgent"`
+	IP          string `json:"ip"`
+	Ctime       string `json:"ctime"`
+	LastRefresh string `json:"lastRefresh"`
+	// Current 是不是当前这台设备
+	Current bool `json:"current"`
+}
@@ -1,142 +0,0 @@
-package web
-
-import (
-	"errors"
-	"fmt"
-	"github.com/basic-go-project-webook/webook/internal/service"
-	"github.com/basic-go-project-webook/webook/internal/service/oauth2/wechat"
-	ijwt "github.com/basic-go-project-webook/webook/internal/web/jwt"
-	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v5"
-	uuid "github.com/lithammer/shortuuid/v4"
-	"net/http"
-	"time"
-)
-
-type OAuth2WechatHandler struct {
-	ijwt.Handler
-	svc      wechat.Service
-	userSvc  service.UserService
-	stateKey []byte
-}
-
-func NewOAuth2WechatHandler(svc wechat.Service, userSvc service.UserService, jwtHdl ijwt.Handler) *OAuth2WechatHandler {
-	return &OAuth2WechatHandler{
-		svc:      svc,
-		userSvc:  userSvc,
-		stateKey: []byte("BTv_D7]5q+f)9MTLwAA'5N!PJ6d6PNQ1"),
-		Handler:  jwtHdl,
-	}
-}
-
-func (h *OAuth2WechatHandler) RegisterRoutes(server *gin.Engine) {
-	g := server.Group("/oauth2/wechat")
-	g.GET("/authurl", h.AuthURL)
-	g.Any("/callback", h.Callback)
-
-}
-
-func (h *OAuth2WechatHandler) AuthURL(ctx *gin.Context) {
-	state := uuid.New()
-	url, err := h.svc.AuthURL(ctx, state)
-	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, Result{
-			Code: 5,
-			Msg:  "构造扫码登录url失败",
-		})
-		return
-	}
-	if err = h.setStateCookie(ctx, state); err != nil {
-		ctx.JSON(http.StatusInternalServerError, Result{
-			Code: 5,
-			Msg:  "系统异常",
-		})
-		return
-	}
-	ctx.JSON(http.StatusOK, &Result{
-		Code: 0,
-		Data: url,
-	})
-}
-
-func (h *OAuth2WechatHandler) setStateCookie(ctx *gin.Context, state string) error {
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, StateClaims{
-		state: state,
-		RegisteredClaims: jwt.RegisteredClaims{
-			// 过期时间
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute * 10)),
-		},
-	})
-	tokenStr, err := token.SignedString(h.stateKey)
-	if err != nil {
-		return err
-	}
-	ctx.SetCookie("jwt-state", tokenStr, 600,
-		"/oauth2/wechat/callback", "", false, true)
-	return nil
-}
-
-func (h *OAuth2WechatHandler) Callback(ctx *gin.Context) {
-	code := ctx.Query("code")
-	err := h.verify(ctx)
-	if err != nil {
-		ctx.JSON(http.StatusOK, Result{
-			Code: 4,
-			Msg:  "登录失败",
-		})
-		// 记录日志
-		return
-	}
-	info, err := h.svc.VerifyCode(ctx, code)
-	if err != nil {
-		ctx.JSON(http.StatusOK, Result{
-			Code: 5,
-			Msg:  "系统错误",
-		})
-		// 记录日志
-		return
-	}
-	user, err := h.userSvc.FindOrCreateByWechat(ctx, info)
-	if err != nil {
-		ctx.JSON(http.StatusOK, Result{
-			Code: 5,
-			Msg:  "系统错误",
-		})
-		// 记录日志
-		return
-	}
-	err = h.SetLoginToken(ctx, user.Id)
-	if err != nil {
-		ctx.JSON(http.StatusOK, Result{
-			Code: 5,
-			Msg:  "系统错误",
-		})
-		// 记录日志
-		return
-	}
-
-}
-
-func (h *OAuth2WechatHandler) verify(ctx *gin.Context) error {
-	state := ctx.Query("state")
-	ck, err := ctx.Cookie("jwt-state")
-	if err != nil {
-		return fmt.Errorf("拿不到 state 的 cookie, %w", err)
-	}
-	var sc StateClaims
-	token, err := jwt.ParseWithClaims(ck, &sc, func(token *jwt.Token) (interface{}, error) {
-		return h.stateKey, nil
-	})
-	if err != nil || !token.Valid {
-		return fmt.Errorf("token 已经过期, %w", err)
-	}
-	if sc.state != state {
-		return errors.New("state 不相等")
-	}
-	return nil
-}
-
-type StateClaims struct {
-	jwt.RegisteredClaims
-	state string
-}
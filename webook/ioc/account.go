@@ -0,0 +1,13 @@
+package ioc
+
+import (
+	"github.com/basic-go-project-webook/webook/internal/web"
+)
+
+// mergeKeyEnv 签名合并凭证的 key，和别的 key 相互独立，只从这个环境变量读取
+const mergeKeyEnv = "WEBOOK_ACCOUNT_MERGE_KEY"
+
+// InitMergeKey 签名合并凭证的 key 必须配置，不能用代码里面写死的
+func InitMergeKey() web.MergeKey {
+	return web.MergeKey(mustSecretEnv(mergeKeyEnv))
+}
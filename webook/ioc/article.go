@@ -0,0 +1,46 @@
+package ioc
+
+import (
+	"fmt"
+	"github.com/basic-go-project-webook/webook/internal/repository/dao/article"
+	"github.com/bwmarrin/snowflake"
+	"github.com/spf13/viper"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"gorm.io/gorm"
+)
+
+const (
+	articleDAOMySQL   = "mysql"
+	articleDAOMongoDB = "mongodb"
+)
+
+// articleDAOType 文章存放在哪里，article.dao 不配置的时候用 MySQL
+func articleDAOType() string {
+	typ := viper.GetString("article.dao")
+	switch typ {
+	case "", articleDAOMySQL:
+		return articleDAOMySQL
+	case articleDAOMongoDB:
+		return articleDAOMongoDB
+	default:
+		panic(fmt.Errorf("未知的 article.dao: %s", typ))
+	}
+}
+
+func InitArticleDAO(db *gorm.DB, mdb *mongo.Database, node *snowflake.Node) article.ArticleDAO {
+	if articleDAOType() == articleDAOMongoDB {
+		err := article.InitCollections(mdb)
+		if err != nil {
+			panic(err)
+		}
+		return article.NewMongoDBArticleDAO(mdb, node)
+	}
+	return article.NewArticleDAO(db)
+}
+
+func InitArticleReaderDAO(db *gorm.DB, mdb *mongo.Database) article.ArticleReaderDAO {
+	if articleDAOType() == articleDAOMongoDB {
+		return article.NewMongoDBArticleReaderDAO(mdb)
+	}
+	return article.NewGORMArticleReaderDAO(db)
+}
@@ -0,0 +1,37 @@
+package ioc
+
+import (
+	commentv1 "github.com/basic-go-project-webook/webook/api/proto/gen/comment/v1"
+	"github.com/spf13/viper"
+	etcdv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/naming/resolver"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func InitCommentGRPCClientEtcd(client *etcdv3.Client) commentv1.CommentServiceClient {
+	type Config struct {
+		Addr   string `yaml:"addr"`
+		Secure bool   `yaml:"secure"`
+	}
+	var cfg Config
+	err := viper.UnmarshalKey("grpc.client.comment", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	resBuilder, err := resolver.NewBuilder(client)
+	if err != nil {
+		panic(err)
+	}
+	opts := []grpc.DialOption{grpc.WithResolvers(resBuilder)}
+	if !cfg.Secure {
+		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+	opts = append(opts, grpc.WithDefaultServiceConfig(`{"loadBalancingPolicy":"round_robin"}`))
+	opts = append(opts, withForwardToken())
+	cc, err := grpc.NewClient(cfg.Addr, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return commentv1.NewCommentServiceClient(cc)
+}
@@ -0,0 +1,10 @@
+package ioc
+
+import (
+	"github.com/basic-go-project-webook/webook/internal/service/email"
+	"github.com/basic-go-project-webook/webook/internal/service/email/memory"
+)
+
+func InitEmailService() email.Service {
+	return memory.NewService()
+}
@@ -0,0 +1,23 @@
+package ioc
+
+import (
+	followv1 "github.com/basic-go-project-webook/webook/api/proto/gen/follow/v1"
+	"github.com/basic-go-project-webook/webook/internal/repository"
+	"github.com/basic-go-project-webook/webook/internal/service"
+	"github.com/spf13/viper"
+)
+
+func InitFeedService(repo repository.FeedRepository, followSvc followv1.FollowServiceClient) service.FeedService {
+	type Config struct {
+		// 作者的粉丝数超过这个值就不再推送，由粉丝自己拉
+		PushThreshold int64 `yaml:"pushThreshold"`
+	}
+	cfg := Config{
+		PushThreshold: 1000,
+	}
+	err := viper.UnmarshalKey("feed", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	return service.NewFeedService(repo, followSvc, cfg.PushThreshold)
+}
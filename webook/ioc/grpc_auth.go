@@ -0,0 +1,39 @@
+package ioc
+
+import (
+	"context"
+	"fmt"
+	"github.com/basic-go-project-webook/webook/pkg/grpcx/interceptors/auth"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"os"
+	"strings"
+)
+
+// serviceTokenEnv webook 调用其它服务用的服务凭证，不放在配置文件里面，从这个环境变量读取
+const serviceTokenEnv = "WEBOOK_GRPC_SERVICE_TOKEN"
+
+// withForwardToken 调用服务的时候把当前用户的短 token 带过去，服务自己验证，
+// 不再相信请求里面的 uid。每一个请求都带上 webook 自己的服务凭证，
+// 没有用户的调用，比如定时任务和消费者，只有服务凭证
+func withForwardToken() grpc.DialOption {
+	token := os.Getenv(serviceTokenEnv)
+	if token == "" {
+		panic(fmt.Errorf("服务凭证没有配置，环境变量 %s 为空", serviceTokenEnv))
+	}
+	return grpc.WithChainUnaryInterceptor(auth.NewClientInterceptorBuilder(forwardToken).
+		ServiceToken(token).Build())
+}
+
+// forwardToken handler 里面是直接把 gin.Context 当作 ctx 传下去的
+func forwardToken(ctx context.Context) string {
+	gctx, ok := ctx.Value(gin.ContextKey).(*gin.Context)
+	if !ok {
+		return ""
+	}
+	segs := strings.Split(gctx.GetHeader("Authorization"), " ")
+	if len(segs) != 2 {
+		return ""
+	}
+	return segs[1]
+}
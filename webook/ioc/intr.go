@@ -32,6 +32,7 @@ func InitIntrGRPCClient(svc service.InteractiveService) intrv1.InteractiveServic
 	if err != nil {
 		panic(err)
 	}
+	ops = append(ops, withForwardToken())
 	cc, err := grpc.NewClient(cfg.Addr, ops...)
 	if err != nil {
 		panic(err)
@@ -71,6 +72,7 @@ func InitIntrGRPCClientEtcd(client *etcdv3.Client) intrv1.InteractiveServiceClie
 	opts = append(opts, grpc.WithDefaultServiceConfig(`{"loadBalancingPolicy":"round_robin"}`))
 	// 加权轮询算法
 	// opts = append(opts, grpc.WithDefaultServiceConfig(`{"loadBalancingPolicy":"weighted_round_robin"}`))
+	opts = append(opts, withForwardToken())
 	cc, err := grpc.NewClient(cfg.Addr, opts...)
 	if err != nil {
 		panic(err)
@@ -1,10 +1,14 @@
 package ioc
 
 import (
+	events "github.com/basic-go-project-webook/webook/internal/events/article"
 	"github.com/basic-go-project-webook/webook/internal/job"
+	"github.com/basic-go-project-webook/webook/internal/repository"
+	"github.com/basic-go-project-webook/webook/internal/repository/dao/outbox"
 	"github.com/basic-go-project-webook/webook/internal/service"
 	rlock "github.com/gotomicro/redis-lock"
 	"github.com/robfig/cron/v3"
+	"go.mongodb.org/mongo-driver/v2/mongo"
 	"time"
 )
 
@@ -12,12 +16,57 @@ func InitRankingJob(svc service.RankingService, client *rlock.Client) *job.Ranki
 	return job.NewRankingJob(svc, client, time.Second*30)
 }
 
-func InitJobs(rjob *job.RankingJob) *cron.Cron {
+// MongoOutboxRelayService 文章存 MongoDB 的时候，文章事件的 outbox 在 MongoDB 里面，要单独投递
+type MongoOutboxRelayService service.OutboxRelayService
+
+// InitMongoOutboxRelayService 和 InitArticleDAO 一样按照 article.dao 选择，文章存 MySQL 的时候返回 nil
+func InitMongoOutboxRelayService(mdb *mongo.Database, producer events.Producer) MongoOutboxRelayService {
+	if articleDAOType() != articleDAOMongoDB {
+		return nil
+	}
+	return service.NewOutboxRelayService(repository.NewOutboxRepository(outbox.NewMongoDBOutboxDAO(mdb)), producer)
+}
+
+// InitOutboxRelayJob outbox 必须和业务数据在同一个库里面，才能在一个事务里面写入。
+// 用户一直在 MySQL 里面，文章存 MongoDB 的时候两边的 outbox 都要投递
+func InitOutboxRelayJob(svc service.OutboxRelayService, mongoSvc MongoOutboxRelayService) *job.OutboxRelayJob {
+	svcs := []service.OutboxRelayService{svc}
+	if mongoSvc != nil {
+		svcs = append(svcs, mongoSvc)
+	}
+	return job.NewOutboxRelayJob(time.Second*10, svcs...)
+}
+
+// InitScheduler 基于 MySQL 抢占的任务调度，目前只有定时发表文章
+func InitScheduler(svc service.CronJobService, publisher *job.ArticlePublishExecutor,
+	backfill *job.SearchBackfillExecutor) *job.Scheduler {
+	scheduler := job.NewScheduler(svc)
+	scheduler.RegisterExecutor(publisher)
+	scheduler.RegisterExecutor(backfill)
+	return scheduler
+}
+
+func InitJobs(rjob *job.RankingJob, ojob *job.OutboxRelayJob,
+	rankingSvc service.RankingService, client *rlock.Client) *cron.Cron {
 	builder := job.NewCronJobBuilder()
 	expr := cron.New(cron.WithSeconds())
 	_, err := expr.AddJob("@every 3s", builder.Build(rjob))
 	if err != nil {
 		panic(err)
 	}
+	// 每个榜单按照自己的周期计算
+	for _, board := range rankingSvc.Boards() {
+		bjob := job.NewRankingBoardJob(rankingSvc, board, client)
+		_, err = expr.AddJob("@every "+board.Interval.String(),
+			cron.NewChain(cron.SkipIfStillRunning(cron.DiscardLogger)).Then(builder.Build(bjob)))
+		if err != nil {
+			panic(err)
+		}
+	}
+	// 上一次还没有跑完就跳过，避免同一个实例重复投递
+	_, err = expr.AddJob("@every 1s", cron.NewChain(cron.SkipIfStillRunning(cron.DiscardLogger)).Then(builder.Build(ojob)))
+	if err != nil {
+		panic(err)
+	}
 	return expr
 }
@@ -0,0 +1,49 @@
+package ioc
+
+import (
+	ijwt "github.com/basic-go-project-webook/webook/internal/web/jwt"
+	"github.com/basic-go-project-webook/webook/pkg/jwtx"
+	"github.com/redis/go-redis/v9"
+	"github.com/spf13/viper"
+)
+
+func InitJwtHandler(cmd redis.Cmdable) ijwt.Handler {
+	type Config struct {
+		// MaxDevices 最多同时登录几台设备，0 不限制
+		MaxDevices int `yaml:"maxDevices"`
+	}
+	cfg := Config{
+		MaxDevices: 5,
+	}
+	err := viper.UnmarshalKey("session", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	atKeys, rtKeys := InitJwtKeyrings()
+	return ijwt.NewRedisJwtHandler(cmd, cfg.MaxDevices, atKeys, rtKeys)
+}
+
+// InitJwtKeyrings 短 token 和长 token 各用一组 key。
+// 轮换的时候在配置里面加一把 notBefore 在将来的新 key，到点之后新 token 用新 key 签名，
+// 旧 key 在一个 token 有效期之后才失效，之后再把它从配置里面删掉。
+// 短 token 要给别的服务验证，用 RS256 或者 EdDSA，把公钥配置到服务上
+func InitJwtKeyrings() (atKeys *jwtx.Keyring, rtKeys *jwtx.Keyring) {
+	type Config struct {
+		Access  []jwtx.KeyConfig `yaml:"access"`
+		Refresh []jwtx.KeyConfig `yaml:"refresh"`
+	}
+	var cfg Config
+	err := viper.UnmarshalKey("jwt", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	atKeys, err = jwtx.NewKeyringFromConfig(cfg.Access, ijwt.AccessTokenExpiration)
+	if err != nil {
+		panic(err)
+	}
+	rtKeys, err = jwtx.NewKeyringFromConfig(cfg.Refresh, ijwt.RefreshTokenExpiration)
+	if err != nil {
+		panic(err)
+	}
+	return atKeys, rtKeys
+}
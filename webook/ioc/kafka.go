@@ -5,7 +5,13 @@ import (
 	"github.com/basic-go-project-webook/webook/interactive/repository"
 	"github.com/basic-go-project-webook/webook/internal/events"
 	"github.com/basic-go-project-webook/webook/internal/events/article"
+	"github.com/basic-go-project-webook/webook/internal/events/feed"
+	"github.com/basic-go-project-webook/webook/internal/events/ranking"
+	"github.com/basic-go-project-webook/webook/internal/service"
+	"github.com/basic-go-project-webook/webook/pkg/kafkax"
+	"github.com/segmentio/kafka-go"
 	"github.com/spf13/viper"
+	"time"
 )
 
 func InitProducer() article.Producer {
@@ -20,7 +26,8 @@ func InitProducer() article.Producer {
 	return article.NewKafkaProducer(cfg.Addr)
 }
 
-func InitInteractiveReadEventConsumer(repo repository.InteractiveRepository) *events2.InteractiveReadEventConsumer {
+// InitDLQWriter 所有消费者共用的死信队列 writer
+func InitDLQWriter() *kafka.Writer {
 	type Config struct {
 		Addr []string `yaml:"addr"`
 	}
@@ -29,9 +36,69 @@ func InitInteractiveReadEventConsumer(repo repository.InteractiveRepository) *ev
 	if err != nil {
 		panic(err)
 	}
-	return events2.NewInteractiveReadEventConsumer(cfg.Addr, repo)
+	return kafkax.NewDLQWriter(cfg.Addr)
 }
 
-func InitConsumers(c1 *events2.InteractiveReadEventConsumer) []events.Consumer {
-	return []events.Consumer{c1}
+func InitInteractiveReadEventConsumer(repo repository.InteractiveRepository, dlq *kafka.Writer) *events2.InteractiveReadEventBatchConsumer {
+	type Config struct {
+		Addr []string `yaml:"addr"`
+		// 每一批最多的消息数和最长的等待时间
+		ReadBatchSize   int           `yaml:"readBatchSize"`
+		ReadBatchWindow time.Duration `yaml:"readBatchWindow"`
+	}
+	cfg := Config{
+		ReadBatchSize:   100,
+		ReadBatchWindow: time.Second,
+	}
+	err := viper.UnmarshalKey("kafka", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	return events2.NewInteractiveReadEventBatchConsumer(cfg.Addr, repo, dlq, cfg.ReadBatchSize, cfg.ReadBatchWindow)
+}
+
+func InitFeedPublishedEventConsumer(svc service.FeedService, dlq *kafka.Writer) *feed.PublishedEventConsumer {
+	type Config struct {
+		Addr []string `yaml:"addr"`
+	}
+	var cfg Config
+	err := viper.UnmarshalKey("kafka", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	return feed.NewPublishedEventConsumer(cfg.Addr, svc, dlq)
+}
+
+func InitRankingInteractiveEventConsumer(svc service.RealtimeRankingService, dlq *kafka.Writer) *ranking.InteractiveEventConsumer {
+	type Config struct {
+		Addr []string `yaml:"addr"`
+	}
+	var cfg Config
+	err := viper.UnmarshalKey("kafka", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	return ranking.NewInteractiveEventConsumer(cfg.Addr, svc, dlq)
+}
+
+func InitRankingReadEventConsumer(svc service.RealtimeRankingService, dlq *kafka.Writer) *ranking.ReadEventConsumer {
+	type Config struct {
+		Addr []string `yaml:"addr"`
+	}
+	var cfg Config
+	err := viper.UnmarshalKey("kafka", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	return ranking.NewReadEventConsumer(cfg.Addr, svc, dlq)
+}
+
+func InitConsumers(c1 *events2.InteractiveReadEventBatchConsumer, c2 *feed.PublishedEventConsumer,
+	c3 *ranking.InteractiveEventConsumer, c4 *ranking.ReadEventConsumer) []events.Consumer {
+	consumers := []events.Consumer{c1, c2}
+	// 批量计算热榜的时候不需要实时加分
+	if rankingMode() == "realtime" {
+		consumers = append(consumers, c3, c4)
+	}
+	return consumers
 }
@@ -0,0 +1,77 @@
+package ioc
+
+import (
+	"fmt"
+	"github.com/basic-go-project-webook/webook/internal/service"
+	"github.com/basic-go-project-webook/webook/internal/service/oauth2"
+	"github.com/basic-go-project-webook/webook/internal/service/oauth2/oidc"
+	"github.com/basic-go-project-webook/webook/internal/service/oauth2/wechat"
+	"github.com/basic-go-project-webook/webook/internal/web"
+	ijwt "github.com/basic-go-project-webook/webook/internal/web/jwt"
+	"github.com/spf13/viper"
+	"net/http"
+	"os"
+	"time"
+)
+
+// InitOAuth2Providers 没有配置的第三方登录就不开
+func InitOAuth2Providers() []oauth2.Provider {
+	type WechatConfig struct {
+		AppId       string `yaml:"appId"`
+		AppSecret   string `yaml:"appSecret"`
+		RedirectURI string `yaml:"redirectURI"`
+	}
+	type Config struct {
+		Wechat WechatConfig `yaml:"wechat"`
+		// OIDC 标准 OIDC 或者 GitHub 这种返回 JSON 用户信息的第三方
+		OIDC []oidc.Config `yaml:"oidc"`
+	}
+	var cfg Config
+	err := viper.UnmarshalKey("oauth2", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	client := &http.Client{Timeout: time.Second * 5}
+	var providers []oauth2.Provider
+	// 密钥不要放在配置文件里面
+	if cfg.Wechat.AppId == "" {
+		cfg.Wechat.AppId = os.Getenv("WECHAT_APP_ID")
+	}
+	if cfg.Wechat.AppSecret == "" {
+		cfg.Wechat.AppSecret = os.Getenv("WECHAT_APP_SECRET")
+	}
+	if cfg.Wechat.AppId != "" {
+		providers = append(providers, wechat.NewService(wechat.Config{
+			AppId:       cfg.Wechat.AppId,
+			AppSecret:   cfg.Wechat.AppSecret,
+			RedirectURI: cfg.Wechat.RedirectURI,
+		}, client))
+	}
+	for _, c := range cfg.OIDC {
+		if c.ClientId == "" {
+			continue
+		}
+		providers = append(providers, oidc.NewService(c, client))
+	}
+	return providers
+}
+
+// stateKeyEnv 签名 state 的 key，和别的 key 相互独立，只从这个环境变量读取
+const stateKeyEnv = "WEBOOK_OAUTH2_STATE_KEY"
+
+// InitOAuth2Handler state 的签名 key 必须配置，不能用代码里面写死的
+func InitOAuth2Handler(providers []oauth2.Provider, userSvc service.UserService,
+	accountSvc service.AccountService, jwtHdl ijwt.Handler, mergeKey web.MergeKey) *web.OAuth2Handler {
+	stateKey := mustSecretEnv(stateKeyEnv)
+	return web.NewOAuth2Handler(providers, userSvc, accountSvc, jwtHdl, []byte(stateKey), mergeKey)
+}
+
+// mustSecretEnv 读取 HMAC 用的 key，没有配置或者太短都直接 panic。
+// 生成一个新的：openssl rand -base64 32
+func mustSecretEnv(env string) string {
+	key := os.Getenv(env)
+	if len(key) < 32 {
+		panic(fmt.Errorf("环境变量 %s 没有配置或者短于 32 字节", env))
+	}
+	return key
+}
@@ -0,0 +1,172 @@
+package ioc
+
+import (
+	"fmt"
+	commentv1 "github.com/basic-go-project-webook/webook/api/proto/gen/comment/v1"
+	intrv1 "github.com/basic-go-project-webook/webook/api/proto/gen/intr/v1"
+	"github.com/basic-go-project-webook/webook/internal/domain"
+	"github.com/basic-go-project-webook/webook/internal/repository"
+	"github.com/basic-go-project-webook/webook/internal/repository/cache"
+	"github.com/basic-go-project-webook/webook/internal/service"
+	"github.com/redis/go-redis/v9"
+	"github.com/spf13/viper"
+	"time"
+)
+
+// InitRankingScorer 根据配置选择热榜的打分策略
+func InitRankingScorer() service.RankingScorer {
+	type Weights struct {
+		Read    float64 `yaml:"read"`
+		Like    float64 `yaml:"like"`
+		Collect float64 `yaml:"collect"`
+		Comment float64 `yaml:"comment"`
+	}
+	type Config struct {
+		// like 只看点赞，weighted 按照权重综合阅读、点赞、收藏和评论
+		Scorer  string  `yaml:"scorer"`
+		Weights Weights `yaml:"weights"`
+		Gravity float64 `yaml:"gravity"`
+	}
+	cfg := Config{
+		Scorer: "like",
+		Weights: Weights{
+			Read:    0.1,
+			Like:    1,
+			Collect: 2,
+			Comment: 1.5,
+		},
+		Gravity: 1.8,
+	}
+	err := viper.UnmarshalKey("ranking", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	switch cfg.Scorer {
+	case "like":
+		return service.NewLikeRankingScorer()
+	case "weighted":
+		return &service.WeightedRankingScorer{
+			ReadWeight:    cfg.Weights.Read,
+			LikeWeight:    cfg.Weights.Like,
+			CollectWeight: cfg.Weights.Collect,
+			CommentWeight: cfg.Weights.Comment,
+			Gravity:       cfg.Gravity,
+		}
+	default:
+		panic(fmt.Sprintf("未知的热榜打分策略 %s", cfg.Scorer))
+	}
+}
+
+// InitRankingBoards 榜单配置，没有配置的时候只有小时榜、日榜和周榜
+func InitRankingBoards() []domain.RankingBoard {
+	type Board struct {
+		Name     string        `yaml:"name"`
+		Window   time.Duration `yaml:"window"`
+		N        int           `yaml:"n"`
+		Category string        `yaml:"category"`
+		Tag      string        `yaml:"tag"`
+		Interval time.Duration `yaml:"interval"`
+	}
+	type Config struct {
+		Boards []Board `yaml:"boards"`
+	}
+	var cfg Config
+	err := viper.UnmarshalKey("ranking", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	// 默认值不能直接放在 cfg 里，切片解码的时候会和配置的合并到一起
+	if len(cfg.Boards) == 0 {
+		cfg.Boards = []Board{
+			{Name: "hourly", Window: time.Hour, N: 100, Interval: time.Minute},
+			{Name: "daily", Window: 24 * time.Hour, N: 100, Interval: 5 * time.Minute},
+			{Name: "weekly", Window: 7 * 24 * time.Hour, N: 100, Interval: 30 * time.Minute},
+		}
+	}
+	seen := make(map[string]bool, len(cfg.Boards))
+	res := make([]domain.RankingBoard, 0, len(cfg.Boards))
+	for _, b := range cfg.Boards {
+		if b.Name == "" || seen[b.Name] {
+			panic(fmt.Sprintf("榜单名字为空或者重复 %q", b.Name))
+		}
+		if b.Window <= 0 || b.Interval <= 0 || b.N <= 0 || b.N > 1000 {
+			panic(fmt.Sprintf("榜单 %s 配置错误", b.Name))
+		}
+		seen[b.Name] = true
+		res = append(res, domain.RankingBoard{
+			Name:     b.Name,
+			Window:   b.Window,
+			N:        b.N,
+			Category: b.Category,
+			Tag:      b.Tag,
+			Interval: b.Interval,
+		})
+	}
+	return res
+}
+
+// rankingMode batch 是定时全量计算热榜，realtime 是互动事件实时加分
+func rankingMode() string {
+	mode := viper.GetString("ranking.mode")
+	if mode == "" {
+		return "batch"
+	}
+	return mode
+}
+
+// InitRankingService 榜单总是批量计算的，热榜按照 ranking.mode 选择
+func InitRankingService(artSvc service.ArticleService, intrSvc intrv1.InteractiveServiceClient,
+	commentSvc commentv1.CommentServiceClient, repo repository.RankingRepository,
+	scorer service.RankingScorer, boards []domain.RankingBoard,
+	realtime service.RealtimeRankingService) service.RankingService {
+	batch := service.NewBatchRankingService(artSvc, intrSvc, commentSvc, repo, scorer, boards)
+	switch mode := rankingMode(); mode {
+	case "batch":
+		return batch
+	case "realtime":
+		return service.NewRealtimeTopNRankingService(batch, realtime)
+	default:
+		panic(fmt.Sprintf("未知的热榜模式 %s", mode))
+	}
+}
+
+func InitRankingRealtimeCache(client redis.Cmdable) cache.RankingRealtimeCache {
+	type Config struct {
+		// 互动的分数过了多久减半
+		HalfLife time.Duration `yaml:"halfLife"`
+		// 压缩之后保留多少篇
+		Keep int `yaml:"keep"`
+	}
+	cfg := Config{
+		HalfLife: 24 * time.Hour,
+		Keep:     1000,
+	}
+	err := viper.UnmarshalKey("ranking.realtime", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	return cache.NewRankingRealtimeRedisCache(client, cfg.HalfLife, cfg.Keep)
+}
+
+// InitRealtimeRankingService 和 weighted 打分共用权重，评论没有事件，所以不算
+func InitRealtimeRankingService(artSvc service.ArticleService, repo repository.RealtimeRankingRepository) service.RealtimeRankingService {
+	type Config struct {
+		Read    float64 `yaml:"read"`
+		Like    float64 `yaml:"like"`
+		Collect float64 `yaml:"collect"`
+	}
+	cfg := Config{
+		Read:    0.1,
+		Like:    1,
+		Collect: 2,
+	}
+	err := viper.UnmarshalKey("ranking.weights", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	return service.NewRealtimeRankingService(artSvc, repo, service.RankingWeights{
+		Read:    cfg.Read,
+		Like:    cfg.Like,
+		Collect: cfg.Collect,
+	})
+}
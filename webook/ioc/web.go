@@ -36,27 +36,43 @@ func InitGinMiddlewares(redisClient redis.Cmdable, jwtHdl ijwt.Handler) []gin.Ha
 		}),
 		otelgin.Middleware("webook"),
 		ratelimit.NewBuilder(ratelimit2.NewRedisSlideWindowLimiter(redisClient, time.Second, 100)).Build(),
-		middleware.NewLoginJWTMiddleWareBuilder(jwtHdl).
-			IgnorePaths("/users/login").
-			IgnorePaths("/users/signup").
-			IgnorePaths("/users/login_sms/code/send").
-			IgnorePaths("/oauth2/wechat/authurl").
-			IgnorePaths("/oauth2/wechat/callback").
-			IgnorePaths("/users/refresh_token").
-			IgnorePaths("/articles/edit").
-			IgnorePaths("/users/login_sms").Build(),
+		loginJWTMiddleware(jwtHdl),
 		logger.NewBuilder(func(ctx context.Context, al *logger.AccessLog) {
 			zap.L().Debug("HTTP请求", zap.Any("AccessLog", al))
 		}).Build(),
 	}
 }
 
-func InitWebserver(mdls []gin.HandlerFunc, userHdl *web.UserHandle,
-	oauth2WechatHandler *web.OAuth2WechatHandler, artHdl *web.ArticleHandle) *gin.Engine {
+// loginJWTMiddleware 除了登录注册和公开的页面，都要求登录
+func loginJWTMiddleware(jwtHdl ijwt.Handler) gin.HandlerFunc {
+	return middleware.NewLoginJWTMiddleWareBuilder(jwtHdl).
+		IgnorePaths("/users/login").
+		IgnorePaths("/users/signup").
+		IgnorePaths("/users/login_sms/code/send").
+		IgnorePaths("/oauth2/:provider/authurl").
+		IgnorePaths("/oauth2/:provider/callback").
+		IgnorePaths("/users/refresh_token").
+		IgnorePaths("/articles/edit").
+		IgnorePaths("/authors/:id/articles").
+		IgnorePaths("/users/login_sms").Build()
+}
+
+func InitWebserver(mdls []gin.HandlerFunc, userHdl *web.UserHandle, accountHdl *web.AccountHandler,
+	oauth2Handler *web.OAuth2Handler, artHdl *web.ArticleHandle,
+	commentHdl *web.CommentHandler, followHdl *web.FollowHandler, feedHdl *web.FeedHandler,
+	collectionHdl *web.CollectionHandler, authorHdl *web.AuthorHandler,
+	rankingHdl *web.RankingHandler) *gin.Engine {
 	server := gin.Default()
 	server.Use(mdls...)
 	userHdl.RegisterRoutes(server)
-	oauth2WechatHandler.RegisterRoutes(server)
+	accountHdl.RegisterRoutes(server)
+	oauth2Handler.RegisterRoutes(server)
 	artHdl.RegisterRoutes(server)
+	commentHdl.RegisterRoutes(server)
+	followHdl.RegisterRoutes(server)
+	feedHdl.RegisterRoutes(server)
+	collectionHdl.RegisterRoutes(server)
+	authorHdl.RegisterRoutes(server)
+	rankingHdl.RegisterRoutes(server)
 	return server
 }
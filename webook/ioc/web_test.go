@@ -0,0 +1,55 @@
+package ioc
+
+import (
+	ijwt "github.com/basic-go-project-webook/webook/internal/web/jwt"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// noTokenHandler 请求里面永远没有 token
+type noTokenHandler struct {
+	ijwt.Handler
+}
+
+func (noTokenHandler) ExtractToken(ctx *gin.Context) string {
+	return ""
+}
+
+func TestLoginJWTMiddleware(t *testing.T) {
+	testCases := []struct {
+		name     string
+		path     string
+		wantCode int
+	}{
+		{
+			name:     "作者主页不需要登录",
+			path:     "/authors/123/articles?limit=10",
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "其它页面需要登录",
+			path:     "/users/profile",
+			wantCode: http.StatusUnauthorized,
+		},
+	}
+	server := gin.New()
+	server.Use(loginJWTMiddleware(noTokenHandler{}))
+	ok := func(ctx *gin.Context) {
+		ctx.Status(http.StatusOK)
+	}
+	server.GET("/authors/:id/articles", ok)
+	server.GET("/users/profile", ok)
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tc.path, nil)
+			require.NoError(t, err)
+			resp := httptest.NewRecorder()
+			server.ServeHTTP(resp, req)
+			assert.Equal(t, tc.wantCode, resp.Code)
+		})
+	}
+}
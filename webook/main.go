@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/basic-go-project-webook/webook/ioc"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -9,6 +10,8 @@ import (
 	_ "github.com/spf13/viper/remote"
 	"go.uber.org/zap"
 	"net/http"
+	"os/signal"
+	"syscall"
 	"time"
 )
 
@@ -33,15 +36,44 @@ func main() {
 	}
 	// 启动定时任务
 	app.cron.Start()
-	defer func() {
-		// 等待定时任务退出
-		<-app.cron.Stop().Done()
+	schCtx, schCancel := context.WithCancel(context.Background())
+	go func() {
+		err := app.scheduler.Schedule(schCtx)
+		if err != nil && schCtx.Err() == nil {
+			zap.L().Error("任务调度退出", zap.Error(err))
+		}
+	}()
+	server := &http.Server{
+		Addr:    ":8080",
+		Handler: app.web,
+	}
+	go func() {
+		err := server.ListenAndServe()
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			panic(err)
+		}
 	}()
-	server := app.web
-	err := server.Run(":8080")
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	<-ctx.Done()
+	stop()
+	zap.L().Info("收到退出信号，开始关闭")
+	// 先停掉消费者，等正在处理的消息处理完
+	for _, consumer := range app.consumers {
+		err := consumer.Close()
+		if err != nil {
+			zap.L().Error("关闭消费者失败", zap.Error(err))
+		}
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	err := server.Shutdown(shutdownCtx)
 	if err != nil {
-		panic(err)
+		zap.L().Error("关闭 HTTP 服务失败", zap.Error(err))
 	}
+	schCancel()
+	// 等待定时任务退出
+	<-app.cron.Stop().Done()
 }
 
 func initPrometheus() {
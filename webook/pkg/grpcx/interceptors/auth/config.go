@@ -0,0 +1,54 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"github.com/basic-go-project-webook/webook/pkg/jwtx"
+	"github.com/spf13/viper"
+	"os"
+	"time"
+)
+
+// accessTokenTTL 和 webook 短 token 的有效期一致，换下来的 key 在这段时间内还能验证
+const accessTokenTTL = time.Minute * 30
+
+// ServiceConfig 允许调用的内部服务，凭证不放在配置文件里面，从 TokenEnv 这个环境变量读取
+type ServiceConfig struct {
+	Name     string `yaml:"name"`
+	TokenEnv string `yaml:"tokenEnv"`
+}
+
+// NewBuilderFromConfig 从配置的 key 下面读取 keys 和 services。
+// keys 是 jwtx.KeyConfig 的列表，服务上只需要配置公钥，不配置就不接受用户的 token；
+// services 是允许调用的内部服务。两个都没有配置的时候返回错误
+func NewBuilderFromConfig(key string) (*ServerInterceptorBuilder, error) {
+	type Config struct {
+		Keys     []jwtx.KeyConfig `yaml:"keys"`
+		Services []ServiceConfig  `yaml:"services"`
+	}
+	var cfg Config
+	err := viper.UnmarshalKey(key, &cfg)
+	if err != nil {
+		return nil, err
+	}
+	if len(cfg.Keys) == 0 && len(cfg.Services) == 0 {
+		return nil, errors.New("没有配置 jwt key 和内部服务")
+	}
+	var verifier Verifier
+	if len(cfg.Keys) > 0 {
+		keyring, err := jwtx.NewKeyringFromConfig(cfg.Keys, accessTokenTTL)
+		if err != nil {
+			return nil, err
+		}
+		verifier = NewJWTVerifier(keyring)
+	}
+	services := make(ServiceTokens, len(cfg.Services))
+	for _, svc := range cfg.Services {
+		token := os.Getenv(svc.TokenEnv)
+		if token == "" {
+			return nil, fmt.Errorf("服务 %s 的凭证没有配置，环境变量 %s 为空", svc.Name, svc.TokenEnv)
+		}
+		services[token] = svc.Name
+	}
+	return NewServerInterceptorBuilder(verifier, services), nil
+}
@@ -0,0 +1,77 @@
+package auth
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"testing"
+	"time"
+)
+
+func TestNewBuilderFromConfig(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	require.NoError(t, err)
+	viper.Set("test_auth", map[string]any{
+		"keys": []map[string]any{
+			{
+				"kid":       "at-v2",
+				"alg":       "EdDSA",
+				"publicKey": string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})),
+			},
+		},
+		"services": []map[string]any{
+			{"name": "webook", "tokenEnv": "TEST_AUTH_SERVICE_TOKEN"},
+		},
+	})
+	// 服务凭证的环境变量没有配置的时候启动失败
+	t.Setenv("TEST_AUTH_SERVICE_TOKEN", "")
+	_, err = NewBuilderFromConfig("test_auth")
+	assert.Error(t, err)
+
+	t.Setenv("TEST_AUTH_SERVICE_TOKEN", "svc-token")
+	builder, err := NewBuilderFromConfig("test_auth")
+	require.NoError(t, err)
+	interceptor := builder.Require("/test/Required").Build()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, jwt.MapClaims{
+		"Uid": 123,
+		"exp": time.Now().Add(time.Minute).Unix(),
+	})
+	token.Header["kid"] = "at-v2"
+	tokenStr, err := token.SignedString(priv)
+	require.NoError(t, err)
+
+	handler := func(ctx context.Context, req any) (any, error) {
+		uid, _ := Uid(ctx)
+		return uid, nil
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: "/test/Required"}
+	_, err = interceptor(context.Background(), nil, info, handler)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(metadataKey, "Bearer "+tokenStr))
+	uid, err := interceptor(ctx, nil, info, handler)
+	require.NoError(t, err)
+	assert.Equal(t, int64(123), uid)
+
+	// 环境变量里面的服务凭证
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(serviceMetadataKey, "svc-token"))
+	_, err = interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/test/Optional"}, handler)
+	require.NoError(t, err)
+
+	// 什么都没有配置的时候启动失败
+	_, err = NewBuilderFromConfig("test_auth_missing")
+	assert.Error(t, err)
+}
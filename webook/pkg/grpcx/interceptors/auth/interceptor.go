@@ -0,0 +1,208 @@
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"github.com/basic-go-project-webook/webook/pkg/jwtx"
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"strings"
+)
+
+const (
+	// metadataKey 用户的 token 放在 metadata 的 authorization 里面，格式和 HTTP 一样是 Bearer xxx
+	metadataKey = "authorization"
+	// serviceMetadataKey 内部服务的凭证，定时任务和消费者这种没有用户的调用只带这个
+	serviceMetadataKey = "x-service-token"
+)
+
+type uidKey struct{}
+
+type serviceKey struct{}
+
+// Verifier 验证 token，返回 token 里面的 uid
+type Verifier interface {
+	Verify(token string) (int64, error)
+}
+
+// JWTVerifier 验证 webook 签发的短 token，服务上一般只配置公钥
+type JWTVerifier struct {
+	keyring *jwtx.Keyring
+}
+
+func NewJWTVerifier(keyring *jwtx.Keyring) *JWTVerifier {
+	return &JWTVerifier{
+		keyring: keyring,
+	}
+}
+
+func (v *JWTVerifier) Verify(tokenStr string) (int64, error) {
+	// 和 webook 里面 UserClaims 的字段保持一致
+	var claims struct {
+		jwt.RegisteredClaims
+		Uid int64
+	}
+	_, err := jwt.ParseWithClaims(tokenStr, &claims, v.keyring.Keyfunc)
+	if err != nil {
+		return 0, err
+	}
+	if claims.Uid <= 0 {
+		return 0, status.Error(codes.Unauthenticated, "token 里面没有 uid")
+	}
+	return claims.Uid, nil
+}
+
+// ServiceTokens 内部服务的凭证，key 是凭证，value 是服务的名字
+type ServiceTokens map[string]string
+
+// Verify 返回凭证对应的服务名字
+func (s ServiceTokens) Verify(token string) (string, bool) {
+	for t, name := range s {
+		if subtle.ConstantTimeCompare([]byte(t), []byte(token)) == 1 {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// ServerInterceptorBuilder 每一个请求都必须带凭证：用户的 token，或者内部服务的凭证，或者两个都带。
+// 用户的 token 验证之后把 uid 放到 context 里面，服务的凭证验证之后把服务名字放到 context 里面。
+// Require 的方法是代表用户操作的，必须带用户的 token；RequireService 的方法只接受内部服务调用。
+// 请求里面哪个字段代表操作人只有服务自己知道，所以要在服务里面用 CheckUid 检查
+type ServerInterceptorBuilder struct {
+	// verifier 为 nil 的时候不接受用户的 token
+	verifier        Verifier
+	services        ServiceTokens
+	required        map[string]struct{}
+	serviceRequired map[string]struct{}
+}
+
+func NewServerInterceptorBuilder(verifier Verifier, services ServiceTokens) *ServerInterceptorBuilder {
+	return &ServerInterceptorBuilder{
+		verifier:        verifier,
+		services:        services,
+		required:        make(map[string]struct{}),
+		serviceRequired: make(map[string]struct{}),
+	}
+}
+
+// Require methods 是完整的方法名，例如 /intr.v1.InteractiveService/Like
+func (b *ServerInterceptorBuilder) Require(methods ...string) *ServerInterceptorBuilder {
+	for _, m := range methods {
+		b.required[m] = struct{}{}
+	}
+	return b
+}
+
+// RequireService 只有内部服务能调用的方法，只带用户 token 的请求会被拒绝
+func (b *ServerInterceptorBuilder) RequireService(methods ...string) *ServerInterceptorBuilder {
+	for _, m := range methods {
+		b.serviceRequired[m] = struct{}{}
+	}
+	return b
+}
+
+func (b *ServerInterceptorBuilder) Build() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		token := fromMetadata(ctx, metadataKey)
+		svcToken := fromMetadata(ctx, serviceMetadataKey)
+		if token == "" && svcToken == "" {
+			return nil, status.Error(codes.Unauthenticated, "没有凭证")
+		}
+		if token != "" {
+			if b.verifier == nil {
+				return nil, status.Error(codes.Unauthenticated, "不接受用户 token")
+			}
+			uid, err := b.verifier.Verify(strings.TrimPrefix(token, "Bearer "))
+			if err != nil {
+				return nil, status.Error(codes.Unauthenticated, "token 无效")
+			}
+			ctx = context.WithValue(ctx, uidKey{}, uid)
+		}
+		if svcToken != "" {
+			name, ok := b.services.Verify(svcToken)
+			if !ok {
+				return nil, status.Error(codes.Unauthenticated, "服务凭证无效")
+			}
+			ctx = context.WithValue(ctx, serviceKey{}, name)
+		}
+		if _, ok := b.required[info.FullMethod]; ok && token == "" {
+			return nil, status.Error(codes.Unauthenticated, "没有登录")
+		}
+		if _, ok := b.serviceRequired[info.FullMethod]; ok && svcToken == "" {
+			return nil, status.Error(codes.PermissionDenied, "只接受内部服务调用")
+		}
+		return handler(ctx, req)
+	}
+}
+
+// ClientInterceptorBuilder 把调用方的 token 放到 metadata 里面转发给服务，
+// 配置了服务凭证的时候每一个请求都带上
+type ClientInterceptorBuilder struct {
+	tokenFrom    func(ctx context.Context) string
+	serviceToken string
+}
+
+// NewClientInterceptorBuilder tokenFrom 从 context 里面取出 token，没有返回空字符串
+func NewClientInterceptorBuilder(tokenFrom func(ctx context.Context) string) *ClientInterceptorBuilder {
+	return &ClientInterceptorBuilder{
+		tokenFrom: tokenFrom,
+	}
+}
+
+// ServiceToken 调用方自己的服务凭证
+func (b *ClientInterceptorBuilder) ServiceToken(token string) *ClientInterceptorBuilder {
+	b.serviceToken = token
+	return b
+}
+
+func (b *ClientInterceptorBuilder) Build() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if token := b.tokenFrom(ctx); token != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, metadataKey, "Bearer "+token)
+		}
+		if b.serviceToken != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, serviceMetadataKey, b.serviceToken)
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// Uid 拦截器验证过的 uid，只带了服务凭证的调用没有
+func Uid(ctx context.Context) (int64, bool) {
+	uid, ok := ctx.Value(uidKey{}).(int64)
+	return uid, ok
+}
+
+// Service 拦截器验证过的调用方服务名字，没有带服务凭证的调用没有
+func Service(ctx context.Context) (string, bool) {
+	name, ok := ctx.Value(serviceKey{}).(string)
+	return name, ok
+}
+
+// CheckUid 请求里面代表操作人的 uid 必须和 token 里面的一致，没有用户 token 的调用一律拒绝
+func CheckUid(ctx context.Context, uid int64) error {
+	tokenUid, ok := Uid(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "没有登录")
+	}
+	if uid != tokenUid {
+		return status.Error(codes.PermissionDenied, "不能代替别的用户操作")
+	}
+	return nil
+}
+
+func fromMetadata(ctx context.Context, key string) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	vals := md.Get(key)
+	if len(vals) == 0 {
+		return ""
+	}
+	return vals[0]
+}
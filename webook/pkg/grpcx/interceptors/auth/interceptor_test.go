@@ -0,0 +1,101 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+type verifierFunc func(token string) (int64, error)
+
+func (f verifierFunc) Verify(token string) (int64, error) {
+	return f(token)
+}
+
+func TestServerInterceptor(t *testing.T) {
+	verifier := verifierFunc(func(token string) (int64, error) {
+		if token == "good" {
+			return 123, nil
+		}
+		return 0, errors.New("bad token")
+	})
+	interceptor := NewServerInterceptorBuilder(verifier, ServiceTokens{"svc-token": "webook"}).
+		Require("/test/Required").
+		RequireService("/test/ServiceOnly").
+		Build()
+	handler := func(ctx context.Context, req any) (any, error) {
+		// 请求里面的 uid 是 req，0 代表这个方法不需要检查操作人
+		uid := req.(int64)
+		if uid == 0 {
+			return nil, nil
+		}
+		return nil, CheckUid(ctx, uid)
+	}
+	testCases := []struct {
+		name     string
+		method   string
+		token    string
+		svcToken string
+		uid      int64
+		wantCode codes.Code
+	}{
+		{name: "什么凭证都没有", method: "/test/Optional", wantCode: codes.Unauthenticated},
+		{name: "只有服务凭证", method: "/test/Optional", svcToken: "svc-token", wantCode: codes.OK},
+		{name: "服务凭证无效", method: "/test/Optional", svcToken: "bad", wantCode: codes.Unauthenticated},
+		{name: "只有服务凭证不能代替用户操作", method: "/test/Optional", svcToken: "svc-token", uid: 456, wantCode: codes.Unauthenticated},
+		{name: "必须登录的方法只有服务凭证", method: "/test/Required", svcToken: "svc-token", uid: 123, wantCode: codes.Unauthenticated},
+		{name: "token 无效", method: "/test/Optional", token: "bad", uid: 123, wantCode: codes.Unauthenticated},
+		{name: "token 和 uid 一致", method: "/test/Required", token: "good", uid: 123, wantCode: codes.OK},
+		{name: "同时带了服务凭证", method: "/test/Required", token: "good", svcToken: "svc-token", uid: 123, wantCode: codes.OK},
+		{name: "代替别人操作", method: "/test/Required", token: "good", uid: 456, wantCode: codes.PermissionDenied},
+		{name: "内部方法只有用户 token", method: "/test/ServiceOnly", token: "good", wantCode: codes.PermissionDenied},
+		{name: "内部方法带了服务凭证", method: "/test/ServiceOnly", svcToken: "svc-token", wantCode: codes.OK},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			md := metadata.MD{}
+			if tc.token != "" {
+				md.Set(metadataKey, "Bearer "+tc.token)
+			}
+			if tc.svcToken != "" {
+				md.Set(serviceMetadataKey, tc.svcToken)
+			}
+			ctx := metadata.NewIncomingContext(context.Background(), md)
+			_, err := interceptor(ctx, tc.uid, &grpc.UnaryServerInfo{FullMethod: tc.method}, handler)
+			assert.Equal(t, tc.wantCode, status.Code(err))
+		})
+	}
+}
+
+func TestServerInterceptor_NoVerifier(t *testing.T) {
+	// 没有配置 jwt key 的服务只接受服务凭证
+	interceptor := NewServerInterceptorBuilder(nil, ServiceTokens{"svc-token": "search"}).Build()
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(metadataKey, "Bearer good"))
+	_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/test/Optional"},
+		func(ctx context.Context, req any) (any, error) {
+			return nil, nil
+		})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestClientInterceptor(t *testing.T) {
+	interceptor := NewClientInterceptorBuilder(func(ctx context.Context) string {
+		return "good"
+	}).ServiceToken("svc-token").Build()
+	var got, gotSvc []string
+	err := interceptor(context.Background(), "/test/Method", nil, nil, nil,
+		func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			md, _ := metadata.FromOutgoingContext(ctx)
+			got = md.Get(metadataKey)
+			gotSvc = md.Get(serviceMetadataKey)
+			return nil
+		})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Bearer good"}, got)
+	assert.Equal(t, []string{"svc-token"}, gotSvc)
+}
@@ -0,0 +1,147 @@
+package jwtx
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+	"os"
+	"time"
+)
+
+// KeyConfig 配置文件里面的一把 key。
+// HS256 配置 secret；RS256 和 EdDSA 配置 PEM 格式的 privateKey，只需要验证的服务配置 publicKey 就可以。
+// secret 和 privateKey 不能提交到仓库里面，一般用 secretEnv 和 privateKeyEnv 从环境变量读取
+type KeyConfig struct {
+	Kid string `yaml:"kid"`
+	// Alg HS256、RS256 或者 EdDSA，不配置就是 HS256
+	Alg        string `yaml:"alg"`
+	Secret     string `yaml:"secret"`
+	PrivateKey string `yaml:"privateKey"`
+	PublicKey  string `yaml:"publicKey"`
+	// SecretEnv 和 PrivateKeyEnv 配置了的时候从这个环境变量读取，环境变量为空会返回错误
+	SecretEnv     string `yaml:"secretEnv"`
+	PrivateKeyEnv string `yaml:"privateKeyEnv"`
+	// NotBefore RFC3339 格式，不配置就是一直有效
+	NotBefore string `yaml:"notBefore"`
+}
+
+func NewKeyringFromConfig(cfgs []KeyConfig, ttl time.Duration) (*Keyring, error) {
+	keys := make([]Key, 0, len(cfgs))
+	for _, cfg := range cfgs {
+		key, err := cfg.Key()
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return NewKeyring(keys, ttl)
+}
+
+func (cfg KeyConfig) Key() (Key, error) {
+	var err error
+	cfg.Secret, err = fromEnv(cfg.Kid, cfg.Secret, cfg.SecretEnv)
+	if err != nil {
+		return Key{}, err
+	}
+	cfg.PrivateKey, err = fromEnv(cfg.Kid, cfg.PrivateKey, cfg.PrivateKeyEnv)
+	if err != nil {
+		return Key{}, err
+	}
+	key := Key{Kid: cfg.Kid}
+	if cfg.NotBefore != "" {
+		nbf, err := time.Parse(time.RFC3339, cfg.NotBefore)
+		if err != nil {
+			return Key{}, fmt.Errorf("jwt key %s 的 notBefore 格式错误: %w", cfg.Kid, err)
+		}
+		key.NotBefore = nbf
+	}
+	switch cfg.Alg {
+	case "", jwt.SigningMethodHS256.Alg():
+		key.Method = jwt.SigningMethodHS256
+		key.SignKey = []byte(cfg.Secret)
+		key.VerifyKey = []byte(cfg.Secret)
+		return key, nil
+	case jwt.SigningMethodRS256.Alg():
+		key.Method = jwt.SigningMethodRS256
+	case jwt.SigningMethodEdDSA.Alg():
+		key.Method = jwt.SigningMethodEdDSA
+	default:
+		return Key{}, fmt.Errorf("jwt key %s 不支持的签名算法: %s", cfg.Kid, cfg.Alg)
+	}
+
+	if cfg.PrivateKey != "" {
+		var priv crypto.Signer
+		priv, err = parsePrivateKey([]byte(cfg.PrivateKey))
+		if err != nil {
+			return Key{}, fmt.Errorf("jwt key %s 的私钥格式错误: %w", cfg.Kid, err)
+		}
+		key.SignKey = priv
+		key.VerifyKey = priv.Public()
+	} else {
+		key.VerifyKey, err = parsePublicKey([]byte(cfg.PublicKey))
+		if err != nil {
+			return Key{}, fmt.Errorf("jwt key %s 的公钥格式错误: %w", cfg.Kid, err)
+		}
+	}
+	if !matchMethod(key.Method, key.VerifyKey) {
+		return Key{}, fmt.Errorf("jwt key %s 的类型和签名算法 %s 不匹配", cfg.Kid, cfg.Alg)
+	}
+	return key, nil
+}
+
+func fromEnv(kid string, val string, env string) (string, error) {
+	if env == "" {
+		return val, nil
+	}
+	val = os.Getenv(env)
+	if val == "" {
+		return "", fmt.Errorf("jwt key %s 没有配置，环境变量 %s 为空", kid, env)
+	}
+	return val, nil
+}
+
+func parsePrivateKey(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("不是 PEM 格式")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, errors.New("不支持的私钥类型")
+	}
+	return signer, nil
+}
+
+func parsePublicKey(data []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("不是 PEM 格式")
+	}
+	if key, err := x509.ParsePKCS1PublicKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	return x509.ParsePKIXPublicKey(block.Bytes)
+}
+
+func matchMethod(method jwt.SigningMethod, pub crypto.PublicKey) bool {
+	switch pub.(type) {
+	case *rsa.PublicKey:
+		return method == jwt.SigningMethodRS256
+	case ed25519.PublicKey:
+		return method == jwt.SigningMethodEdDSA
+	default:
+		return false
+	}
+}
@@ -0,0 +1,151 @@
+package jwtx
+
+import (
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+	"sort"
+	"time"
+)
+
+var (
+	ErrUnknownKid = errors.New("未知的 kid")
+	// ErrNoSigningKey 只配置了公钥的 keyring 只能用来验证
+	ErrNoSigningKey = errors.New("没有可以用来签名的 key")
+)
+
+// keyLeeway 新 key 生效时间前后允许的误差，避免实例之间时钟不一致的时候，
+// 先切换的实例签发的 token 在别的实例上验证不过
+const keyLeeway = time.Minute
+
+// Key 一把 key。HS256 的 SignKey 和 VerifyKey 都是 []byte；
+// RS256 和 EdDSA 的 SignKey 是私钥，VerifyKey 是公钥，公钥可以分发给别的服务
+type Key struct {
+	Kid    string
+	Method jwt.SigningMethod
+	// SignKey 只用来验证的时候可以为 nil
+	SignKey   any
+	VerifyKey any
+	// NotBefore 从这个时间开始用来签名，轮换的时候提前把新 key 配上去，到点自动切换
+	NotBefore time.Time
+}
+
+// Keyring 用已经生效的 key 里面最新的那一把签名，并且在 token 头里面带上 kid。
+// 被换下来的 key 在 ttl 内还可以验证，也就是换 key 之前签发的 token 在过期之前都有效
+type Keyring struct {
+	// keys 按照 NotBefore 排序
+	keys []Key
+	ttl  time.Duration
+	now  func() time.Time
+}
+
+// NewKeyring ttl 是用这个 keyring 签发的 token 的有效期
+func NewKeyring(keys []Key, ttl time.Duration) (*Keyring, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("没有配置 jwt key")
+	}
+	sorted := make([]Key, len(keys))
+	copy(sorted, keys)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].NotBefore.Before(sorted[j].NotBefore)
+	})
+	kids := make(map[string]struct{}, len(keys))
+	for _, key := range sorted {
+		if key.Kid == "" {
+			return nil, errors.New("jwt key 缺少 kid")
+		}
+		if _, ok := kids[key.Kid]; ok {
+			return nil, fmt.Errorf("jwt key 的 kid 重复: %s", key.Kid)
+		}
+		kids[key.Kid] = struct{}{}
+		if key.Method == nil || key.VerifyKey == nil {
+			return nil, fmt.Errorf("jwt key 缺少签名算法或者验证用的 key, kid: %s", key.Kid)
+		}
+		if secret, ok := key.VerifyKey.([]byte); ok && len(secret) < 32 {
+			return nil, fmt.Errorf("jwt key 太短, kid: %s", key.Kid)
+		}
+	}
+	k := &Keyring{
+		keys: sorted,
+		ttl:  ttl,
+		now:  time.Now,
+	}
+	if _, ok := k.current(); !ok {
+		return nil, errors.New("没有已经生效的 jwt key")
+	}
+	return k, nil
+}
+
+// Sign 用当前的 key 签名
+func (k *Keyring) Sign(claims jwt.Claims) (string, error) {
+	key, ok := k.current()
+	if !ok {
+		return "", errors.New("没有已经生效的 jwt key")
+	}
+	if key.SignKey == nil {
+		return "", ErrNoSigningKey
+	}
+	token := jwt.NewWithClaims(key.Method, claims)
+	token.Header["kid"] = key.Kid
+	return token.SignedString(key.SignKey)
+}
+
+// Keyfunc 给 jwt.ParseWithClaims 用，只接受和 kid 对应的 key 一样的签名算法
+func (k *Keyring) Keyfunc(token *jwt.Token) (interface{}, error) {
+	alg := token.Method.Alg()
+	keys := k.verifiable()
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		// 加上 kid 之前签发的 token，挨个试
+		set := jwt.VerificationKeySet{Keys: make([]jwt.VerificationKey, 0, len(keys))}
+		for _, key := range keys {
+			if key.Method.Alg() == alg {
+				set.Keys = append(set.Keys, key.VerifyKey)
+			}
+		}
+		if len(set.Keys) == 0 {
+			return nil, fmt.Errorf("不支持的签名算法: %s", alg)
+		}
+		return set, nil
+	}
+	for _, key := range keys {
+		if key.Kid != kid {
+			continue
+		}
+		if key.Method.Alg() != alg {
+			return nil, fmt.Errorf("签名算法和 key 不匹配: %s", alg)
+		}
+		return key.VerifyKey, nil
+	}
+	return nil, ErrUnknownKid
+}
+
+func (k *Keyring) current() (Key, bool) {
+	now := k.now()
+	for i := len(k.keys) - 1; i >= 0; i-- {
+		if !k.keys[i].NotBefore.After(now) {
+			return k.keys[i], true
+		}
+	}
+	return Key{}, false
+}
+
+// verifiable 还可以用来验证的 key：已经生效（允许 keyLeeway 的误差），
+// 并且没有被换下来，或者换下来还不到 ttl
+func (k *Keyring) verifiable() []Key {
+	now := k.now()
+	res := make([]Key, 0, len(k.keys))
+	for i, key := range k.keys {
+		if key.NotBefore.After(now.Add(keyLeeway)) {
+			break
+		}
+		if i+1 < len(k.keys) {
+			replacedAt := k.keys[i+1].NotBefore
+			if !replacedAt.After(now) && now.Sub(replacedAt) > k.ttl+keyLeeway {
+				continue
+			}
+		}
+		res = append(res, key)
+	}
+	return res
+}
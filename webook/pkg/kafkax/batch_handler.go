@@ -0,0 +1,133 @@
+package kafkax
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+	"io"
+	"time"
+)
+
+// BatchHandler 处理一批反序列化之后的消息，msgs 和 evts 一一对应。
+// 反序列化失败的消息不会出现在这里。返回 error 的时候整批重试
+type BatchHandler[T any] func(ctx context.Context, msgs []kafka.Message, evts []T) error
+
+// BatchHandlerConsumer 攒够 batchSize 条消息或者等满 window 之后批量处理，
+// 整批处理完之后才提交偏移量
+type BatchHandlerConsumer[T any] struct {
+	consumer
+	fn        BatchHandler[T]
+	batchSize int
+	window    time.Duration
+}
+
+func NewBatchHandlerConsumer[T any](reader *kafka.Reader, fn BatchHandler[T],
+	batchSize int, window time.Duration) *BatchHandlerConsumer[T] {
+	return &BatchHandlerConsumer[T]{
+		consumer:  newConsumer(reader),
+		fn:        fn,
+		batchSize: batchSize,
+		window:    window,
+	}
+}
+
+// DLQ 设置死信队列，消息会写到 DLQTopic(topic)。writer 由调用者负责关闭
+func (c *BatchHandlerConsumer[T]) DLQ(writer *kafka.Writer) *BatchHandlerConsumer[T] {
+	c.setDLQ(writer)
+	return c
+}
+
+// Retry 设置重试次数和退避间隔，maxRetries 小于 0 的时候一直重试
+func (c *BatchHandlerConsumer[T]) Retry(maxRetries int, initialBackoff, maxBackoff time.Duration) *BatchHandlerConsumer[T] {
+	c.maxRetries = maxRetries
+	c.initialBackoff = initialBackoff
+	c.maxBackoff = maxBackoff
+	return c
+}
+
+// Timeout 设置处理一批消息的超时时间
+func (c *BatchHandlerConsumer[T]) Timeout(timeout time.Duration) *BatchHandlerConsumer[T] {
+	c.timeout = timeout
+	return c
+}
+
+func (c *BatchHandlerConsumer[T]) Start() {
+	c.start(c.Run)
+}
+
+// Run 阻塞消费，直到 ctx 被取消。正在处理的一批消息会处理完再返回
+func (c *BatchHandlerConsumer[T]) Run(ctx context.Context) error {
+	for {
+		msgs, err := c.fetchBatch(ctx)
+		if len(msgs) > 0 && c.handle(ctx, msgs) {
+			c.commit(msgs...)
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+	}
+}
+
+// fetchBatch 读取一批消息，第一条消息到达之后开始计算 window
+func (c *BatchHandlerConsumer[T]) fetchBatch(ctx context.Context) ([]kafka.Message, error) {
+	msg, err := c.fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	msgs := make([]kafka.Message, 1, c.batchSize)
+	msgs[0] = msg
+	wctx, cancel := context.WithTimeout(ctx, c.window)
+	defer cancel()
+	for len(msgs) < c.batchSize {
+		msg, err = c.reader.FetchMessage(wctx)
+		if err == nil {
+			msgs = append(msgs, msg)
+			continue
+		}
+		if ctx.Err() != nil || errors.Is(err, io.EOF) {
+			return msgs, io.EOF
+		}
+		if !errors.Is(err, context.DeadlineExceeded) {
+			zap.L().Error("kafka 读取消息失败", zap.Error(err), zap.String("topic", c.topic))
+		}
+		break
+	}
+	return msgs, nil
+}
+
+// handle 返回是否需要提交偏移量
+func (c *BatchHandlerConsumer[T]) handle(ctx context.Context, msgs []kafka.Message) bool {
+	start := time.Now()
+	sctx, span := c.startSpan(ctx, "kafka.consume_batch "+c.topic, msgs[0])
+	valid := make([]kafka.Message, 0, len(msgs))
+	evts := make([]T, 0, len(msgs))
+	for _, msg := range msgs {
+		var evt T
+		err := json.Unmarshal(msg.Value, &evt)
+		if err != nil {
+			c.deadLetter(sctx, err, msg)
+			continue
+		}
+		valid = append(valid, msg)
+		evts = append(evts, evt)
+	}
+	if len(evts) == 0 {
+		c.observe(span, start, resultDecodeErr, nil)
+		return true
+	}
+	err := c.retry(sctx, func(ctx context.Context) error {
+		return c.fn(ctx, valid, evts)
+	})
+	if err == nil {
+		c.observe(span, start, resultOK, nil)
+		return true
+	}
+	if ctx.Err() != nil {
+		c.observe(span, start, resultAborted, err)
+		return false
+	}
+	c.observe(span, start, c.deadLetter(sctx, err, valid...), err)
+	return true
+}
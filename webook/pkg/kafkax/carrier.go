@@ -0,0 +1,40 @@
+package kafkax
+
+import (
+	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+var _ propagation.TextMapCarrier = (*headerCarrier)(nil)
+
+// headerCarrier 让 otel 可以从 kafka 消息头里面读写 trace 信息
+type headerCarrier struct {
+	headers *[]kafka.Header
+}
+
+func (h headerCarrier) Get(key string) string {
+	for _, header := range *h.headers {
+		if header.Key == key {
+			return string(header.Value)
+		}
+	}
+	return ""
+}
+
+func (h headerCarrier) Set(key string, value string) {
+	for i, header := range *h.headers {
+		if header.Key == key {
+			(*h.headers)[i].Value = []byte(value)
+			return
+		}
+	}
+	*h.headers = append(*h.headers, kafka.Header{Key: key, Value: []byte(value)})
+}
+
+func (h headerCarrier) Keys() []string {
+	keys := make([]string, 0, len(*h.headers))
+	for _, header := range *h.headers {
+		keys = append(keys, header.Key)
+	}
+	return keys
+}
@@ -0,0 +1,229 @@
+package kafkax
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"io"
+	"strconv"
+	"time"
+)
+
+// 死信消息带上的消息头，方便排查和人工重放
+const (
+	HeaderOriginTopic     = "x-origin-topic"
+	HeaderOriginPartition = "x-origin-partition"
+	HeaderOriginOffset    = "x-origin-offset"
+	HeaderError           = "x-error"
+)
+
+// DLQTopic 死信队列的 topic
+func DLQTopic(topic string) string {
+	return topic + "-dlq"
+}
+
+// messageReader 是 consumer 用到的 *kafka.Reader 的方法，测试的时候可以替换
+type messageReader interface {
+	FetchMessage(ctx context.Context) (kafka.Message, error)
+	CommitMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
+// messageWriter 是死信队列用到的 *kafka.Writer 的方法
+type messageWriter interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+}
+
+// consumer 是 HandlerConsumer 和 BatchHandlerConsumer 共用的部分：
+// 重试、死信队列、退出和监控
+type consumer struct {
+	reader messageReader
+	topic  string
+	group  string
+	// dlq 为 nil 的时候，重试耗尽的消息只记录日志然后跳过
+	dlq messageWriter
+	// maxRetries 小于 0 的时候一直重试，直到成功或者退出
+	maxRetries     int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	// 单次处理的超时时间
+	timeout time.Duration
+	tracer  trace.Tracer
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func newConsumer(reader *kafka.Reader) consumer {
+	initMetrics()
+	cfg := reader.Config()
+	return consumer{
+		reader:         reader,
+		topic:          cfg.Topic,
+		group:          cfg.GroupID,
+		maxRetries:     3,
+		initialBackoff: time.Millisecond * 100,
+		maxBackoff:     time.Second * 10,
+		timeout:        time.Second * 3,
+		tracer:         otel.GetTracerProvider().Tracer("webook/pkg/kafkax"),
+	}
+}
+
+// start 在后台执行 run，Close 的时候取消
+func (c *consumer) start(run func(ctx context.Context) error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+	c.done = make(chan struct{})
+	go func() {
+		defer close(c.done)
+		err := run(ctx)
+		if err != nil {
+			zap.L().Error("kafka 消费者退出", zap.Error(err),
+				zap.String("topic", c.topic), zap.String("group", c.group))
+		}
+	}()
+}
+
+// setDLQ writer 为 nil 的时候不设置，避免 dlq 变成一个装着 nil 指针的接口
+func (c *consumer) setDLQ(writer *kafka.Writer) {
+	if writer != nil {
+		c.dlq = writer
+	}
+}
+
+func (c *consumer) Close() error {
+	if c.cancel != nil {
+		c.cancel()
+		<-c.done
+	}
+	return c.reader.Close()
+}
+
+// fetch 读取下一条消息，返回 io.EOF 说明消费者已经退出
+func (c *consumer) fetch(ctx context.Context) (kafka.Message, error) {
+	for {
+		msg, err := c.reader.FetchMessage(ctx)
+		if err == nil {
+			return msg, nil
+		}
+		if ctx.Err() != nil || errors.Is(err, io.EOF) {
+			return kafka.Message{}, io.EOF
+		}
+		zap.L().Error("kafka 读取消息失败", zap.Error(err), zap.String("topic", c.topic))
+		if !c.sleep(ctx, c.initialBackoff) {
+			return kafka.Message{}, io.EOF
+		}
+	}
+}
+
+func (c *consumer) commit(msgs ...kafka.Message) {
+	// 这时候可能已经在退出了，所以不能用 run 的 ctx
+	err := c.reader.CommitMessages(context.Background(), msgs...)
+	if err != nil {
+		zap.L().Error("kafka 提交偏移量失败", zap.Error(err),
+			zap.String("topic", c.topic), zap.String("group", c.group))
+	}
+}
+
+// retry 执行 fn 直到成功、重试耗尽或者 ctx 被取消。
+// fn 拿到的 ctx 不会因为退出而取消，这样正在处理的消息可以处理完
+func (c *consumer) retry(ctx context.Context, fn func(ctx context.Context) error) error {
+	backoff := c.initialBackoff
+	for i := 0; ; i++ {
+		hctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), c.timeout)
+		err := fn(hctx)
+		cancel()
+		if err == nil {
+			return nil
+		}
+		if c.maxRetries >= 0 && i >= c.maxRetries {
+			return err
+		}
+		zap.L().Warn("kafka 处理消息失败，准备重试", zap.Error(err),
+			zap.String("topic", c.topic), zap.Int("retry", i+1), zap.Duration("backoff", backoff))
+		retryVector.WithLabelValues(c.topic, c.group).Inc()
+		if !c.sleep(ctx, backoff) {
+			return ctx.Err()
+		}
+		backoff = min(backoff*2, c.maxBackoff)
+	}
+}
+
+// sleep 返回 false 说明 ctx 已经被取消
+func (c *consumer) sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// deadLetter 把处理失败的消息转发到死信队列，返回最终的处理结果
+func (c *consumer) deadLetter(ctx context.Context, cause error, msgs ...kafka.Message) string {
+	if c.dlq == nil {
+		for _, msg := range msgs {
+			zap.L().Error("kafka 消息处理失败，丢弃消息", zap.Error(cause),
+				zap.String("topic", msg.Topic), zap.Int("partition", msg.Partition),
+				zap.Int64("offset", msg.Offset))
+		}
+		return resultDropped
+	}
+	dead := make([]kafka.Message, 0, len(msgs))
+	for _, msg := range msgs {
+		headers := make([]kafka.Header, 0, len(msg.Headers)+4)
+		headers = append(headers, msg.Headers...)
+		headers = append(headers,
+			kafka.Header{Key: HeaderOriginTopic, Value: []byte(msg.Topic)},
+			kafka.Header{Key: HeaderOriginPartition, Value: []byte(strconv.Itoa(msg.Partition))},
+			kafka.Header{Key: HeaderOriginOffset, Value: []byte(strconv.FormatInt(msg.Offset, 10))},
+			kafka.Header{Key: HeaderError, Value: []byte(cause.Error())},
+		)
+		dead = append(dead, kafka.Message{
+			Topic:   DLQTopic(c.topic),
+			Key:     msg.Key,
+			Value:   msg.Value,
+			Headers: headers,
+		})
+	}
+	err := c.retry(ctx, func(ctx context.Context) error {
+		return c.dlq.WriteMessages(ctx, dead...)
+	})
+	if err != nil {
+		zap.L().Error("kafka 写入死信队列失败，丢弃消息", zap.Error(err),
+			zap.NamedError("cause", cause), zap.String("topic", c.topic), zap.Int("size", len(msgs)))
+		return resultDropped
+	}
+	return resultDLQ
+}
+
+func (c *consumer) startSpan(ctx context.Context, name string, msg kafka.Message) (context.Context, trace.Span) {
+	ctx = otel.GetTextMapPropagator().Extract(ctx, headerCarrier{headers: &msg.Headers})
+	return c.tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "kafka"),
+			attribute.String("messaging.destination.name", c.topic),
+			attribute.String("messaging.consumer.group.name", c.group),
+			attribute.Int("messaging.kafka.partition", msg.Partition),
+			attribute.Int64("messaging.kafka.offset", msg.Offset),
+		))
+}
+
+func (c *consumer) observe(span trace.Span, start time.Time, result string, err error) {
+	handleVector.WithLabelValues(c.topic, c.group, result).
+		Observe(float64(time.Since(start).Milliseconds()))
+	span.SetAttributes(attribute.String("result", result))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, fmt.Sprintf("%s: %s", result, err.Error()))
+	}
+	span.End()
+}
@@ -0,0 +1,264 @@
+package kafkax
+
+import (
+	"context"
+	"errors"
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"sync"
+	"testing"
+	"time"
+)
+
+type testEvent struct {
+	Id int64 `json:"id"`
+}
+
+// fakeReader 按顺序返回 msgs 里面的消息，读完之后阻塞到 ctx 被取消
+type fakeReader struct {
+	msgs chan kafka.Message
+
+	mu        sync.Mutex
+	committed []int64
+}
+
+func newFakeReader(msgs ...kafka.Message) *fakeReader {
+	ch := make(chan kafka.Message, len(msgs))
+	for _, msg := range msgs {
+		ch <- msg
+	}
+	return &fakeReader{msgs: ch}
+}
+
+func (r *fakeReader) FetchMessage(ctx context.Context) (kafka.Message, error) {
+	select {
+	case msg := <-r.msgs:
+		return msg, nil
+	case <-ctx.Done():
+		return kafka.Message{}, ctx.Err()
+	}
+}
+
+func (r *fakeReader) CommitMessages(ctx context.Context, msgs ...kafka.Message) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, msg := range msgs {
+		r.committed = append(r.committed, msg.Offset)
+	}
+	return nil
+}
+
+func (r *fakeReader) Committed() []int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]int64(nil), r.committed...)
+}
+
+func (r *fakeReader) Close() error {
+	return nil
+}
+
+// fakeWriter 前 failures 次写入失败
+type fakeWriter struct {
+	failures int
+	msgs     []kafka.Message
+}
+
+func (w *fakeWriter) WriteMessages(ctx context.Context, msgs ...kafka.Message) error {
+	if w.failures > 0 {
+		w.failures--
+		return errors.New("写入失败")
+	}
+	w.msgs = append(w.msgs, msgs...)
+	return nil
+}
+
+func newTestConsumer(reader messageReader, dlq messageWriter) consumer {
+	initMetrics()
+	return consumer{
+		reader:         reader,
+		topic:          "test",
+		group:          "test",
+		dlq:            dlq,
+		maxRetries:     2,
+		initialBackoff: time.Millisecond,
+		maxBackoff:     time.Millisecond * 10,
+		timeout:        time.Second,
+		tracer:         otel.GetTracerProvider().Tracer("test"),
+	}
+}
+
+func testMessage(offset int64, value string) kafka.Message {
+	return kafka.Message{
+		Topic:     "test",
+		Partition: 1,
+		Offset:    offset,
+		Key:       []byte("key"),
+		Value:     []byte(value),
+	}
+}
+
+func TestHandlerConsumer_handle(t *testing.T) {
+	testCases := []struct {
+		name string
+		msg  kafka.Message
+		// 前 failures 次处理失败
+		failures int
+		dlq      *fakeWriter
+
+		wantCalls  int
+		wantCommit bool
+		wantDead   []kafka.Message
+	}{
+		{
+			name:       "重试之后成功",
+			msg:        testMessage(10, `{"id":1}`),
+			failures:   2,
+			dlq:        &fakeWriter{},
+			wantCalls:  3,
+			wantCommit: true,
+		},
+		{
+			name:       "重试耗尽进死信队列",
+			msg:        testMessage(10, `{"id":1}`),
+			failures:   3,
+			dlq:        &fakeWriter{},
+			wantCalls:  3,
+			wantCommit: true,
+			wantDead: []kafka.Message{
+				{
+					Topic: "test-dlq",
+					Key:   []byte("key"),
+					Value: []byte(`{"id":1}`),
+					Headers: []kafka.Header{
+						{Key: HeaderOriginTopic, Value: []byte("test")},
+						{Key: HeaderOriginPartition, Value: []byte("1")},
+						{Key: HeaderOriginOffset, Value: []byte("10")},
+						{Key: HeaderError, Value: []byte("处理失败")},
+					},
+				},
+			},
+		},
+		{
+			name:       "写死信队列失败也会重试",
+			msg:        testMessage(10, `{"id":1}`),
+			failures:   3,
+			dlq:        &fakeWriter{failures: 1},
+			wantCalls:  3,
+			wantCommit: true,
+			wantDead: []kafka.Message{
+				{
+					Topic: "test-dlq",
+					Key:   []byte("key"),
+					Value: []byte(`{"id":1}`),
+					Headers: []kafka.Header{
+						{Key: HeaderOriginTopic, Value: []byte("test")},
+						{Key: HeaderOriginPartition, Value: []byte("1")},
+						{Key: HeaderOriginOffset, Value: []byte("10")},
+						{Key: HeaderError, Value: []byte("处理失败")},
+					},
+				},
+			},
+		},
+		{
+			name:       "反序列化失败不重试",
+			msg:        testMessage(10, `{`),
+			dlq:        &fakeWriter{},
+			wantCalls:  0,
+			wantCommit: true,
+			wantDead: []kafka.Message{
+				{
+					Topic: "test-dlq",
+					Key:   []byte("key"),
+					Value: []byte(`{`),
+					Headers: []kafka.Header{
+						{Key: HeaderOriginTopic, Value: []byte("test")},
+						{Key: HeaderOriginPartition, Value: []byte("1")},
+						{Key: HeaderOriginOffset, Value: []byte("10")},
+						{Key: HeaderError, Value: []byte("unexpected end of JSON input")},
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			calls := 0
+			c := &HandlerConsumer[testEvent]{
+				consumer: newTestConsumer(newFakeReader(), tc.dlq),
+				fn: func(ctx context.Context, msg kafka.Message, evt testEvent) error {
+					calls++
+					assert.Equal(t, int64(1), evt.Id)
+					if calls <= tc.failures {
+						return errors.New("处理失败")
+					}
+					return nil
+				},
+			}
+			commit := c.handle(context.Background(), tc.msg)
+			assert.Equal(t, tc.wantCommit, commit)
+			assert.Equal(t, tc.wantCalls, calls)
+			assert.Equal(t, tc.wantDead, tc.dlq.msgs)
+		})
+	}
+}
+
+func TestHandlerConsumer_handle_Aborted(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	c := &HandlerConsumer[testEvent]{
+		consumer: newTestConsumer(newFakeReader(), &fakeWriter{}),
+		fn: func(ctx context.Context, msg kafka.Message, evt testEvent) error {
+			// 处理的时候收到了退出信号
+			cancel()
+			return errors.New("处理失败")
+		},
+	}
+	// 退出的时候还没有处理成功，不能提交，也不能进死信队列
+	commit := c.handle(ctx, testMessage(10, `{"id":1}`))
+	assert.False(t, commit)
+	assert.Empty(t, c.dlq.(*fakeWriter).msgs)
+}
+
+func TestBatchHandlerConsumer_Run(t *testing.T) {
+	reader := newFakeReader(
+		testMessage(1, `{"id":1}`),
+		testMessage(2, `{"id":2}`),
+		testMessage(3, `{"id":3}`),
+		testMessage(4, `{`),
+		testMessage(5, `{"id":5}`),
+	)
+	dlq := &fakeWriter{}
+	var batches [][]int64
+	c := &BatchHandlerConsumer[testEvent]{
+		consumer: newTestConsumer(reader, dlq),
+		fn: func(ctx context.Context, msgs []kafka.Message, evts []testEvent) error {
+			ids := make([]int64, 0, len(evts))
+			for _, evt := range evts {
+				ids = append(ids, evt.Id)
+			}
+			batches = append(batches, ids)
+			return nil
+		},
+		batchSize: 3,
+		window:    time.Millisecond * 50,
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- c.Run(ctx)
+	}()
+	// 前三条攒够了一批，后两条等满 window 之后处理
+	require.Eventually(t, func() bool {
+		return len(reader.Committed()) == 5
+	}, time.Second, time.Millisecond*10)
+	cancel()
+	require.NoError(t, <-done)
+
+	assert.Equal(t, [][]int64{{1, 2, 3}, {5}}, batches)
+	assert.Equal(t, []int64{1, 2, 3, 4, 5}, reader.Committed())
+	// 格式不对的消息单独进死信队列
+	require.Len(t, dlq.msgs, 1)
+	assert.Equal(t, []byte(`{`), dlq.msgs[0].Value)
+}
@@ -0,0 +1,11 @@
+package kafkax
+
+import "github.com/segmentio/kafka-go"
+
+// NewDLQWriter 死信队列的 writer，topic 由消息自己指定，所以多个消费者可以共用
+func NewDLQWriter(addrs []string) *kafka.Writer {
+	return &kafka.Writer{
+		Addr:     kafka.TCP(addrs...),
+		Balancer: &kafka.LeastBytes{},
+	}
+}
@@ -0,0 +1,92 @@
+package kafkax
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/segmentio/kafka-go"
+	"io"
+	"time"
+)
+
+// Handler 处理一条反序列化之后的消息。
+// 返回 error 的时候会重试，重试耗尽之后投递到死信队列，然后提交偏移量
+type Handler[T any] func(ctx context.Context, msg kafka.Message, evt T) error
+
+// HandlerConsumer 逐条消费消息，消息体是 JSON 格式的 T
+type HandlerConsumer[T any] struct {
+	consumer
+	fn Handler[T]
+}
+
+func NewHandlerConsumer[T any](reader *kafka.Reader, fn Handler[T]) *HandlerConsumer[T] {
+	return &HandlerConsumer[T]{
+		consumer: newConsumer(reader),
+		fn:       fn,
+	}
+}
+
+// DLQ 设置死信队列，消息会写到 DLQTopic(topic)。writer 由调用者负责关闭
+func (c *HandlerConsumer[T]) DLQ(writer *kafka.Writer) *HandlerConsumer[T] {
+	c.setDLQ(writer)
+	return c
+}
+
+// Retry 设置重试次数和退避间隔，maxRetries 小于 0 的时候一直重试
+func (c *HandlerConsumer[T]) Retry(maxRetries int, initialBackoff, maxBackoff time.Duration) *HandlerConsumer[T] {
+	c.maxRetries = maxRetries
+	c.initialBackoff = initialBackoff
+	c.maxBackoff = maxBackoff
+	return c
+}
+
+// Timeout 设置单次处理的超时时间
+func (c *HandlerConsumer[T]) Timeout(timeout time.Duration) *HandlerConsumer[T] {
+	c.timeout = timeout
+	return c
+}
+
+func (c *HandlerConsumer[T]) Start() {
+	c.start(c.Run)
+}
+
+// Run 阻塞消费，直到 ctx 被取消。正在处理的消息会处理完再返回
+func (c *HandlerConsumer[T]) Run(ctx context.Context) error {
+	for {
+		msg, err := c.fetch(ctx)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if c.handle(ctx, msg) {
+			c.commit(msg)
+		}
+	}
+}
+
+// handle 返回是否需要提交偏移量
+func (c *HandlerConsumer[T]) handle(ctx context.Context, msg kafka.Message) bool {
+	start := time.Now()
+	sctx, span := c.startSpan(ctx, "kafka.consume "+c.topic, msg)
+	var evt T
+	err := json.Unmarshal(msg.Value, &evt)
+	if err != nil {
+		// 格式不对的消息重试也没用，直接进死信队列
+		c.deadLetter(sctx, err, msg)
+		c.observe(span, start, resultDecodeErr, err)
+		return true
+	}
+	err = c.retry(sctx, func(ctx context.Context) error {
+		return c.fn(ctx, msg, evt)
+	})
+	if err == nil {
+		c.observe(span, start, resultOK, nil)
+		return true
+	}
+	if ctx.Err() != nil {
+		// 退出的时候还没处理成功，不提交，重启之后重新消费
+		c.observe(span, start, resultAborted, err)
+		return false
+	}
+	c.observe(span, start, c.deadLetter(sctx, err, msg), err)
+	return true
+}
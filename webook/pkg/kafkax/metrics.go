@@ -0,0 +1,50 @@
+package kafkax
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sync"
+)
+
+const (
+	resultOK = "ok"
+	// 重试耗尽之后投递到了死信队列
+	resultDLQ = "dlq"
+	// 重试耗尽，也没有配置死信队列，只能丢弃
+	resultDropped = "dropped"
+	// 反序列化失败，不会重试
+	resultDecodeErr = "decode_error"
+	// 还没有处理成功就退出了，不会提交偏移量
+	resultAborted = "aborted"
+)
+
+var (
+	metricsOnce sync.Once
+	// 处理消息的耗时，批量消费的时候是一批的耗时
+	handleVector *prometheus.SummaryVec
+	retryVector  *prometheus.CounterVec
+)
+
+// initMetrics 所有消费者共用同一组指标，用 topic 和 group 来区分
+func initMetrics() {
+	metricsOnce.Do(func() {
+		handleVector = prometheus.NewSummaryVec(prometheus.SummaryOpts{
+			Namespace: "study",
+			Subsystem: "webook_kafka",
+			Name:      "consume",
+			Help:      "统计 kafka 消息的处理情况",
+			Objectives: map[float64]float64{
+				0.5:  0.01,
+				0.75: 0.01,
+				0.9:  0.01,
+				0.99: 0.001,
+			},
+		}, []string{"topic", "group", "result"})
+		retryVector = prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "study",
+			Subsystem: "webook_kafka",
+			Name:      "consume_retry",
+			Help:      "统计 kafka 消息处理失败之后的重试次数",
+		}, []string{"topic", "group"})
+		prometheus.MustRegister(handleVector, retryVector)
+	})
+}
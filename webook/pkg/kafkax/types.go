@@ -2,4 +2,6 @@ package kafkax
 
 type Consumer interface {
 	Start()
+	// Close 停止消费，等待正在处理的消息处理完之后关闭连接
+	Close() error
 }
@@ -2,23 +2,24 @@ package fixer
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
+	"github.com/basic-go-project-webook/webook/pkg/kafkax"
 	"github.com/basic-go-project-webook/webook/pkg/migrator"
 	"github.com/basic-go-project-webook/webook/pkg/migrator/events"
 	"github.com/basic-go-project-webook/webook/pkg/migrator/fixer"
 	"github.com/segmentio/kafka-go"
-	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
 
 type Consumer[T migrator.Entity] struct {
-	reader   *kafka.Reader
+	*kafkax.HandlerConsumer[events.InconsistentEvent]
 	srcFirst *fixer.OverrideFixer[T]
 	dstFirst *fixer.OverrideFixer[T]
 }
 
-func NewConsumer[T migrator.Entity](addrs []string, topic string, src *gorm.DB, dst *gorm.DB) (*Consumer[T], error) {
+// NewConsumer dlq 可以为 nil，这时候修复失败的消息只记录日志
+func NewConsumer[T migrator.Entity](addrs []string, topic string, src *gorm.DB, dst *gorm.DB,
+	dlq *kafka.Writer) (*Consumer[T], error) {
 	srcFirst, err := fixer.NewOverrideFixer[T](src, dst)
 	if err != nil {
 		return nil, err
@@ -34,33 +35,15 @@ func NewConsumer[T migrator.Entity](addrs []string, topic string, src *gorm.DB,
 		MinBytes: 10e3,
 		MaxBytes: 10e6,
 	})
-	return &Consumer[T]{
-		reader:   reader,
+	c := &Consumer[T]{
 		srcFirst: srcFirst,
 		dstFirst: dstFirst,
-	}, nil
-}
-
-func (c *Consumer[T]) Start() {
-	go func() {
-		for {
-			msg, err := c.reader.ReadMessage(context.Background())
-			if err != nil {
-				zap.L().Error("kafka 读取消息失败", zap.Error(err))
-				continue
-			}
-			var evt events.InconsistentEvent
-			if err := json.Unmarshal(msg.Value, &evt); err != nil {
-				zap.L().Error("kafka 反序列化消息失败", zap.Error(err))
-				continue
-			}
-			err = c.Consume(context.Background(), evt)
-			if err != nil {
-				zap.L().Error("kafka 消费消息失败", zap.Error(err))
-				continue
-			}
-		}
-	}()
+	}
+	c.HandlerConsumer = kafkax.NewHandlerConsumer[events.InconsistentEvent](reader,
+		func(ctx context.Context, msg kafka.Message, evt events.InconsistentEvent) error {
+			return c.Consume(ctx, evt)
+		}).DLQ(dlq)
+	return c, nil
 }
 
 func (c *Consumer[T]) Consume(ctx context.Context, evt events.InconsistentEvent) error {
@@ -72,7 +55,3 @@ func (c *Consumer[T]) Consume(ctx context.Context, evt events.InconsistentEvent)
 	}
 	return errors.New("未知的方向")
 }
-
-func (c *Consumer[T]) Close() error {
-	return c.reader.Close()
-}
@@ -0,0 +1,41 @@
+package domain
+
+import "time"
+
+type Article struct {
+	Id       int64
+	Title    string
+	Content  string
+	AuthorId int64
+	Utime    time.Time
+}
+
+type User struct {
+	Id       int64
+	Nickname string
+}
+
+type ArticleHit struct {
+	Article Article
+	Score   float64
+	// 高亮的片段
+	TitleHighlights   []string
+	ContentHighlights []string
+}
+
+type UserHit struct {
+	User               User
+	Score              float64
+	NicknameHighlights []string
+}
+
+type ArticleSearchResult struct {
+	// 命中的总数
+	Total int64
+	Hits  []ArticleHit
+}
+
+type UserSearchResult struct {
+	Total int64
+	Hits  []UserHit
+}
@@ -0,0 +1,131 @@
+package events
+
+import (
+	"context"
+	"github.com/basic-go-project-webook/webook/pkg/kafkax"
+	"github.com/basic-go-project-webook/webook/search/domain"
+	"github.com/basic-go-project-webook/webook/search/service"
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+	"time"
+)
+
+const (
+	topicPublishedArticle = "published-article"
+	topicUpdatedArticle   = "updated-article"
+	topicWithdrawnArticle = "withdrawn-article"
+	// articleEventVersion 能够处理的最高的文章事件版本
+	articleEventVersion = 1
+)
+
+// ArticleEvent 和 webook 的 article.ArticlePublished、article.ArticleUpdated 保持一致
+type ArticleEvent struct {
+	Version int
+	Aid     int64
+	Uid     int64
+	Title   string
+	Content string
+	Utime   int64
+}
+
+// ArticleWithdrawnEvent 和 webook 的 article.ArticleWithdrawn 保持一致
+type ArticleWithdrawnEvent struct {
+	Version int
+	Aid     int64
+	Uid     int64
+	Utime   int64
+}
+
+// ArticleEventConsumer 文章发表或者修改之后写入索引，
+// 发表和修改的事件结构一样，所以同一个消费者按照 topic 各起一个
+type ArticleEventConsumer struct {
+	*kafkax.HandlerConsumer[ArticleEvent]
+	svc service.SyncService
+}
+
+// ArticlePublishedEventConsumer 和 ArticleUpdatedEventConsumer 只是为了区分类型，方便 wire 注入
+type ArticlePublishedEventConsumer struct {
+	*ArticleEventConsumer
+}
+
+type ArticleUpdatedEventConsumer struct {
+	*ArticleEventConsumer
+}
+
+func NewArticlePublishedEventConsumer(addrs []string, group string, svc service.SyncService, dlq *kafka.Writer) *ArticlePublishedEventConsumer {
+	return &ArticlePublishedEventConsumer{
+		ArticleEventConsumer: newArticleEventConsumer(addrs, group, topicPublishedArticle, svc, dlq),
+	}
+}
+
+func NewArticleUpdatedEventConsumer(addrs []string, group string, svc service.SyncService, dlq *kafka.Writer) *ArticleUpdatedEventConsumer {
+	return &ArticleUpdatedEventConsumer{
+		ArticleEventConsumer: newArticleEventConsumer(addrs, group, topicUpdatedArticle, svc, dlq),
+	}
+}
+
+func newArticleEventConsumer(addrs []string, group string, topic string, svc service.SyncService, dlq *kafka.Writer) *ArticleEventConsumer {
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:  addrs,
+		GroupID:  group,
+		Topic:    topic,
+		MinBytes: 10e3,
+		MaxBytes: 10e6,
+	})
+	c := &ArticleEventConsumer{
+		svc: svc,
+	}
+	c.HandlerConsumer = kafkax.NewHandlerConsumer[ArticleEvent](reader,
+		func(ctx context.Context, msg kafka.Message, evt ArticleEvent) error {
+			return c.Consume(ctx, evt)
+		}).DLQ(dlq)
+	return c
+}
+
+func (c *ArticleEventConsumer) Consume(ctx context.Context, evt ArticleEvent) error {
+	if evt.Version > articleEventVersion {
+		zap.L().Warn("不认识的文章事件版本，跳过", zap.Int("version", evt.Version), zap.Int64("aid", evt.Aid))
+		return nil
+	}
+	// 以文章 id 作为文档 id，重复消费只会覆盖。
+	// 发表、修改、撤回在不同的 topic，互相之间没有顺序，比索引里面旧的事件会被丢弃
+	return c.svc.InputArticle(ctx, domain.Article{
+		Id:       evt.Aid,
+		Title:    evt.Title,
+		Content:  evt.Content,
+		AuthorId: evt.Uid,
+		Utime:    time.UnixMilli(evt.Utime),
+	})
+}
+
+// ArticleWithdrawnEventConsumer 文章撤回之后从索引里面删除
+type ArticleWithdrawnEventConsumer struct {
+	*kafkax.HandlerConsumer[ArticleWithdrawnEvent]
+	svc service.SyncService
+}
+
+func NewArticleWithdrawnEventConsumer(addrs []string, group string, svc service.SyncService, dlq *kafka.Writer) *ArticleWithdrawnEventConsumer {
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:  addrs,
+		GroupID:  group,
+		Topic:    topicWithdrawnArticle,
+		MinBytes: 10e3,
+		MaxBytes: 10e6,
+	})
+	c := &ArticleWithdrawnEventConsumer{
+		svc: svc,
+	}
+	c.HandlerConsumer = kafkax.NewHandlerConsumer[ArticleWithdrawnEvent](reader,
+		func(ctx context.Context, msg kafka.Message, evt ArticleWithdrawnEvent) error {
+			return c.Consume(ctx, evt)
+		}).DLQ(dlq)
+	return c
+}
+
+func (c *ArticleWithdrawnEventConsumer) Consume(ctx context.Context, evt ArticleWithdrawnEvent) error {
+	if evt.Version > articleEventVersion {
+		zap.L().Warn("不认识的文章事件版本，跳过", zap.Int("version", evt.Version), zap.Int64("aid", evt.Aid))
+		return nil
+	}
+	return c.svc.DeleteArticle(ctx, evt.Aid, time.UnixMilli(evt.Utime))
+}
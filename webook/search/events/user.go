@@ -0,0 +1,58 @@
+package events
+
+import (
+	"context"
+	"github.com/basic-go-project-webook/webook/pkg/kafkax"
+	"github.com/basic-go-project-webook/webook/search/domain"
+	"github.com/basic-go-project-webook/webook/search/service"
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+)
+
+const (
+	topicUpdatedUser = "updated-user"
+	// userEventVersion 能够处理的最高的用户事件版本
+	userEventVersion = 1
+)
+
+// UserUpdatedEvent 和 webook 的 user.UserUpdated 保持一致
+type UserUpdatedEvent struct {
+	Version  int
+	Uid      int64
+	Nickname string
+}
+
+// UserUpdatedEventConsumer 用户修改资料之后更新索引
+type UserUpdatedEventConsumer struct {
+	*kafkax.HandlerConsumer[UserUpdatedEvent]
+	svc service.SyncService
+}
+
+func NewUserUpdatedEventConsumer(addrs []string, group string, svc service.SyncService, dlq *kafka.Writer) *UserUpdatedEventConsumer {
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:  addrs,
+		GroupID:  group,
+		Topic:    topicUpdatedUser,
+		MinBytes: 10e3,
+		MaxBytes: 10e6,
+	})
+	c := &UserUpdatedEventConsumer{
+		svc: svc,
+	}
+	c.HandlerConsumer = kafkax.NewHandlerConsumer[UserUpdatedEvent](reader,
+		func(ctx context.Context, msg kafka.Message, evt UserUpdatedEvent) error {
+			return c.Consume(ctx, evt)
+		}).DLQ(dlq)
+	return c
+}
+
+func (c *UserUpdatedEventConsumer) Consume(ctx context.Context, evt UserUpdatedEvent) error {
+	if evt.Version > userEventVersion {
+		zap.L().Warn("不认识的用户事件版本，跳过", zap.Int("version", evt.Version), zap.Int64("uid", evt.Uid))
+		return nil
+	}
+	return c.svc.InputUser(ctx, domain.User{
+		Id:       evt.Uid,
+		Nickname: evt.Nickname,
+	})
+}
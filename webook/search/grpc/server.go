@@ -0,0 +1,136 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	searchv1 "github.com/basic-go-project-webook/webook/api/proto/gen/search/v1"
+	"github.com/basic-go-project-webook/webook/search/domain"
+	"github.com/basic-go-project-webook/webook/search/service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"time"
+)
+
+// maxLimit 一页最多返回的结果数
+const maxLimit = 100
+
+type SearchServiceServer struct {
+	searchv1.UnimplementedSearchServiceServer
+	svc     service.SearchService
+	syncSvc service.SyncService
+}
+
+func NewSearchServiceServer(svc service.SearchService, syncSvc service.SyncService) *SearchServiceServer {
+	return &SearchServiceServer{
+		svc:     svc,
+		syncSvc: syncSvc,
+	}
+}
+
+func (s *SearchServiceServer) Register(server *grpc.Server) {
+	searchv1.RegisterSearchServiceServer(server, s)
+}
+
+func (s *SearchServiceServer) SearchArticles(ctx context.Context, request *searchv1.SearchArticlesRequest) (*searchv1.SearchArticlesResponse, error) {
+	if err := checkPage(request.GetOffset(), request.GetLimit()); err != nil {
+		return nil, err
+	}
+	res, err := s.svc.SearchArticles(ctx, request.GetKeyword(), request.GetAuthorId(),
+		int(request.GetOffset()), int(request.GetLimit()))
+	if err != nil {
+		return nil, toStatusErr(err)
+	}
+	hits := make([]*searchv1.ArticleHit, 0, len(res.Hits))
+	for _, h := range res.Hits {
+		hits = append(hits, &searchv1.ArticleHit{
+			Article:           toArticleDTO(h.Article),
+			Score:             h.Score,
+			TitleHighlights:   h.TitleHighlights,
+			ContentHighlights: h.ContentHighlights,
+		})
+	}
+	return &searchv1.SearchArticlesResponse{
+		Total: res.Total,
+		Hits:  hits,
+	}, nil
+}
+
+func (s *SearchServiceServer) SearchUsers(ctx context.Context, request *searchv1.SearchUsersRequest) (*searchv1.SearchUsersResponse, error) {
+	if err := checkPage(request.GetOffset(), request.GetLimit()); err != nil {
+		return nil, err
+	}
+	res, err := s.svc.SearchUsers(ctx, request.GetKeyword(), int(request.GetOffset()), int(request.GetLimit()))
+	if err != nil {
+		return nil, toStatusErr(err)
+	}
+	hits := make([]*searchv1.UserHit, 0, len(res.Hits))
+	for _, h := range res.Hits {
+		hits = append(hits, &searchv1.UserHit{
+			User: &searchv1.User{
+				Id:       h.User.Id,
+				Nickname: h.User.Nickname,
+			},
+			Score:              h.Score,
+			NicknameHighlights: h.NicknameHighlights,
+		})
+	}
+	return &searchv1.SearchUsersResponse{
+		Total: res.Total,
+		Hits:  hits,
+	}, nil
+}
+
+func (s *SearchServiceServer) InputArticle(ctx context.Context, request *searchv1.InputArticleRequest) (*searchv1.InputArticleResponse, error) {
+	art := request.GetArticle()
+	if art.GetId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "文章 id 不合法")
+	}
+	err := s.syncSvc.InputArticle(ctx, domain.Article{
+		Id:       art.GetId(),
+		Title:    art.GetTitle(),
+		Content:  art.GetContent(),
+		AuthorId: art.GetAuthorId(),
+		Utime:    time.UnixMilli(art.GetUtime()),
+	})
+	return &searchv1.InputArticleResponse{}, err
+}
+
+func (s *SearchServiceServer) InputUser(ctx context.Context, request *searchv1.InputUserRequest) (*searchv1.InputUserResponse, error) {
+	u := request.GetUser()
+	if u.GetId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "用户 id 不合法")
+	}
+	err := s.syncSvc.InputUser(ctx, domain.User{
+		Id:       u.GetId(),
+		Nickname: u.GetNickname(),
+	})
+	return &searchv1.InputUserResponse{}, err
+}
+
+func checkPage(offset, limit int32) error {
+	if offset < 0 {
+		return status.Error(codes.InvalidArgument, "offset 不能小于 0")
+	}
+	if limit <= 0 || limit > maxLimit {
+		return status.Errorf(codes.InvalidArgument, "limit 必须在 1 到 %d 之间", maxLimit)
+	}
+	return nil
+}
+
+func toStatusErr(err error) error {
+	if errors.Is(err, service.ErrEmptyKeyword) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return err
+}
+
+func toArticleDTO(art domain.Article) *searchv1.Article {
+	return &searchv1.Article{
+		Id:       art.Id,
+		Title:    art.Title,
+		Content:  art.Content,
+		AuthorId: art.AuthorId,
+		Utime:    art.Utime.UnixMilli(),
+	}
+}
@@ -0,0 +1,20 @@
+package ioc
+
+import (
+	searchv1 "github.com/basic-go-project-webook/webook/api/proto/gen/search/v1"
+	"github.com/basic-go-project-webook/webook/pkg/grpcx/interceptors/auth"
+	"google.golang.org/grpc"
+)
+
+// InitAuthInterceptor 只接受内部服务调用，搜索本身没有代表用户的操作，所以不配置 jwt key。
+// 写入索引只会写到被调用的那个实例上，只用来修复单个实例，全量导入走事件
+func InitAuthInterceptor() grpc.UnaryServerInterceptor {
+	builder, err := auth.NewBuilderFromConfig("auth")
+	if err != nil {
+		panic(err)
+	}
+	return builder.RequireService(
+		searchv1.SearchService_InputArticle_FullMethodName,
+		searchv1.SearchService_InputUser_FullMethodName,
+	).Build()
+}
@@ -0,0 +1,31 @@
+package ioc
+
+import (
+	"github.com/basic-go-project-webook/webook/pkg/grpcx"
+	grpc2 "github.com/basic-go-project-webook/webook/search/grpc"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+)
+
+func InitGRPCXServer(searchSvc *grpc2.SearchServiceServer) *grpcx.Server {
+	type Config struct {
+		EtcdAddr string `yaml:"etcdAddr"`
+		Port     int    `yaml:"port"`
+		Name     string `yaml:"name"`
+	}
+	var cfg Config
+	err := viper.UnmarshalKey("grpc", &cfg)
+	if err != nil {
+		panic(err)
+	}
+
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(InitAuthInterceptor()))
+	searchSvc.Register(server)
+
+	return &grpcx.Server{
+		Server:   server,
+		Port:     cfg.Port,
+		Name:     cfg.Name,
+		EtcdAddr: cfg.EtcdAddr,
+	}
+}
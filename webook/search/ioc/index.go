@@ -0,0 +1,38 @@
+package ioc
+
+import (
+	"github.com/basic-go-project-webook/webook/search/repository/dao"
+	"github.com/spf13/viper"
+	"path/filepath"
+)
+
+func InitArticleIndex() dao.ArticleIndex {
+	idx, err := dao.OpenIndex(filepath.Join(indexDir(), "article"), dao.NewArticleMapping())
+	if err != nil {
+		panic(err)
+	}
+	return idx
+}
+
+func InitUserIndex() dao.UserIndex {
+	idx, err := dao.OpenIndex(filepath.Join(indexDir(), "user"), dao.NewUserMapping())
+	if err != nil {
+		panic(err)
+	}
+	return idx
+}
+
+// indexDir 索引文件所在的目录，文章和用户各占一个子目录
+func indexDir() string {
+	type Config struct {
+		Dir string `yaml:"dir"`
+	}
+	cfg := Config{
+		Dir: "./data/search",
+	}
+	err := viper.UnmarshalKey("index", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	return cfg.Dir
+}
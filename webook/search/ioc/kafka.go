@@ -0,0 +1,74 @@
+package ioc
+
+import (
+	"github.com/basic-go-project-webook/webook/pkg/kafkax"
+	"github.com/basic-go-project-webook/webook/search/events"
+	"github.com/basic-go-project-webook/webook/search/service"
+	"github.com/segmentio/kafka-go"
+	"github.com/spf13/viper"
+	"os"
+)
+
+func InitArticlePublishedEventConsumer(svc service.SyncService, dlq *kafka.Writer) *events.ArticlePublishedEventConsumer {
+	return events.NewArticlePublishedEventConsumer(kafkaAddrs(), consumerGroup(), svc, dlq)
+}
+
+func InitArticleUpdatedEventConsumer(svc service.SyncService, dlq *kafka.Writer) *events.ArticleUpdatedEventConsumer {
+	return events.NewArticleUpdatedEventConsumer(kafkaAddrs(), consumerGroup(), svc, dlq)
+}
+
+func InitArticleWithdrawnEventConsumer(svc service.SyncService, dlq *kafka.Writer) *events.ArticleWithdrawnEventConsumer {
+	return events.NewArticleWithdrawnEventConsumer(kafkaAddrs(), consumerGroup(), svc, dlq)
+}
+
+func InitUserUpdatedEventConsumer(svc service.SyncService, dlq *kafka.Writer) *events.UserUpdatedEventConsumer {
+	return events.NewUserUpdatedEventConsumer(kafkaAddrs(), consumerGroup(), svc, dlq)
+}
+
+// InitDLQWriter 所有消费者共用的死信队列 writer
+func InitDLQWriter() *kafka.Writer {
+	return kafkax.NewDLQWriter(kafkaAddrs())
+}
+
+func InitConsumers(published *events.ArticlePublishedEventConsumer,
+	updated *events.ArticleUpdatedEventConsumer,
+	withdrawn *events.ArticleWithdrawnEventConsumer,
+	user *events.UserUpdatedEventConsumer) []kafkax.Consumer {
+	return []kafkax.Consumer{published, updated, withdrawn, user}
+}
+
+// consumerGroup 每个实例都有自己的索引，要用自己的消费者组才能收到全部的消息，
+// 共用一个消费者组的话分区分给了谁，对应的文档就只会出现在谁的索引里面。
+// 消费进度和索引文件是配套的，instance 要和 index.dir 一起保持不变，没有配置的时候用主机名
+func consumerGroup() string {
+	type Config struct {
+		Group    string `yaml:"group"`
+		Instance string `yaml:"instance"`
+	}
+	cfg := Config{
+		Group: "search",
+	}
+	err := viper.UnmarshalKey("kafka", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	if cfg.Instance == "" {
+		cfg.Instance, err = os.Hostname()
+		if err != nil {
+			panic(err)
+		}
+	}
+	return cfg.Group + "-" + cfg.Instance
+}
+
+func kafkaAddrs() []string {
+	type Config struct {
+		Addr []string `yaml:"addr"`
+	}
+	var cfg Config
+	err := viper.UnmarshalKey("kafka", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	return cfg.Addr
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+	"net/http"
+	"os/signal"
+	"syscall"
+)
+
+func main() {
+	initViper()
+	initZap()
+	initPrometheus()
+	app := InitApp()
+	for _, c := range app.consumers {
+		c.Start()
+	}
+	go func() {
+		ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+		defer stop()
+		<-ctx.Done()
+		// 先停掉消费者，等正在处理的消息处理完
+		for _, c := range app.consumers {
+			err := c.Close()
+			if err != nil {
+				zap.L().Error("关闭消费者失败", zap.Error(err))
+			}
+		}
+		_ = app.server.Close()
+	}()
+	err := app.server.Serve()
+	if err != nil {
+		panic(err)
+	}
+}
+
+func initViper() {
+	viper.SetConfigName("dev")
+	viper.SetConfigType("yaml")
+	viper.AddConfigPath("./config")
+	err := viper.ReadInConfig()
+	if err != nil {
+		panic(fmt.Errorf("viper 启动失败: %s \n", err))
+	}
+}
+
+func initPrometheus() {
+	go func() {
+		http.Handle("/metrics", promhttp.Handler())
+		err := http.ListenAndServe(":8085", nil)
+		if err != nil {
+			panic(err)
+		}
+	}()
+}
+
+func initZap() {
+	logger, err := zap.NewDevelopment()
+	if err != nil {
+		panic(err)
+	}
+	zap.ReplaceGlobals(logger)
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"github.com/basic-go-project-webook/webook/search/domain"
+	"github.com/basic-go-project-webook/webook/search/repository/dao"
+	"strconv"
+	"time"
+)
+
+type ArticleRepository interface {
+	InputArticle(ctx context.Context, art domain.Article) error
+	DeleteArticle(ctx context.Context, id int64, utime time.Time) error
+	SearchArticles(ctx context.Context, keyword string, authorId int64, offset int, limit int) (domain.ArticleSearchResult, error)
+}
+
+type articleRepository struct {
+	dao dao.ArticleDAO
+}
+
+func NewArticleRepository(dao dao.ArticleDAO) ArticleRepository {
+	return &articleRepository{
+		dao: dao,
+	}
+}
+
+func (a *articleRepository) InputArticle(ctx context.Context, art domain.Article) error {
+	return a.dao.InputArticle(ctx, dao.Article{
+		Id:       art.Id,
+		Title:    art.Title,
+		Content:  art.Content,
+		AuthorId: strconv.FormatInt(art.AuthorId, 10),
+		Utime:    art.Utime.UnixMilli(),
+	})
+}
+
+func (a *articleRepository) DeleteArticle(ctx context.Context, id int64, utime time.Time) error {
+	return a.dao.DeleteArticle(ctx, id, utime.UnixMilli())
+}
+
+func (a *articleRepository) SearchArticles(ctx context.Context, keyword string, authorId int64, offset int, limit int) (domain.ArticleSearchResult, error) {
+	res, err := a.dao.Search(ctx, keyword, authorId, offset, limit)
+	if err != nil {
+		return domain.ArticleSearchResult{}, err
+	}
+	hits := make([]domain.ArticleHit, 0, len(res.Hits))
+	for _, h := range res.Hits {
+		authorId, _ := strconv.ParseInt(h.Doc.AuthorId, 10, 64)
+		hits = append(hits, domain.ArticleHit{
+			Article: domain.Article{
+				Id:       h.Doc.Id,
+				Title:    h.Doc.Title,
+				Content:  h.Doc.Content,
+				AuthorId: authorId,
+				Utime:    time.UnixMilli(h.Doc.Utime),
+			},
+			Score:             h.Score,
+			TitleHighlights:   h.Fragments["title"],
+			ContentHighlights: h.Fragments["content"],
+		})
+	}
+	return domain.ArticleSearchResult{
+		Total: int64(res.Total),
+		Hits:  hits,
+	}, nil
+}
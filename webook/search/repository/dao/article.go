@@ -0,0 +1,117 @@
+package dao
+
+import (
+	"context"
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/search"
+	"github.com/blevesearch/bleve/v2/search/highlight/format/html"
+	"github.com/blevesearch/bleve/v2/search/query"
+	"strconv"
+	"sync"
+)
+
+type BleveArticleDAO struct {
+	idx bleve.Index
+	// 保护先读 utime 再写的过程，发表、修改、撤回三个消费者是并发的
+	mu sync.Mutex
+}
+
+func NewBleveArticleDAO(idx ArticleIndex) ArticleDAO {
+	return &BleveArticleDAO{
+		idx: idx,
+	}
+}
+
+func (dao *BleveArticleDAO) InputArticle(ctx context.Context, art Article) error {
+	return dao.write(ctx, art)
+}
+
+// DeleteArticle 不直接删除文档，而是写入一个只有 id 和 utime 的墓碑，
+// 这样撤回之前的发表事件晚到的时候，也不会把文章重新写回索引。
+// 墓碑没有标题和内容，关键字搜索不会命中
+func (dao *BleveArticleDAO) DeleteArticle(ctx context.Context, id int64, utime int64) error {
+	return dao.write(ctx, Article{
+		Id:    id,
+		Utime: utime,
+	})
+}
+
+// write 以 utime 为准，后写的赢。索引里面已经有更新的版本的时候，直接丢弃 art
+func (dao *BleveArticleDAO) write(ctx context.Context, art Article) error {
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+	docId := strconv.FormatInt(art.Id, 10)
+	utime, ok, err := dao.storedUtime(ctx, docId)
+	if err != nil {
+		return err
+	}
+	if ok && utime > art.Utime {
+		return nil
+	}
+	return dao.idx.Index(docId, art)
+}
+
+func (dao *BleveArticleDAO) storedUtime(ctx context.Context, docId string) (int64, bool, error) {
+	req := bleve.NewSearchRequestOptions(bleve.NewDocIDQuery([]string{docId}), 1, 0, false)
+	req.Fields = []string{"utime"}
+	res, err := dao.idx.SearchInContext(ctx, req)
+	if err != nil {
+		return 0, false, err
+	}
+	if len(res.Hits) == 0 {
+		return 0, false, nil
+	}
+	return int64(numberField(res.Hits[0], "utime")), true, nil
+}
+
+func (dao *BleveArticleDAO) Search(ctx context.Context, keyword string, authorId int64, offset int, limit int) (SearchResult[Article], error) {
+	title := bleve.NewMatchQuery(keyword)
+	title.SetField("title")
+	// 标题命中的权重更高
+	title.SetBoost(2)
+	content := bleve.NewMatchQuery(keyword)
+	content.SetField("content")
+	var q query.Query = bleve.NewDisjunctionQuery(title, content)
+	if authorId > 0 {
+		author := bleve.NewTermQuery(strconv.FormatInt(authorId, 10))
+		author.SetField("author_id")
+		q = bleve.NewConjunctionQuery(q, author)
+	}
+	req := bleve.NewSearchRequestOptions(q, limit, offset, false)
+	req.Fields = []string{"id", "title", "content", "author_id", "utime"}
+	req.Highlight = bleve.NewHighlightWithStyle(html.Name)
+	req.Highlight.AddField("title")
+	req.Highlight.AddField("content")
+	res, err := dao.idx.SearchInContext(ctx, req)
+	if err != nil {
+		return SearchResult[Article]{}, err
+	}
+	hits := make([]Hit[Article], 0, len(res.Hits))
+	for _, h := range res.Hits {
+		hits = append(hits, Hit[Article]{
+			Doc: Article{
+				Id:       int64(numberField(h, "id")),
+				Title:    stringField(h, "title"),
+				Content:  stringField(h, "content"),
+				AuthorId: stringField(h, "author_id"),
+				Utime:    int64(numberField(h, "utime")),
+			},
+			Score:     h.Score,
+			Fragments: h.Fragments,
+		})
+	}
+	return SearchResult[Article]{
+		Total: res.Total,
+		Hits:  hits,
+	}, nil
+}
+
+func stringField(h *search.DocumentMatch, field string) string {
+	val, _ := h.Fields[field].(string)
+	return val
+}
+
+func numberField(h *search.DocumentMatch, field string) float64 {
+	val, _ := h.Fields[field].(float64)
+	return val
+}
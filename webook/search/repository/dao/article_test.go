@@ -0,0 +1,73 @@
+package dao
+
+import (
+	"context"
+	"github.com/blevesearch/bleve/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestBleveArticleDAO_OutOfOrder(t *testing.T) {
+	testCases := []struct {
+		name string
+		// 按照到达的顺序执行
+		events []func(d *BleveArticleDAO) error
+		// 最后能不能搜到
+		wantHit bool
+	}{
+		{
+			name: "撤回之后重新发表",
+			events: []func(d *BleveArticleDAO) error{
+				publish(1, 100), withdraw(1, 200), publish(1, 300),
+			},
+			wantHit: true,
+		},
+		{
+			name: "重新发表之后才收到撤回",
+			events: []func(d *BleveArticleDAO) error{
+				publish(1, 100), publish(1, 300), withdraw(1, 200),
+			},
+			wantHit: true,
+		},
+		{
+			name: "撤回之后才收到发表",
+			events: []func(d *BleveArticleDAO) error{
+				withdraw(1, 200), publish(1, 100),
+			},
+			wantHit: false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			idx, err := bleve.NewMemOnly(NewArticleMapping())
+			require.NoError(t, err)
+			defer idx.Close()
+			d := NewBleveArticleDAO(idx).(*BleveArticleDAO)
+			for _, evt := range tc.events {
+				require.NoError(t, evt(d))
+			}
+			res, err := d.Search(context.Background(), "golang", 0, 0, 10)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantHit, res.Total == 1)
+		})
+	}
+}
+
+func publish(id int64, utime int64) func(d *BleveArticleDAO) error {
+	return func(d *BleveArticleDAO) error {
+		return d.InputArticle(context.Background(), Article{
+			Id:       id,
+			Title:    "golang 入门",
+			Content:  "内容",
+			AuthorId: "2",
+			Utime:    utime,
+		})
+	}
+}
+
+func withdraw(id int64, utime int64) func(d *BleveArticleDAO) error {
+	return func(d *BleveArticleDAO) error {
+		return d.DeleteArticle(context.Background(), id, utime)
+	}
+}
@@ -0,0 +1,56 @@
+package dao
+
+import (
+	"errors"
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/analysis/lang/cjk"
+	"github.com/blevesearch/bleve/v2/mapping"
+)
+
+// OpenIndex 打开 path 下的索引，不存在的时候用 m 创建一个新的
+func OpenIndex(path string, m mapping.IndexMapping) (bleve.Index, error) {
+	idx, err := bleve.Open(path)
+	if errors.Is(err, bleve.ErrorIndexPathDoesNotExist) {
+		return bleve.New(path, m)
+	}
+	return idx, err
+}
+
+// NewArticleMapping 标题和内容用 cjk 分词，中英文混合的时候也能搜到
+func NewArticleMapping() mapping.IndexMapping {
+	doc := bleve.NewDocumentStaticMapping()
+	doc.AddFieldMappingsAt("id", storedNumericField())
+	doc.AddFieldMappingsAt("title", storedTextField())
+	doc.AddFieldMappingsAt("content", storedTextField())
+	author := bleve.NewKeywordFieldMapping()
+	author.Store = true
+	doc.AddFieldMappingsAt("author_id", author)
+	doc.AddFieldMappingsAt("utime", storedNumericField())
+	m := bleve.NewIndexMapping()
+	m.DefaultMapping = doc
+	return m
+}
+
+func NewUserMapping() mapping.IndexMapping {
+	doc := bleve.NewDocumentStaticMapping()
+	doc.AddFieldMappingsAt("id", storedNumericField())
+	doc.AddFieldMappingsAt("nickname", storedTextField())
+	m := bleve.NewIndexMapping()
+	m.DefaultMapping = doc
+	return m
+}
+
+func storedTextField() *mapping.FieldMapping {
+	f := bleve.NewTextFieldMapping()
+	f.Analyzer = cjk.AnalyzerName
+	f.Store = true
+	// 高亮需要词的位置
+	f.IncludeTermVectors = true
+	return f
+}
+
+func storedNumericField() *mapping.FieldMapping {
+	f := bleve.NewNumericFieldMapping()
+	f.Store = true
+	return f
+}
@@ -0,0 +1,52 @@
+package dao
+
+import (
+	"context"
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/search"
+)
+
+type ArticleDAO interface {
+	// InputArticle 写入或者覆盖文章，utime 比索引里面旧的会被丢弃
+	InputArticle(ctx context.Context, art Article) error
+	// DeleteArticle utime 是撤回的时间，同样比索引里面旧的会被丢弃
+	DeleteArticle(ctx context.Context, id int64, utime int64) error
+	// Search authorId 大于 0 的时候只搜索这个作者的文章
+	Search(ctx context.Context, keyword string, authorId int64, offset int, limit int) (SearchResult[Article], error)
+}
+
+type UserDAO interface {
+	InputUser(ctx context.Context, u User) error
+	Search(ctx context.Context, keyword string, offset int, limit int) (SearchResult[User], error)
+}
+
+type SearchResult[T any] struct {
+	Total uint64
+	Hits  []Hit[T]
+}
+
+type Hit[T any] struct {
+	Doc   T
+	Score float64
+	// 字段名到高亮片段
+	Fragments search.FieldFragmentMap
+}
+
+type Article struct {
+	Id      int64  `json:"id"`
+	Title   string `json:"title"`
+	Content string `json:"content"`
+	// 用 keyword 索引，精确匹配
+	AuthorId string `json:"author_id"`
+	Utime    int64  `json:"utime"`
+}
+
+type User struct {
+	Id       int64  `json:"id"`
+	Nickname string `json:"nickname"`
+}
+
+// ArticleIndex 和 UserIndex 用来区分两个索引，方便 wire 注入
+type ArticleIndex bleve.Index
+
+type UserIndex bleve.Index
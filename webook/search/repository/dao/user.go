@@ -0,0 +1,50 @@
+package dao
+
+import (
+	"context"
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/search/highlight/format/html"
+	"strconv"
+)
+
+type BleveUserDAO struct {
+	idx bleve.Index
+}
+
+func NewBleveUserDAO(idx UserIndex) UserDAO {
+	return &BleveUserDAO{
+		idx: idx,
+	}
+}
+
+func (dao *BleveUserDAO) InputUser(ctx context.Context, u User) error {
+	return dao.idx.Index(strconv.FormatInt(u.Id, 10), u)
+}
+
+func (dao *BleveUserDAO) Search(ctx context.Context, keyword string, offset int, limit int) (SearchResult[User], error) {
+	q := bleve.NewMatchQuery(keyword)
+	q.SetField("nickname")
+	req := bleve.NewSearchRequestOptions(q, limit, offset, false)
+	req.Fields = []string{"id", "nickname"}
+	req.Highlight = bleve.NewHighlightWithStyle(html.Name)
+	req.Highlight.AddField("nickname")
+	res, err := dao.idx.SearchInContext(ctx, req)
+	if err != nil {
+		return SearchResult[User]{}, err
+	}
+	hits := make([]Hit[User], 0, len(res.Hits))
+	for _, h := range res.Hits {
+		hits = append(hits, Hit[User]{
+			Doc: User{
+				Id:       int64(numberField(h, "id")),
+				Nickname: stringField(h, "nickname"),
+			},
+			Score:     h.Score,
+			Fragments: h.Fragments,
+		})
+	}
+	return SearchResult[User]{
+		Total: res.Total,
+		Hits:  hits,
+	}, nil
+}
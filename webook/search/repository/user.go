@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	"github.com/basic-go-project-webook/webook/search/domain"
+	"github.com/basic-go-project-webook/webook/search/repository/dao"
+)
+
+type UserRepository interface {
+	InputUser(ctx context.Context, u domain.User) error
+	SearchUsers(ctx context.Context, keyword string, offset int, limit int) (domain.UserSearchResult, error)
+}
+
+type userRepository struct {
+	dao dao.UserDAO
+}
+
+func NewUserRepository(dao dao.UserDAO) UserRepository {
+	return &userRepository{
+		dao: dao,
+	}
+}
+
+func (u *userRepository) InputUser(ctx context.Context, user domain.User) error {
+	return u.dao.InputUser(ctx, dao.User{
+		Id:       user.Id,
+		Nickname: user.Nickname,
+	})
+}
+
+func (u *userRepository) SearchUsers(ctx context.Context, keyword string, offset int, limit int) (domain.UserSearchResult, error) {
+	res, err := u.dao.Search(ctx, keyword, offset, limit)
+	if err != nil {
+		return domain.UserSearchResult{}, err
+	}
+	hits := make([]domain.UserHit, 0, len(res.Hits))
+	for _, h := range res.Hits {
+		hits = append(hits, domain.UserHit{
+			User: domain.User{
+				Id:       h.Doc.Id,
+				Nickname: h.Doc.Nickname,
+			},
+			Score:              h.Score,
+			NicknameHighlights: h.Fragments["nickname"],
+		})
+	}
+	return domain.UserSearchResult{
+		Total: int64(res.Total),
+		Hits:  hits,
+	}, nil
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/basic-go-project-webook/webook/search/domain"
+	"github.com/basic-go-project-webook/webook/search/repository"
+	"strings"
+)
+
+var ErrEmptyKeyword = errors.New("关键字不能为空")
+
+type SearchService interface {
+	SearchArticles(ctx context.Context, keyword string, authorId int64, offset int, limit int) (domain.ArticleSearchResult, error)
+	SearchUsers(ctx context.Context, keyword string, offset int, limit int) (domain.UserSearchResult, error)
+}
+
+type searchService struct {
+	articleRepo repository.ArticleRepository
+	userRepo    repository.UserRepository
+}
+
+func NewSearchService(articleRepo repository.ArticleRepository, userRepo repository.UserRepository) SearchService {
+	return &searchService{
+		articleRepo: articleRepo,
+		userRepo:    userRepo,
+	}
+}
+
+func (s *searchService) SearchArticles(ctx context.Context, keyword string, authorId int64, offset int, limit int) (domain.ArticleSearchResult, error) {
+	keyword = strings.TrimSpace(keyword)
+	if keyword == "" {
+		return domain.ArticleSearchResult{}, ErrEmptyKeyword
+	}
+	return s.articleRepo.SearchArticles(ctx, keyword, authorId, offset, limit)
+}
+
+func (s *searchService) SearchUsers(ctx context.Context, keyword string, offset int, limit int) (domain.UserSearchResult, error) {
+	keyword = strings.TrimSpace(keyword)
+	if keyword == "" {
+		return domain.UserSearchResult{}, ErrEmptyKeyword
+	}
+	return s.userRepo.SearchUsers(ctx, keyword, offset, limit)
+}
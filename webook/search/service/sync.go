@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+	"github.com/basic-go-project-webook/webook/search/domain"
+	"github.com/basic-go-project-webook/webook/search/repository"
+	"time"
+)
+
+// SyncService 维护索引，由事件或者全量导入驱动
+type SyncService interface {
+	InputArticle(ctx context.Context, art domain.Article) error
+	// DeleteArticle 文章撤回之后就搜索不到了。
+	// 事件可能乱序到达，索引以 utime 为准，后写的赢
+	DeleteArticle(ctx context.Context, id int64, utime time.Time) error
+	InputUser(ctx context.Context, u domain.User) error
+}
+
+type syncService struct {
+	articleRepo repository.ArticleRepository
+	userRepo    repository.UserRepository
+}
+
+func NewSyncService(articleRepo repository.ArticleRepository, userRepo repository.UserRepository) SyncService {
+	return &syncService{
+		articleRepo: articleRepo,
+		userRepo:    userRepo,
+	}
+}
+
+func (s *syncService) InputArticle(ctx context.Context, art domain.Article) error {
+	return s.articleRepo.InputArticle(ctx, art)
+}
+
+func (s *syncService) DeleteArticle(ctx context.Context, id int64, utime time.Time) error {
+	return s.articleRepo.DeleteArticle(ctx, id, utime)
+}
+
+func (s *syncService) InputUser(ctx context.Context, u domain.User) error {
+	return s.userRepo.InputUser(ctx, u)
+}
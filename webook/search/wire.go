@@ -0,0 +1,47 @@
+//go:build wireinject
+
+package main
+
+import (
+	grpc2 "github.com/basic-go-project-webook/webook/search/grpc"
+	"github.com/basic-go-project-webook/webook/search/ioc"
+	"github.com/basic-go-project-webook/webook/search/repository"
+	"github.com/basic-go-project-webook/webook/search/repository/dao"
+	"github.com/basic-go-project-webook/webook/search/service"
+	"github.com/google/wire"
+)
+
+var thirdProvider = wire.NewSet(
+	ioc.InitArticleIndex,
+	ioc.InitUserIndex,
+	ioc.InitDLQWriter,
+)
+
+var serviceProvider = wire.NewSet(
+	dao.NewBleveArticleDAO,
+	dao.NewBleveUserDAO,
+	repository.NewArticleRepository,
+	repository.NewUserRepository,
+	service.NewSearchService,
+	service.NewSyncService,
+	grpc2.NewSearchServiceServer,
+)
+
+var consumerProvider = wire.NewSet(
+	ioc.InitArticlePublishedEventConsumer,
+	ioc.InitArticleUpdatedEventConsumer,
+	ioc.InitArticleWithdrawnEventConsumer,
+	ioc.InitUserUpdatedEventConsumer,
+	ioc.InitConsumers,
+)
+
+func InitApp() *App {
+	wire.Build(
+		thirdProvider,
+		serviceProvider,
+		consumerProvider,
+		ioc.InitGRPCXServer,
+		wire.Struct(new(App), "*"),
+	)
+	return new(App)
+}
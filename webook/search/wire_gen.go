@@ -0,0 +1,50 @@
+// Code generated by Wire. DO NOT EDIT.
+
+//go:generate go run -mod=mod github.com/google/wire/cmd/wire
+//go:build !wireinject
+// +build !wireinject
+
+package main
+
+import (
+	"github.com/basic-go-project-webook/webook/search/grpc"
+	"github.com/basic-go-project-webook/webook/search/ioc"
+	"github.com/basic-go-project-webook/webook/search/repository"
+	"github.com/basic-go-project-webook/webook/search/repository/dao"
+	"github.com/basic-go-project-webook/webook/search/service"
+	"github.com/google/wire"
+)
+
+// Injectors from wire.go:
+
+func InitApp() *App {
+	articleIndex := ioc.InitArticleIndex()
+	articleDAO := dao.NewBleveArticleDAO(articleIndex)
+	articleRepository := repository.NewArticleRepository(articleDAO)
+	userIndex := ioc.InitUserIndex()
+	userDAO := dao.NewBleveUserDAO(userIndex)
+	userRepository := repository.NewUserRepository(userDAO)
+	searchService := service.NewSearchService(articleRepository, userRepository)
+	syncService := service.NewSyncService(articleRepository, userRepository)
+	searchServiceServer := grpc.NewSearchServiceServer(searchService, syncService)
+	server := ioc.InitGRPCXServer(searchServiceServer)
+	writer := ioc.InitDLQWriter()
+	articlePublishedEventConsumer := ioc.InitArticlePublishedEventConsumer(syncService, writer)
+	articleUpdatedEventConsumer := ioc.InitArticleUpdatedEventConsumer(syncService, writer)
+	articleWithdrawnEventConsumer := ioc.InitArticleWithdrawnEventConsumer(syncService, writer)
+	userUpdatedEventConsumer := ioc.InitUserUpdatedEventConsumer(syncService, writer)
+	v := ioc.InitConsumers(articlePublishedEventConsumer, articleUpdatedEventConsumer, articleWithdrawnEventConsumer, userUpdatedEventConsumer)
+	app := &App{
+		server:    server,
+		consumers: v,
+	}
+	return app
+}
+
+// wire.go:
+
+var thirdProvider = wire.NewSet(ioc.InitArticleIndex, ioc.InitUserIndex, ioc.InitDLQWriter)
+
+var serviceProvider = wire.NewSet(dao.NewBleveArticleDAO, dao.NewBleveUserDAO, repository.NewArticleRepository, repository.NewUserRepository, service.NewSearchService, service.NewSyncService, grpc.NewSearchServiceServer)
+
+var consumerProvider = wire.NewSet(ioc.InitArticlePublishedEventConsumer, ioc.InitArticleUpdatedEventConsumer, ioc.InitArticleWithdrawnEventConsumer, ioc.InitUserUpdatedEventConsumer, ioc.InitConsumers)
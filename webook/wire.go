@@ -7,14 +7,14 @@ import (
 	cache2 "github.com/basic-go-project-webook/webook/interactive/repository/cache"
 	dao2 "github.com/basic-go-project-webook/webook/interactive/repository/dao"
 	service2 "github.com/basic-go-project-webook/webook/interactive/service"
+	"github.com/basic-go-project-webook/webook/internal/job"
 	"github.com/basic-go-project-webook/webook/internal/repository"
 	"github.com/basic-go-project-webook/webook/internal/repository/article"
 	"github.com/basic-go-project-webook/webook/internal/repository/cache"
 	"github.com/basic-go-project-webook/webook/internal/repository/dao"
-	article2 "github.com/basic-go-project-webook/webook/internal/repository/dao/article"
+	"github.com/basic-go-project-webook/webook/internal/repository/dao/outbox"
 	"github.com/basic-go-project-webook/webook/internal/service"
 	"github.com/basic-go-project-webook/webook/internal/web"
-	ijwt "github.com/basic-go-project-webook/webook/internal/web/jwt"
 	"github.com/basic-go-project-webook/webook/ioc"
 	"github.com/google/wire"
 )
@@ -23,7 +23,16 @@ var rankingSvcSet = wire.NewSet(
 	cache.NewRankingRedisCache,
 	cache.NewRankingLocalCache,
 	repository.NewOnlyCachedRankingRepository,
-	service.NewBatchRankingService,
+	ioc.InitRankingService,
+	// 实时热榜
+	ioc.InitRankingRealtimeCache,
+	repository.NewCachedRealtimeRankingRepository,
+	ioc.InitRealtimeRankingService,
+	ioc.InitRankingInteractiveEventConsumer,
+	ioc.InitRankingReadEventConsumer,
+	ioc.InitRankingScorer,
+	ioc.InitRankingBoards,
+	cache.NewRankingBoardRedisCache,
 )
 
 var interactiveSvcSet = wire.NewSet(
@@ -33,19 +42,26 @@ var interactiveSvcSet = wire.NewSet(
 	service2.NewInteractiveService,
 )
 
+var feedSvcSet = wire.NewSet(
+	ioc.InitArticleReaderDAO,
+	cache.NewRedisFeedCache,
+	repository.NewCachedFeedRepository,
+	ioc.InitFeedService,
+)
+
 func InitWebServer() *App {
 	wire.Build(
 		// 第三方依赖
 		ioc.InitDB, ioc.InitRedis,
 		ioc.InitProducer,
-		//ioc.InitMongoDB,
-		//ioc.InitSnowFlakeNode,
+		ioc.InitMongoDB,
+		ioc.InitSnowFlakeNode,
 		ioc.InitRlockClient,
 
 		// dao 部分
 		dao.NewUserDAO,
-		article2.NewArticleDAO,
-		//article2.NewMongoDBArticleDAO,
+		// article.dao 决定文章存放在 MySQL 还是 MongoDB
+		ioc.InitArticleDAO,
 
 		// cache 部分
 		cache.NewUserCache, cache.NewCodeCache,
@@ -54,32 +70,63 @@ func InitWebServer() *App {
 		interactiveSvcSet,
 		ioc.InitETCD,
 		ioc.InitIntrGRPCClientEtcd,
+		ioc.InitCommentGRPCClientEtcd,
+		ioc.InitFollowGRPCClientEtcd,
 		// ranking
 		rankingSvcSet,
+		// feed
+		feedSvcSet,
 
 		ioc.InitJobs,
 		ioc.InitRankingJob,
+		// 定时发表
+		dao.NewGORMJobDAO,
+		repository.NewPreemptJobRepository,
+		service.NewCronJobService,
+		job.NewArticlePublishExecutor,
+		// 全量导入搜索索引
+		job.NewSearchBackfillExecutor,
+		ioc.InitScheduler,
+		// outbox
+		outbox.NewGORMOutboxDAO,
+		repository.NewOutboxRepository,
+		service.NewOutboxRelayService,
+		ioc.InitMongoOutboxRelayService,
+		ioc.InitOutboxRelayJob,
 
 		// repository
 		repository.NewUserRepository,
 		repository.NewCodeRepository,
 		article.NewArticleRepository,
 
+		ioc.InitDLQWriter,
 		ioc.InitInteractiveReadEventConsumer,
+		ioc.InitFeedPublishedEventConsumer,
 		ioc.InitConsumers,
 
 		// service 部分
 		ioc.InitSMSService,
 		service.NewUserService,
 		service.NewCodeService,
-		ioc.InitOAuth2WechatService,
+		ioc.InitEmailService,
+		service.NewEmailCodeService,
+		service.NewAccountService,
+		ioc.InitOAuth2Providers,
 		service.NewArticleService,
 
 		// handler 部分
-		ijwt.NewRedisJwtHandler,
+		ioc.InitJwtHandler,
 		web.NewUserHandle,
+		ioc.InitMergeKey,
+		web.NewAccountHandler,
 		web.NewArticleHandle,
-		web.NewOAuth2WechatHandler,
+		ioc.InitOAuth2Handler,
+		web.NewCommentHandler,
+		web.NewFollowHandler,
+		web.NewFeedHandler,
+		web.NewCollectionHandler,
+		web.NewAuthorHandler,
+		web.NewRankingHandler,
 		ioc.InitGinMiddlewares,
 		ioc.InitWebserver,
 
@@ -11,14 +11,14 @@ import (
 	cache2 "github.com/basic-go-project-webook/webook/interactive/repository/cache"
 	dao2 "github.com/basic-go-project-webook/webook/interactive/repository/dao"
 	service2 "github.com/basic-go-project-webook/webook/interactive/service"
+	"github.com/basic-go-project-webook/webook/internal/job"
 	"github.com/basic-go-project-webook/webook/internal/repository"
-	article2 "github.com/basic-go-project-webook/webook/internal/repository/article"
+	"github.com/basic-go-project-webook/webook/internal/repository/article"
 	"github.com/basic-go-project-webook/webook/internal/repository/cache"
 	"github.com/basic-go-project-webook/webook/internal/repository/dao"
-	"github.com/basic-go-project-webook/webook/internal/repository/dao/article"
+	"github.com/basic-go-project-webook/webook/internal/repository/dao/outbox"
 	"github.com/basic-go-project-webook/webook/internal/service"
 	"github.com/basic-go-project-webook/webook/internal/web"
-	"github.com/basic-go-project-webook/webook/internal/web/jwt"
 	"github.com/basic-go-project-webook/webook/ioc"
 	"github.com/google/wire"
 )
@@ -31,7 +31,7 @@ import (
 
 func InitWebServer() *App {
 	cmdable := ioc.InitRedis()
-	handler := jwt.NewRedisJwtHandler(cmdable)
+	handler := ioc.InitJwtHandler(cmdable)
 	v := ioc.InitGinMiddlewares(cmdable, handler)
 	db := ioc.InitDB()
 	userDAO := dao.NewUserDAO(db)
@@ -43,39 +43,82 @@ func InitWebServer() *App {
 	smsService := ioc.InitSMSService()
 	codeService := service.NewCodeService(codeRepository, smsService)
 	userHandle := web.NewUserHandle(userService, codeService, cmdable, handler)
-	wechatService := ioc.InitOAuth2WechatService()
-	oAuth2WechatHandler := web.NewOAuth2WechatHandler(wechatService, userService, handler)
-	articleDAO := article.NewArticleDAO(db)
+	database := ioc.InitMongoDB()
+	node := ioc.InitSnowFlakeNode()
+	articleDAO := ioc.InitArticleDAO(db, database, node)
 	articleCache := cache.NewRedisArticleCache(cmdable)
-	articleRepository := article2.NewArticleRepository(articleDAO, articleCache, userRepository)
+	articleRepository := article.NewArticleRepository(articleDAO, articleCache, userRepository)
 	producer := ioc.InitProducer()
-	articleService := service.NewArticleService(articleRepository, producer)
+	jobDAO := dao.NewGORMJobDAO(db)
+	cronJobRepository := repository.NewPreemptJobRepository(jobDAO)
+	cronJobService := service.NewCronJobService(cronJobRepository)
+	articleService := service.NewArticleService(articleRepository, producer, cronJobService)
 	client := ioc.InitETCD()
 	interactiveServiceClient := ioc.InitIntrGRPCClientEtcd(client)
+	followServiceClient := ioc.InitFollowGRPCClientEtcd(client)
+	accountService := service.NewAccountService(userRepository, articleService, interactiveServiceClient, followServiceClient)
+	emailService := ioc.InitEmailService()
+	emailCodeService := service.NewEmailCodeService(codeRepository, emailService)
+	mergeKey := ioc.InitMergeKey()
+	accountHandler := web.NewAccountHandler(accountService, codeService, emailCodeService, handler, mergeKey)
+	v2 := ioc.InitOAuth2Providers()
+	oAuth2Handler := ioc.InitOAuth2Handler(v2, userService, accountService, handler, mergeKey)
 	articleHandle := web.NewArticleHandle(articleService, handler, interactiveServiceClient)
-	engine := ioc.InitWebserver(v, userHandle, oAuth2WechatHandler, articleHandle)
+	commentServiceClient := ioc.InitCommentGRPCClientEtcd(client)
+	commentHandler := web.NewCommentHandler(commentServiceClient, handler)
+	followHandler := web.NewFollowHandler(followServiceClient, handler)
+	articleReaderDAO := ioc.InitArticleReaderDAO(db, database)
+	feedCache := cache.NewRedisFeedCache(cmdable)
+	feedRepository := repository.NewCachedFeedRepository(articleReaderDAO, feedCache)
+	feedService := ioc.InitFeedService(feedRepository, followServiceClient)
+	feedHandler := web.NewFeedHandler(feedService, interactiveServiceClient, handler)
+	collectionHandler := web.NewCollectionHandler(interactiveServiceClient, handler)
+	authorHandler := web.NewAuthorHandler(articleService, userService, followServiceClient, interactiveServiceClient)
+	rankingRedisCache := cache.NewRankingRedisCache(cmdable)
+	rankingLocalCache := cache.NewRankingLocalCache()
+	rankingBoardCache := cache.NewRankingBoardRedisCache(cmdable)
+	rankingRepository := repository.NewOnlyCachedRankingRepository(rankingRedisCache, rankingLocalCache, rankingBoardCache)
+	rankingScorer := ioc.InitRankingScorer()
+	v3 := ioc.InitRankingBoards()
+	rankingRealtimeCache := ioc.InitRankingRealtimeCache(cmdable)
+	realtimeRankingRepository := repository.NewCachedRealtimeRankingRepository(rankingRealtimeCache)
+	realtimeRankingService := ioc.InitRealtimeRankingService(articleService, realtimeRankingRepository)
+	rankingService := ioc.InitRankingService(articleService, interactiveServiceClient, commentServiceClient, rankingRepository, rankingScorer, v3, realtimeRankingService)
+	rankingHandler := web.NewRankingHandler(rankingService, interactiveServiceClient)
+	engine := ioc.InitWebserver(v, userHandle, accountHandler, oAuth2Handler, articleHandle, commentHandler, followHandler, feedHandler, collectionHandler, authorHandler, rankingHandler)
 	interactiveDAO := dao2.NewGORMInteractiveDAO(db)
 	interactiveCache := cache2.NewInteractiveRedisCache(cmdable)
 	interactiveRepository := repository2.NewCachedInteractiveRepository(interactiveDAO, interactiveCache)
-	interactiveReadEventConsumer := ioc.InitInteractiveReadEventConsumer(interactiveRepository)
-	v2 := ioc.InitConsumers(interactiveReadEventConsumer)
-	rankingRedisCache := cache.NewRankingRedisCache(cmdable)
-	rankingLocalCache := cache.NewRankingLocalCache()
-	rankingRepository := repository.NewOnlyCachedRankingRepository(rankingRedisCache, rankingLocalCache)
-	rankingService := service.NewBatchRankingService(articleService, interactiveServiceClient, rankingRepository)
+	writer := ioc.InitDLQWriter()
+	interactiveReadEventBatchConsumer := ioc.InitInteractiveReadEventConsumer(interactiveRepository, writer)
+	publishedEventConsumer := ioc.InitFeedPublishedEventConsumer(feedService, writer)
+	interactiveEventConsumer := ioc.InitRankingInteractiveEventConsumer(realtimeRankingService, writer)
+	readEventConsumer := ioc.InitRankingReadEventConsumer(realtimeRankingService, writer)
+	v4 := ioc.InitConsumers(interactiveReadEventBatchConsumer, publishedEventConsumer, interactiveEventConsumer, readEventConsumer)
 	rlockClient := ioc.InitRlockClient(cmdable)
 	rankingJob := ioc.InitRankingJob(rankingService, rlockClient)
-	cron := ioc.InitJobs(rankingJob)
+	outboxDAO := outbox.NewGORMOutboxDAO(db)
+	outboxRepository := repository.NewOutboxRepository(outboxDAO)
+	outboxRelayService := service.NewOutboxRelayService(outboxRepository, producer)
+	mongoOutboxRelayService := ioc.InitMongoOutboxRelayService(database, producer)
+	outboxRelayJob := ioc.InitOutboxRelayJob(outboxRelayService, mongoOutboxRelayService)
+	cron := ioc.InitJobs(rankingJob, outboxRelayJob, rankingService, rlockClient)
+	articlePublishExecutor := job.NewArticlePublishExecutor(articleService)
+	searchBackfillExecutor := job.NewSearchBackfillExecutor(userService, articleService, producer)
+	scheduler := ioc.InitScheduler(cronJobService, articlePublishExecutor, searchBackfillExecutor)
 	app := &App{
 		web:       engine,
-		consumers: v2,
+		consumers: v4,
 		cron:      cron,
+		scheduler: scheduler,
 	}
 	return app
 }
 
 // wire.go:
 
-var rankingSvcSet = wire.NewSet(cache.NewRankingRedisCache, cache.NewRankingLocalCache, repository.NewOnlyCachedRankingRepository, service.NewBatchRankingService)
+var rankingSvcSet = wire.NewSet(cache.NewRankingRedisCache, cache.NewRankingLocalCache, repository.NewOnlyCachedRankingRepository, ioc.InitRankingService, ioc.InitRankingRealtimeCache, repository.NewCachedRealtimeRankingRepository, ioc.InitRealtimeRankingService, ioc.InitRankingInteractiveEventConsumer, ioc.InitRankingReadEventConsumer, ioc.InitRankingScorer, ioc.InitRankingBoards, cache.NewRankingBoardRedisCache)
 
 var interactiveSvcSet = wire.NewSet(dao2.NewGORMInteractiveDAO, cache2.NewInteractiveRedisCache, repository2.NewCachedInteractiveRepository, service2.NewInteractiveService)
+
+var feedSvcSet = wire.NewSet(ioc.InitArticleReaderDAO, cache.NewRedisFeedCache, repository.NewCachedFeedRepository, ioc.InitFeedService)